@@ -57,6 +57,9 @@ are deleted they are no longer visible on the `/metrics` endpoint.
 * [Usage](#usage)
   * [Kubernetes Deployment](#kubernetes-deployment)
   * [Limited privileges environment](#limited-privileges-environment)
+  * [Securing the endpoints](#securing-the-endpoints)
+  * [Pushing metrics via remote-write](#pushing-metrics-via-remote-write)
+  * [Multi-cluster mode](#multi-cluster-mode)
   * [Helm Chart](#helm-chart)
   * [Development](#development)
   * [Developer Contributions](#developer-contributions)
@@ -146,6 +149,73 @@ kube_state_metrics_list_total{resource="*v1.Node",result="error"} 52
 kube_state_metrics_watch_total{resource="*v1beta1.Ingress",result="success"} 1
 ```
 
+Every failed attempt to list and watch a resource is counted in `kube_state_metrics_watch_errors_total`, and retries are backed off exponentially up to 30 seconds.
+A resource kube-state-metrics is not permitted to list, e.g. due to a missing RBAC rule, shows up as a steadily increasing counter and an error in the logs. The ClusterRole rule needed to list and watch the resource is logged once as well:
+
+```
+kube_state_metrics_watch_errors_total{resource="*v1.Lease"} 12
+```
+
+The number of objects added, updated and deleted by the watch of each resource quantifies the churn of the resource. High update rates, e.g. of
+leases or endpoints, drive the CPU usage of kube-state-metrics:
+
+```
+kube_state_metrics_informer_events_total{event_type="update",resource="*v1.Lease"} 5832
+```
+
+kube-state-metrics also exposes the number of objects currently held in memory per resource. This can be used to alert on unexpected object growth:
+
+```
+kube_state_metrics_store_object_count{resource="pods"} 1034
+```
+
+Whether the initial list of each resource has been synced into the stores is exposed as well. A resource that stays at `0` never finished its initial list, e.g. because of a missing RBAC rule, and its metrics are incomplete:
+
+```
+kube_state_metrics_cache_sync_completed{resource="pods"} 1
+```
+
+The time spent writing out the metrics of each resource during the last scrape is exposed as well. This helps to identify the resources which dominate the scrape time and are worth sharding:
+
+```
+kube_state_metrics_scrape_duration_seconds{resource="pods"} 0.0431
+```
+
+The number of series written out for each resource is counted across scrapes, which allows tracking the growth of the series over time:
+
+```
+kube_state_metrics_series_emitted_total{resource="pods"} 1.2834e+06
+```
+
+The time of the last scrape which wrote out the metrics of all resources without error is exposed too. If it falls behind
+while Prometheus keeps scraping, kube-state-metrics itself is stalled rather than lagging behind the API server:
+
+```
+kube_state_metrics_last_scrape_success_timestamp_seconds 1.7288448e+09
+```
+
+The label keys captured in the labels metric of each resource, as resolved from `--metric-labels-allowlist` without the keys in `--metric-labels-denylist`, are exposed to help tuning the allowlist. A `label_key` of `*` means all labels are captured:
+
+```
+kube_state_metrics_captured_label_keys{label_key="app",resource="pods"} 1
+kube_state_metrics_captured_label_keys{label_key="*",resource="deployments"} 1
+```
+
+Objects whose metrics cannot be generated, e.g. because a metric family panics on an unexpected object, are skipped instead of crashing kube-state-metrics.
+They are counted by resource, and the error is logged:
+
+```
+kube_state_metrics_generate_errors_total{resource="*v1.Pod"} 0
+```
+
+`--max-series-per-family` guards Prometheus against runaway cardinality: a metric family with more series than the limit is
+dropped from the scrape entirely, and the drop is counted by family. The series of a family generated by several custom
+resources are counted together:
+
+```
+kube_state_metrics_family_dropped_total{family="kube_pod_labels"} 3
+```
+
 kube-state-metrics also exposes some http request metrics, examples of those are:
 
 ```
@@ -161,14 +231,24 @@ kube-state-metrics also exposes build and configuration metrics:
 
 ```
 kube_state_metrics_build_info{branch="main",goversion="go1.15.3",revision="6c9d775d",version="v2.0.0-beta"} 1
+kube_state_metrics_resource_enabled{resource="pods"} 1
+kube_state_metrics_resource_enabled{resource="secrets"} 0
 kube_state_metrics_shard_ordinal{shard_ordinal="0"} 0
 kube_state_metrics_total_shards 1
 ```
 
 `kube_state_metrics_build_info` is used to expose version and other build information. For more usage about the info pattern,
 please check the blog post [here](https://www.robustperception.io/exposing-the-software-version-to-prometheus).
+`kube_state_metrics_resource_enabled` tells for each available resource whether this instance exposes its metrics,
+so dashboards can show what an instance covers.
 Sharding metrics expose `--shard` and `--total-shards` flags and can be used to validate
 run-time configuration, see [`/examples/prometheus-alerting-rules`](./examples/prometheus-alerting-rules).
+When sharding is enabled, `kube_state_metrics_sharded_out_total` counts the listed objects and watch events of each resource
+that were dropped because they belong to another shard, which confirms sharding actually reduces the load of each shard:
+
+```
+kube_state_metrics_sharded_out_total{resource="*v1.Pod"} 5123
+```
 
 kube-state-metrics also exposes metrics about it config file and the Custom Resource State config file:
 
@@ -181,6 +261,16 @@ kube_state_metrics_last_config_reload_successful{filename="crs.yml",type="custom
 kube_state_metrics_last_config_reload_successful{filename="config.yml",type="config"} 1
 ```
 
+For every resource configured in the Custom Resource State config, `kube_state_metrics_custom_resource_registered` reports whether
+it is registered in the cluster, so that typos or missing CRDs do not go unnoticed. Resources configured with a wildcard version or kind
+are reported with the version and kind of every resource they resolve to, or as configured if they do not resolve to any. The series of
+resources removed from the config are deleted:
+
+```
+kube_state_metrics_custom_resource_registered{group="myteam.io",kind="Foo",version="v1"} 1
+kube_state_metrics_custom_resource_registered{group="myteam.io",kind="Fooo",version="v1"} 0
+```
+
 ### Scaling kube-state-metrics
 
 #### Resource recommendation
@@ -351,10 +441,29 @@ The following healthcheck endpoints are available (`self` refers to the telemetr
 
 * `/healthz` (exposed on `main`): Returns a 200 status code if the application is running. We recommend to use this for the startup probe.
 * `/livez` (exposed on `main`): Returns a 200 status code if the application is not affected by an outage of the Kubernetes API Server. We recommend to using this for the liveness probe.
+* `/readyz` (exposed on `main`): Returns a 503 status code until the initial list of every enabled resource has been synced into the metric stores, and a 200 status code afterwards. This prevents partial metrics from being scraped right after startup or a shard change.
 * `/readyz` (exposed on `self`): Returns a 200 status code if the application is ready to accept requests and expose metrics. We recommend using this for the readiness probe.
 
 Note that it is discouraged to use the telemetry metrics endpoint for any probe when proxying the exposition data.
 
+#### Delta endpoint
+
+In very large clusters, re-reading the full state on every scrape can be expensive. With the experimental `--enable-delta-endpoint` flag,
+kube-state-metrics additionally exposes `/metrics/delta` (the metrics path suffixed with `/delta`) on the exposition port, which only returns the
+series of the objects added, updated or deleted since a given cursor:
+
+* `# CHANGED <resource> <uid>` is followed by all current series of an added or updated object, which replace its previous series.
+* `# DELETED <resource> <uid>` means that all series of the object are gone.
+* `# RESET` means that all previously received state has to be dropped. It is written out if no valid cursor was given, e.g. on the first read or
+  after the stores were rebuilt because of a shard or configuration change, and is followed by the full state.
+* `# RESET <resource>` means the same for the series of a single resource, whose changes since the cursor are no longer available.
+* `# CURSOR <cursor>` ends every complete response. It is to be passed as the `cursor` query parameter of the next request, e.g. `/metrics/delta?cursor=<cursor>`.
+
+Reading the endpoint does not consume the changes. A consumer which did not receive a complete response asks for the same changes again with
+its previous cursor, and any number of consumers can read the endpoint independently. Metric headers are not part of the delta and can be taken
+from the regular metrics endpoint. Metrics depending on other objects, e.g. `kube_service_status_ready_endpoints`, only show up in the delta
+when their own object changes. Tracking the changes adds some memory overhead.
+
 #### Limited privileges environment
 
 If you want to run kube-state-metrics in an environment where you don't have cluster-reader role, you can:
@@ -402,6 +511,31 @@ spec:
 
 For the full list of arguments available, see the documentation in [docs/developer/cli-arguments.md](./docs/developer/cli-arguments.md)
 
+#### Securing the endpoints
+
+TLS and basic authentication for the metrics and telemetry servers are configured through `--web.config-file`, using the
+[exporter-toolkit web configuration format](https://github.com/prometheus/exporter-toolkit/blob/master/docs/web-configuration.md).
+Alternatively, `--web.bearer-token-file` requires clients to send the token contained in the given file as `Authorization: Bearer <token>`.
+Requests with missing or invalid credentials are rejected with `401 Unauthorized`.
+Both modes protect the health check endpoints `/healthz`, `/livez` and `/readyz` as well, since the exporter-toolkit applies
+basic authentication to every path. Configure the credentials in the `httpHeaders` of the liveness and readiness probes,
+or use `tcpSocket` probes instead.
+
+#### Pushing metrics via remote-write
+
+In environments where Prometheus can not scrape kube-state-metrics, the metrics can instead be pushed to a
+[Prometheus remote-write](https://prometheus.io/docs/specs/remote_write_spec/) endpoint by setting `--remote-write-url`.
+The metrics are pushed every `--remote-write-interval` (default 1m), in requests of at most 2000 samples each, and failed requests are retried with an exponential backoff.
+Headers required for authentication can be set with `--remote-write-headers`, e.g. `--remote-write-headers='Authorization=Bearer <token>'`.
+The `/metrics` endpoint keeps being served while pushing is enabled.
+
+#### Multi-cluster mode
+
+A single kube-state-metrics instance, e.g. running in a management cluster, can expose the metrics of several clusters.
+Pass the contexts of the kubeconfig file to use via `--kubeconfig-context`, e.g. `--kubeconfig=/etc/ksm/kubeconfig --kubeconfig-context=management,workload-1,workload-2`.
+With more than one context, every metric carries a `cluster` label set to the name of its context.
+The first context is the primary one, used for autosharding. Custom resource state metrics are not supported in this mode.
+
 #### Helm Chart
 
 Starting from the kube-state-metrics chart `v2.13.3` (kube-state-metrics image `v1.9.8`), the official [Helm chart](https://artifacthub.io/packages/helm/prometheus-community/kube-state-metrics/) is maintained in [prometheus-community/helm-charts](https://github.com/prometheus-community/helm-charts/tree/main/charts/kube-state-metrics). Starting from kube-state-metrics chart `v3.0.0` only kube-state-metrics images of `v2.0.0 +` are supported.
@@ -40,7 +40,10 @@ const Interval = 3 * time.Second
 // StartDiscovery starts the discovery process, fetching all the objects that can be listed from the apiserver, every `Interval` seconds.
 // resolveGVK needs to be called after StartDiscovery to generate factories.
 func (r *CRDiscoverer) StartDiscovery(ctx context.Context, config *rest.Config) error {
-	client := dynamic.NewForConfigOrDie(config)
+	return r.startDiscovery(ctx, dynamic.NewForConfigOrDie(config))
+}
+
+func (r *CRDiscoverer) startDiscovery(ctx context.Context, client dynamic.Interface) error {
 	factory := dynamicinformer.NewFilteredDynamicInformer(client, schema.GroupVersionResource{
 		Group:    "apiextensions.k8s.io",
 		Version:  "v1",
@@ -116,7 +119,15 @@ func (r *CRDiscoverer) StartDiscovery(ctx context.Context, config *rest.Config)
 }
 
 // ResolveGVKToGVKPs resolves the variable VKs to a GVK list, based on the current cache.
+// It records which registered resources the GVK resolved to.
 func (r *CRDiscoverer) ResolveGVKToGVKPs(gvk schema.GroupVersionKind) (resolvedGVKPs []groupVersionKindPlural, err error) { // nolint:revive
+	defer func() {
+		resolved := make([]schema.GroupVersionKind, 0, len(resolvedGVKPs))
+		for _, gvkp := range resolvedGVKPs {
+			resolved = append(resolved, gvkp.GroupVersionKind)
+		}
+		r.SetRegistered(gvk, resolved)
+	}()
 	g := gvk.Group
 	v := gvk.Version
 	k := gvk.Kind
@@ -206,6 +217,7 @@ func (r *CRDiscoverer) PollForCacheUpdates(
 		if err != nil {
 			klog.ErrorS(err, "failed to update custom resource stores")
 		}
+		generated := err == nil
 		// Update the list of enabled custom resources.
 		var enabledCustomResources []string
 		for _, factory := range customFactories {
@@ -228,6 +240,11 @@ func (r *CRDiscoverer) PollForCacheUpdates(
 		}
 		// Update the store builder with the new clients.
 		storeBuilder.WithCustomResourceClients(discoveredCustomResourceClients)
+		// Drop the stores of custom resources which are not configured anymore, unless the
+		// factories could not all be generated.
+		if generated {
+			storeBuilder.RetainCustomResourceStoreFactories(enabledCustomResources)
+		}
 		// Inject families' constructors to the existing set of stores.
 		storeBuilder.WithCustomResourceStoreFactories(customFactories...)
 		// Update the store builder with the new custom resources.
@@ -241,8 +258,8 @@ func (r *CRDiscoverer) PollForCacheUpdates(
 		r.SafeWrite(func() {
 			r.WasUpdated = false
 		})
-		// Update metric handler with the new configs.
-		m.BuildWriters(ctx)
+		// Update metric handler with the new configs, restarting only the stores of the changed custom resources.
+		m.RebuildWriters(ctx)
 	}
 	go func() {
 		for range t.C {
@@ -14,11 +14,19 @@
 package discovery
 
 import (
+	"context"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 )
 
 func TestGVKMapsResolveGVK(t *testing.T) {
@@ -198,3 +206,94 @@ func TestGVKMapsResolveGVK(t *testing.T) {
 		}
 	}
 }
+
+func TestCustomResourceRegistered(t *testing.T) {
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata": map[string]interface{}{
+			"name": "foos.myteam.io",
+		},
+		"spec": map[string]interface{}{
+			"group": "myteam.io",
+			"names": map[string]interface{}{
+				"kind":   "Foo",
+				"plural": "foos",
+			},
+			"versions": []interface{}{
+				map[string]interface{}{"name": "v1"},
+			},
+		},
+	}}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}: "CustomResourceDefinitionList",
+	}, crd)
+
+	registered := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kube_state_metrics_custom_resource_registered",
+		Help: "Whether a custom resource configured for custom resource state metrics is registered in the cluster.",
+	}, []string{"group", "version", "kind"})
+	r := &CRDiscoverer{
+		CRDsAddEventsCounter:          prometheus.NewCounter(prometheus.CounterOpts{Name: "add_events"}),
+		CRDsDeleteEventsCounter:       prometheus.NewCounter(prometheus.CounterOpts{Name: "delete_events"}),
+		CRDsCacheCountGauge:           prometheus.NewGauge(prometheus.GaugeOpts{Name: "cache"}),
+		CustomResourceRegisteredGauge: registered,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := r.startDiscovery(ctx, client); err != nil {
+		t.Fatal(err)
+	}
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		var updated bool
+		r.SafeRead(func() {
+			updated = r.WasUpdated
+		})
+		if updated {
+			break
+		}
+		if time.Since(start) > 10*time.Second {
+			t.Fatal("timed out waiting for the CRD to be discovered")
+		}
+	}
+
+	configured := []schema.GroupVersionKind{
+		{Group: "myteam.io", Version: "v1", Kind: "Foo"},
+		{Group: "myteam.io", Version: "*", Kind: "Foo"},
+		{Group: "myteam.io", Version: "v1", Kind: "Fooo"},
+		{Group: "myteam.io", Version: "*", Kind: "Bar"},
+	}
+	r.SetConfigured(configured...)
+	for _, gvk := range configured {
+		if _, err := r.ResolveGVKToGVKPs(gvk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Wildcards are reported with the resolved version, unless they did not resolve.
+	want := `# HELP kube_state_metrics_custom_resource_registered Whether a custom resource configured for custom resource state metrics is registered in the cluster.
+# TYPE kube_state_metrics_custom_resource_registered gauge
+kube_state_metrics_custom_resource_registered{group="myteam.io",kind="Bar",version="*"} 0
+kube_state_metrics_custom_resource_registered{group="myteam.io",kind="Foo",version="v1"} 1
+kube_state_metrics_custom_resource_registered{group="myteam.io",kind="Fooo",version="v1"} 0
+`
+	if err := testutil.CollectAndCompare(registered, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+
+	// The series of resources removed from the configuration are deleted, unless another
+	// configured resource resolves to them.
+	r.SetConfigured(configured[1])
+	want = `# HELP kube_state_metrics_custom_resource_registered Whether a custom resource configured for custom resource state metrics is registered in the cluster.
+# TYPE kube_state_metrics_custom_resource_registered gauge
+kube_state_metrics_custom_resource_registered{group="myteam.io",kind="Foo",version="v1"} 1
+`
+	if err := testutil.CollectAndCompare(registered, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+	r.SetConfigured()
+	if n := testutil.CollectAndCount(registered); n != 0 {
+		t.Errorf("expected no series without configured resources, got %d", n)
+	}
+}
@@ -15,6 +15,7 @@ package discovery
 
 import (
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -43,6 +44,12 @@ type CRDiscoverer struct {
 	CRDsDeleteEventsCounter prometheus.Counter
 	// CRDsCacheCountGauge tracks the net amount of CRDs affecting the cache at this point.
 	CRDsCacheCountGauge prometheus.Gauge
+	// CustomResourceRegisteredGauge tracks whether the configured custom resources are registered in the cluster.
+	CustomResourceRegisteredGauge *prometheus.GaugeVec
+	// registered holds the label sets of CustomResourceRegisteredGauge set for each configured GVK.
+	registered map[schema.GroupVersionKind][]schema.GroupVersionKind
+	// registeredMtx protects registered and CustomResourceRegisteredGauge.
+	registeredMtx sync.Mutex
 	// Map is a cache of the collected GVKs.
 	Map map[string]map[string][]kindPlural
 	// m is a mutex to protect the cache.
@@ -105,3 +112,75 @@ func (r *CRDiscoverer) RemoveFromMap(gvkps ...groupVersionKindPlural) {
 		}
 	}
 }
+
+// SetConfigured records the GVKs configured for custom resource state metrics. The registered
+// series of GVKs which are no longer configured are deleted, newly configured GVKs are reported as
+// not registered until they are resolved.
+func (r *CRDiscoverer) SetConfigured(gvks ...schema.GroupVersionKind) {
+	if r.CustomResourceRegisteredGauge == nil {
+		return
+	}
+	r.registeredMtx.Lock()
+	defer r.registeredMtx.Unlock()
+
+	configured := map[schema.GroupVersionKind]struct{}{}
+	for _, gvk := range gvks {
+		configured[gvk] = struct{}{}
+		if _, ok := r.registered[gvk]; !ok {
+			r.setRegistered(gvk, nil)
+		}
+	}
+	for gvk := range r.registered {
+		if _, ok := configured[gvk]; !ok {
+			r.deleteRegistered(gvk)
+		}
+	}
+}
+
+// SetRegistered records which resources registered in the cluster the given configured GVK resolved to.
+// A configured GVK with wildcards is reported with the version and kind of every resource it resolved to,
+// or as configured if it did not resolve to any.
+func (r *CRDiscoverer) SetRegistered(gvk schema.GroupVersionKind, resolved []schema.GroupVersionKind) {
+	if r.CustomResourceRegisteredGauge == nil {
+		return
+	}
+	r.registeredMtx.Lock()
+	defer r.registeredMtx.Unlock()
+
+	r.setRegistered(gvk, resolved)
+}
+
+func (r *CRDiscoverer) setRegistered(gvk schema.GroupVersionKind, resolved []schema.GroupVersionKind) {
+	r.deleteRegistered(gvk)
+	if r.registered == nil {
+		r.registered = map[schema.GroupVersionKind][]schema.GroupVersionKind{}
+	}
+	if len(resolved) == 0 {
+		r.registered[gvk] = []schema.GroupVersionKind{gvk}
+		r.CustomResourceRegisteredGauge.WithLabelValues(gvk.Group, gvk.Version, gvk.Kind).Set(0)
+		return
+	}
+	r.registered[gvk] = resolved
+	for _, res := range resolved {
+		r.CustomResourceRegisteredGauge.WithLabelValues(res.Group, res.Version, res.Kind).Set(1)
+	}
+}
+
+// deleteRegistered forgets the given configured GVK, deleting the series it set which
+// are not set by another configured GVK.
+func (r *CRDiscoverer) deleteRegistered(gvk schema.GroupVersionKind) {
+	previous := r.registered[gvk]
+	delete(r.registered, gvk)
+	for _, labels := range previous {
+		shared := false
+		for _, other := range r.registered {
+			if slices.Contains(other, labels) {
+				shared = true
+				break
+			}
+		}
+		if !shared {
+			r.CustomResourceRegisteredGauge.DeleteLabelValues(labels.Group, labels.Version, labels.Kind)
+		}
+	}
+}
@@ -19,10 +19,13 @@ package store
 import (
 	"context"
 	"fmt"
+	"maps"
 	"reflect"
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -35,15 +38,27 @@ import (
 	v1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	nodev1 "k8s.io/api/node/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	k8swatch "k8s.io/apimachinery/pkg/watch"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 
 	ksmtypes "k8s.io/kube-state-metrics/v2/pkg/builder/types"
 	"k8s.io/kube-state-metrics/v2/pkg/customresource"
+	"k8s.io/kube-state-metrics/v2/pkg/metric"
 	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
 	metricsstore "k8s.io/kube-state-metrics/v2/pkg/metrics_store"
 	"k8s.io/kube-state-metrics/v2/pkg/options"
@@ -58,36 +73,79 @@ const ResourceDiscoveryTimeout = 10 * time.Second
 // ResourceDiscoveryInterval is the interval for the resource discovery.
 const ResourceDiscoveryInterval = 100 * time.Millisecond
 
-// Make sure the internal Builder implements the public BuilderInterface.
-// New Builder methods should be added to the public BuilderInterface.
-var _ ksmtypes.BuilderInterface = &Builder{}
+// Make sure the internal Builder implements the public BuilderInterface,
+// as well as the optional interfaces of the public builder types.
+// New Builder methods should be added to a new optional interface, not to
+// BuilderInterface, so that its external implementations keep compiling.
+var (
+	_ ksmtypes.BuilderInterface                   = &Builder{}
+	_ ksmtypes.ClusterKubeClientsBuilder          = &Builder{}
+	_ ksmtypes.ListChunkSizeBuilder               = &Builder{}
+	_ ksmtypes.MetricOverridesBuilder             = &Builder{}
+	_ ksmtypes.ObjectNamesBuilder                 = &Builder{}
+	_ ksmtypes.LabelSelectorsBuilder              = &Builder{}
+	_ ksmtypes.DisableUIDLabelBuilder             = &Builder{}
+	_ ksmtypes.DenyLabelsBuilder                  = &Builder{}
+	_ ksmtypes.DropCompletedInitContainersBuilder = &Builder{}
+)
 
 // Builder helps to build store. It follows the builder pattern
 // (https://en.wikipedia.org/wiki/Builder_pattern).
 type Builder struct {
 	kubeClient                    clientset.Interface
+	clusterKubeClients            map[string]clientset.Interface
 	ctx                           context.Context
 	familyGeneratorFilter         generator.FamilyGeneratorFilter
 	customResourceClients         map[string]interface{}
 	listWatchMetrics              *watch.ListWatchMetrics
 	shardingMetrics               *sharding.Metrics
+	objectCountCollector          *metricsstore.ObjectCountCollector
+	cacheSyncCollector            *metricsstore.CacheSyncCollector
+	generateErrors                *prometheus.CounterVec
+	capturedLabelKeys             *prometheus.GaugeVec
+	resourceEnabled               *prometheus.GaugeVec
 	buildStoresFunc               ksmtypes.BuildStoresFunc
 	buildCustomResourceStoresFunc ksmtypes.BuildCustomResourceStoresFunc
 	allowAnnotationsList          map[string][]string
 	allowLabelsList               map[string][]string
+	denyLabelsList                map[string][]string
+	labelSelectors                map[string]string
+	objectNames                   map[string]string
 	utilOptions                   *options.Options
 	// namespaceFilter is inside fieldSelectorFilter
-	fieldSelectorFilter string
-	namespaces          options.NamespaceList
-	enabledResources    []string
-	totalShards         int
-	shard               int32
-	useAPIServerCache   bool
+	fieldSelectorFilter         string
+	namespaces                  options.NamespaceList
+	enabledResources            []string
+	totalShards                 int
+	shard                       int32
+	useAPIServerCache           bool
+	listChunkSize               int64
+	metricOverrides             options.MetricOverrides
+	disableUIDLabel             bool
+	dropCompletedInitContainers bool
+	clock                       clock.PassiveClock
+
+	// builtStores caches the stores of every resource from the last Build, so that
+	// only the stores of resources which changed since are rebuilt.
+	builtStores map[string]builtStores
+	// customResourceFactories holds the custom resource store factories by GVR.
+	customResourceFactories map[string]customresource.RegistryFactory
+	// staleResources holds the resources whose stores need to be rebuilt as their factory changed.
+	staleResources map[string]struct{}
+}
+
+// builtStores are the stores of a resource, along with the context their reflectors run in.
+type builtStores struct {
+	parent context.Context
+	cancel context.CancelFunc
+	stores []*metricsstore.MetricsStore
 }
 
 // NewBuilder returns a new builder.
 func NewBuilder() *Builder {
-	b := &Builder{}
+	b := &Builder{
+		clock: clock.RealClock{},
+	}
 	return b
 }
 
@@ -103,6 +161,11 @@ func (b *Builder) WithUtilOptions(opts *options.Options) {
 func (b *Builder) WithMetrics(r prometheus.Registerer) {
 	b.listWatchMetrics = watch.NewListWatchMetrics(r)
 	b.shardingMetrics = sharding.NewShardingMetrics(r)
+	b.objectCountCollector = metricsstore.NewObjectCountCollector(r)
+	b.cacheSyncCollector = metricsstore.NewCacheSyncCollector(r)
+	b.generateErrors = generator.NewGenerateErrorsMetric(r)
+	b.capturedLabelKeys = metricsstore.NewCapturedLabelKeysMetric(r)
+	b.resourceEnabled = metricsstore.NewResourceEnabledMetric(r)
 }
 
 // WithEnabledResources sets the enabledResources property of a Builder.
@@ -125,6 +188,38 @@ func (b *Builder) WithFieldSelectorFilter(fieldSelectorFilter string) {
 	b.fieldSelectorFilter = fieldSelectorFilter
 }
 
+// WithLabelSelectors sets the label selectors used when listing and watching
+// the given resources. Keys are resource names, values are label selectors.
+func (b *Builder) WithLabelSelectors(selectors map[string]string) error {
+	for resource, selector := range selectors {
+		if !slices.Contains(labelSelectorResources, resource) {
+			return fmt.Errorf("label selectors are not supported for resource %s. Supported resources: %s", resource, strings.Join(labelSelectorResources, ","))
+		}
+		if _, err := labels.Parse(selector); err != nil {
+			return fmt.Errorf("invalid label selector %q for resource %s: %w", selector, resource, err)
+		}
+	}
+
+	b.labelSelectors = selectors
+	return nil
+}
+
+// WithObjectNames sets the names of the only objects listed and watched for the
+// given resources. Keys are resource names, values are object names.
+func (b *Builder) WithObjectNames(names map[string]string) error {
+	for resource, name := range names {
+		if !slices.Contains(objectNameResources, resource) {
+			return fmt.Errorf("object names are not supported for resource %s. Supported resources: %s", resource, strings.Join(objectNameResources, ","))
+		}
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return fmt.Errorf("invalid object name %q for resource %s: %s", name, resource, strings.Join(errs, ", "))
+		}
+	}
+
+	b.objectNames = names
+	return nil
+}
+
 // WithNamespaces sets the namespaces property of a Builder.
 func (b *Builder) WithNamespaces(n options.NamespaceList) {
 	b.namespaces = n
@@ -155,6 +250,13 @@ func (b *Builder) WithKubeClient(c clientset.Interface) {
 	b.kubeClient = c
 }
 
+// WithClusterKubeClients sets the kube clients of the clusters whose metrics are exposed
+// in multi-cluster mode, keyed by the value of the cluster label their metrics carry.
+// When set, the stores of every resource are built for each of the clusters.
+func (b *Builder) WithClusterKubeClients(clients map[string]clientset.Interface) {
+	b.clusterKubeClients = clients
+}
+
 // WithCustomResourceClients sets the customResourceClients property of a Builder.
 func (b *Builder) WithCustomResourceClients(cs map[string]interface{}) {
 	b.customResourceClients = cs
@@ -165,6 +267,34 @@ func (b *Builder) WithUsingAPIServerCache(u bool) {
 	b.useAPIServerCache = u
 }
 
+// WithListChunkSize configures the maximum number of objects listed per request.
+// 0 uses the default page size of client-go.
+func (b *Builder) WithListChunkSize(s int64) {
+	b.listChunkSize = s
+}
+
+// WithMetricOverrides configures the help texts and units overriding the ones of the metric families.
+func (b *Builder) WithMetricOverrides(o options.MetricOverrides) {
+	b.metricOverrides = o
+}
+
+// WithDisableUIDLabel configures whether the uid label is dropped from the metrics of
+// the resources carrying it by default.
+func (b *Builder) WithDisableUIDLabel(d bool) {
+	b.disableUIDLabel = d
+}
+
+// WithDropCompletedInitContainers configures whether the init container metrics of pods
+// are dropped for init containers which terminated successfully.
+func (b *Builder) WithDropCompletedInitContainers(d bool) {
+	b.dropCompletedInitContainers = d
+}
+
+// WithClock sets the clock used by the metric families which depend on the current time.
+func (b *Builder) WithClock(c clock.PassiveClock) {
+	b.clock = c
+}
+
 // WithFamilyGeneratorFilter configures the family generator filter which decides which
 // metrics are to be exposed by the store build by the Builder.
 func (b *Builder) WithFamilyGeneratorFilter(l generator.FamilyGeneratorFilter) {
@@ -205,9 +335,20 @@ func (b *Builder) WithCustomResourceStoreFactories(fs ...customresource.Registry
 		} else {
 			gvrString = f.Name()
 		}
-		if _, ok := availableStores[gvrString]; ok {
+		if resourceExists(gvrString) {
 			klog.InfoS("Updating store", "GVR", gvrString)
 		}
+		if previous, ok := b.customResourceFactories[gvrString]; !ok || !equalFactories(previous, f) {
+			if b.staleResources == nil {
+				b.staleResources = map[string]struct{}{}
+			}
+			b.staleResources[gvrString] = struct{}{}
+		}
+		if b.customResourceFactories == nil {
+			b.customResourceFactories = map[string]customresource.RegistryFactory{}
+		}
+		b.customResourceFactories[gvrString] = f
+		availableStoresMtx.Lock()
 		availableStores[gvrString] = func(b *Builder) []cache.Store {
 			return b.buildCustomResourceStoresFunc(
 				f.Name(),
@@ -217,6 +358,31 @@ func (b *Builder) WithCustomResourceStoreFactories(fs ...customresource.Registry
 				b.useAPIServerCache,
 			)
 		}
+		availableStoresMtx.Unlock()
+	}
+}
+
+// RetainCustomResourceStoreFactories removes the custom resource store factories whose GVR is not
+// among the given ones, along with their stores, so that the next Build stops their reflectors.
+func (b *Builder) RetainCustomResourceStoreFactories(gvrs []string) {
+	for gvrString := range b.customResourceFactories {
+		if slices.Contains(gvrs, gvrString) {
+			continue
+		}
+		klog.InfoS("Removing store", "GVR", gvrString)
+		delete(b.customResourceFactories, gvrString)
+		b.enabledResources = slices.DeleteFunc(b.enabledResources, func(r string) bool {
+			return r == gvrString
+		})
+		availableStoresMtx.Lock()
+		delete(availableStores, gvrString)
+		availableStoresMtx.Unlock()
+		if b.resourceEnabled != nil {
+			b.resourceEnabled.DeleteLabelValues(gvrString)
+		}
+		if b.capturedLabelKeys != nil {
+			b.capturedLabelKeys.DeletePartialMatch(prometheus.Labels{"resource": gvrString})
+		}
 	}
 }
 
@@ -233,6 +399,16 @@ func (b *Builder) allowList(list map[string][]string) (map[string][]string, erro
 		}
 	}
 
+	// Keys following a leading wildcard are redundant, and denote excluded keys to createPrometheusLabelKeysValues.
+	normalized := make(map[string][]string, len(list))
+	for l, keys := range list {
+		if len(keys) > 0 && keys[0] == options.LabelWildcard {
+			keys = keys[:1]
+		}
+		normalized[l] = keys
+	}
+	list = normalized
+
 	// "*" takes precedence over other specifications
 	allowedList, ok := list["*"]
 	if !ok {
@@ -259,9 +435,23 @@ func (b *Builder) WithAllowLabels(labels map[string][]string) error {
 	return err
 }
 
+// WithDenyLabels configures which labels are never returned for metrics, even if
+// they are allowed by a wildcard.
+func (b *Builder) WithDenyLabels(labels map[string][]string) error {
+	for _, keys := range labels {
+		if slices.Contains(keys, options.LabelWildcard) {
+			return fmt.Errorf("the wildcard %q can not be denied, restrict the labels allow list instead", options.LabelWildcard)
+		}
+	}
+	var err error
+	b.denyLabelsList, err = b.allowList(labels)
+	return err
+}
+
 // Build initializes and registers all enabled stores.
 // It returns metrics writers which can be used to write out
-// metrics from the stores.
+// metrics from the stores. Stores built by a previous Build within the
+// same context are reused, unless the factory of their custom resource changed.
 func (b *Builder) Build() metricsstore.MetricsWriterList {
 	if b.familyGeneratorFilter == nil {
 		panic("familyGeneratorFilter should not be nil")
@@ -269,15 +459,43 @@ func (b *Builder) Build() metricsstore.MetricsWriterList {
 
 	var metricsWriters metricsstore.MetricsWriterList
 	var activeStoreNames []string
+	activeStores := map[string][]*metricsstore.MetricsStore{}
+	built := map[string]builtStores{}
 
 	for _, c := range b.enabledResources {
-		constructor, ok := availableStores[c]
+		constructor, ok := availableStore(c)
 		if ok {
-			stores := cacheStoresToMetricStores(constructor(b))
+			stores, ok := b.builtStores[c]
+			if _, stale := b.staleResources[c]; !ok || stale || stores.parent != b.ctx {
+				if ok {
+					stores.cancel()
+				}
+				stores = b.buildResourceStores(constructor)
+			}
+			built[c] = stores
 			activeStoreNames = append(activeStoreNames, c)
-			metricsWriters = append(metricsWriters, metricsstore.NewMetricsWriter(stores...))
+			activeStores[c] = stores.stores
+			metricsWriters = append(metricsWriters, metricsstore.NewResourceMetricsWriter(c, stores.stores...))
+		}
+	}
+
+	// Stop the stores of resources which are not enabled anymore.
+	for c, stores := range b.builtStores {
+		if _, ok := built[c]; !ok {
+			stores.cancel()
 		}
 	}
+	b.builtStores = built
+	b.staleResources = nil
+
+	if b.objectCountCollector != nil {
+		b.objectCountCollector.SetStores(activeStores)
+	}
+	if b.cacheSyncCollector != nil {
+		b.cacheSyncCollector.SetStores(activeStores)
+	}
+	b.updateCapturedLabelKeys(activeStoreNames)
+	b.updateResourceEnabled(activeStoreNames)
 
 	if len(activeStoreNames) > 0 {
 		klog.InfoS("Active resources", "activeStoreNames", strings.Join(activeStoreNames, ","))
@@ -286,6 +504,71 @@ func (b *Builder) Build() metricsstore.MetricsWriterList {
 	return metricsWriters
 }
 
+// updateCapturedLabelKeys exposes the label keys allowed and not denied for each of the given resources.
+func (b *Builder) updateCapturedLabelKeys(resources []string) {
+	if b.capturedLabelKeys == nil {
+		return
+	}
+
+	b.capturedLabelKeys.Reset()
+	for _, resource := range resources {
+		denied := make(map[string]struct{}, len(b.denyLabelsList[resource]))
+		for _, key := range b.denyLabelsList[resource] {
+			denied[key] = struct{}{}
+		}
+		for _, key := range b.allowLabelsList[resource] {
+			if _, ok := denied[key]; ok {
+				continue
+			}
+			b.capturedLabelKeys.WithLabelValues(resource, key).Set(1)
+		}
+	}
+}
+
+// updateResourceEnabled exposes which of the available resources are among the given active resources.
+// The available resources are read under the lock, so the series are reset and set for a single set of stores.
+func (b *Builder) updateResourceEnabled(resources []string) {
+	if b.resourceEnabled == nil {
+		return
+	}
+
+	availableStoresMtx.RLock()
+	defer availableStoresMtx.RUnlock()
+
+	b.resourceEnabled.Reset()
+	for resource := range availableStores {
+		enabled := 0.0
+		if slices.Contains(resources, resource) {
+			enabled = 1
+		}
+		b.resourceEnabled.WithLabelValues(resource).Set(enabled)
+	}
+}
+
+// buildResourceStores builds the stores of a resource, running their reflectors
+// in a context of their own, so that they can be stopped independently of other resources.
+func (b *Builder) buildResourceStores(constructor func(b *Builder) []cache.Store) builtStores {
+	parent := b.ctx
+	ctx, cancel := context.WithCancel(parent)
+	b.ctx = ctx
+	defer func() {
+		b.ctx = parent
+	}()
+
+	return builtStores{
+		parent: parent,
+		cancel: cancel,
+		stores: cacheStoresToMetricStores(constructor(b)),
+	}
+}
+
+// equalFactories reports whether the custom resource store factory replacing
+// previous generates the same metrics, so that the stores of previous can be kept.
+func equalFactories(previous, f customresource.RegistryFactory) bool {
+	c, ok := previous.(customresource.ComparableRegistryFactory)
+	return ok && c.Equal(f)
+}
+
 // BuildStores initializes and registers all enabled stores.
 // It returns metric stores which can be used to consume
 // the generated metrics from the stores.
@@ -296,27 +579,43 @@ func (b *Builder) BuildStores() [][]cache.Store {
 
 	var allStores [][]cache.Store
 	var activeStoreNames []string
+	activeStores := map[string][]*metricsstore.MetricsStore{}
 
 	for _, c := range b.enabledResources {
-		constructor, ok := availableStores[c]
+		constructor, ok := availableStore(c)
 		if ok {
 			stores := constructor(b)
 			activeStoreNames = append(activeStoreNames, c)
 			allStores = append(allStores, stores)
+			for _, store := range stores {
+				if ms, ok := store.(*metricsstore.MetricsStore); ok {
+					activeStores[c] = append(activeStores[c], ms)
+				}
+			}
 		}
 	}
 
+	if b.objectCountCollector != nil {
+		b.objectCountCollector.SetStores(activeStores)
+	}
+
 	klog.InfoS("Active resources", "activeStoreNames", strings.Join(activeStoreNames, ","))
 
 	return allStores
 }
 
+// availableStoresMtx guards availableStores, which stores are registered in at runtime.
+var availableStoresMtx sync.RWMutex
+
 var availableStores = map[string]func(f *Builder) []cache.Store{
 	"certificatesigningrequests":      func(b *Builder) []cache.Store { return b.buildCsrStores() },
 	"clusterroles":                    func(b *Builder) []cache.Store { return b.buildClusterRoleStores() },
 	"configmaps":                      func(b *Builder) []cache.Store { return b.buildConfigMapStores() },
 	"clusterrolebindings":             func(b *Builder) []cache.Store { return b.buildClusterRoleBindingStores() },
 	"cronjobs":                        func(b *Builder) []cache.Store { return b.buildCronJobStores() },
+	"csidrivers":                      func(b *Builder) []cache.Store { return b.buildCSIDriverStores() },
+	"csinodes":                        func(b *Builder) []cache.Store { return b.buildCSINodeStores() },
+	"csistoragecapacities":            func(b *Builder) []cache.Store { return b.buildCSIStorageCapacityStores() },
 	"daemonsets":                      func(b *Builder) []cache.Store { return b.buildDaemonSetStores() },
 	"deployments":                     func(b *Builder) []cache.Store { return b.buildDeploymentStores() },
 	"endpoints":                       func(b *Builder) []cache.Store { return b.buildEndpointsStores() },
@@ -335,10 +634,12 @@ var availableStores = map[string]func(f *Builder) []cache.Store{
 	"persistentvolumes":               func(b *Builder) []cache.Store { return b.buildPersistentVolumeStores() },
 	"poddisruptionbudgets":            func(b *Builder) []cache.Store { return b.buildPodDisruptionBudgetStores() },
 	"pods":                            func(b *Builder) []cache.Store { return b.buildPodStores() },
+	"priorityclasses":                 func(b *Builder) []cache.Store { return b.buildPriorityClassStores() },
 	"replicasets":                     func(b *Builder) []cache.Store { return b.buildReplicaSetStores() },
 	"replicationcontrollers":          func(b *Builder) []cache.Store { return b.buildReplicationControllerStores() },
 	"resourcequotas":                  func(b *Builder) []cache.Store { return b.buildResourceQuotaStores() },
 	"roles":                           func(b *Builder) []cache.Store { return b.buildRoleStores() },
+	"runtimeclasses":                  func(b *Builder) []cache.Store { return b.buildRuntimeClassStores() },
 	"rolebindings":                    func(b *Builder) []cache.Store { return b.buildRoleBindingStores() },
 	"secrets":                         func(b *Builder) []cache.Store { return b.buildSecretStores() },
 	"serviceaccounts":                 func(b *Builder) []cache.Store { return b.buildServiceAccountStores() },
@@ -349,12 +650,77 @@ var availableStores = map[string]func(f *Builder) []cache.Store{
 	"volumeattachments":               func(b *Builder) []cache.Store { return b.buildVolumeAttachmentStores() },
 }
 
+// RegisterStore makes a store for the given resource available to all builders,
+// generating the given metric families for objects of the expected type.
+// It is safe for concurrent use, and fails if a store for the resource is already
+// available.
+func RegisterStore(resource string, metricFamilies []generator.FamilyGenerator, expectedType interface{}, listWatchFunc func(kubeClient clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher) error {
+	availableStoresMtx.Lock()
+	defer availableStoresMtx.Unlock()
+
+	if _, ok := availableStores[resource]; ok {
+		return fmt.Errorf("a store for resource %s is already registered", resource)
+	}
+
+	availableStores[resource] = func(b *Builder) []cache.Store {
+		return b.buildStoresFunc(metricFamilies, expectedType, listWatchFunc, b.useAPIServerCache)
+	}
+	return nil
+}
+
+// UnregisterStore removes the store registered for the given resource by RegisterStore.
+// Builders which already built the store keep it until they are rebuilt.
+func UnregisterStore(resource string) {
+	availableStoresMtx.Lock()
+	defer availableStoresMtx.Unlock()
+
+	delete(availableStores, resource)
+}
+
+// MetricFamilyNames returns the sorted names of the metric families of all
+// available stores, excluding custom resource stores.
+func MetricFamilyNames() []string {
+	names := []string{}
+	b := NewBuilder()
+	b.buildStoresFunc = func(metricFamilies []generator.FamilyGenerator, _ interface{}, _ func(kubeClient clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher, _ bool) []cache.Store {
+		for _, f := range metricFamilies {
+			names = append(names, f.Name)
+		}
+		return nil
+	}
+	b.buildCustomResourceStoresFunc = func(string, []generator.FamilyGenerator, interface{}, func(customResourceClient interface{}, ns string, fieldSelector string) cache.ListerWatcher, bool) []cache.Store {
+		return nil
+	}
+	availableStoresMtx.RLock()
+	constructors := make([]func(f *Builder) []cache.Store, 0, len(availableStores))
+	for _, constructor := range availableStores {
+		constructors = append(constructors, constructor)
+	}
+	availableStoresMtx.RUnlock()
+	for _, constructor := range constructors {
+		constructor(b)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+func availableStore(name string) (func(f *Builder) []cache.Store, bool) {
+	availableStoresMtx.RLock()
+	defer availableStoresMtx.RUnlock()
+
+	constructor, ok := availableStores[name]
+	return constructor, ok
+}
+
 func resourceExists(name string) bool {
-	_, ok := availableStores[name]
+	_, ok := availableStore(name)
 	return ok
 }
 
 func availableResources() []string {
+	availableStoresMtx.RLock()
+	defer availableStoresMtx.RUnlock()
+
 	c := []string{}
 	for name := range availableStores {
 		c = append(c, name)
@@ -363,39 +729,39 @@ func availableResources() []string {
 }
 
 func (b *Builder) buildConfigMapStores() []cache.Store {
-	return b.buildStoresFunc(configMapMetricFamilies(b.allowAnnotationsList["configmaps"], b.allowLabelsList["configmaps"]), &v1.ConfigMap{}, createConfigMapListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(configMapMetricFamilies(b.allowAnnotationsList["configmaps"], b.allowedLabels("configmaps")), &v1.ConfigMap{}, createConfigMapListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildCronJobStores() []cache.Store {
-	return b.buildStoresFunc(cronJobMetricFamilies(b.allowAnnotationsList["cronjobs"], b.allowLabelsList["cronjobs"]), &batchv1.CronJob{}, createCronJobListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(cronJobMetricFamilies(b.allowAnnotationsList["cronjobs"], b.allowedLabels("cronjobs")), &batchv1.CronJob{}, createCronJobListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildDaemonSetStores() []cache.Store {
-	return b.buildStoresFunc(daemonSetMetricFamilies(b.allowAnnotationsList["daemonsets"], b.allowLabelsList["daemonsets"]), &appsv1.DaemonSet{}, createDaemonSetListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(daemonSetMetricFamilies(b.allowAnnotationsList["daemonsets"], b.allowedLabels("daemonsets")), &appsv1.DaemonSet{}, b.withObjectName("daemonsets", createDaemonSetListWatch), b.useAPIServerCache)
 }
 
 func (b *Builder) buildDeploymentStores() []cache.Store {
-	return b.buildStoresFunc(deploymentMetricFamilies(b.allowAnnotationsList["deployments"], b.allowLabelsList["deployments"]), &appsv1.Deployment{}, createDeploymentListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(deploymentMetricFamilies(b.allowAnnotationsList["deployments"], b.allowedLabels("deployments")), &appsv1.Deployment{}, b.withObjectName("deployments", createDeploymentListWatch), b.useAPIServerCache)
 }
 
 func (b *Builder) buildEndpointsStores() []cache.Store {
-	return b.buildStoresFunc(endpointMetricFamilies(b.allowAnnotationsList["endpoints"], b.allowLabelsList["endpoints"]), &v1.Endpoints{}, createEndpointsListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(endpointMetricFamilies(b.allowAnnotationsList["endpoints"], b.allowedLabels("endpoints")), &v1.Endpoints{}, createEndpointsListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildEndpointSlicesStores() []cache.Store {
-	return b.buildStoresFunc(endpointSliceMetricFamilies(b.allowAnnotationsList["endpointslices"], b.allowLabelsList["endpointslices"]), &discoveryv1.EndpointSlice{}, createEndpointSliceListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(endpointSliceMetricFamilies(b.allowAnnotationsList["endpointslices"], b.allowedLabels("endpointslices")), &discoveryv1.EndpointSlice{}, createEndpointSliceListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildHPAStores() []cache.Store {
-	return b.buildStoresFunc(hpaMetricFamilies(b.allowAnnotationsList["horizontalpodautoscalers"], b.allowLabelsList["horizontalpodautoscalers"]), &autoscaling.HorizontalPodAutoscaler{}, createHPAListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(hpaMetricFamilies(b.allowAnnotationsList["horizontalpodautoscalers"], b.allowedLabels("horizontalpodautoscalers")), &autoscaling.HorizontalPodAutoscaler{}, createHPAListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildIngressStores() []cache.Store {
-	return b.buildStoresFunc(ingressMetricFamilies(b.allowAnnotationsList["ingresses"], b.allowLabelsList["ingresses"]), &networkingv1.Ingress{}, createIngressListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(ingressMetricFamilies(b.allowAnnotationsList["ingresses"], b.allowedLabels("ingresses")), &networkingv1.Ingress{}, createIngressListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildJobStores() []cache.Store {
-	return b.buildStoresFunc(jobMetricFamilies(b.allowAnnotationsList["jobs"], b.allowLabelsList["jobs"]), &batchv1.Job{}, createJobListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(jobMetricFamilies(b.allowAnnotationsList["jobs"], b.allowedLabels("jobs")), &batchv1.Job{}, createJobListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildLimitRangeStores() []cache.Store {
@@ -407,31 +773,31 @@ func (b *Builder) buildMutatingWebhookConfigurationStores() []cache.Store {
 }
 
 func (b *Builder) buildNamespaceStores() []cache.Store {
-	return b.buildStoresFunc(namespaceMetricFamilies(b.allowAnnotationsList["namespaces"], b.allowLabelsList["namespaces"]), &v1.Namespace{}, createNamespaceListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(namespaceMetricFamilies(b.allowAnnotationsList["namespaces"], b.allowedLabels("namespaces")), &v1.Namespace{}, createNamespaceListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildNetworkPolicyStores() []cache.Store {
-	return b.buildStoresFunc(networkPolicyMetricFamilies(b.allowAnnotationsList["networkpolicies"], b.allowLabelsList["networkpolicies"]), &networkingv1.NetworkPolicy{}, createNetworkPolicyListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(networkPolicyMetricFamilies(b.allowAnnotationsList["networkpolicies"], b.allowedLabels("networkpolicies")), &networkingv1.NetworkPolicy{}, createNetworkPolicyListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildNodeStores() []cache.Store {
-	return b.buildStoresFunc(nodeMetricFamilies(b.allowAnnotationsList["nodes"], b.allowLabelsList["nodes"]), &v1.Node{}, createNodeListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(nodeMetricFamilies(b.allowAnnotationsList["nodes"], b.allowedLabels("nodes")), &v1.Node{}, createNodeListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildPersistentVolumeClaimStores() []cache.Store {
-	return b.buildStoresFunc(persistentVolumeClaimMetricFamilies(b.allowAnnotationsList["persistentvolumeclaims"], b.allowLabelsList["persistentvolumeclaims"]), &v1.PersistentVolumeClaim{}, createPersistentVolumeClaimListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(persistentVolumeClaimMetricFamilies(b.allowAnnotationsList["persistentvolumeclaims"], b.allowedLabels("persistentvolumeclaims")), &v1.PersistentVolumeClaim{}, createPersistentVolumeClaimListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildPersistentVolumeStores() []cache.Store {
-	return b.buildStoresFunc(persistentVolumeMetricFamilies(b.allowAnnotationsList["persistentvolumes"], b.allowLabelsList["persistentvolumes"]), &v1.PersistentVolume{}, createPersistentVolumeListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(persistentVolumeMetricFamilies(b.allowAnnotationsList["persistentvolumes"], b.allowedLabels("persistentvolumes")), &v1.PersistentVolume{}, createPersistentVolumeListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildPodDisruptionBudgetStores() []cache.Store {
-	return b.buildStoresFunc(podDisruptionBudgetMetricFamilies(b.allowAnnotationsList["poddisruptionbudgets"], b.allowLabelsList["poddisruptionbudgets"]), &policyv1.PodDisruptionBudget{}, createPodDisruptionBudgetListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(podDisruptionBudgetMetricFamilies(b.allowAnnotationsList["poddisruptionbudgets"], b.allowedLabels("poddisruptionbudgets")), &policyv1.PodDisruptionBudget{}, createPodDisruptionBudgetListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildReplicaSetStores() []cache.Store {
-	return b.buildStoresFunc(replicaSetMetricFamilies(b.allowAnnotationsList["replicasets"], b.allowLabelsList["replicasets"]), &appsv1.ReplicaSet{}, createReplicaSetListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(replicaSetMetricFamilies(b.allowAnnotationsList["replicasets"], b.allowedLabels("replicasets")), &appsv1.ReplicaSet{}, createReplicaSetListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildReplicationControllerStores() []cache.Store {
@@ -439,35 +805,276 @@ func (b *Builder) buildReplicationControllerStores() []cache.Store {
 }
 
 func (b *Builder) buildResourceQuotaStores() []cache.Store {
-	return b.buildStoresFunc(resourceQuotaMetricFamilies(b.allowAnnotationsList["resourcequotas"], b.allowLabelsList["resourcequotas"]), &v1.ResourceQuota{}, createResourceQuotaListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(resourceQuotaMetricFamilies(b.allowAnnotationsList["resourcequotas"], b.allowedLabels("resourcequotas")), &v1.ResourceQuota{}, createResourceQuotaListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildSecretStores() []cache.Store {
-	return b.buildStoresFunc(secretMetricFamilies(b.allowAnnotationsList["secrets"], b.allowLabelsList["secrets"]), &v1.Secret{}, createSecretListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(secretMetricFamilies(b.allowAnnotationsList["secrets"], b.allowedLabels("secrets")), &v1.Secret{}, createSecretListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildServiceAccountStores() []cache.Store {
-	return b.buildStoresFunc(serviceAccountMetricFamilies(b.allowAnnotationsList["serviceaccounts"], b.allowLabelsList["serviceaccounts"]), &v1.ServiceAccount{}, createServiceAccountListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(b.withUIDLabel(serviceAccountMetricFamilies(b.allowAnnotationsList["serviceaccounts"], b.allowedLabels("serviceaccounts"))), &v1.ServiceAccount{}, createServiceAccountListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildServiceStores() []cache.Store {
-	return b.buildStoresFunc(serviceMetricFamilies(b.allowAnnotationsList["services"], b.allowLabelsList["services"]), &v1.Service{}, createServiceListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(b.withUIDLabel(serviceMetricFamilies(b.allowAnnotationsList["services"], b.allowedLabels("services"), b.endpointSliceLister())), &v1.Service{}, createServiceListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildStatefulSetStores() []cache.Store {
-	return b.buildStoresFunc(statefulSetMetricFamilies(b.allowAnnotationsList["statefulsets"], b.allowLabelsList["statefulsets"]), &appsv1.StatefulSet{}, createStatefulSetListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(statefulSetMetricFamilies(b.allowAnnotationsList["statefulsets"], b.allowedLabels("statefulsets")), &appsv1.StatefulSet{}, b.withObjectName("statefulsets", createStatefulSetListWatch), b.useAPIServerCache)
 }
 
 func (b *Builder) buildStorageClassStores() []cache.Store {
-	return b.buildStoresFunc(storageClassMetricFamilies(b.allowAnnotationsList["storageclasses"], b.allowLabelsList["storageclasses"]), &storagev1.StorageClass{}, createStorageClassListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(storageClassMetricFamilies(b.allowAnnotationsList["storageclasses"], b.allowedLabels("storageclasses")), &storagev1.StorageClass{}, createStorageClassListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildPodStores() []cache.Store {
-	return b.buildStoresFunc(podMetricFamilies(b.allowAnnotationsList["pods"], b.allowLabelsList["pods"]), &v1.Pod{}, createPodListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(b.withoutCompletedInitContainers(b.withUIDLabel(podMetricFamilies(b.allowAnnotationsList["pods"], b.allowedLabels("pods"), b.clock, b.limitRangeLister(), b.nodeGetter(), b.ownerGetter()))), &v1.Pod{}, b.withObjectName("pods", b.withLabelSelector("pods", createPodListWatch)), b.useAPIServerCache)
+}
+
+// limitRangeLister starts reflectors for the LimitRanges of the watched namespaces if
+// one of the pod families filling in LimitRange defaults is enabled, and returns a lister
+// reading from them. The LimitRanges are not sharded, as the pods of every shard need them.
+func (b *Builder) limitRangeLister() limitRangeLister {
+	if b.familyGeneratorFilter == nil || b.kubeClient == nil {
+		return nil
+	}
+	enabled := false
+	for _, name := range []string{descPodContainerResourceRequestsWithDefaultsName, descPodContainerResourceLimitsWithDefaultsName} {
+		if b.familyGeneratorFilter.Test(generator.FamilyGenerator{Name: name, OptIn: true}) {
+			enabled = true
+		}
+	}
+	if !enabled {
+		return nil
+	}
+	if len(b.clusterKubeClients) > 0 {
+		klog.InfoS("LimitRange defaults are not filled in for pods in multi-cluster mode")
+		return nil
+	}
+
+	limitRanges := b.startNamespacedIndexers(b.namespaces, &v1.LimitRange{}, createLimitRangeListWatch, cache.Indexers{})
+
+	return func(namespace string) ([]*v1.LimitRange, bool) {
+		indexer, synced := limitRanges.indexer(namespace)
+		if !synced {
+			return nil, false
+		}
+		objs, err := indexer.ByIndex(cache.NamespaceIndex, namespace)
+		if err != nil {
+			return nil, false
+		}
+		lrs := make([]*v1.LimitRange, 0, len(objs))
+		for _, obj := range objs {
+			lrs = append(lrs, obj.(*v1.LimitRange))
+		}
+		// Apply the defaults of the LimitRanges in a stable order.
+		slices.SortFunc(lrs, func(a, b *v1.LimitRange) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		return lrs, true
+	}
+}
+
+// namespacedIndexers holds the stores of the reflectors of a resource, one per watched namespace,
+// as a reflector replaces the whole content of its store whenever it relists its namespace.
+type namespacedIndexers map[string]*syncedIndexer
+
+// syncedIndexer is an indexer recording whether it was populated by the initial list of its reflector.
+type syncedIndexer struct {
+	cache.Indexer
+	synced atomic.Bool
+}
+
+// Replace replaces the content of the indexer with the given list.
+func (s *syncedIndexer) Replace(list []interface{}, resourceVersion string) error {
+	if err := s.Indexer.Replace(list, resourceVersion); err != nil {
+		return err
+	}
+	s.synced.Store(true)
+	return nil
+}
+
+// startNamespacedIndexers starts a reflector listing and watching the given resource for each
+// of the given namespaces, storing the objects of each namespace in a separate indexer, which
+// is always indexed by namespace. The reflectors are not sharded and stop with the builder's context.
+func (b *Builder) startNamespacedIndexers(
+	namespaces []string,
+	expectedType interface{},
+	listWatchFunc func(kubeClient clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher,
+	indexers cache.Indexers,
+) namespacedIndexers {
+	n := make(namespacedIndexers, len(namespaces))
+	resource := reflect.TypeOf(expectedType).String()
+	for _, ns := range namespaces {
+		nsIndexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+		for name, f := range indexers {
+			nsIndexers[name] = f
+		}
+		indexer := &syncedIndexer{Indexer: cache.NewIndexer(cache.MetaNamespaceKeyFunc, nsIndexers)}
+		listWatcher := watch.NewInstrumentedListerWatcher(listWatchFunc(b.kubeClient, ns, ""), b.listWatchMetrics, resource, b.useAPIServerCache)
+		reflector := b.newReflector(listWatcher, expectedType, indexer)
+		n[ns] = indexer
+		go watch.RunReflector(reflector, b.listWatchMetrics, resource, b.ctx.Done())
+	}
+	return n
+}
+
+// indexer returns the indexer holding the objects of the given namespace, and whether its
+// reflector has listed them yet. Objects which are not namespaced are looked up with an empty
+// namespace. It returns false if the namespace is not watched.
+func (n namespacedIndexers) indexer(namespace string) (cache.Indexer, bool) {
+	indexer, ok := n[namespace]
+	if !ok {
+		indexer, ok = n[metav1.NamespaceAll]
+	}
+	if !ok {
+		return nil, false
+	}
+	return indexer, indexer.synced.Load()
+}
+
+// nodeGetter starts a reflector for the nodes of the cluster if the pod family exposing
+// the topology of the node of a pod is enabled, and returns a getter reading from it.
+// The nodes are not sharded, as the pods of every shard need them.
+func (b *Builder) nodeGetter() nodeGetter {
+	if b.familyGeneratorFilter == nil || b.kubeClient == nil {
+		return nil
+	}
+	if !b.familyGeneratorFilter.Test(generator.FamilyGenerator{Name: descPodNodeTopologyName, OptIn: true}) {
+		return nil
+	}
+	if len(b.clusterKubeClients) > 0 {
+		klog.InfoS("Node topology is not exposed for pods in multi-cluster mode")
+		return nil
+	}
+
+	nodes := b.startNamespacedIndexers([]string{metav1.NamespaceAll}, &v1.Node{}, createNodeListWatch, cache.Indexers{})
+
+	return func(name string) *v1.Node {
+		indexer, synced := nodes.indexer(metav1.NamespaceAll)
+		if !synced {
+			return nil
+		}
+		obj, exists, err := indexer.GetByKey(name)
+		if err != nil || !exists {
+			return nil
+		}
+		return obj.(*v1.Node)
+	}
+}
+
+// ownerKinds are the kinds of controller owners of pods whose existence is tracked
+// for kube_pod_orphaned, along with the functions listing and watching them.
+var ownerKinds = map[schema.GroupKind]struct {
+	expectedType  interface{}
+	listWatchFunc func(kubeClient clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher
+}{
+	{Group: appsv1.GroupName, Kind: "DaemonSet"}:         {&appsv1.DaemonSet{}, createDaemonSetListWatch},
+	{Group: appsv1.GroupName, Kind: "ReplicaSet"}:        {&appsv1.ReplicaSet{}, createReplicaSetListWatch},
+	{Group: appsv1.GroupName, Kind: "StatefulSet"}:       {&appsv1.StatefulSet{}, createStatefulSetListWatch},
+	{Group: batchv1.GroupName, Kind: "Job"}:              {&batchv1.Job{}, createJobListWatch},
+	{Group: v1.GroupName, Kind: "ReplicationController"}: {&v1.ReplicationController{}, createReplicationControllerListWatch},
+}
+
+// ownerGetter starts reflectors for the controllers owning pods in the watched namespaces if
+// the pod family exposing orphaned pods is enabled, and returns a getter reading from them.
+// The controllers are not sharded, as the pods of a shard may be owned by controllers of any shard.
+func (b *Builder) ownerGetter() ownerGetter {
+	if b.familyGeneratorFilter == nil || b.kubeClient == nil {
+		return nil
+	}
+	if !b.familyGeneratorFilter.Test(generator.FamilyGenerator{Name: descPodOrphanedName, OptIn: true}) {
+		return nil
+	}
+	if len(b.clusterKubeClients) > 0 {
+		klog.InfoS("Orphaned pods are not exposed in multi-cluster mode")
+		return nil
+	}
+
+	owners := make(map[schema.GroupKind]namespacedIndexers, len(ownerKinds))
+	for gk, kind := range ownerKinds {
+		owners[gk] = b.startNamespacedIndexers(b.namespaces, kind.expectedType, kind.listWatchFunc, cache.Indexers{})
+	}
+
+	return func(namespace string, owner metav1.OwnerReference) (bool, bool) {
+		gv, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err != nil {
+			return false, false
+		}
+		indexers, ok := owners[gv.WithKind(owner.Kind).GroupKind()]
+		if !ok {
+			return false, false
+		}
+		indexer, synced := indexers.indexer(namespace)
+		if !synced {
+			return false, false
+		}
+		obj, exists, err := indexer.GetByKey(namespace + "/" + owner.Name)
+		if err != nil {
+			return false, false
+		}
+		if !exists {
+			return false, true
+		}
+		// An owner recreated under the same name is a different object.
+		o, err := meta.Accessor(obj)
+		if err != nil {
+			return false, false
+		}
+		return o.GetUID() == owner.UID, true
+	}
+}
+
+// endpointSliceServiceIndex indexes EndpointSlices by the namespaced name of their service.
+const endpointSliceServiceIndex = "service"
+
+// endpointSliceLister starts reflectors for the EndpointSlices of the watched namespaces if
+// the service family exposing ready endpoints is enabled, and returns a lister reading from them.
+// The EndpointSlices are not sharded, as the services of a shard may have slices of any shard.
+func (b *Builder) endpointSliceLister() endpointSliceLister {
+	if b.familyGeneratorFilter == nil || b.kubeClient == nil {
+		return nil
+	}
+	if !b.familyGeneratorFilter.Test(generator.FamilyGenerator{Name: descServiceReadyEndpointsName, OptIn: true}) {
+		return nil
+	}
+	if len(b.clusterKubeClients) > 0 {
+		klog.InfoS("Ready endpoints are not exposed for services in multi-cluster mode")
+		return nil
+	}
+
+	endpointSlices := b.startNamespacedIndexers(b.namespaces, &discoveryv1.EndpointSlice{}, createEndpointSliceListWatch, cache.Indexers{
+		endpointSliceServiceIndex: func(obj interface{}) ([]string, error) {
+			slice := obj.(*discoveryv1.EndpointSlice)
+			service, ok := slice.Labels[discoveryv1.LabelServiceName]
+			if !ok {
+				return nil, nil
+			}
+			return []string{slice.Namespace + "/" + service}, nil
+		},
+	})
+
+	return func(namespace, service string) ([]*discoveryv1.EndpointSlice, bool) {
+		// Services cannot be told apart from services without EndpointSlices before the initial list.
+		indexer, synced := endpointSlices.indexer(namespace)
+		if !synced {
+			return nil, false
+		}
+		objs, err := indexer.ByIndex(endpointSliceServiceIndex, namespace+"/"+service)
+		if err != nil {
+			return nil, false
+		}
+		slices := make([]*discoveryv1.EndpointSlice, 0, len(objs))
+		for _, obj := range objs {
+			slices = append(slices, obj.(*discoveryv1.EndpointSlice))
+		}
+		return slices, true
+	}
 }
 
 func (b *Builder) buildCsrStores() []cache.Store {
-	return b.buildStoresFunc(csrMetricFamilies(b.allowAnnotationsList["certificatesigningrequests"], b.allowLabelsList["certificatesigningrequests"]), &certv1.CertificateSigningRequest{}, createCSRListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(csrMetricFamilies(b.allowAnnotationsList["certificatesigningrequests"], b.allowedLabels("certificatesigningrequests")), &certv1.CertificateSigningRequest{}, createCSRListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildValidatingWebhookConfigurationStores() []cache.Store {
@@ -483,23 +1090,43 @@ func (b *Builder) buildLeasesStores() []cache.Store {
 }
 
 func (b *Builder) buildClusterRoleStores() []cache.Store {
-	return b.buildStoresFunc(clusterRoleMetricFamilies(b.allowAnnotationsList["clusterroles"], b.allowLabelsList["clusterroles"]), &rbacv1.ClusterRole{}, createClusterRoleListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(clusterRoleMetricFamilies(b.allowAnnotationsList["clusterroles"], b.allowedLabels("clusterroles")), &rbacv1.ClusterRole{}, createClusterRoleListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildRoleStores() []cache.Store {
-	return b.buildStoresFunc(roleMetricFamilies(b.allowAnnotationsList["roles"], b.allowLabelsList["roles"]), &rbacv1.Role{}, createRoleListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(roleMetricFamilies(b.allowAnnotationsList["roles"], b.allowedLabels("roles")), &rbacv1.Role{}, createRoleListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildClusterRoleBindingStores() []cache.Store {
-	return b.buildStoresFunc(clusterRoleBindingMetricFamilies(b.allowAnnotationsList["clusterrolebindings"], b.allowLabelsList["clusterrolebindings"]), &rbacv1.ClusterRoleBinding{}, createClusterRoleBindingListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(clusterRoleBindingMetricFamilies(b.allowAnnotationsList["clusterrolebindings"], b.allowedLabels("clusterrolebindings")), &rbacv1.ClusterRoleBinding{}, createClusterRoleBindingListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildRoleBindingStores() []cache.Store {
-	return b.buildStoresFunc(roleBindingMetricFamilies(b.allowAnnotationsList["rolebindings"], b.allowLabelsList["rolebindings"]), &rbacv1.RoleBinding{}, createRoleBindingListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(roleBindingMetricFamilies(b.allowAnnotationsList["rolebindings"], b.allowedLabels("rolebindings")), &rbacv1.RoleBinding{}, createRoleBindingListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildIngressClassStores() []cache.Store {
-	return b.buildStoresFunc(ingressClassMetricFamilies(b.allowAnnotationsList["ingressclasses"], b.allowLabelsList["ingressclasses"]), &networkingv1.IngressClass{}, createIngressClassListWatch, b.useAPIServerCache)
+	return b.buildStoresFunc(ingressClassMetricFamilies(b.allowAnnotationsList["ingressclasses"], b.allowedLabels("ingressclasses")), &networkingv1.IngressClass{}, createIngressClassListWatch, b.useAPIServerCache)
+}
+
+func (b *Builder) buildRuntimeClassStores() []cache.Store {
+	return b.buildStoresFunc(runtimeClassMetricFamilies(b.allowAnnotationsList["runtimeclasses"], b.allowedLabels("runtimeclasses")), &nodev1.RuntimeClass{}, createRuntimeClassListWatch, b.useAPIServerCache)
+}
+
+func (b *Builder) buildPriorityClassStores() []cache.Store {
+	return b.buildStoresFunc(priorityClassMetricFamilies(b.allowAnnotationsList["priorityclasses"], b.allowedLabels("priorityclasses")), &schedulingv1.PriorityClass{}, createPriorityClassListWatch, b.useAPIServerCache)
+}
+
+func (b *Builder) buildCSINodeStores() []cache.Store {
+	return b.buildStoresFunc(csiNodeMetricFamilies(b.allowAnnotationsList["csinodes"], b.allowedLabels("csinodes")), &storagev1.CSINode{}, createCSINodeListWatch, b.useAPIServerCache)
+}
+
+func (b *Builder) buildCSIDriverStores() []cache.Store {
+	return b.buildStoresFunc(csiDriverMetricFamilies(b.allowAnnotationsList["csidrivers"], b.allowedLabels("csidrivers")), &storagev1.CSIDriver{}, createCSIDriverListWatch, b.useAPIServerCache)
+}
+
+func (b *Builder) buildCSIStorageCapacityStores() []cache.Store {
+	return b.buildStoresFunc(csiStorageCapacityMetricFamilies(b.allowAnnotationsList["csistoragecapacities"], b.allowedLabels("csistoragecapacities")), &storagev1.CSIStorageCapacity{}, createCSIStorageCapacityListWatch, b.useAPIServerCache)
 }
 
 func (b *Builder) buildStores(
@@ -508,8 +1135,29 @@ func (b *Builder) buildStores(
 	listWatchFunc func(kubeClient clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher,
 	useAPIServerCache bool,
 ) []cache.Store {
-	metricFamilies = generator.FilterFamilyGenerators(b.familyGeneratorFilter, metricFamilies)
-	composedMetricGenFuncs := generator.ComposeMetricGenFuncs(metricFamilies)
+	metricFamilies = b.withMetricOverrides(generator.FilterFamilyGenerators(b.familyGeneratorFilter, metricFamilies))
+	if len(b.clusterKubeClients) == 0 {
+		return b.buildClusterStores(b.kubeClient, metricFamilies, expectedType, listWatchFunc, useAPIServerCache)
+	}
+
+	// The stores of all clusters end up in the same metrics writer, so that the
+	// headers of every family are only written out once.
+	var stores []cache.Store
+	for _, cluster := range slices.Sorted(maps.Keys(b.clusterKubeClients)) {
+		stores = append(stores, b.buildClusterStores(b.clusterKubeClients[cluster], withClusterLabel(cluster, metricFamilies), expectedType, listWatchFunc, useAPIServerCache)...)
+	}
+	return stores
+}
+
+// buildClusterStores builds the stores of a resource for the cluster of the given client.
+func (b *Builder) buildClusterStores(
+	kubeClient clientset.Interface,
+	metricFamilies []generator.FamilyGenerator,
+	expectedType interface{},
+	listWatchFunc func(kubeClient clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher,
+	useAPIServerCache bool,
+) []cache.Store {
+	composedMetricGenFuncs := generator.ComposeMetricGenFuncsWithErrors(metricFamilies, b.generateErrorsCounter(reflect.TypeOf(expectedType).String()))
 	familyHeaders := generator.ExtractMetricFamilyHeaders(metricFamilies)
 
 	if b.namespaces.IsAllNamespaces() {
@@ -520,7 +1168,7 @@ func (b *Builder) buildStores(
 		if b.fieldSelectorFilter != "" {
 			klog.InfoS("FieldSelector is used", "fieldSelector", b.fieldSelectorFilter)
 		}
-		listWatcher := listWatchFunc(b.kubeClient, v1.NamespaceAll, b.fieldSelectorFilter)
+		listWatcher := listWatchFunc(kubeClient, v1.NamespaceAll, b.fieldSelectorFilter)
 		b.startReflector(expectedType, store, listWatcher, useAPIServerCache)
 		return []cache.Store{store}
 	}
@@ -534,7 +1182,7 @@ func (b *Builder) buildStores(
 		if b.fieldSelectorFilter != "" {
 			klog.InfoS("FieldSelector is used", "fieldSelector", b.fieldSelectorFilter)
 		}
-		listWatcher := listWatchFunc(b.kubeClient, ns, b.fieldSelectorFilter)
+		listWatcher := listWatchFunc(kubeClient, ns, b.fieldSelectorFilter)
 		b.startReflector(expectedType, store, listWatcher, useAPIServerCache)
 		stores = append(stores, store)
 	}
@@ -549,8 +1197,8 @@ func (b *Builder) buildCustomResourceStores(resourceName string,
 	listWatchFunc func(customResourceClient interface{}, ns string, fieldSelector string) cache.ListerWatcher,
 	useAPIServerCache bool,
 ) []cache.Store {
-	metricFamilies = generator.FilterFamilyGenerators(b.familyGeneratorFilter, metricFamilies)
-	composedMetricGenFuncs := generator.ComposeMetricGenFuncs(metricFamilies)
+	metricFamilies = b.withMetricOverrides(generator.FilterFamilyGenerators(b.familyGeneratorFilter, metricFamilies))
+	composedMetricGenFuncs := generator.ComposeMetricGenFuncsWithErrors(metricFamilies, b.generateErrorsCounter(resourceName))
 
 	familyHeaders := generator.ExtractMetricFamilyHeaders(metricFamilies)
 
@@ -598,6 +1246,196 @@ func (b *Builder) buildCustomResourceStores(resourceName string,
 	return stores
 }
 
+// withClusterLabel returns copies of the given families which prepend a cluster label
+// with the given value to every metric they generate.
+func withClusterLabel(cluster string, families []generator.FamilyGenerator) []generator.FamilyGenerator {
+	labeled := make([]generator.FamilyGenerator, len(families))
+	for i := range families {
+		labeled[i] = families[i]
+		generate := families[i].GenerateFunc
+		labeled[i].GenerateFunc = func(obj interface{}) *metric.Family {
+			family := generate(obj)
+			family.Each(func(m *metric.Metric) {
+				m.LabelKeys = append([]string{"cluster"}, m.LabelKeys...)
+				m.LabelValues = append([]string{cluster}, m.LabelValues...)
+			})
+			return family
+		}
+	}
+	return labeled
+}
+
+// withUIDLabel drops the uid label from the metrics generated by the given families
+// if the uid label is disabled.
+func (b *Builder) withUIDLabel(families []generator.FamilyGenerator) []generator.FamilyGenerator {
+	if !b.disableUIDLabel {
+		return families
+	}
+
+	for i := range families {
+		generate := families[i].GenerateFunc
+		families[i].GenerateFunc = func(obj interface{}) *metric.Family {
+			family := generate(obj)
+			family.Each(func(m *metric.Metric) {
+				m.LabelKeys, m.LabelValues = withoutLabel(m.LabelKeys, m.LabelValues, "uid")
+			})
+			return family
+		}
+	}
+	return families
+}
+
+// withoutCompletedInitContainers drops the metrics of init containers which terminated
+// successfully from the init container families of pods, if configured to do so.
+func (b *Builder) withoutCompletedInitContainers(families []generator.FamilyGenerator) []generator.FamilyGenerator {
+	if !b.dropCompletedInitContainers {
+		return families
+	}
+
+	for i := range families {
+		if !strings.Contains(families[i].Name, "_init_container_") {
+			continue
+		}
+		generate := families[i].GenerateFunc
+		families[i].GenerateFunc = func(obj interface{}) *metric.Family {
+			family := generate(obj)
+			completed := completedInitContainers(obj.(*v1.Pod))
+			if len(completed) == 0 {
+				return family
+			}
+
+			ms := family.Metrics[:0]
+			for _, m := range family.Metrics {
+				container := ""
+				if idx := slices.Index(m.LabelKeys, "container"); idx >= 0 {
+					container = m.LabelValues[idx]
+				}
+				if !slices.Contains(completed, container) {
+					ms = append(ms, m)
+				}
+			}
+			family.Metrics = ms
+			return family
+		}
+	}
+	return families
+}
+
+// completedInitContainers returns the names of the init containers of a pod which
+// terminated successfully.
+func completedInitContainers(p *v1.Pod) []string {
+	var completed []string
+	for _, cs := range p.Status.InitContainerStatuses {
+		if cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0 {
+			completed = append(completed, cs.Name)
+		}
+	}
+	return completed
+}
+
+// withMetricOverrides replaces the help texts and units of the given families by the configured overrides.
+func (b *Builder) withMetricOverrides(families []generator.FamilyGenerator) []generator.FamilyGenerator {
+	for i := range families {
+		override, ok := b.metricOverrides[families[i].Name]
+		if !ok {
+			continue
+		}
+		if override.Help != "" {
+			families[i].Help = override.Help
+		}
+		if override.Unit != "" {
+			families[i].Unit = override.Unit
+		}
+	}
+	return families
+}
+
+// allowedLabels returns the labels allow list of the given resource without its denied labels.
+// A wildcard is followed by the denied labels instead, to be excluded by createPrometheusLabelKeysValues.
+func (b *Builder) allowedLabels(resource string) []string {
+	allowed := b.allowLabelsList[resource]
+	denied := b.denyLabelsList[resource]
+	if len(allowed) > 0 && allowed[0] == options.LabelWildcard {
+		return append([]string{options.LabelWildcard}, denied...)
+	}
+	if len(denied) == 0 {
+		return allowed
+	}
+	return slices.DeleteFunc(slices.Clone(allowed), func(l string) bool {
+		return slices.Contains(denied, l)
+	})
+}
+
+// labelSelectorResources lists the resources which support a custom label selector.
+var labelSelectorResources = []string{"pods"}
+
+// withLabelSelector wraps the given listWatchFunc so that the configured label
+// selector of the resource, if any, is set on every List and Watch request.
+func (b *Builder) withLabelSelector(
+	resource string,
+	listWatchFunc func(kubeClient clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher,
+) func(kubeClient clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher {
+	selector := b.labelSelectors[resource]
+	if selector == "" {
+		return listWatchFunc
+	}
+	return func(kubeClient clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher {
+		klog.InfoS("LabelSelector is used", "resource", resource, "labelSelector", selector)
+		return &labelSelectorListWatch{lw: listWatchFunc(kubeClient, ns, fieldSelector), labelSelector: selector}
+	}
+}
+
+// objectNameResources lists the resources which support listing and watching a single object by name.
+var objectNameResources = []string{"daemonsets", "deployments", "pods", "statefulsets"}
+
+// withObjectName wraps the given listWatchFunc so that only the object with the
+// configured name of the resource, if any, is listed and watched.
+func (b *Builder) withObjectName(
+	resource string,
+	listWatchFunc func(kubeClient clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher,
+) func(kubeClient clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher {
+	name := b.objectNames[resource]
+	if name == "" {
+		return listWatchFunc
+	}
+	return func(kubeClient clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher {
+		merged, err := options.MergeFieldSelectors([]string{fieldSelector, fields.OneTermEqualSelector("metadata.name", name).String()})
+		if err != nil {
+			klog.ErrorS(err, "Failed to merge the object name into the field selector, listing all objects", "resource", resource, "name", name, "fieldSelector", fieldSelector)
+			return listWatchFunc(kubeClient, ns, fieldSelector)
+		}
+		klog.InfoS("Object name is used", "resource", resource, "name", name, "fieldSelector", merged)
+		return listWatchFunc(kubeClient, ns, merged)
+	}
+}
+
+// labelSelectorListWatch sets a label selector on the options of the wrapped cache.ListerWatcher.
+type labelSelectorListWatch struct {
+	lw            cache.ListerWatcher
+	labelSelector string
+}
+
+// List sets the label selector and lists the objects of the wrapped cache.ListerWatcher.
+func (l *labelSelectorListWatch) List(options metav1.ListOptions) (runtime.Object, error) {
+	options.LabelSelector = l.labelSelector
+	return l.lw.List(options)
+}
+
+// Watch sets the label selector and watches the objects of the wrapped cache.ListerWatcher.
+func (l *labelSelectorListWatch) Watch(options metav1.ListOptions) (k8swatch.Interface, error) {
+	options.LabelSelector = l.labelSelector
+	return l.lw.Watch(options)
+}
+
+// generateErrorsCounter returns the counter of objects of the given resource whose
+// metrics could not be generated, or nil if the builder has no metrics.
+func (b *Builder) generateErrorsCounter(resource string) prometheus.Counter {
+	if b.generateErrors == nil {
+		return nil
+	}
+	return b.generateErrors.WithLabelValues(resource)
+}
+
 // startReflector starts a Kubernetes client-go reflector with the given
 // listWatcher and registers it with the given store.
 func (b *Builder) startReflector(
@@ -606,9 +1444,19 @@ func (b *Builder) startReflector(
 	listWatcher cache.ListerWatcher,
 	useAPIServerCache bool,
 ) {
-	instrumentedListWatch := watch.NewInstrumentedListerWatcher(listWatcher, b.listWatchMetrics, reflect.TypeOf(expectedType).String(), useAPIServerCache)
-	reflector := cache.NewReflectorWithOptions(sharding.NewShardedListWatch(b.shard, b.totalShards, instrumentedListWatch), expectedType, store, cache.ReflectorOptions{ResyncPeriod: 0})
-	go reflector.Run(b.ctx.Done())
+	resource := reflect.TypeOf(expectedType).String()
+	instrumentedListWatch := watch.NewInstrumentedListerWatcher(listWatcher, b.listWatchMetrics, resource, useAPIServerCache)
+	instrumentedStore := watch.NewInstrumentedStore(store, b.listWatchMetrics, resource)
+	reflector := b.newReflector(sharding.NewInstrumentedShardedListWatch(b.shard, b.totalShards, instrumentedListWatch, b.shardingMetrics, resource), expectedType, instrumentedStore)
+	go watch.RunReflector(reflector, b.listWatchMetrics, resource, b.ctx.Done())
+}
+
+// newReflector returns a reflector filling the store with the objects of the given list-watch,
+// paginating its lists by the configured chunk size.
+func (b *Builder) newReflector(listWatcher cache.ListerWatcher, expectedType interface{}, store cache.Store) *cache.Reflector {
+	reflector := cache.NewReflectorWithOptions(listWatcher, expectedType, store, cache.ReflectorOptions{ResyncPeriod: 0})
+	reflector.WatchListPageSize = b.listChunkSize
+	return reflector
 }
 
 // cacheStoresToMetricStores converts []cache.Store into []*metricsstore.MetricsStore
@@ -17,10 +17,39 @@ limitations under the License.
 package store
 
 import (
+	"bytes"
+	"context"
 	"reflect"
 	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	samplev1alpha1 "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
+	"k8s.io/utils/clock"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	"k8s.io/kube-state-metrics/v2/pkg/allowdenylist"
+	"k8s.io/kube-state-metrics/v2/pkg/customresource"
+	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+	metricsstore "k8s.io/kube-state-metrics/v2/pkg/metrics_store"
+	"k8s.io/kube-state-metrics/v2/pkg/metricsprofile"
 	"k8s.io/kube-state-metrics/v2/pkg/options"
 )
 
@@ -258,3 +287,1039 @@ func TestWithEnabledResources(t *testing.T) {
 		}
 	}
 }
+
+func TestWithLabelSelectors(t *testing.T) {
+	tests := []struct {
+		Desc           string
+		LabelSelectors map[string]string
+		expectedError  bool
+	}{
+		{
+			Desc:           "set-based selector for pods",
+			LabelSelectors: map[string]string{"pods": "app in (a,b)"},
+		},
+		{
+			Desc:           "invalid selector for pods",
+			LabelSelectors: map[string]string{"pods": "app in (a,b"},
+			expectedError:  true,
+		},
+		{
+			Desc:           "unsupported resource",
+			LabelSelectors: map[string]string{"foo": "app=a"},
+			expectedError:  true,
+		},
+	}
+
+	for _, test := range tests {
+		b := NewBuilder()
+		err := b.WithLabelSelectors(test.LabelSelectors)
+		if test.expectedError != (err != nil) {
+			t.Errorf("Test error for Desc: %s. Expected error: %t, got: %v", test.Desc, test.expectedError, err)
+		}
+	}
+}
+
+func TestWithLabelSelectorAppliesToListWatch(t *testing.T) {
+	b := NewBuilder()
+	if err := b.WithLabelSelectors(map[string]string{"pods": "app in (a,b)"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string][]metav1.ListOptions{}
+	listWatchFunc := func(_ clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				opts.FieldSelector = fieldSelector
+				got[ns] = append(got[ns], opts)
+				return &v1.PodList{}, nil
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				opts.FieldSelector = fieldSelector
+				got[ns] = append(got[ns], opts)
+				return watch.NewFake(), nil
+			},
+		}
+	}
+
+	wrapped := b.withLabelSelector("pods", listWatchFunc)
+	for _, ns := range []string{"default", "kube-system"} {
+		lw := wrapped(nil, ns, "spec.nodeName=node-1")
+		if _, err := lw.List(metav1.ListOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lw.Watch(metav1.ListOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, ns := range []string{"default", "kube-system"} {
+		if len(got[ns]) != 2 {
+			t.Fatalf("expected a list and a watch call for namespace %s, got %d calls", ns, len(got[ns]))
+		}
+		for _, opts := range got[ns] {
+			if opts.LabelSelector != "app in (a,b)" {
+				t.Errorf("expected label selector %q for namespace %s, got %q", "app in (a,b)", ns, opts.LabelSelector)
+			}
+			if opts.FieldSelector != "spec.nodeName=node-1" {
+				t.Errorf("expected field selector %q for namespace %s, got %q", "spec.nodeName=node-1", ns, opts.FieldSelector)
+			}
+		}
+	}
+
+	// Resources without a configured selector keep their list-watch untouched.
+	lw := b.withLabelSelector("deployments", listWatchFunc)(nil, "default", "")
+	if _, ok := lw.(*labelSelectorListWatch); ok {
+		t.Error("expected no label selector wrapper for deployments")
+	}
+}
+
+func TestWithObjectNames(t *testing.T) {
+	tests := []struct {
+		Desc          string
+		ObjectNames   map[string]string
+		expectedError bool
+	}{
+		{
+			Desc:        "deployment name",
+			ObjectNames: map[string]string{"deployments": "my-app"},
+		},
+		{
+			Desc:          "invalid name",
+			ObjectNames:   map[string]string{"deployments": "my-app,metadata.namespace=default"},
+			expectedError: true,
+		},
+		{
+			Desc:          "unsupported resource",
+			ObjectNames:   map[string]string{"foo": "my-app"},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		b := NewBuilder()
+		err := b.WithObjectNames(test.ObjectNames)
+		if test.expectedError != (err != nil) {
+			t.Errorf("Test error for Desc: %s. Expected error: %t, got: %v", test.Desc, test.expectedError, err)
+		}
+	}
+}
+
+func TestWithObjectNameAppliesToListWatch(t *testing.T) {
+	b := NewBuilder()
+	if err := b.WithObjectNames(map[string]string{"pods": "my-pod"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []metav1.ListOptions
+	listWatchFunc := func(_ clientset.Interface, _ string, fieldSelector string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				opts.FieldSelector = fieldSelector
+				got = append(got, opts)
+				return &v1.PodList{}, nil
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				opts.FieldSelector = fieldSelector
+				got = append(got, opts)
+				return watch.NewFake(), nil
+			},
+		}
+	}
+
+	for _, test := range []struct {
+		fieldSelector string
+		want          string
+	}{
+		{
+			want: "metadata.name=my-pod",
+		},
+		{
+			fieldSelector: "metadata.namespace=default",
+			want:          "metadata.namespace=default,metadata.name=my-pod",
+		},
+	} {
+		got = nil
+		lw := b.withObjectName("pods", listWatchFunc)(nil, "", test.fieldSelector)
+		if _, err := lw.List(metav1.ListOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lw.Watch(metav1.ListOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("expected a list and a watch call, got %d calls", len(got))
+		}
+		for _, opts := range got {
+			if opts.FieldSelector != test.want {
+				t.Errorf("expected field selector %q, got %q", test.want, opts.FieldSelector)
+			}
+		}
+	}
+
+	// Resources without a configured name keep their field selector untouched.
+	got = nil
+	lw := b.withObjectName("deployments", listWatchFunc)(nil, "", "spec.nodeName=node-1")
+	if _, err := lw.List(metav1.ListOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got[0].FieldSelector != "spec.nodeName=node-1" {
+		t.Errorf("expected field selector %q for deployments, got %q", "spec.nodeName=node-1", got[0].FieldSelector)
+	}
+}
+
+func TestWithDisableUIDLabel(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod1",
+			Namespace: "ns1",
+			UID:       "abc-123",
+		},
+	}
+
+	for _, disable := range []bool{false, true} {
+		b := NewBuilder()
+		b.WithDisableUIDLabel(disable)
+
+		for _, f := range b.withUIDLabel(podMetricFamilies(nil, nil, clock.RealClock{}, nil, nil, nil)) {
+			for _, m := range f.Generate(pod).Metrics {
+				if len(m.LabelKeys) != len(m.LabelValues) {
+					t.Fatalf("%s: expected as many label keys as values, got %v and %v", f.Name, m.LabelKeys, m.LabelValues)
+				}
+				if got := slices.Contains(m.LabelKeys, "uid"); got == disable {
+					t.Errorf("%s: expected uid label to be present: %t, got labels %v", f.Name, !disable, m.LabelKeys)
+				}
+			}
+		}
+	}
+}
+
+func TestWithDropCompletedInitContainers(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod1",
+			Namespace: "ns1",
+			UID:       "abc-123",
+		},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{
+				{Name: "completed", Image: "busybox"},
+				{Name: "failed", Image: "busybox"},
+			},
+		},
+		Status: v1.PodStatus{
+			InitContainerStatuses: []v1.ContainerStatus{
+				{
+					Name: "completed",
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{Reason: "Completed", ExitCode: 0},
+					},
+				},
+				{
+					Name: "failed",
+					State: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 1},
+					},
+				},
+			},
+		},
+	}
+
+	for _, drop := range []bool{false, true} {
+		b := NewBuilder()
+		b.WithDropCompletedInitContainers(drop)
+
+		var containers []string
+		for _, f := range b.withoutCompletedInitContainers(podMetricFamilies(nil, nil, clock.RealClock{}, nil, nil, nil)) {
+			if f.Name != "kube_pod_init_container_status_terminated_reason" {
+				continue
+			}
+			for _, m := range f.Generate(pod).Metrics {
+				containers = append(containers, m.LabelValues[slices.Index(m.LabelKeys, "container")])
+			}
+		}
+
+		want := []string{"completed", "failed"}
+		if drop {
+			want = []string{"failed"}
+		}
+		if !reflect.DeepEqual(containers, want) {
+			t.Errorf("drop %t: want metrics for init containers %v, got %v", drop, want, containers)
+		}
+	}
+}
+
+func TestWithDenyLabels(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod1",
+			Namespace: "ns1",
+			UID:       "abc-123",
+			Labels: map[string]string{
+				"app":                    "foo",
+				"pod-template-hash":      "5d4f8c7b9",
+				"app.kubernetes.io/name": "foo",
+				"app_kubernetes_io_name": "bar",
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		allow map[string][]string
+		deny  map[string][]string
+		want  []string
+	}{
+		{
+			name:  "wildcard",
+			allow: map[string][]string{"pods": {"*"}},
+			deny:  map[string][]string{"*": {"pod-template-hash"}},
+			want:  []string{"namespace", "pod", "uid", "label_app", "label_app_kubernetes_io_name_conflict1", "label_app_kubernetes_io_name_conflict2"},
+		},
+		{
+			// Denying one of two keys sanitized to the same label name leaves the other one without a conflict.
+			name:  "wildcard with conflicting keys",
+			allow: map[string][]string{"pods": {"*"}},
+			deny:  map[string][]string{"pods": {"pod-template-hash", "app.kubernetes.io/name"}},
+			want:  []string{"namespace", "pod", "uid", "label_app", "label_app_kubernetes_io_name"},
+		},
+		{
+			name:  "explicit allow list",
+			allow: map[string][]string{"pods": {"app", "pod-template-hash"}},
+			deny:  map[string][]string{"pods": {"pod-template-hash"}},
+			want:  []string{"namespace", "pod", "uid", "label_app"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := NewBuilder()
+			if err := b.WithEnabledResources([]string{"pods"}); err != nil {
+				t.Fatal(err)
+			}
+			if err := b.WithAllowLabels(test.allow); err != nil {
+				t.Fatal(err)
+			}
+			if err := b.WithDenyLabels(test.deny); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, f := range podMetricFamilies(b.allowAnnotationsList["pods"], b.allowedLabels("pods"), clock.RealClock{}, nil, nil, nil) {
+				if f.Name != "kube_pod_labels" {
+					continue
+				}
+				for _, m := range f.Generate(pod).Metrics {
+					got = append(got, m.LabelKeys...)
+				}
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want label keys %v, got %v", test.want, got)
+			}
+		})
+	}
+
+	b := NewBuilder()
+	if err := b.WithDenyLabels(map[string][]string{"pods": {"*"}}); err == nil {
+		t.Error("expected an error when denying the wildcard")
+	}
+}
+
+func TestAllowListWildcardIgnoresFollowingKeys(t *testing.T) {
+	b := NewBuilder()
+	if err := b.WithEnabledResources([]string{"pods"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.WithAllowAnnotations(map[string][]string{"pods": {"*", "team"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1", Annotations: map[string]string{"team": "a"}}}
+	for _, f := range podMetricFamilies(b.allowAnnotationsList["pods"], b.allowedLabels("pods"), clock.RealClock{}, nil, nil, nil) {
+		if f.Name != "kube_pod_annotations" {
+			continue
+		}
+		if keys := f.Generate(pod).Metrics[0].LabelKeys; !slices.Contains(keys, "annotation_team") {
+			t.Errorf("expected the wildcard to allow all annotations, got %v", keys)
+		}
+	}
+}
+
+func TestCapturedLabelKeys(t *testing.T) {
+	r := prometheus.NewRegistry()
+	b := NewBuilder()
+	b.WithMetrics(r)
+	if err := b.WithEnabledResources([]string{"deployments", "pods", "services"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.WithAllowLabels(map[string][]string{
+		"pods":        {"app", "team", "secret"},
+		"deployments": {"*"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.WithDenyLabels(map[string][]string{
+		"pods": {"secret"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	b.updateCapturedLabelKeys([]string{"deployments", "pods", "services"})
+
+	want := `
+		# HELP kube_state_metrics_captured_label_keys The Kubernetes label keys captured in the labels metric of a resource, as resolved from the labels allowlist.
+		# TYPE kube_state_metrics_captured_label_keys gauge
+		kube_state_metrics_captured_label_keys{label_key="*",resource="deployments"} 1
+		kube_state_metrics_captured_label_keys{label_key="app",resource="pods"} 1
+		kube_state_metrics_captured_label_keys{label_key="team",resource="pods"} 1
+	`
+	if err := testutil.GatherAndCompare(r, strings.NewReader(want), "kube_state_metrics_captured_label_keys"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestResourceEnabled(t *testing.T) {
+	r := prometheus.NewRegistry()
+	b := NewBuilder()
+	b.WithMetrics(r)
+
+	b.updateResourceEnabled([]string{"deployments", "pods"})
+
+	for resource, want := range map[string]float64{
+		"deployments": 1,
+		"pods":        1,
+		"services":    0,
+		"secrets":     0,
+	} {
+		if got := testutil.ToFloat64(b.resourceEnabled.WithLabelValues(resource)); got != want {
+			t.Errorf("expected kube_state_metrics_resource_enabled{resource=%q} to be %v, got %v", resource, want, got)
+		}
+	}
+	if got, want := testutil.CollectAndCount(b.resourceEnabled), len(availableResources()); got != want {
+		t.Errorf("expected a series for each of the %d available resources, got %d", want, got)
+	}
+}
+
+func TestListChunkSize(t *testing.T) {
+	b := NewBuilder()
+	b.WithListChunkSize(100)
+
+	limits := make(chan int64, 1)
+	lw := &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			select {
+			case limits <- opts.Limit:
+			default:
+			}
+			return &v1.PodList{}, nil
+		},
+		WatchFunc: func(_ metav1.ListOptions) (watch.Interface, error) {
+			return watch.NewFake(), nil
+		},
+	}
+	reflector := b.newReflector(lw, &v1.Pod{}, cache.NewStore(cache.MetaNamespaceKeyFunc))
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go reflector.Run(stopCh)
+
+	select {
+	case limit := <-limits:
+		if limit != 100 {
+			t.Errorf("expected the list to be limited to 100 objects, got %d", limit)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the list")
+	}
+}
+
+func TestMetricOverrides(t *testing.T) {
+	b := NewBuilder()
+	b.WithMetricOverrides(options.MetricOverrides{
+		"kube_pod_info":       {Help: "Information about the pod, aligned across exporters."},
+		"kube_pod_start_time": {Unit: "time"},
+	})
+
+	families := b.withMetricOverrides(podMetricFamilies(nil, nil, clock.RealClock{}, nil, nil, nil))
+	headers := map[string]string{}
+	for i, header := range generator.ExtractMetricFamilyHeaders(families) {
+		headers[families[i].Name] = header
+	}
+
+	for name, want := range map[string]string{
+		"kube_pod_info":       "# HELP kube_pod_info [STABLE] Information about the pod, aligned across exporters.\n# TYPE kube_pod_info gauge",
+		"kube_pod_start_time": "# HELP kube_pod_start_time [STABLE] Start time in unix timestamp for a pod.\n# UNIT kube_pod_start_time time\n# TYPE kube_pod_start_time gauge",
+		"kube_pod_created":    "# HELP kube_pod_created [STABLE] Unix creation timestamp\n# TYPE kube_pod_created gauge",
+	} {
+		if got := headers[name]; got != want {
+			t.Errorf("expected header %q of %s, got %q", want, name, got)
+		}
+	}
+}
+
+func TestMetadataProfile(t *testing.T) {
+	filter, err := metricsprofile.NewMetricFamilyFilter(metricsprofile.Metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, f := range generator.FilterFamilyGenerators(filter, podMetricFamilies(nil, nil, clock.RealClock{}, nil, nil, nil)) {
+		got = append(got, f.Name)
+	}
+	want := []string{
+		"kube_pod_container_info",
+		"kube_pod_created",
+		"kube_pod_info",
+		"kube_pod_init_container_info",
+		"kube_pod_annotations",
+		"kube_pod_labels",
+		"kube_pod_runtimeclass_name_info",
+		"kube_pod_spec_volumes_persistentvolumeclaims_info",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want metadata families %v, got %v", want, got)
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l, err := allowdenylist.New(options.MetricSet{"kube_pod_status_unscheduled_duration_seconds": {}}, options.MetricSet{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	kubeClient := fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"},
+		Status: v1.PodStatus{
+			Conditions: []v1.PodCondition{
+				{
+					Type:               v1.PodScheduled,
+					Status:             v1.ConditionFalse,
+					LastTransitionTime: metav1.Unix(1501568418, 0),
+				},
+			},
+		},
+	})
+
+	b := NewBuilder()
+	b.WithMetrics(prometheus.NewRegistry())
+	b.WithContext(ctx)
+	b.WithKubeClient(kubeClient)
+	b.WithNamespaces(options.DefaultNamespaces)
+	b.WithSharding(0, 1)
+	b.WithClock(testingclock.NewFakePassiveClock(time.Unix(1501569018, 0)))
+	b.WithFamilyGeneratorFilter(l)
+	b.WithGenerateStoresFunc(b.DefaultGenerateStoresFunc())
+	if err := b.WithEnabledResources([]string{"pods"}); err != nil {
+		t.Fatal(err)
+	}
+	writers := b.Build()
+
+	want := `kube_pod_status_unscheduled_duration_seconds{namespace="default",pod="pod1",uid="uid1"} 600`
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		buf := &bytes.Buffer{}
+		for _, w := range writers {
+			if err := w.WriteAll(buf); err != nil {
+				return false, err
+			}
+		}
+		return strings.Contains(buf.String(), want), nil
+	})
+	if err != nil {
+		t.Fatalf("expected the duration to be computed with the configured clock, %s: %v", want, err)
+	}
+}
+
+func TestPodStoresWithOrphanedPods(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l, err := allowdenylist.New(options.MetricSet{descPodOrphanedName: {}}, options.MetricSet{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "rs1", Namespace: "ns1", UID: "rs1-uid"}}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1", UID: "pod1-uid", OwnerReferences: []metav1.OwnerReference{
+			{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs1", UID: "rs1-uid", Controller: ptr.To(true)},
+		}},
+	}
+	kubeClient := fake.NewSimpleClientset(rs, pod)
+
+	b := NewBuilder()
+	b.WithMetrics(prometheus.NewRegistry())
+	b.WithContext(ctx)
+	b.WithKubeClient(kubeClient)
+	b.WithNamespaces(options.NamespaceList{"ns1", "ns2"})
+	b.WithSharding(0, 1)
+	b.WithFamilyGeneratorFilter(l)
+	b.WithGenerateStoresFunc(b.DefaultGenerateStoresFunc())
+	if err := b.WithEnabledResources([]string{"pods"}); err != nil {
+		t.Fatal(err)
+	}
+	writers := b.Build()
+
+	waitFor := func(want string) {
+		t.Helper()
+		var got string
+		err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(_ context.Context) (bool, error) {
+			buf := &bytes.Buffer{}
+			for _, w := range writers {
+				if err := w.WriteAll(buf); err != nil {
+					return false, err
+				}
+			}
+			got = buf.String()
+			return strings.Contains(got, want), nil
+		})
+		if err != nil {
+			t.Fatalf("want:\n%s\ngot:\n%s", want, got)
+		}
+	}
+
+	waitFor(`kube_pod_orphaned{namespace="ns1",pod="pod1",uid="pod1-uid"} 0`)
+
+	// The ReplicaSet is deleted while the pod is never updated.
+	if err := kubeClient.AppsV1().ReplicaSets("ns1").Delete(ctx, "rs1", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(`kube_pod_orphaned{namespace="ns1",pod="pod1",uid="pod1-uid"} 1`)
+}
+
+func TestPodStoresWithLimitRangeDefaults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l, err := allowdenylist.New(options.MetricSet{descPodContainerResourceRequestsWithDefaultsName: {}}, options.MetricSet{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	limitRange := func(namespace, cpu string) *v1.LimitRange {
+		return &v1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: namespace},
+			Spec: v1.LimitRangeSpec{
+				Limits: []v1.LimitRangeItem{
+					{
+						Type: v1.LimitTypeContainer,
+						DefaultRequest: v1.ResourceList{
+							v1.ResourceCPU: resource.MustParse(cpu),
+						},
+					},
+				},
+			},
+		}
+	}
+	pod := func(namespace string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: namespace, UID: types.UID(namespace)},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "app"}},
+			},
+		}
+	}
+
+	kubeClient := fake.NewSimpleClientset(pod("ns1"), pod("ns2"), limitRange("ns1", "250m"))
+
+	b := NewBuilder()
+	b.WithMetrics(prometheus.NewRegistry())
+	b.WithContext(ctx)
+	b.WithKubeClient(kubeClient)
+	b.WithNamespaces(options.NamespaceList{"ns1", "ns2"})
+	b.WithSharding(0, 1)
+	b.WithFamilyGeneratorFilter(l)
+	b.WithGenerateStoresFunc(b.DefaultGenerateStoresFunc())
+	if err := b.WithEnabledResources([]string{"pods"}); err != nil {
+		t.Fatal(err)
+	}
+	writers := b.Build()
+
+	// The LimitRange of ns2 is only created after the pods were listed, and the pods are never updated.
+	if _, err := kubeClient.CoreV1().LimitRanges("ns2").Create(ctx, limitRange("ns2", "500m"), metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`kube_pod_container_resource_requests_with_defaults{namespace="ns1",pod="pod1",uid="ns1",container="app",node="",resource="cpu",unit="core"} 0.25`,
+		`kube_pod_container_resource_requests_with_defaults{namespace="ns2",pod="pod1",uid="ns2",container="app",node="",resource="cpu",unit="core"} 0.5`,
+	}
+	var got string
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(_ context.Context) (bool, error) {
+		buf := &bytes.Buffer{}
+		for _, w := range writers {
+			if err := w.WriteAll(buf); err != nil {
+				return false, err
+			}
+		}
+		got = buf.String()
+		for _, m := range want {
+			if !strings.Contains(got, m) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("expected the LimitRange defaults of both namespaces to be filled in, want:\n%s\ngot:\n%s", strings.Join(want, "\n"), got)
+	}
+}
+
+func TestPodStoresWithNodeTopology(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l, err := allowdenylist.New(options.MetricSet{descPodNodeTopologyName: {}}, options.MetricSet{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	node := func(zone string) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node1",
+				Labels: map[string]string{v1.LabelTopologyZone: zone},
+			},
+		}
+	}
+
+	kubeClient := fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", UID: "uid1"},
+		Spec:       v1.PodSpec{NodeName: "node1"},
+	})
+
+	b := NewBuilder()
+	b.WithMetrics(prometheus.NewRegistry())
+	b.WithContext(ctx)
+	b.WithKubeClient(kubeClient)
+	b.WithNamespaces(options.DefaultNamespaces)
+	b.WithSharding(0, 1)
+	b.WithFamilyGeneratorFilter(l)
+	b.WithGenerateStoresFunc(b.DefaultGenerateStoresFunc())
+	if err := b.WithEnabledResources([]string{"pods"}); err != nil {
+		t.Fatal(err)
+	}
+	writers := b.Build()
+
+	waitFor := func(want string) {
+		t.Helper()
+		var got string
+		err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(_ context.Context) (bool, error) {
+			buf := &bytes.Buffer{}
+			for _, w := range writers {
+				if err := w.WriteAll(buf); err != nil {
+					return false, err
+				}
+			}
+			got = buf.String()
+			return strings.Contains(got, want), nil
+		})
+		if err != nil {
+			t.Fatalf("expected to find %s, got:\n%s", want, got)
+		}
+	}
+
+	// The node is only created after the pod was listed, and later relabeled, while the pod is never updated.
+	if _, err := kubeClient.CoreV1().Nodes().Create(ctx, node("zone-a"), metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(`kube_pod_node_topology{namespace="default",pod="pod1",uid="uid1",node="node1",zone="zone-a",region=""} 1`)
+
+	if _, err := kubeClient.CoreV1().Nodes().Update(ctx, node("zone-b"), metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(`kube_pod_node_topology{namespace="default",pod="pod1",uid="uid1",node="node1",zone="zone-b",region=""} 1`)
+}
+
+func TestServiceStoresWithReadyEndpoints(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l, err := allowdenylist.New(options.MetricSet{descServiceReadyEndpointsName: {}}, options.MetricSet{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	service := func(namespace string) *v1.Service {
+		return &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: namespace, UID: types.UID(namespace)},
+		}
+	}
+	endpointSlice := func(namespace string, ready ...bool) *discoveryv1.EndpointSlice {
+		slice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "svc-abc",
+				Namespace: namespace,
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "svc"},
+			},
+		}
+		for _, r := range ready {
+			slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+				Addresses:  []string{"10.0.0.1"},
+				Conditions: discoveryv1.EndpointConditions{Ready: &r},
+			})
+		}
+		return slice
+	}
+
+	kubeClient := fake.NewSimpleClientset(service("ns1"), service("ns2"), endpointSlice("ns1", true, true))
+
+	b := NewBuilder()
+	b.WithMetrics(prometheus.NewRegistry())
+	b.WithContext(ctx)
+	b.WithKubeClient(kubeClient)
+	b.WithNamespaces(options.NamespaceList{"ns1", "ns2"})
+	b.WithSharding(0, 1)
+	b.WithFamilyGeneratorFilter(l)
+	b.WithGenerateStoresFunc(b.DefaultGenerateStoresFunc())
+	if err := b.WithEnabledResources([]string{"services"}); err != nil {
+		t.Fatal(err)
+	}
+	writers := b.Build()
+
+	waitFor := func(want ...string) {
+		t.Helper()
+		var got string
+		err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(_ context.Context) (bool, error) {
+			buf := &bytes.Buffer{}
+			for _, w := range writers {
+				if err := w.WriteAll(buf); err != nil {
+					return false, err
+				}
+			}
+			got = buf.String()
+			for _, m := range want {
+				if !strings.Contains(got, m) {
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+		if err != nil {
+			t.Fatalf("expected to find:\n%s\ngot:\n%s", strings.Join(want, "\n"), got)
+		}
+	}
+
+	// The EndpointSlices change after the services were listed, while the services are never updated.
+	if _, err := kubeClient.DiscoveryV1().EndpointSlices("ns2").Create(ctx, endpointSlice("ns2", true), metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(
+		`kube_service_status_ready_endpoints{namespace="ns1",service="svc",uid="ns1"} 2`,
+		`kube_service_status_ready_endpoints{namespace="ns2",service="svc",uid="ns2"} 1`,
+	)
+
+	if _, err := kubeClient.DiscoveryV1().EndpointSlices("ns1").Update(ctx, endpointSlice("ns1", true, false), metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(
+		`kube_service_status_ready_endpoints{namespace="ns1",service="svc",uid="ns1"} 1`,
+		`kube_service_status_ready_endpoints{namespace="ns2",service="svc",uid="ns2"} 1`,
+	)
+}
+
+func TestWithClusterKubeClients(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l, err := allowdenylist.New(options.MetricSet{"kube_pod_info": {}}, options.MetricSet{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Parse(); err != nil {
+		t.Fatal(err)
+	}
+
+	newKubeClient := func(podName string) clientset.Interface {
+		return fake.NewSimpleClientset(&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: "default", UID: types.UID(podName)},
+		})
+	}
+
+	b := NewBuilder()
+	b.WithMetrics(prometheus.NewRegistry())
+	b.WithContext(ctx)
+	b.WithClusterKubeClients(map[string]clientset.Interface{
+		"management": newKubeClient("pod1"),
+		"workload":   newKubeClient("pod2"),
+	})
+	b.WithNamespaces(options.DefaultNamespaces)
+	b.WithSharding(0, 1)
+	b.WithFamilyGeneratorFilter(l)
+	b.WithGenerateStoresFunc(b.DefaultGenerateStoresFunc())
+	if err := b.WithEnabledResources([]string{"pods"}); err != nil {
+		t.Fatal(err)
+	}
+	writers := b.Build()
+	if len(writers) != 1 {
+		t.Fatalf("expected the stores of both clusters to share a single writer, got %d writers", len(writers))
+	}
+
+	want := []string{
+		`kube_pod_info{cluster="management",namespace="default",pod="pod1",uid="pod1"`,
+		`kube_pod_info{cluster="workload",namespace="default",pod="pod2",uid="pod2"`,
+	}
+	var got string
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		buf := &bytes.Buffer{}
+		if err := writers[0].WriteAll(buf); err != nil {
+			return false, err
+		}
+		got = buf.String()
+		for _, w := range want {
+			if !strings.Contains(got, w) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("expected cluster labeled metrics %v, got:\n%s", want, got)
+	}
+	if n := strings.Count(got, "# HELP kube_pod_info "); n != 1 {
+		t.Errorf("expected the header of kube_pod_info to be written out once, got %d times", n)
+	}
+}
+
+// testFactory is a custom resource store factory whose metrics are determined by its config.
+type testFactory struct {
+	config string
+}
+
+func (f *testFactory) Name() string {
+	return "testfoos"
+}
+
+func (f *testFactory) CreateClient(_ *rest.Config) (interface{}, error) {
+	return nil, nil
+}
+
+func (f *testFactory) MetricFamilyGenerators() []generator.FamilyGenerator {
+	return []generator.FamilyGenerator{}
+}
+
+func (f *testFactory) ExpectedType() interface{} {
+	return &samplev1alpha1.Foo{}
+}
+
+func (f *testFactory) ListWatch(_ interface{}, _ string, _ string) cache.ListerWatcher {
+	return &cache.ListWatch{
+		ListFunc: func(_ metav1.ListOptions) (runtime.Object, error) {
+			return &samplev1alpha1.FooList{}, nil
+		},
+		WatchFunc: func(_ metav1.ListOptions) (watch.Interface, error) {
+			return watch.NewFake(), nil
+		},
+	}
+}
+
+func (f *testFactory) Equal(other customresource.RegistryFactory) bool {
+	o, ok := other.(*testFactory)
+	return ok && o.config == f.config
+}
+
+func TestBuildReusesUnchangedStores(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l, err := allowdenylist.New(options.MetricSet{}, options.MetricSet{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := NewBuilder()
+	b.WithMetrics(prometheus.NewRegistry())
+	b.WithKubeClient(fake.NewSimpleClientset())
+	b.WithNamespaces(options.DefaultNamespaces)
+	b.WithFamilyGeneratorFilter(l)
+	b.WithGenerateStoresFunc(b.DefaultGenerateStoresFunc())
+	b.WithGenerateCustomResourceStoresFunc(b.DefaultGenerateCustomResourceStoresFunc())
+	b.WithCustomResourceClients(map[string]interface{}{"testfoos": struct{}{}})
+	b.WithCustomResourceStoreFactories(&testFactory{config: "a"})
+	if err := b.WithEnabledResources([]string{"configmaps", "testfoos"}); err != nil {
+		t.Fatal(err)
+	}
+	b.WithContext(ctx)
+
+	stores := func() map[string]*metricsstore.MetricsStore {
+		b.Build()
+		got := map[string]*metricsstore.MetricsStore{}
+		for resource, built := range b.builtStores {
+			if len(built.stores) != 1 {
+				t.Fatalf("expected a single store for %s, got %d", resource, len(built.stores))
+			}
+			got[resource] = built.stores[0]
+		}
+		return got
+	}
+
+	initial := stores()
+
+	// Replacing a custom resource factory with an equal one keeps all stores.
+	b.WithCustomResourceStoreFactories(&testFactory{config: "a"})
+	got := stores()
+	if got["configmaps"] != initial["configmaps"] || got["testfoos"] != initial["testfoos"] {
+		t.Error("expected all stores to be kept")
+	}
+
+	// Changing a custom resource factory only rebuilds the stores of that custom resource.
+	b.WithCustomResourceStoreFactories(&testFactory{config: "b"})
+	changed := stores()
+	if changed["configmaps"] != initial["configmaps"] {
+		t.Error("expected the configmaps stores to be kept")
+	}
+	if changed["testfoos"] == initial["testfoos"] {
+		t.Error("expected the testfoos stores to be rebuilt")
+	}
+
+	// A new context rebuilds all stores.
+	b.WithContext(context.WithoutCancel(ctx))
+	rebuilt := stores()
+	if rebuilt["configmaps"] == changed["configmaps"] || rebuilt["testfoos"] == changed["testfoos"] {
+		t.Error("expected all stores to be rebuilt")
+	}
+
+	// Removing a custom resource from the configuration stops and removes its stores.
+	b.RetainCustomResourceStoreFactories(nil)
+	if got := testutil.CollectAndCount(b.resourceEnabled); got != len(availableResources()) {
+		t.Errorf("expected the testfoos series to be removed from kube_state_metrics_resource_enabled, got %d series", got)
+	}
+	removed := stores()
+	if _, ok := removed["testfoos"]; ok {
+		t.Error("expected the testfoos stores to be removed")
+	}
+	if removed["configmaps"] != rebuilt["configmaps"] {
+		t.Error("expected the configmaps stores to be kept")
+	}
+	if resourceExists("testfoos") || slices.Contains(b.enabledResources, "testfoos") {
+		t.Error("expected testfoos not to be available anymore")
+	}
+}
+
+func TestMetricFamilyNames(t *testing.T) {
+	names := MetricFamilyNames()
+	if !slices.IsSorted(names) {
+		t.Error("expected the metric family names to be sorted")
+	}
+	for _, name := range []string{"kube_pod_info", "kube_deployment_spec_replicas", "kube_pod_resource_requests_sum"} {
+		if _, found := slices.BinarySearch(names, name); !found {
+			t.Errorf("expected metric family %s to be known", name)
+		}
+	}
+}
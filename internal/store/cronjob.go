@@ -216,6 +216,34 @@ func cronJobMetricFamilies(allowAnnotationsList, allowLabelsList []string) []gen
 				}
 			}),
 		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_cronjob_spec_concurrency_policy",
+			"Concurrency policy of the cronjob, specifying how to treat concurrent executions of a job.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapCronJobFunc(func(j *batchv1.CronJob) *metric.Family {
+				// An unset policy defaults to Allow.
+				policy := j.Spec.ConcurrencyPolicy
+				if policy == "" {
+					policy = batchv1.AllowConcurrent
+				}
+
+				policies := []batchv1.ConcurrencyPolicy{batchv1.AllowConcurrent, batchv1.ForbidConcurrent, batchv1.ReplaceConcurrent}
+				ms := make([]*metric.Metric, len(policies))
+				for i, p := range policies {
+					ms[i] = &metric.Metric{
+						LabelKeys:   []string{"policy"},
+						LabelValues: []string{string(p)},
+						Value:       boolFloat64(policy == p),
+					}
+				}
+
+				return &metric.Family{
+					Metrics: ms,
+				}
+			}),
+		),
 		*generator.NewFamilyGeneratorWithStability(
 			"kube_cronjob_spec_starting_deadline_seconds",
 			"Deadline in seconds for starting the job if it misses scheduled time for any reason.",
@@ -462,6 +462,56 @@ func TestCronJobStore(t *testing.T) {
 					float64(ActiveCronJob1NoLastScheduledNextScheduleTime.Unix())/math.Pow10(9)),
 			MetricNames: []string{"kube_cronjob_status_last_successful_time", "kube_cronjob_next_schedule_time", "kube_cronjob_spec_starting_deadline_seconds", "kube_cronjob_status_active", "kube_cronjob_metadata_resource_version", "kube_cronjob_spec_suspend", "kube_cronjob_info", "kube_cronjob_created", "kube_cronjob_labels", "kube_cronjob_spec_successful_job_history_limit", "kube_cronjob_spec_failed_job_history_limit"},
 		},
+		{
+			Obj: &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "ForbidCronJobWithTZ",
+					Namespace:         "ns1",
+					CreationTimestamp: metav1.Time{Time: time.Unix(1520742896, 0)},
+				},
+				Spec: batchv1.CronJobSpec{
+					Schedule:          "0 2 * * *",
+					Suspend:           &SuspendFalse,
+					ConcurrencyPolicy: batchv1.ForbidConcurrent,
+					TimeZone:          &TimeZone,
+				},
+			},
+			Want: `
+				# HELP kube_cronjob_info [STABLE] Info about cronjob.
+				# HELP kube_cronjob_spec_concurrency_policy Concurrency policy of the cronjob, specifying how to treat concurrent executions of a job.
+				# TYPE kube_cronjob_info gauge
+				# TYPE kube_cronjob_spec_concurrency_policy gauge
+				kube_cronjob_info{concurrency_policy="Forbid",cronjob="ForbidCronJobWithTZ",namespace="ns1",schedule="0 2 * * *",timezone="Asia/Shanghai"} 1
+				kube_cronjob_spec_concurrency_policy{cronjob="ForbidCronJobWithTZ",namespace="ns1",policy="Allow"} 0
+				kube_cronjob_spec_concurrency_policy{cronjob="ForbidCronJobWithTZ",namespace="ns1",policy="Forbid"} 1
+				kube_cronjob_spec_concurrency_policy{cronjob="ForbidCronJobWithTZ",namespace="ns1",policy="Replace"} 0
+`,
+			MetricNames: []string{"kube_cronjob_info", "kube_cronjob_spec_concurrency_policy"},
+		},
+		{
+			Obj: &batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "DefaultPolicyCronJobNoTZ",
+					Namespace:         "ns1",
+					CreationTimestamp: metav1.Time{Time: time.Unix(1520742896, 0)},
+				},
+				Spec: batchv1.CronJobSpec{
+					Schedule: "0 2 * * *",
+					Suspend:  &SuspendFalse,
+				},
+			},
+			Want: `
+				# HELP kube_cronjob_info [STABLE] Info about cronjob.
+				# HELP kube_cronjob_spec_concurrency_policy Concurrency policy of the cronjob, specifying how to treat concurrent executions of a job.
+				# TYPE kube_cronjob_info gauge
+				# TYPE kube_cronjob_spec_concurrency_policy gauge
+				kube_cronjob_info{concurrency_policy="",cronjob="DefaultPolicyCronJobNoTZ",namespace="ns1",schedule="0 2 * * *",timezone="local"} 1
+				kube_cronjob_spec_concurrency_policy{cronjob="DefaultPolicyCronJobNoTZ",namespace="ns1",policy="Allow"} 1
+				kube_cronjob_spec_concurrency_policy{cronjob="DefaultPolicyCronJobNoTZ",namespace="ns1",policy="Forbid"} 0
+				kube_cronjob_spec_concurrency_policy{cronjob="DefaultPolicyCronJobNoTZ",namespace="ns1",policy="Replace"} 0
+`,
+			MetricNames: []string{"kube_cronjob_info", "kube_cronjob_spec_concurrency_policy"},
+		},
 	}
 	for i, c := range cases {
 		c.Func = generator.ComposeMetricGenFuncs(cronJobMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
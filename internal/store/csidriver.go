@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"context"
+	"strconv"
+
+	basemetrics "k8s.io/component-base/metrics"
+
+	"k8s.io/kube-state-metrics/v2/pkg/metric"
+	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+)
+
+var (
+	descCSIDriverAnnotationsName     = "kube_csidriver_annotations"
+	descCSIDriverAnnotationsHelp     = "Kubernetes annotations converted to Prometheus labels."
+	descCSIDriverLabelsName          = "kube_csidriver_labels"
+	descCSIDriverLabelsHelp          = "Kubernetes labels converted to Prometheus labels."
+	descCSIDriverLabelsDefaultLabels = []string{"csidriver"}
+)
+
+func csiDriverMetricFamilies(allowAnnotationsList, allowLabelsList []string) []generator.FamilyGenerator {
+	return []generator.FamilyGenerator{
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_csidriver_info",
+			"Information about the capabilities of a csidriver.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapCSIDriverFunc(func(d *storagev1.CSIDriver) *metric.Family {
+				// Unset capabilities are reported with the defaults of the API server.
+				m := metric.Metric{
+					LabelKeys: []string{"attach_required", "pod_info_on_mount", "storage_capacity"},
+					LabelValues: []string{
+						strconv.FormatBool(ptr.Deref(d.Spec.AttachRequired, true)),
+						strconv.FormatBool(ptr.Deref(d.Spec.PodInfoOnMount, false)),
+						strconv.FormatBool(ptr.Deref(d.Spec.StorageCapacity, false)),
+					},
+					Value: 1,
+				}
+				return &metric.Family{Metrics: []*metric.Metric{&m}}
+			}),
+		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_csidriver_created",
+			"Unix creation timestamp",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapCSIDriverFunc(func(d *storagev1.CSIDriver) *metric.Family {
+				ms := []*metric.Metric{}
+				if !d.CreationTimestamp.IsZero() {
+					ms = append(ms, &metric.Metric{
+						Value: float64(d.CreationTimestamp.Unix()),
+					})
+				}
+				return &metric.Family{
+					Metrics: ms,
+				}
+			}),
+		),
+		*generator.NewFamilyGeneratorWithStability(
+			descCSIDriverAnnotationsName,
+			descCSIDriverAnnotationsHelp,
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapCSIDriverFunc(func(d *storagev1.CSIDriver) *metric.Family {
+				if len(allowAnnotationsList) == 0 {
+					return &metric.Family{}
+				}
+				annotationKeys, annotationValues := createPrometheusLabelKeysValues("annotation", d.Annotations, allowAnnotationsList)
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							LabelKeys:   annotationKeys,
+							LabelValues: annotationValues,
+							Value:       1,
+						},
+					},
+				}
+			}),
+		),
+		*generator.NewFamilyGeneratorWithStability(
+			descCSIDriverLabelsName,
+			descCSIDriverLabelsHelp,
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapCSIDriverFunc(func(d *storagev1.CSIDriver) *metric.Family {
+				if len(allowLabelsList) == 0 {
+					return &metric.Family{}
+				}
+				labelKeys, labelValues := createPrometheusLabelKeysValues("label", d.Labels, allowLabelsList)
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							LabelKeys:   labelKeys,
+							LabelValues: labelValues,
+							Value:       1,
+						},
+					},
+				}
+			}),
+		),
+	}
+}
+
+func wrapCSIDriverFunc(f func(*storagev1.CSIDriver) *metric.Family) func(interface{}) *metric.Family {
+	return func(obj interface{}) *metric.Family {
+		csiDriver := obj.(*storagev1.CSIDriver)
+
+		metricFamily := f(csiDriver)
+
+		for _, m := range metricFamily.Metrics {
+			m.LabelKeys, m.LabelValues = mergeKeyValues(descCSIDriverLabelsDefaultLabels, []string{csiDriver.Name}, m.LabelKeys, m.LabelValues)
+		}
+
+		return metricFamily
+	}
+}
+
+func createCSIDriverListWatch(kubeClient clientset.Interface, _ string, _ string) cache.ListerWatcher {
+	return &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			return kubeClient.StorageV1().CSIDrivers().List(context.TODO(), opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			return kubeClient.StorageV1().CSIDrivers().Watch(context.TODO(), opts)
+		},
+	}
+}
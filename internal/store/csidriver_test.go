@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"testing"
+
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+)
+
+func TestCSIDriverStore(t *testing.T) {
+	startTime := 1501569018
+	metav1StartTime := metav1.Unix(int64(startTime), 0)
+
+	cases := []generateMetricsTestCase{
+		{
+			Obj: &storagev1.CSIDriver{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "efs.csi.aws.com",
+					CreationTimestamp: metav1StartTime,
+				},
+				Spec: storagev1.CSIDriverSpec{
+					AttachRequired:  ptr.To(false),
+					PodInfoOnMount:  ptr.To(true),
+					StorageCapacity: ptr.To(true),
+				},
+			},
+			Want: `
+					# HELP kube_csidriver_created Unix creation timestamp
+					# HELP kube_csidriver_info Information about the capabilities of a csidriver.
+					# TYPE kube_csidriver_created gauge
+					# TYPE kube_csidriver_info gauge
+					kube_csidriver_created{csidriver="efs.csi.aws.com"} 1.501569018e+09
+					kube_csidriver_info{csidriver="efs.csi.aws.com",attach_required="false",pod_info_on_mount="true",storage_capacity="true"} 1
+				`,
+			MetricNames: []string{
+				"kube_csidriver_created",
+				"kube_csidriver_info",
+			},
+		},
+		{
+			Obj: &storagev1.CSIDriver{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ebs.csi.aws.com",
+				},
+			},
+			Want: `
+					# HELP kube_csidriver_info Information about the capabilities of a csidriver.
+					# TYPE kube_csidriver_info gauge
+					kube_csidriver_info{csidriver="ebs.csi.aws.com",attach_required="true",pod_info_on_mount="false",storage_capacity="false"} 1
+				`,
+			MetricNames: []string{
+				"kube_csidriver_info",
+			},
+		},
+		{
+			AllowAnnotationsList: []string{
+				"app.kubernetes.io/managed-by",
+			},
+			AllowLabelsList: []string{
+				"foo",
+			},
+			Obj: &storagev1.CSIDriver{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ebs.csi.aws.com",
+					Annotations: map[string]string{
+						"app.kubernetes.io/managed-by": "helm",
+					},
+					Labels: map[string]string{
+						"foo": "bar",
+					},
+				},
+			},
+			Want: `
+					# HELP kube_csidriver_annotations Kubernetes annotations converted to Prometheus labels.
+					# HELP kube_csidriver_labels Kubernetes labels converted to Prometheus labels.
+					# TYPE kube_csidriver_annotations gauge
+					# TYPE kube_csidriver_labels gauge
+					kube_csidriver_annotations{csidriver="ebs.csi.aws.com",annotation_app_kubernetes_io_managed_by="helm"} 1
+					kube_csidriver_labels{csidriver="ebs.csi.aws.com",label_foo="bar"} 1
+				`,
+			MetricNames: []string{
+				"kube_csidriver_annotations", "kube_csidriver_labels",
+			},
+		},
+	}
+	for i, c := range cases {
+		c.Func = generator.ComposeMetricGenFuncs(csiDriverMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
+		c.Headers = generator.ExtractMetricFamilyHeaders(csiDriverMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
+		if err := c.run(); err != nil {
+			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
+		}
+	}
+}
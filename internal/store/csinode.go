@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"context"
+
+	basemetrics "k8s.io/component-base/metrics"
+
+	"k8s.io/kube-state-metrics/v2/pkg/metric"
+	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	descCSINodeAnnotationsName     = "kube_csinode_annotations"
+	descCSINodeAnnotationsHelp     = "Kubernetes annotations converted to Prometheus labels."
+	descCSINodeLabelsName          = "kube_csinode_labels"
+	descCSINodeLabelsHelp          = "Kubernetes labels converted to Prometheus labels."
+	descCSINodeLabelsDefaultLabels = []string{"csinode"}
+)
+
+func csiNodeMetricFamilies(allowAnnotationsList, allowLabelsList []string) []generator.FamilyGenerator {
+	return []generator.FamilyGenerator{
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_csinode_created",
+			"Unix creation timestamp",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapCSINodeFunc(func(n *storagev1.CSINode) *metric.Family {
+				ms := []*metric.Metric{}
+				if !n.CreationTimestamp.IsZero() {
+					ms = append(ms, &metric.Metric{
+						Value: float64(n.CreationTimestamp.Unix()),
+					})
+				}
+				return &metric.Family{
+					Metrics: ms,
+				}
+			}),
+		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_csinode_driver_info",
+			"Information about a CSI driver registered on the node of a csinode.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapCSINodeFunc(func(n *storagev1.CSINode) *metric.Family {
+				ms := make([]*metric.Metric, 0, len(n.Spec.Drivers))
+				for _, d := range n.Spec.Drivers {
+					ms = append(ms, &metric.Metric{
+						LabelKeys:   []string{"driver", "node_id"},
+						LabelValues: []string{d.Name, d.NodeID},
+						Value:       1,
+					})
+				}
+				return &metric.Family{
+					Metrics: ms,
+				}
+			}),
+		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_csinode_driver_allocatable_count",
+			"The maximum number of unique volumes of a CSI driver which can be used on the node of a csinode.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapCSINodeFunc(func(n *storagev1.CSINode) *metric.Family {
+				ms := []*metric.Metric{}
+				for _, d := range n.Spec.Drivers {
+					if d.Allocatable == nil || d.Allocatable.Count == nil {
+						continue
+					}
+					ms = append(ms, &metric.Metric{
+						LabelKeys:   []string{"driver"},
+						LabelValues: []string{d.Name},
+						Value:       float64(*d.Allocatable.Count),
+					})
+				}
+				return &metric.Family{
+					Metrics: ms,
+				}
+			}),
+		),
+		*generator.NewFamilyGeneratorWithStability(
+			descCSINodeAnnotationsName,
+			descCSINodeAnnotationsHelp,
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapCSINodeFunc(func(n *storagev1.CSINode) *metric.Family {
+				if len(allowAnnotationsList) == 0 {
+					return &metric.Family{}
+				}
+				annotationKeys, annotationValues := createPrometheusLabelKeysValues("annotation", n.Annotations, allowAnnotationsList)
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							LabelKeys:   annotationKeys,
+							LabelValues: annotationValues,
+							Value:       1,
+						},
+					},
+				}
+			}),
+		),
+		*generator.NewFamilyGeneratorWithStability(
+			descCSINodeLabelsName,
+			descCSINodeLabelsHelp,
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapCSINodeFunc(func(n *storagev1.CSINode) *metric.Family {
+				if len(allowLabelsList) == 0 {
+					return &metric.Family{}
+				}
+				labelKeys, labelValues := createPrometheusLabelKeysValues("label", n.Labels, allowLabelsList)
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							LabelKeys:   labelKeys,
+							LabelValues: labelValues,
+							Value:       1,
+						},
+					},
+				}
+			}),
+		),
+	}
+}
+
+func wrapCSINodeFunc(f func(*storagev1.CSINode) *metric.Family) func(interface{}) *metric.Family {
+	return func(obj interface{}) *metric.Family {
+		csiNode := obj.(*storagev1.CSINode)
+
+		metricFamily := f(csiNode)
+
+		for _, m := range metricFamily.Metrics {
+			m.LabelKeys, m.LabelValues = mergeKeyValues(descCSINodeLabelsDefaultLabels, []string{csiNode.Name}, m.LabelKeys, m.LabelValues)
+		}
+
+		return metricFamily
+	}
+}
+
+func createCSINodeListWatch(kubeClient clientset.Interface, _ string, _ string) cache.ListerWatcher {
+	return &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			return kubeClient.StorageV1().CSINodes().List(context.TODO(), opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			return kubeClient.StorageV1().CSINodes().Watch(context.TODO(), opts)
+		},
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"testing"
+
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+)
+
+func TestCSINodeStore(t *testing.T) {
+	startTime := 1501569018
+	metav1StartTime := metav1.Unix(int64(startTime), 0)
+
+	cases := []generateMetricsTestCase{
+		{
+			Obj: &storagev1.CSINode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "node1",
+					CreationTimestamp: metav1StartTime,
+				},
+				Spec: storagev1.CSINodeSpec{
+					Drivers: []storagev1.CSINodeDriver{
+						{
+							Name:         "ebs.csi.aws.com",
+							NodeID:       "i-0123456789abcdef0",
+							TopologyKeys: []string{"topology.ebs.csi.aws.com/zone"},
+							Allocatable: &storagev1.VolumeNodeResources{
+								Count: ptr.To[int32](25),
+							},
+						},
+						{
+							Name:   "efs.csi.aws.com",
+							NodeID: "i-0123456789abcdef0",
+						},
+					},
+				},
+			},
+			Want: `
+					# HELP kube_csinode_created Unix creation timestamp
+					# HELP kube_csinode_driver_allocatable_count The maximum number of unique volumes of a CSI driver which can be used on the node of a csinode.
+					# HELP kube_csinode_driver_info Information about a CSI driver registered on the node of a csinode.
+					# TYPE kube_csinode_created gauge
+					# TYPE kube_csinode_driver_allocatable_count gauge
+					# TYPE kube_csinode_driver_info gauge
+					kube_csinode_created{csinode="node1"} 1.501569018e+09
+					kube_csinode_driver_allocatable_count{csinode="node1",driver="ebs.csi.aws.com"} 25
+					kube_csinode_driver_info{csinode="node1",driver="ebs.csi.aws.com",node_id="i-0123456789abcdef0"} 1
+					kube_csinode_driver_info{csinode="node1",driver="efs.csi.aws.com",node_id="i-0123456789abcdef0"} 1
+				`,
+			MetricNames: []string{
+				"kube_csinode_created",
+				"kube_csinode_driver_allocatable_count",
+				"kube_csinode_driver_info",
+			},
+		},
+		{
+			Obj: &storagev1.CSINode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node2",
+				},
+			},
+			Want: `
+					# HELP kube_csinode_driver_allocatable_count The maximum number of unique volumes of a CSI driver which can be used on the node of a csinode.
+					# HELP kube_csinode_driver_info Information about a CSI driver registered on the node of a csinode.
+					# TYPE kube_csinode_driver_allocatable_count gauge
+					# TYPE kube_csinode_driver_info gauge
+				`,
+			MetricNames: []string{
+				"kube_csinode_driver_allocatable_count",
+				"kube_csinode_driver_info",
+			},
+		},
+		{
+			AllowAnnotationsList: []string{
+				"app.kubernetes.io/managed-by",
+			},
+			AllowLabelsList: []string{
+				"foo",
+			},
+			Obj: &storagev1.CSINode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node1",
+					Annotations: map[string]string{
+						"app.kubernetes.io/managed-by": "kubelet",
+					},
+					Labels: map[string]string{
+						"foo": "bar",
+					},
+				},
+			},
+			Want: `
+					# HELP kube_csinode_annotations Kubernetes annotations converted to Prometheus labels.
+					# HELP kube_csinode_labels Kubernetes labels converted to Prometheus labels.
+					# TYPE kube_csinode_annotations gauge
+					# TYPE kube_csinode_labels gauge
+					kube_csinode_annotations{csinode="node1",annotation_app_kubernetes_io_managed_by="kubelet"} 1
+					kube_csinode_labels{csinode="node1",label_foo="bar"} 1
+				`,
+			MetricNames: []string{
+				"kube_csinode_annotations", "kube_csinode_labels",
+			},
+		},
+	}
+	for i, c := range cases {
+		c.Func = generator.ComposeMetricGenFuncs(csiNodeMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
+		c.Headers = generator.ExtractMetricFamilyHeaders(csiNodeMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
+		if err := c.run(); err != nil {
+			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"testing"
+
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+)
+
+func TestCSIStorageCapacityStore(t *testing.T) {
+	startTime := 1501569018
+	metav1StartTime := metav1.Unix(int64(startTime), 0)
+	capacity := resource.MustParse("100Gi")
+
+	cases := []generateMetricsTestCase{
+		{
+			Obj: &storagev1.CSIStorageCapacity{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "csisc-abcde",
+					Namespace:         "kube-system",
+					CreationTimestamp: metav1StartTime,
+				},
+				StorageClassName: "standard",
+				NodeTopology: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"topology.kubernetes.io/zone": "us-east-1a",
+					},
+				},
+				Capacity: &capacity,
+			},
+			Want: `
+					# HELP kube_csistoragecapacity_capacity_bytes The capacity available for volumes of a storageclass in the topology segment of a csistoragecapacity.
+					# HELP kube_csistoragecapacity_created Unix creation timestamp
+					# TYPE kube_csistoragecapacity_capacity_bytes gauge
+					# TYPE kube_csistoragecapacity_created gauge
+					kube_csistoragecapacity_capacity_bytes{csistoragecapacity="csisc-abcde",namespace="kube-system",storageclass="standard",topology="topology.kubernetes.io/zone=us-east-1a"} 1.073741824e+11
+					kube_csistoragecapacity_created{csistoragecapacity="csisc-abcde",namespace="kube-system"} 1.501569018e+09
+				`,
+			MetricNames: []string{
+				"kube_csistoragecapacity_capacity_bytes",
+				"kube_csistoragecapacity_created",
+			},
+		},
+		{
+			Obj: &storagev1.CSIStorageCapacity{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "csisc-fghij",
+					Namespace: "kube-system",
+				},
+				StorageClassName: "standard",
+			},
+			Want: `
+					# HELP kube_csistoragecapacity_capacity_bytes The capacity available for volumes of a storageclass in the topology segment of a csistoragecapacity.
+					# TYPE kube_csistoragecapacity_capacity_bytes gauge
+				`,
+			MetricNames: []string{
+				"kube_csistoragecapacity_capacity_bytes",
+			},
+		},
+		{
+			AllowAnnotationsList: []string{
+				"app.kubernetes.io/managed-by",
+			},
+			AllowLabelsList: []string{
+				"foo",
+			},
+			Obj: &storagev1.CSIStorageCapacity{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "csisc-abcde",
+					Namespace: "kube-system",
+					Annotations: map[string]string{
+						"app.kubernetes.io/managed-by": "external-provisioner",
+					},
+					Labels: map[string]string{
+						"foo": "bar",
+					},
+				},
+			},
+			Want: `
+					# HELP kube_csistoragecapacity_annotations Kubernetes annotations converted to Prometheus labels.
+					# HELP kube_csistoragecapacity_labels Kubernetes labels converted to Prometheus labels.
+					# TYPE kube_csistoragecapacity_annotations gauge
+					# TYPE kube_csistoragecapacity_labels gauge
+					kube_csistoragecapacity_annotations{csistoragecapacity="csisc-abcde",namespace="kube-system",annotation_app_kubernetes_io_managed_by="external-provisioner"} 1
+					kube_csistoragecapacity_labels{csistoragecapacity="csisc-abcde",namespace="kube-system",label_foo="bar"} 1
+				`,
+			MetricNames: []string{
+				"kube_csistoragecapacity_annotations", "kube_csistoragecapacity_labels",
+			},
+		},
+	}
+	for i, c := range cases {
+		c.Func = generator.ComposeMetricGenFuncs(csiStorageCapacityMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
+		c.Headers = generator.ExtractMetricFamilyHeaders(csiStorageCapacityMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
+		if err := c.run(); err != nil {
+			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
+		}
+	}
+}
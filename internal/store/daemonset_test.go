@@ -21,6 +21,7 @@ import (
 	"time"
 
 	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
@@ -228,6 +229,43 @@ func TestDaemonSetStore(t *testing.T) {
 				"kube_daemonset_status_updated_number_scheduled",
 			},
 		},
+		{
+			Obj: &v1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ds4",
+					Namespace: "ns4",
+				},
+				Status: v1.DaemonSetStatus{
+					Conditions: []v1.DaemonSetCondition{
+						{
+							Type:   "Progressing",
+							Status: corev1.ConditionFalse,
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_daemonset_status_condition The current status conditions of a daemonset.
+				# TYPE kube_daemonset_status_condition gauge
+				kube_daemonset_status_condition{condition="Progressing",daemonset="ds4",namespace="ns4",status="false"} 1
+				kube_daemonset_status_condition{condition="Progressing",daemonset="ds4",namespace="ns4",status="true"} 0
+				kube_daemonset_status_condition{condition="Progressing",daemonset="ds4",namespace="ns4",status="unknown"} 0
+`,
+			MetricNames: []string{"kube_daemonset_status_condition"},
+		},
+		{
+			Obj: &v1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ds5",
+					Namespace: "ns5",
+				},
+			},
+			Want: `
+				# HELP kube_daemonset_status_condition The current status conditions of a daemonset.
+				# TYPE kube_daemonset_status_condition gauge
+`,
+			MetricNames: []string{"kube_daemonset_status_condition"},
+		},
 	}
 	for i, c := range cases {
 		c.Func = generator.ComposeMetricGenFuncs(daemonSetMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
@@ -217,6 +217,39 @@ func deploymentMetricFamilies(allowAnnotationsList, allowLabelsList []string) []
 				}
 			}),
 		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_deployment_spec_revision_history_limit",
+			"The number of old replicasets retained to allow rollback of a deployment.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapDeploymentFunc(func(d *v1.Deployment) *metric.Family {
+				// The API server defaults spec.revisionHistoryLimit to 10 when unset.
+				limit := int32(10)
+				if d.Spec.RevisionHistoryLimit != nil {
+					limit = *d.Spec.RevisionHistoryLimit
+				}
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							Value: float64(limit),
+						},
+					},
+				}
+			}),
+		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_deployment_spec_template_container_image",
+			"The image of each container in the pod template of a deployment.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapDeploymentFunc(func(d *v1.Deployment) *metric.Family {
+				return &metric.Family{
+					Metrics: podTemplateContainerImageMetrics(d.Spec.Template),
+				}
+			}),
+		),
 		*generator.NewFamilyGeneratorWithStability(
 			"kube_deployment_spec_strategy_rollingupdate_max_unavailable",
 			"Maximum number of unavailable replicas during a rolling update of a deployment.",
@@ -283,6 +316,22 @@ func deploymentMetricFamilies(allowAnnotationsList, allowLabelsList []string) []
 				}
 			}),
 		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_deployment_metadata_generation_matches_observed",
+			"Whether the generation of the deployment was observed by the deployment controller.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapDeploymentFunc(func(d *v1.Deployment) *metric.Family {
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							Value: boolFloat64(d.ObjectMeta.Generation == d.Status.ObservedGeneration),
+						},
+					},
+				}
+			}),
+		),
 		*generator.NewFamilyGeneratorWithStability(
 			descDeploymentAnnotationsName,
 			descDeploymentAnnotationsHelp,
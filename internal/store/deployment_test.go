@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
 )
@@ -49,8 +50,12 @@ func TestDeploymentStore(t *testing.T) {
 		# TYPE kube_deployment_created gauge
 		# HELP kube_deployment_metadata_generation [STABLE] Sequence number representing a specific generation of the desired state.
 		# TYPE kube_deployment_metadata_generation gauge
+		# HELP kube_deployment_metadata_generation_matches_observed Whether the generation of the deployment was observed by the deployment controller.
+		# TYPE kube_deployment_metadata_generation_matches_observed gauge
 		# HELP kube_deployment_spec_paused [STABLE] Whether the deployment is paused and will not be processed by the deployment controller.
 		# TYPE kube_deployment_spec_paused gauge
+		# HELP kube_deployment_spec_revision_history_limit The number of old replicasets retained to allow rollback of a deployment.
+		# TYPE kube_deployment_spec_revision_history_limit gauge
 		# HELP kube_deployment_spec_replicas [STABLE] Number of desired pods for a deployment.
 		# TYPE kube_deployment_spec_replicas gauge
 		# HELP kube_deployment_status_replicas [STABLE] The number of replicas per deployment.
@@ -69,6 +74,8 @@ func TestDeploymentStore(t *testing.T) {
 		# TYPE kube_deployment_status_condition gauge
 		# HELP kube_deployment_spec_strategy_rollingupdate_max_unavailable [STABLE] Maximum number of unavailable replicas during a rolling update of a deployment.
 		# TYPE kube_deployment_spec_strategy_rollingupdate_max_unavailable gauge
+		# HELP kube_deployment_spec_template_container_image The image of each container in the pod template of a deployment.
+		# TYPE kube_deployment_spec_template_container_image gauge
 		# HELP kube_deployment_spec_strategy_rollingupdate_max_surge [STABLE] Maximum number of replicas that can be scheduled above the desired number of replicas during a rolling update of a deployment.
 		# TYPE kube_deployment_spec_strategy_rollingupdate_max_surge gauge
 		# HELP kube_deployment_labels [STABLE] Kubernetes labels converted to Prometheus labels.
@@ -116,7 +123,9 @@ func TestDeploymentStore(t *testing.T) {
         kube_deployment_annotations{annotation_company_io_team="my-brilliant-team",deployment="depl1",namespace="ns1"} 1
         kube_deployment_created{deployment="depl1",namespace="ns1"} 1.5e+09
         kube_deployment_metadata_generation{deployment="depl1",namespace="ns1"} 21
+        kube_deployment_metadata_generation_matches_observed{deployment="depl1",namespace="ns1"} 0
         kube_deployment_spec_paused{deployment="depl1",namespace="ns1"} 0
+        kube_deployment_spec_revision_history_limit{deployment="depl1",namespace="ns1"} 10
         kube_deployment_spec_replicas{deployment="depl1",namespace="ns1"} 200
         kube_deployment_spec_strategy_rollingupdate_max_surge{deployment="depl1",namespace="ns1"} 10
         kube_deployment_spec_strategy_rollingupdate_max_unavailable{deployment="depl1",namespace="ns1"} 10
@@ -158,8 +167,9 @@ func TestDeploymentStore(t *testing.T) {
 					},
 				},
 				Spec: v1.DeploymentSpec{
-					Paused:   true,
-					Replicas: &depl2Replicas,
+					Paused:               true,
+					Replicas:             &depl2Replicas,
+					RevisionHistoryLimit: ptr.To[int32](3),
 					Strategy: v1.DeploymentStrategy{
 						RollingUpdate: &v1.RollingUpdateDeployment{
 							MaxUnavailable: &depl2MaxUnavailable,
@@ -170,7 +180,9 @@ func TestDeploymentStore(t *testing.T) {
 			},
 			Want: metadata + `
         kube_deployment_metadata_generation{deployment="depl2",namespace="ns2"} 14
+        kube_deployment_metadata_generation_matches_observed{deployment="depl2",namespace="ns2"} 0
         kube_deployment_spec_paused{deployment="depl2",namespace="ns2"} 1
+        kube_deployment_spec_revision_history_limit{deployment="depl2",namespace="ns2"} 3
         kube_deployment_spec_replicas{deployment="depl2",namespace="ns2"} 5
         kube_deployment_spec_strategy_rollingupdate_max_surge{deployment="depl2",namespace="ns2"} 1
         kube_deployment_spec_strategy_rollingupdate_max_unavailable{deployment="depl2",namespace="ns2"} 1
@@ -191,6 +203,74 @@ func TestDeploymentStore(t *testing.T) {
         kube_deployment_status_condition{deployment="depl2",namespace="ns2",condition="ReplicaFailure",status="unknown"} 0
 `,
 		},
+		{
+			Obj: &v1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "depl3",
+					Namespace: "ns3",
+				},
+				Spec: v1.DeploymentSpec{
+					Replicas:             &depl2Replicas,
+					RevisionHistoryLimit: ptr.To[int32](0),
+				},
+			},
+			Want: `
+        # HELP kube_deployment_spec_revision_history_limit The number of old replicasets retained to allow rollback of a deployment.
+        # TYPE kube_deployment_spec_revision_history_limit gauge
+        kube_deployment_spec_revision_history_limit{deployment="depl3",namespace="ns3"} 0
+`,
+			MetricNames: []string{"kube_deployment_spec_revision_history_limit"},
+		},
+		{
+			Obj: &v1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "depl4",
+					Namespace:  "ns4",
+					Generation: 7,
+				},
+				Spec: v1.DeploymentSpec{
+					Replicas: &depl2Replicas,
+				},
+				Status: v1.DeploymentStatus{
+					ObservedGeneration: 7,
+				},
+			},
+			Want: `
+        # HELP kube_deployment_metadata_generation_matches_observed Whether the generation of the deployment was observed by the deployment controller.
+        # HELP kube_deployment_status_observed_generation [STABLE] The generation observed by the deployment controller.
+        # TYPE kube_deployment_metadata_generation_matches_observed gauge
+        # TYPE kube_deployment_status_observed_generation gauge
+        kube_deployment_metadata_generation_matches_observed{deployment="depl4",namespace="ns4"} 1
+        kube_deployment_status_observed_generation{deployment="depl4",namespace="ns4"} 7
+`,
+			MetricNames: []string{"kube_deployment_metadata_generation_matches_observed", "kube_deployment_status_observed_generation"},
+		},
+		{
+			Obj: &v1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "depl5",
+					Namespace: "ns5",
+				},
+				Spec: v1.DeploymentSpec{
+					Replicas: &depl2Replicas,
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{Name: "app", Image: "registry.example.com/app:v1.2.3"},
+								{Name: "sidecar", Image: "registry.example.com/proxy@sha256:4ab8"},
+							},
+						},
+					},
+				},
+			},
+			Want: `
+        # HELP kube_deployment_spec_template_container_image The image of each container in the pod template of a deployment.
+        # TYPE kube_deployment_spec_template_container_image gauge
+        kube_deployment_spec_template_container_image{deployment="depl5",namespace="ns5",container="app",image="registry.example.com/app:v1.2.3"} 1
+        kube_deployment_spec_template_container_image{deployment="depl5",namespace="ns5",container="sidecar",image="registry.example.com/proxy@sha256:4ab8"} 1
+`,
+			MetricNames: []string{"kube_deployment_spec_template_container_image"},
+		},
 	}
 
 	for i, c := range cases {
@@ -38,7 +38,7 @@ func TestEndpointStore(t *testing.T) {
 		# TYPE kube_endpoint_info gauge
 		# HELP kube_endpoint_labels [STABLE] Kubernetes labels converted to Prometheus labels.
 		# TYPE kube_endpoint_labels gauge
-		# HELP kube_endpoint_ports [STABLE] (Deprecated since v2.14.0) Information about the Endpoint ports.
+		# HELP kube_endpoint_ports [STABLE] [DEPRECATED since v2.14.0] Information about the Endpoint ports.
 		# TYPE kube_endpoint_ports gauge
 		# HELP kube_endpoint_address [STABLE] Information about Endpoint available and non available addresses.
 		# TYPE kube_endpoint_address gauge
@@ -162,7 +162,7 @@ func TestEndpointStoreWithLabels(t *testing.T) {
 		# TYPE kube_endpoint_info gauge
 		# HELP kube_endpoint_labels [STABLE] Kubernetes labels converted to Prometheus labels.
 		# TYPE kube_endpoint_labels gauge
-		# HELP kube_endpoint_ports [STABLE] (Deprecated since v2.14.0) Information about the Endpoint ports.
+		# HELP kube_endpoint_ports [STABLE] [DEPRECATED since v2.14.0] Information about the Endpoint ports.
 		# TYPE kube_endpoint_ports gauge
 		# HELP kube_endpoint_address [STABLE] Information about Endpoint available and non available addresses.
 		# TYPE kube_endpoint_address gauge
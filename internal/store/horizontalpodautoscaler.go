@@ -53,6 +53,12 @@ var (
 	targetMetricLabels = []string{"metric_name", "metric_target_type"}
 )
 
+const (
+	// The stabilization windows the HorizontalPodAutoscaler controller uses if the behavior does not set one.
+	defaultHPAScaleUpStabilizationWindowSeconds   = 0
+	defaultHPAScaleDownStabilizationWindowSeconds = 300
+)
+
 func hpaMetricFamilies(allowAnnotationsList, allowLabelsList []string) []generator.FamilyGenerator {
 	return []generator.FamilyGenerator{
 		createHPAInfo(),
@@ -60,6 +66,8 @@ func hpaMetricFamilies(allowAnnotationsList, allowLabelsList []string) []generat
 		createHPASpecMaxReplicas(),
 		createHPASpecMinReplicas(),
 		createHPASpecTargetMetric(),
+		createHPASpecBehaviorScaleUpStabilizationWindowSeconds(),
+		createHPASpecBehaviorScaleDownStabilizationWindowSeconds(),
 		createHPAStatusTargetMetric(),
 		createHPAStatusCurrentReplicas(),
 		createHPAStatusDesiredReplicas(),
@@ -239,6 +247,61 @@ func createHPASpecTargetMetric() generator.FamilyGenerator {
 	)
 }
 
+func createHPASpecBehaviorScaleUpStabilizationWindowSeconds() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_horizontalpodautoscaler_spec_behavior_scale_up_stabilization_window_seconds",
+		"The number of seconds for which past recommendations are considered when scaling up, default 0.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapHPAFunc(func(a *autoscaling.HorizontalPodAutoscaler) *metric.Family {
+			var rules *autoscaling.HPAScalingRules
+			if a.Spec.Behavior != nil {
+				rules = a.Spec.Behavior.ScaleUp
+			}
+			return &metric.Family{
+				Metrics: []*metric.Metric{
+					{
+						Value: hpaStabilizationWindowSeconds(rules, defaultHPAScaleUpStabilizationWindowSeconds),
+					},
+				},
+			}
+		}),
+	)
+}
+
+func createHPASpecBehaviorScaleDownStabilizationWindowSeconds() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_horizontalpodautoscaler_spec_behavior_scale_down_stabilization_window_seconds",
+		"The number of seconds for which past recommendations are considered when scaling down, default 300.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapHPAFunc(func(a *autoscaling.HorizontalPodAutoscaler) *metric.Family {
+			var rules *autoscaling.HPAScalingRules
+			if a.Spec.Behavior != nil {
+				rules = a.Spec.Behavior.ScaleDown
+			}
+			return &metric.Family{
+				Metrics: []*metric.Metric{
+					{
+						Value: hpaStabilizationWindowSeconds(rules, defaultHPAScaleDownStabilizationWindowSeconds),
+					},
+				},
+			}
+		}),
+	)
+}
+
+// hpaStabilizationWindowSeconds returns the stabilization window of the scaling rules,
+// or the given default if the rules or their window are not set.
+func hpaStabilizationWindowSeconds(rules *autoscaling.HPAScalingRules, defaultSeconds float64) float64 {
+	if rules == nil || rules.StabilizationWindowSeconds == nil {
+		return defaultSeconds
+	}
+	return float64(*rules.StabilizationWindowSeconds)
+}
+
 func createHPAStatusTargetMetric() generator.FamilyGenerator {
 	return *generator.NewFamilyGeneratorWithStability(
 		"kube_horizontalpodautoscaler_status_target_metric",
@@ -423,6 +423,61 @@ func TestHPAStore(t *testing.T) {
 				"kube_horizontalpodautoscaler_labels",
 			},
 		},
+		{
+			// Verify a custom scale down stabilization window, the scale up window falls back to the default.
+			Obj: &autoscaling.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "hpa3",
+					Namespace: "ns1",
+				},
+				Spec: autoscaling.HorizontalPodAutoscalerSpec{
+					MaxReplicas: 4,
+					MinReplicas: int32ptr(1),
+					Behavior: &autoscaling.HorizontalPodAutoscalerBehavior{
+						ScaleDown: &autoscaling.HPAScalingRules{
+							StabilizationWindowSeconds: int32ptr(600),
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_horizontalpodautoscaler_spec_behavior_scale_down_stabilization_window_seconds The number of seconds for which past recommendations are considered when scaling down, default 300.
+				# HELP kube_horizontalpodautoscaler_spec_behavior_scale_up_stabilization_window_seconds The number of seconds for which past recommendations are considered when scaling up, default 0.
+				# TYPE kube_horizontalpodautoscaler_spec_behavior_scale_down_stabilization_window_seconds gauge
+				# TYPE kube_horizontalpodautoscaler_spec_behavior_scale_up_stabilization_window_seconds gauge
+				kube_horizontalpodautoscaler_spec_behavior_scale_down_stabilization_window_seconds{horizontalpodautoscaler="hpa3",namespace="ns1"} 600
+				kube_horizontalpodautoscaler_spec_behavior_scale_up_stabilization_window_seconds{horizontalpodautoscaler="hpa3",namespace="ns1"} 0
+			`,
+			MetricNames: []string{
+				"kube_horizontalpodautoscaler_spec_behavior_scale_down_stabilization_window_seconds",
+				"kube_horizontalpodautoscaler_spec_behavior_scale_up_stabilization_window_seconds",
+			},
+		},
+		{
+			// Verify the defaults are exposed if the behavior is not set.
+			Obj: &autoscaling.HorizontalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "hpa4",
+					Namespace: "ns1",
+				},
+				Spec: autoscaling.HorizontalPodAutoscalerSpec{
+					MaxReplicas: 4,
+					MinReplicas: int32ptr(1),
+				},
+			},
+			Want: `
+				# HELP kube_horizontalpodautoscaler_spec_behavior_scale_down_stabilization_window_seconds The number of seconds for which past recommendations are considered when scaling down, default 300.
+				# HELP kube_horizontalpodautoscaler_spec_behavior_scale_up_stabilization_window_seconds The number of seconds for which past recommendations are considered when scaling up, default 0.
+				# TYPE kube_horizontalpodautoscaler_spec_behavior_scale_down_stabilization_window_seconds gauge
+				# TYPE kube_horizontalpodautoscaler_spec_behavior_scale_up_stabilization_window_seconds gauge
+				kube_horizontalpodautoscaler_spec_behavior_scale_down_stabilization_window_seconds{horizontalpodautoscaler="hpa4",namespace="ns1"} 300
+				kube_horizontalpodautoscaler_spec_behavior_scale_up_stabilization_window_seconds{horizontalpodautoscaler="hpa4",namespace="ns1"} 0
+			`,
+			MetricNames: []string{
+				"kube_horizontalpodautoscaler_spec_behavior_scale_down_stabilization_window_seconds",
+				"kube_horizontalpodautoscaler_spec_behavior_scale_up_stabilization_window_seconds",
+			},
+		},
 	}
 	for i, c := range cases {
 		c.Func = generator.ComposeMetricGenFuncs(hpaMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
@@ -73,6 +73,22 @@ func ingressClassMetricFamilies(allowAnnotationsList, allowLabelsList []string)
 				}
 			}),
 		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_ingressclass_annotation_is_default",
+			"Whether the ingressclass is marked as the default of the cluster by its annotation.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapIngressClassFunc(func(s *networkingv1.IngressClass) *metric.Family {
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							Value: boolFloat64(s.Annotations[networkingv1.AnnotationIsDefaultIngressClass] == "true"),
+						},
+					},
+				}
+			}),
+		),
 		*generator.NewFamilyGeneratorWithStability(
 			descIngressClassAnnotationsName,
 			descIngressClassAnnotationsHelp,
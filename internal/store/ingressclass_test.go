@@ -64,6 +64,45 @@ func TestIngressClassStore(t *testing.T) {
 				"kube_ingressclass_created",
 			},
 		},
+		{
+			Obj: &networkingv1.IngressClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test_ingressclass-default",
+					Annotations: map[string]string{
+						"ingressclass.kubernetes.io/is-default-class": "true",
+					},
+				},
+				Spec: networkingv1.IngressClassSpec{
+					Controller: "controller",
+				},
+			},
+			Want: `
+					# HELP kube_ingressclass_annotation_is_default Whether the ingressclass is marked as the default of the cluster by its annotation.
+					# TYPE kube_ingressclass_annotation_is_default gauge
+					kube_ingressclass_annotation_is_default{ingressclass="test_ingressclass-default"} 1
+				`,
+			MetricNames: []string{
+				"kube_ingressclass_annotation_is_default",
+			},
+		},
+		{
+			Obj: &networkingv1.IngressClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test_ingressclass-not-default",
+				},
+				Spec: networkingv1.IngressClassSpec{
+					Controller: "controller",
+				},
+			},
+			Want: `
+					# HELP kube_ingressclass_annotation_is_default Whether the ingressclass is marked as the default of the cluster by its annotation.
+					# TYPE kube_ingressclass_annotation_is_default gauge
+					kube_ingressclass_annotation_is_default{ingressclass="test_ingressclass-not-default"} 0
+				`,
+			MetricNames: []string{
+				"kube_ingressclass_annotation_is_default",
+			},
+		},
 		{
 			AllowAnnotationsList: []string{
 				"ingressclass.kubernetes.io/is-default-class",
@@ -185,6 +185,22 @@ func jobMetricFamilies(allowAnnotationsList, allowLabelsList []string) []generat
 				}
 			}),
 		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_job_spec_suspend",
+			"Whether the job is suspended, i.e. the job controller does not create pods for it.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapJobFunc(func(j *v1batch.Job) *metric.Family {
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							Value: boolFloat64(j.Spec.Suspend != nil && *j.Spec.Suspend),
+						},
+					},
+				}
+			}),
+		),
 		*generator.NewFamilyGeneratorWithStability(
 			"kube_job_status_succeeded",
 			"The number of pods which reached Phase Succeeded.",
@@ -315,6 +331,32 @@ func jobMetricFamilies(allowAnnotationsList, allowLabelsList []string) []generat
 				}
 			}),
 		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_job_status_condition",
+			"The current status conditions of a job and the reason for them.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapJobFunc(func(j *v1batch.Job) *metric.Family {
+				ms := make([]*metric.Metric, len(j.Status.Conditions)*len(conditionStatuses))
+
+				for i, c := range j.Status.Conditions {
+					conditionMetrics := addConditionMetrics(c.Status)
+
+					for k, m := range conditionMetrics {
+						metric := m
+
+						metric.LabelKeys = []string{"condition", "status", "reason"}
+						metric.LabelValues = []string{string(c.Type), metric.LabelValues[0], c.Reason}
+						ms[i*len(conditionStatuses)+k] = metric
+					}
+				}
+
+				return &metric.Family{
+					Metrics: ms,
+				}
+			}),
+		),
 		*generator.NewFamilyGeneratorWithStability(
 			"kube_job_status_start_time",
 			"StartTime represents time when the job was acknowledged by the Job Manager.",
@@ -69,10 +69,14 @@ func TestJobStore(t *testing.T) {
 		# TYPE kube_job_spec_completions gauge
 		# HELP kube_job_spec_parallelism [STABLE] The maximum desired number of pods the job should run at any given time.
 		# TYPE kube_job_spec_parallelism gauge
+		# HELP kube_job_spec_suspend Whether the job is suspended, i.e. the job controller does not create pods for it.
+		# TYPE kube_job_spec_suspend gauge
 		# HELP kube_job_status_active [STABLE] The number of actively running pods.
 		# TYPE kube_job_status_active gauge
 		# HELP kube_job_status_completion_time [STABLE] CompletionTime represents time when the job was completed.
 		# TYPE kube_job_status_completion_time gauge
+		# HELP kube_job_status_condition The current status conditions of a job and the reason for them.
+		# TYPE kube_job_status_condition gauge
 		# HELP kube_job_status_failed [STABLE] The number of pods which reached Phase Failed and the reason for failure.
 		# TYPE kube_job_status_failed gauge
 		# HELP kube_job_status_start_time [STABLE] StartTime represents time when the job was acknowledged by the Job Manager.
@@ -119,6 +123,7 @@ func TestJobStore(t *testing.T) {
 				kube_job_owner{job_name="RunningJob1",namespace="ns1",owner_is_controller="true",owner_kind="CronJob",owner_name="cronjob-name"} 1
 				kube_job_created{job_name="RunningJob1",namespace="ns1"} 1.5e+09
 				kube_job_info{job_name="RunningJob1",namespace="ns1"} 1
+				kube_job_spec_suspend{job_name="RunningJob1",namespace="ns1"} 0
 				kube_job_spec_active_deadline_seconds{job_name="RunningJob1",namespace="ns1"} 900
 				kube_job_spec_completions{job_name="RunningJob1",namespace="ns1"} 1
 				kube_job_spec_parallelism{job_name="RunningJob1",namespace="ns1"} 1
@@ -160,11 +165,15 @@ func TestJobStore(t *testing.T) {
 				kube_job_complete{condition="true",job_name="SuccessfulJob1",namespace="ns1"} 1
 				kube_job_complete{condition="unknown",job_name="SuccessfulJob1",namespace="ns1"} 0
 				kube_job_info{job_name="SuccessfulJob1",namespace="ns1"} 1
+				kube_job_spec_suspend{job_name="SuccessfulJob1",namespace="ns1"} 0
 				kube_job_spec_active_deadline_seconds{job_name="SuccessfulJob1",namespace="ns1"} 900
 				kube_job_spec_completions{job_name="SuccessfulJob1",namespace="ns1"} 1
 				kube_job_spec_parallelism{job_name="SuccessfulJob1",namespace="ns1"} 1
 				kube_job_status_active{job_name="SuccessfulJob1",namespace="ns1"} 0
 				kube_job_status_completion_time{job_name="SuccessfulJob1",namespace="ns1"} 1.495803607e+09
+				kube_job_status_condition{condition="Complete",job_name="SuccessfulJob1",namespace="ns1",reason="",status="false"} 0
+				kube_job_status_condition{condition="Complete",job_name="SuccessfulJob1",namespace="ns1",reason="",status="true"} 1
+				kube_job_status_condition{condition="Complete",job_name="SuccessfulJob1",namespace="ns1",reason="",status="unknown"} 0
 				kube_job_status_failed{job_name="SuccessfulJob1",namespace="ns1"} 0
 				kube_job_status_start_time{job_name="SuccessfulJob1",namespace="ns1"} 1.495800007e+09
 				kube_job_status_succeeded{job_name="SuccessfulJob1",namespace="ns1"} 1
@@ -202,11 +211,15 @@ func TestJobStore(t *testing.T) {
 				kube_job_failed{condition="true",job_name="FailedJob1",namespace="ns1"} 1
 				kube_job_failed{condition="unknown",job_name="FailedJob1",namespace="ns1"} 0
 				kube_job_info{job_name="FailedJob1",namespace="ns1"} 1
+				kube_job_spec_suspend{job_name="FailedJob1",namespace="ns1"} 0
 				kube_job_spec_active_deadline_seconds{job_name="FailedJob1",namespace="ns1"} 900
 				kube_job_spec_completions{job_name="FailedJob1",namespace="ns1"} 1
 				kube_job_spec_parallelism{job_name="FailedJob1",namespace="ns1"} 1
 				kube_job_status_active{job_name="FailedJob1",namespace="ns1"} 0
 				kube_job_status_completion_time{job_name="FailedJob1",namespace="ns1"} 1.495810807e+09
+				kube_job_status_condition{condition="Failed",job_name="FailedJob1",namespace="ns1",reason="BackoffLimitExceeded",status="false"} 0
+				kube_job_status_condition{condition="Failed",job_name="FailedJob1",namespace="ns1",reason="BackoffLimitExceeded",status="true"} 1
+				kube_job_status_condition{condition="Failed",job_name="FailedJob1",namespace="ns1",reason="BackoffLimitExceeded",status="unknown"} 0
 				kube_job_status_failed{job_name="FailedJob1",namespace="ns1",reason="BackoffLimitExceeded"} 1
 				kube_job_status_failed{job_name="FailedJob1",namespace="ns1",reason="DeadlineExceeded"} 0
 				kube_job_status_failed{job_name="FailedJob1",namespace="ns1",reason="Evicted"} 0
@@ -230,6 +243,7 @@ func TestJobStore(t *testing.T) {
 			Want: metadata + `
 				kube_job_owner{job_name="FailedJobWithNoConditions",namespace="ns1",owner_is_controller="",owner_kind="",owner_name=""} 1
 				kube_job_info{job_name="FailedJobWithNoConditions",namespace="ns1"} 1
+				kube_job_spec_suspend{job_name="FailedJobWithNoConditions",namespace="ns1"} 0
 				kube_job_spec_active_deadline_seconds{job_name="FailedJobWithNoConditions",namespace="ns1"} 900
 				kube_job_status_active{job_name="FailedJobWithNoConditions",namespace="ns1"} 0
 				kube_job_status_failed{job_name="FailedJobWithNoConditions",namespace="ns1",reason=""} 1
@@ -269,10 +283,14 @@ func TestJobStore(t *testing.T) {
 
 				kube_job_complete{condition="unknown",job_name="SuccessfulJob2NoActiveDeadlineSeconds",namespace="ns1"} 0
 				kube_job_info{job_name="SuccessfulJob2NoActiveDeadlineSeconds",namespace="ns1"} 1
+				kube_job_spec_suspend{job_name="SuccessfulJob2NoActiveDeadlineSeconds",namespace="ns1"} 0
 				kube_job_spec_completions{job_name="SuccessfulJob2NoActiveDeadlineSeconds",namespace="ns1"} 1
 				kube_job_spec_parallelism{job_name="SuccessfulJob2NoActiveDeadlineSeconds",namespace="ns1"} 1
 				kube_job_status_active{job_name="SuccessfulJob2NoActiveDeadlineSeconds",namespace="ns1"} 0
 				kube_job_status_completion_time{job_name="SuccessfulJob2NoActiveDeadlineSeconds",namespace="ns1"} 1.495804207e+09
+				kube_job_status_condition{condition="Complete",job_name="SuccessfulJob2NoActiveDeadlineSeconds",namespace="ns1",reason="",status="false"} 0
+				kube_job_status_condition{condition="Complete",job_name="SuccessfulJob2NoActiveDeadlineSeconds",namespace="ns1",reason="",status="true"} 1
+				kube_job_status_condition{condition="Complete",job_name="SuccessfulJob2NoActiveDeadlineSeconds",namespace="ns1",reason="",status="unknown"} 0
 				kube_job_status_failed{job_name="SuccessfulJob2NoActiveDeadlineSeconds",namespace="ns1"} 0
 				kube_job_status_start_time{job_name="SuccessfulJob2NoActiveDeadlineSeconds",namespace="ns1"} 1.495800607e+09
 				kube_job_status_succeeded{job_name="SuccessfulJob2NoActiveDeadlineSeconds",namespace="ns1"} 1
@@ -303,9 +321,13 @@ func TestJobStore(t *testing.T) {
 			Want: metadata + `
 				kube_job_owner{job_name="SuspendedNoActiveDeadlineSeconds",namespace="ns1",owner_is_controller="",owner_kind="",owner_name=""} 1
 				kube_job_info{job_name="SuspendedNoActiveDeadlineSeconds",namespace="ns1"} 1
+				kube_job_spec_suspend{job_name="SuspendedNoActiveDeadlineSeconds",namespace="ns1"} 1
 				kube_job_spec_completions{job_name="SuspendedNoActiveDeadlineSeconds",namespace="ns1"} 1
 				kube_job_spec_parallelism{job_name="SuspendedNoActiveDeadlineSeconds",namespace="ns1"} 1
 				kube_job_status_active{job_name="SuspendedNoActiveDeadlineSeconds",namespace="ns1"} 0
+				kube_job_status_condition{condition="Suspended",job_name="SuspendedNoActiveDeadlineSeconds",namespace="ns1",reason="",status="false"} 0
+				kube_job_status_condition{condition="Suspended",job_name="SuspendedNoActiveDeadlineSeconds",namespace="ns1",reason="",status="true"} 1
+				kube_job_status_condition{condition="Suspended",job_name="SuspendedNoActiveDeadlineSeconds",namespace="ns1",reason="",status="unknown"} 0
 				kube_job_status_failed{job_name="SuspendedNoActiveDeadlineSeconds",namespace="ns1"} 0
 				kube_job_status_start_time{job_name="SuspendedNoActiveDeadlineSeconds",namespace="ns1"} 1.495800607e+09
 				kube_job_status_succeeded{job_name="SuspendedNoActiveDeadlineSeconds",namespace="ns1"} 0
@@ -337,9 +359,13 @@ func TestJobStore(t *testing.T) {
 			Want: metadata + `
 				kube_job_owner{job_name="UnsuspendedNoActiveDeadlineSeconds",namespace="ns1",owner_is_controller="",owner_kind="",owner_name=""} 1
 				kube_job_info{job_name="UnsuspendedNoActiveDeadlineSeconds",namespace="ns1"} 1
+				kube_job_spec_suspend{job_name="UnsuspendedNoActiveDeadlineSeconds",namespace="ns1"} 0
 				kube_job_spec_completions{job_name="UnsuspendedNoActiveDeadlineSeconds",namespace="ns1"} 1
 				kube_job_spec_parallelism{job_name="UnsuspendedNoActiveDeadlineSeconds",namespace="ns1"} 1
 				kube_job_status_active{job_name="UnsuspendedNoActiveDeadlineSeconds",namespace="ns1"} 0
+				kube_job_status_condition{condition="Suspended",job_name="UnsuspendedNoActiveDeadlineSeconds",namespace="ns1",reason="",status="false"} 1
+				kube_job_status_condition{condition="Suspended",job_name="UnsuspendedNoActiveDeadlineSeconds",namespace="ns1",reason="",status="true"} 0
+				kube_job_status_condition{condition="Suspended",job_name="UnsuspendedNoActiveDeadlineSeconds",namespace="ns1",reason="",status="unknown"} 0
 				kube_job_status_failed{job_name="UnsuspendedNoActiveDeadlineSeconds",namespace="ns1"} 0
 				kube_job_status_start_time{job_name="UnsuspendedNoActiveDeadlineSeconds",namespace="ns1"} 1.495800607e+09
 				kube_job_status_succeeded{job_name="UnsuspendedNoActiveDeadlineSeconds",namespace="ns1"} 0
@@ -34,6 +34,8 @@ func TestNamespaceStore(t *testing.T) {
 		# TYPE kube_namespace_annotations gauge
 		# HELP kube_namespace_created [STABLE] Unix creation timestamp
 		# TYPE kube_namespace_created gauge
+		# HELP kube_namespace_deletion_timestamp Unix deletion timestamp
+		# TYPE kube_namespace_deletion_timestamp gauge
 		# HELP kube_namespace_labels [STABLE] Kubernetes labels converted to Prometheus labels.
 		# TYPE kube_namespace_labels gauge
 		# HELP kube_namespace_status_phase [STABLE] kubernetes namespace status phase.
@@ -75,6 +77,25 @@ func TestNamespaceStore(t *testing.T) {
 			Want: metadata + `
 				kube_namespace_status_phase{namespace="nsTerminateTest",phase="Active"} 0
 				kube_namespace_status_phase{namespace="nsTerminateTest",phase="Terminating"} 1
+`,
+		},
+		{
+			Obj: &v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "nsDeletionTimestampTest",
+					DeletionTimestamp: &metav1.Time{Time: time.Unix(1800000000, 0)},
+				},
+				Spec: v1.NamespaceSpec{
+					Finalizers: []v1.FinalizerName{v1.FinalizerKubernetes},
+				},
+				Status: v1.NamespaceStatus{
+					Phase: v1.NamespaceTerminating,
+				},
+			},
+			Want: metadata + `
+				kube_namespace_deletion_timestamp{namespace="nsDeletionTimestampTest"} 1.8e+09
+				kube_namespace_status_phase{namespace="nsDeletionTimestampTest",phase="Active"} 0
+				kube_namespace_status_phase{namespace="nsDeletionTimestampTest",phase="Terminating"} 1
 `,
 		},
 		{
@@ -109,6 +130,30 @@ func TestNamespaceStore(t *testing.T) {
 `,
 		},
 		{
+			Obj: &v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "nsTerminateWithContentRemainingTest",
+				},
+				Status: v1.NamespaceStatus{
+					Phase: v1.NamespaceTerminating,
+					Conditions: []v1.NamespaceCondition{
+						{Type: v1.NamespaceContentRemaining, Status: v1.ConditionTrue, Reason: "SomeResourcesRemain"},
+						{Type: v1.NamespaceFinalizersRemaining, Status: v1.ConditionFalse},
+					},
+				},
+			},
+			Want: metadata + `
+				kube_namespace_status_phase{namespace="nsTerminateWithContentRemainingTest",phase="Active"} 0
+				kube_namespace_status_phase{namespace="nsTerminateWithContentRemainingTest",phase="Terminating"} 1
+				kube_namespace_status_condition{condition="NamespaceContentRemaining",namespace="nsTerminateWithContentRemainingTest",status="false"} 0
+				kube_namespace_status_condition{condition="NamespaceContentRemaining",namespace="nsTerminateWithContentRemainingTest",status="true"} 1
+				kube_namespace_status_condition{condition="NamespaceContentRemaining",namespace="nsTerminateWithContentRemainingTest",status="unknown"} 0
+				kube_namespace_status_condition{condition="NamespaceFinalizersRemaining",namespace="nsTerminateWithContentRemainingTest",status="false"} 1
+				kube_namespace_status_condition{condition="NamespaceFinalizersRemaining",namespace="nsTerminateWithContentRemainingTest",status="true"} 0
+				kube_namespace_status_condition{condition="NamespaceFinalizersRemaining",namespace="nsTerminateWithContentRemainingTest",status="unknown"} 0
+`,
+		},
+		{
 
 			Obj: &v1.Namespace{
 				ObjectMeta: metav1.ObjectMeta{
@@ -55,6 +55,7 @@ func nodeMetricFamilies(allowAnnotationsList, allowLabelsList []string) []genera
 		createNodeStatusAllocatableFamilyGenerator(),
 		createNodeStatusCapacityFamilyGenerator(),
 		createNodeStatusConditionFamilyGenerator(),
+		createNodeStatusReadyTransitionTimeFamilyGenerator(),
 		createNodeStateAddressFamilyGenerator(),
 	}
 }
@@ -146,6 +147,8 @@ func createNodeInfoFamilyGenerator() generator.FamilyGenerator {
 				"provider_id",
 				"pod_cidr",
 				"system_uuid",
+				"operating_system",
+				"architecture",
 			}
 			labelValues := []string{
 				n.Status.NodeInfo.KernelVersion,
@@ -156,6 +159,8 @@ func createNodeInfoFamilyGenerator() generator.FamilyGenerator {
 				n.Spec.ProviderID,
 				n.Spec.PodCIDR,
 				n.Status.NodeInfo.SystemUUID,
+				n.Status.NodeInfo.OperatingSystem,
+				n.Status.NodeInfo.Architecture,
 			}
 
 			// TODO: remove internal_ip in v3, replaced by kube_node_status_addresses
@@ -506,6 +511,33 @@ func createNodeStatusConditionFamilyGenerator() generator.FamilyGenerator {
 	)
 }
 
+func createNodeStatusReadyTransitionTimeFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_node_status_ready_transition_time",
+		"Unix timestamp when the node last became ready. Only emitted if the node is ready.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapNodeFunc(func(n *v1.Node) *metric.Family {
+			ms := []*metric.Metric{}
+
+			for _, c := range n.Status.Conditions {
+				if c.Type == v1.NodeReady && c.Status == v1.ConditionTrue && !c.LastTransitionTime.IsZero() {
+					ms = append(ms, &metric.Metric{
+						LabelKeys:   []string{},
+						LabelValues: []string{},
+						Value:       float64(c.LastTransitionTime.Unix()),
+					})
+				}
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
 func wrapNodeFunc(f func(*v1.Node) *metric.Family) func(interface{}) *metric.Family {
 	return func(obj interface{}) *metric.Family {
 		node := obj.(*v1.Node)
@@ -43,6 +43,8 @@ func TestNodeStore(t *testing.T) {
 						OSImage:                 "osimage",
 						ContainerRuntimeVersion: "rkt",
 						SystemUUID:              "6a934e21-5207-4a84-baea-3a952d926c80",
+						OperatingSystem:         "linux",
+						Architecture:            "amd64",
 					},
 					Addresses: []v1.NodeAddress{
 						{Type: "InternalIP", Address: "1.2.3.4"},
@@ -60,7 +62,7 @@ func TestNodeStore(t *testing.T) {
 				# TYPE kube_node_info gauge
 				# TYPE kube_node_labels gauge
 				# TYPE kube_node_spec_unschedulable gauge
-				kube_node_info{container_runtime_version="rkt",kernel_version="kernel",kubelet_version="kubelet",kubeproxy_version="deprecated",node="127.0.0.1",os_image="osimage",pod_cidr="172.24.10.0/24",provider_id="provider://i-uniqueid",internal_ip="1.2.3.4",system_uuid="6a934e21-5207-4a84-baea-3a952d926c80"} 1
+				kube_node_info{architecture="amd64",container_runtime_version="rkt",kernel_version="kernel",kubelet_version="kubelet",kubeproxy_version="deprecated",node="127.0.0.1",operating_system="linux",os_image="osimage",pod_cidr="172.24.10.0/24",provider_id="provider://i-uniqueid",internal_ip="1.2.3.4",system_uuid="6a934e21-5207-4a84-baea-3a952d926c80"} 1
 				kube_node_spec_unschedulable{node="127.0.0.1"} 0
 			`,
 			MetricNames: []string{"kube_node_spec_unschedulable", "kube_node_labels", "kube_node_info"},
@@ -75,7 +77,7 @@ func TestNodeStore(t *testing.T) {
 			Want: `
 				# HELP kube_node_info [STABLE] Information about a cluster node.
 				# TYPE kube_node_info gauge
-				kube_node_info{container_runtime_version="",kernel_version="",kubelet_version="",kubeproxy_version="deprecated",node="",os_image="",pod_cidr="",provider_id="",internal_ip="",system_uuid=""} 1
+				kube_node_info{architecture="",container_runtime_version="",kernel_version="",kubelet_version="",kubeproxy_version="deprecated",node="",operating_system="",os_image="",pod_cidr="",provider_id="",internal_ip="",system_uuid=""} 1
 			`,
 			MetricNames: []string{"kube_node_info"},
 		},
@@ -140,7 +142,7 @@ func TestNodeStore(t *testing.T) {
 		# TYPE kube_node_status_allocatable gauge
 		# TYPE kube_node_status_capacity gauge
 		kube_node_created{node="127.0.0.1"} 1.5e+09
-        kube_node_info{container_runtime_version="rkt",kernel_version="kernel",kubelet_version="kubelet",kubeproxy_version="deprecated",node="127.0.0.1",os_image="osimage",pod_cidr="172.24.10.0/24",provider_id="provider://i-randomidentifier",internal_ip="1.2.3.4",system_uuid="6a934e21-5207-4a84-baea-3a952d926c80"} 1
+        kube_node_info{architecture="",container_runtime_version="rkt",kernel_version="kernel",kubelet_version="kubelet",kubeproxy_version="deprecated",node="127.0.0.1",operating_system="",os_image="osimage",pod_cidr="172.24.10.0/24",provider_id="provider://i-randomidentifier",internal_ip="1.2.3.4",system_uuid="6a934e21-5207-4a84-baea-3a952d926c80"} 1
 		kube_node_role{node="127.0.0.1",role="master"} 1
         kube_node_spec_unschedulable{node="127.0.0.1"} 1
         kube_node_status_allocatable{node="127.0.0.1",resource="cpu",unit="core"} 3
@@ -166,6 +168,42 @@ func TestNodeStore(t *testing.T) {
 				"kube_node_created",
 			},
 		},
+		// Verify the pods entry and zero-valued resources are not dropped.
+		{
+			Obj: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "127.0.0.1",
+				},
+				Status: v1.NodeStatus{
+					Capacity: v1.ResourceList{
+						v1.ResourceCPU:                    resource.MustParse("2"),
+						v1.ResourcePods:                   resource.MustParse("110"),
+						v1.ResourceName("nvidia.com/gpu"): resource.MustParse("0"),
+					},
+					Allocatable: v1.ResourceList{
+						v1.ResourceCPU:                    resource.MustParse("0"),
+						v1.ResourcePods:                   resource.MustParse("110"),
+						v1.ResourceName("nvidia.com/gpu"): resource.MustParse("0"),
+					},
+				},
+			},
+			Want: `
+		# HELP kube_node_status_allocatable [STABLE] The allocatable for different resources of a node that are available for scheduling.
+		# HELP kube_node_status_capacity [STABLE] The capacity for different resources of a node.
+		# TYPE kube_node_status_allocatable gauge
+		# TYPE kube_node_status_capacity gauge
+		kube_node_status_allocatable{node="127.0.0.1",resource="cpu",unit="core"} 0
+		kube_node_status_allocatable{node="127.0.0.1",resource="nvidia_com_gpu",unit="integer"} 0
+		kube_node_status_allocatable{node="127.0.0.1",resource="pods",unit="integer"} 110
+		kube_node_status_capacity{node="127.0.0.1",resource="cpu",unit="core"} 2
+		kube_node_status_capacity{node="127.0.0.1",resource="nvidia_com_gpu",unit="integer"} 0
+		kube_node_status_capacity{node="127.0.0.1",resource="pods",unit="integer"} 110
+			`,
+			MetricNames: []string{
+				"kube_node_status_capacity",
+				"kube_node_status_allocatable",
+			},
+		},
 		// Verify StatusCondition
 		{
 			Obj: &v1.Node{
@@ -251,6 +289,47 @@ func TestNodeStore(t *testing.T) {
 			`,
 			MetricNames: []string{"kube_node_status_condition"},
 		},
+		// Verify StatusReadyTransitionTime
+		{
+			Obj: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "127.0.0.1",
+					CreationTimestamp: metav1.Time{Time: time.Unix(1500000000, 0)},
+				},
+				Status: v1.NodeStatus{
+					Conditions: []v1.NodeCondition{
+						{Type: v1.NodeNetworkUnavailable, Status: v1.ConditionFalse, LastTransitionTime: metav1.Unix(1500000030, 0)},
+						{Type: v1.NodeReady, Status: v1.ConditionTrue, LastTransitionTime: metav1.Unix(1500000090, 0)},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_node_created [STABLE] Unix creation timestamp
+				# HELP kube_node_status_ready_transition_time Unix timestamp when the node last became ready. Only emitted if the node is ready.
+				# TYPE kube_node_created gauge
+				# TYPE kube_node_status_ready_transition_time gauge
+				kube_node_created{node="127.0.0.1"} 1.5e+09
+				kube_node_status_ready_transition_time{node="127.0.0.1"} 1.50000009e+09
+			`,
+			MetricNames: []string{"kube_node_created", "kube_node_status_ready_transition_time"},
+		},
+		{
+			Obj: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "127.0.0.2",
+				},
+				Status: v1.NodeStatus{
+					Conditions: []v1.NodeCondition{
+						{Type: v1.NodeReady, Status: v1.ConditionFalse, LastTransitionTime: metav1.Unix(1500000090, 0)},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_node_status_ready_transition_time Unix timestamp when the node last became ready. Only emitted if the node is ready.
+				# TYPE kube_node_status_ready_transition_time gauge
+			`,
+			MetricNames: []string{"kube_node_status_ready_transition_time"},
+		},
 		// Verify SpecTaints
 		{
 			Obj: &v1.Node{
@@ -360,7 +439,7 @@ func TestNodeStore(t *testing.T) {
 		# TYPE kube_node_status_allocatable gauge
 		# TYPE kube_node_status_capacity gauge
 		kube_node_created{node="127.0.0.1"} 1.5e+09
-        kube_node_info{container_runtime_version="rkt",kernel_version="kernel",kubelet_version="kubelet",kubeproxy_version="deprecated",node="127.0.0.1",os_image="osimage",pod_cidr="172.24.10.0/24",provider_id="provider://i-randomidentifier",internal_ip="1.2.3.4",system_uuid="6a934e21-5207-4a84-baea-3a952d926c80"} 1
+        kube_node_info{architecture="",container_runtime_version="rkt",kernel_version="kernel",kubelet_version="kubelet",kubeproxy_version="deprecated",node="127.0.0.1",operating_system="",os_image="osimage",pod_cidr="172.24.10.0/24",provider_id="provider://i-randomidentifier",internal_ip="1.2.3.4",system_uuid="6a934e21-5207-4a84-baea-3a952d926c80"} 1
 		kube_node_role{node="127.0.0.1",role="master"} 1
         kube_node_spec_unschedulable{node="127.0.0.1"} 1
         kube_node_status_allocatable{node="127.0.0.1",resource="cpu",unit="core"} 3
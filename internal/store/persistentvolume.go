@@ -57,6 +57,7 @@ func persistentVolumeMetricFamilies(allowAnnotationsList, allowLabelsList []stri
 		createPersistentVolumeCapacityBytes(),
 		createPersistentVolumeCreated(),
 		createPersistentVolumeDeletionTimestamp(),
+		createPersistentVolumeFinalizer(),
 		createPersistentVolumeCSIAttributes(),
 		createPersistentVolumeMode(),
 	}
@@ -413,6 +414,31 @@ func createPersistentVolumeDeletionTimestamp() generator.FamilyGenerator {
 	)
 }
 
+func createPersistentVolumeFinalizer() generator.FamilyGenerator {
+	return *generator.NewOptInFamilyGenerator(
+		"kube_persistentvolume_finalizer",
+		"The finalizers of the persistent volume which have to be removed before it is deleted.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPersistentVolumeFunc(func(p *v1.PersistentVolume) *metric.Family {
+			ms := make([]*metric.Metric, len(p.Finalizers))
+
+			for i, f := range p.Finalizers {
+				ms[i] = &metric.Metric{
+					LabelKeys:   []string{"finalizer"},
+					LabelValues: []string{f},
+					Value:       1,
+				}
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
 func createPersistentVolumeCSIAttributes() generator.FamilyGenerator {
 	return *generator.NewOptInFamilyGenerator(
 		descPersistentVolumeCSIAttributesName,
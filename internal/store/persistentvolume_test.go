@@ -26,6 +26,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+	"k8s.io/kube-state-metrics/v2/pkg/optin"
 )
 
 func TestPersistentVolumeStore(t *testing.T) {
@@ -789,3 +790,60 @@ func TestPersistentVolumeStore(t *testing.T) {
 func hostPathTypePointer(p v1.HostPathType) *v1.HostPathType {
 	return &p
 }
+
+func TestPersistentVolumeFinalizer(t *testing.T) {
+	filter, err := optin.NewMetricFamilyFilter(map[string]struct{}{"kube_persistentvolume_finalizer": {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	families := generator.FilterFamilyGenerators(filter, persistentVolumeMetricFamilies(nil, nil))
+
+	cases := []generateMetricsTestCase{
+		{
+			Obj: &v1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-pv-finalizers",
+					DeletionTimestamp: &metav1.Time{Time: time.Unix(1800000000, 0)},
+					Finalizers:        []string{"kubernetes.io/pv-protection", "external-provisioner.volume.kubernetes.io/finalizer"},
+				},
+			},
+			Want: `
+				# HELP kube_persistentvolume_finalizer The finalizers of the persistent volume which have to be removed before it is deleted.
+				# TYPE kube_persistentvolume_finalizer gauge
+				kube_persistentvolume_finalizer{finalizer="kubernetes.io/pv-protection",persistentvolume="test-pv-finalizers"} 1
+				kube_persistentvolume_finalizer{finalizer="external-provisioner.volume.kubernetes.io/finalizer",persistentvolume="test-pv-finalizers"} 1
+`,
+			MetricNames: []string{"kube_persistentvolume_finalizer"},
+		},
+		{
+			Obj: &v1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-pv-no-finalizers",
+				},
+			},
+			Want: `
+				# HELP kube_persistentvolume_finalizer The finalizers of the persistent volume which have to be removed before it is deleted.
+				# TYPE kube_persistentvolume_finalizer gauge
+`,
+			MetricNames: []string{"kube_persistentvolume_finalizer"},
+		},
+	}
+	for i, c := range cases {
+		c.Func = generator.ComposeMetricGenFuncs(families)
+		c.Headers = generator.ExtractMetricFamilyHeaders(families)
+		if err := c.run(); err != nil {
+			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
+		}
+	}
+
+	// The family is opt-in.
+	filter, err = optin.NewMetricFamilyFilter(map[string]struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range generator.FilterFamilyGenerators(filter, persistentVolumeMetricFamilies(nil, nil)) {
+		if f.Name == "kube_persistentvolume_finalizer" {
+			t.Error("expected kube_persistentvolume_finalizer to be disabled unless opted in")
+		}
+	}
+}
@@ -260,6 +260,28 @@ func persistentVolumeClaimMetricFamilies(allowAnnotationsList, allowLabelsList [
 					})
 				}
 
+				return &metric.Family{
+					Metrics: ms,
+				}
+			}),
+		),
+		*generator.NewOptInFamilyGenerator(
+			"kube_persistentvolumeclaim_finalizer",
+			"The finalizers of the persistent volume claim which have to be removed before it is deleted.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapPersistentVolumeClaimFunc(func(p *v1.PersistentVolumeClaim) *metric.Family {
+				ms := make([]*metric.Metric, len(p.Finalizers))
+
+				for i, f := range p.Finalizers {
+					ms[i] = &metric.Metric{
+						LabelKeys:   []string{"finalizer"},
+						LabelValues: []string{f},
+						Value:       1,
+					}
+				}
+
 				return &metric.Family{
 					Metrics: ms,
 				}
@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+	"k8s.io/kube-state-metrics/v2/pkg/optin"
 )
 
 func TestPersistentVolumeClaimStore(t *testing.T) {
@@ -320,3 +321,61 @@ func TestPersistentVolumeClaimStore(t *testing.T) {
 		}
 	}
 }
+
+func TestPersistentVolumeClaimFinalizer(t *testing.T) {
+	filter, err := optin.NewMetricFamilyFilter(map[string]struct{}{"kube_persistentvolumeclaim_finalizer": {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	families := generator.FilterFamilyGenerators(filter, persistentVolumeClaimMetricFamilies(nil, nil))
+
+	cases := []generateMetricsTestCase{
+		{
+			Obj: &v1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "finalizers-data",
+					Namespace:         "default",
+					DeletionTimestamp: &metav1.Time{Time: time.Unix(1800000000, 0)},
+					Finalizers:        []string{"kubernetes.io/pvc-protection"},
+				},
+			},
+			Want: `
+				# HELP kube_persistentvolumeclaim_finalizer The finalizers of the persistent volume claim which have to be removed before it is deleted.
+				# TYPE kube_persistentvolumeclaim_finalizer gauge
+				kube_persistentvolumeclaim_finalizer{finalizer="kubernetes.io/pvc-protection",namespace="default",persistentvolumeclaim="finalizers-data"} 1
+`,
+			MetricNames: []string{"kube_persistentvolumeclaim_finalizer"},
+		},
+		{
+			Obj: &v1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "no-finalizers-data",
+					Namespace: "default",
+				},
+			},
+			Want: `
+				# HELP kube_persistentvolumeclaim_finalizer The finalizers of the persistent volume claim which have to be removed before it is deleted.
+				# TYPE kube_persistentvolumeclaim_finalizer gauge
+`,
+			MetricNames: []string{"kube_persistentvolumeclaim_finalizer"},
+		},
+	}
+	for i, c := range cases {
+		c.Func = generator.ComposeMetricGenFuncs(families)
+		c.Headers = generator.ExtractMetricFamilyHeaders(families)
+		if err := c.run(); err != nil {
+			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
+		}
+	}
+
+	// The family is opt-in.
+	filter, err = optin.NewMetricFamilyFilter(map[string]struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range generator.FilterFamilyGenerators(filter, persistentVolumeClaimMetricFamilies(nil, nil)) {
+		if f.Name == "kube_persistentvolumeclaim_finalizer" {
+			t.Error("expected kube_persistentvolumeclaim_finalizer to be disabled unless opted in")
+		}
+	}
+}
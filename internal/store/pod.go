@@ -19,8 +19,10 @@ package store
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	basemetrics "k8s.io/component-base/metrics"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/net"
 
 	"k8s.io/kube-state-metrics/v2/pkg/constant"
@@ -38,18 +40,49 @@ import (
 var (
 	descPodLabelsDefaultLabels = []string{"namespace", "pod", "uid"}
 	podStatusReasons           = []string{"Evicted", "NodeAffinity", "NodeLost", "Shutdown", "UnexpectedAdmissionError"}
+
+	descPodContainerResourceRequestsWithDefaultsName = "kube_pod_container_resource_requests_with_defaults"
+	descPodContainerResourceLimitsWithDefaultsName   = "kube_pod_container_resource_limits_with_defaults"
+	descPodNodeTopologyName                          = "kube_pod_node_topology"
+	descPodOrphanedName                              = "kube_pod_orphaned"
 )
 
-func podMetricFamilies(allowAnnotationsList, allowLabelsList []string) []generator.FamilyGenerator {
+// podContainerTerminationMessageMaxLength is the length in bytes termination messages are truncated to.
+const podContainerTerminationMessageMaxLength = 256
+
+// limitRangeLister returns the LimitRanges of the given namespace. known is false
+// if the LimitRanges of the namespace were not listed yet.
+type limitRangeLister func(namespace string) (limitRanges []*v1.LimitRange, known bool)
+
+// nodeGetter returns the node with the given name, or nil if it is not known or the
+// nodes were not listed yet.
+type nodeGetter func(name string) *v1.Node
+
+// ownerGetter reports whether the given owner of an object in the given namespace exists.
+// known is false if this cannot be determined, e.g. because owners of its kind are not
+// tracked or were not listed yet.
+type ownerGetter func(namespace string, owner metav1.OwnerReference) (exists, known bool)
+
+func podMetricFamilies(allowAnnotationsList, allowLabelsList []string, c clock.PassiveClock, limitRanges limitRangeLister, nodes nodeGetter, owners ownerGetter) []generator.FamilyGenerator {
 	return []generator.FamilyGenerator{
 		createPodCompletionTimeFamilyGenerator(),
+		createPodContainerCountFamilyGenerator(),
+		createPodContainerImageFamilyGenerator(),
 		createPodContainerInfoFamilyGenerator(),
+		createPodContainerProbeFamilyGenerator(),
+		createPodContainerProbeSettingsFamilyGenerator(),
 		createPodContainerResourceLimitsFamilyGenerator(),
 		createPodContainerResourceRequestsFamilyGenerator(),
+		createPodContainerResourceRequestsWithDefaultsFamilyGenerator(limitRanges),
+		createPodContainerResourceLimitsWithDefaultsFamilyGenerator(limitRanges),
+		createPodContainerSecurityContextFamilyGenerator(),
+		createPodContainerSpecTerminationMessagePolicyFamilyGenerator(),
 		createPodContainerStateStartedFamilyGenerator(),
+		createPodContainerStatusImagePinnedFamilyGenerator(),
 		createPodContainerStatusLastTerminatedReasonFamilyGenerator(),
 		createPodContainerStatusLastTerminatedExitCodeFamilyGenerator(),
 		createPodContainerStatusLastTerminatedTimestampFamilyGenerator(),
+		createPodContainerStatusLastTerminatedMessageFamilyGenerator(),
 		createPodContainerStatusReadyFamilyGenerator(),
 		createPodContainerStatusRestartsTotalFamilyGenerator(),
 		createPodContainerStatusRunningFamilyGenerator(),
@@ -61,6 +94,8 @@ func podMetricFamilies(allowAnnotationsList, allowLabelsList []string) []generat
 		createPodDeletionTimestampFamilyGenerator(),
 		createPodInfoFamilyGenerator(),
 		createPodIPFamilyGenerator(),
+		createPodNodeTopologyFamilyGenerator(nodes),
+		createPodOrphanedFamilyGenerator(owners),
 		createPodInitContainerInfoFamilyGenerator(),
 		createPodInitContainerResourceLimitsFamilyGenerator(),
 		createPodInitContainerResourceRequestsFamilyGenerator(),
@@ -74,16 +109,26 @@ func podMetricFamilies(allowAnnotationsList, allowLabelsList []string) []generat
 		createPodInitContainerStatusWaitingReasonFamilyGenerator(),
 		createPodAnnotationsGenerator(allowAnnotationsList),
 		createPodLabelsGenerator(allowLabelsList),
+		createPodManagedByFamilyGenerator(),
 		createPodOverheadCPUCoresFamilyGenerator(),
 		createPodOverheadMemoryBytesFamilyGenerator(),
 		createPodOwnerFamilyGenerator(),
+		createPodResourceRequestsFamilyGenerator(),
+		createPodResourceRequestsSumFamilyGenerator(),
 		createPodRestartPolicyFamilyGenerator(),
 		createPodRuntimeClassNameInfoFamilyGenerator(),
+		createPodSpecAffinityFamilyGenerator(),
+		createPodSpecPriorityFamilyGenerator(),
+		createPodSpecSecurityContextFamilyGenerator(),
+		createPodSpecHostNamespacesFamilyGenerator(),
+		createPodSpecTopologySpreadConstraintFamilyGenerator(),
 		createPodSpecVolumesPersistentVolumeClaimsInfoFamilyGenerator(),
 		createPodSpecVolumesPersistentVolumeClaimsReadonlyFamilyGenerator(),
 		createPodStartTimeFamilyGenerator(),
 		createPodStatusPhaseFamilyGenerator(),
+		createPodStatusConditionFamilyGenerator(),
 		createPodStatusQosClassFamilyGenerator(),
+		createPodStatusNotReadyFamilyGenerator(),
 		createPodStatusReadyFamilyGenerator(),
 		createPodStatusReadyTimeFamilyGenerator(),
 		createPodStatusInitializedTimeFamilyGenerator(),
@@ -92,6 +137,8 @@ func podMetricFamilies(allowAnnotationsList, allowLabelsList []string) []generat
 		createPodStatusScheduledFamilyGenerator(),
 		createPodStatusScheduledTimeFamilyGenerator(),
 		createPodStatusUnschedulableFamilyGenerator(),
+		createPodStatusUnscheduledTimeFamilyGenerator(),
+		createPodStatusUnscheduledDurationFamilyGenerator(c),
 		createPodTolerationsFamilyGenerator(),
 		createPodNodeSelectorsFamilyGenerator(),
 		createPodServiceAccountFamilyGenerator(),
@@ -134,6 +181,90 @@ func createPodCompletionTimeFamilyGenerator() generator.FamilyGenerator {
 	)
 }
 
+func createPodContainerCountFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewOptInFamilyGenerator(
+		"kube_pod_container_count",
+		"The number of containers of a pod by their current state.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			var running, waiting, terminated int
+			for _, cs := range p.Status.ContainerStatuses {
+				switch {
+				case cs.State.Running != nil:
+					running++
+				case cs.State.Waiting != nil:
+					waiting++
+				case cs.State.Terminated != nil:
+					terminated++
+				}
+			}
+
+			ms := []*metric.Metric{}
+			for _, c := range []struct {
+				state string
+				count int
+			}{
+				{"running", running},
+				{"waiting", waiting},
+				{"terminated", terminated},
+			} {
+				ms = append(ms, &metric.Metric{
+					LabelKeys:   []string{"state"},
+					LabelValues: []string{c.state},
+					Value:       float64(c.count),
+				})
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
+func createPodContainerImageFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_container_image",
+		"The image a container in a pod is running and the digest it was resolved to by the container runtime.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := []*metric.Metric{}
+
+			for _, cs := range p.Status.ContainerStatuses {
+				digest := imageIDDigest(cs.ImageID)
+				if digest == "" {
+					continue
+				}
+				ms = append(ms, &metric.Metric{
+					LabelKeys:   []string{"container", "image", "image_id"},
+					LabelValues: []string{cs.Name, cs.Image, digest},
+					Value:       1,
+				})
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
+// imageIDDigest returns the digest of the image ID reported by the container runtime,
+// e.g. sha256:<hex> for both docker-pullable://nginx@sha256:<hex> and docker.io/library/nginx@sha256:<hex>.
+func imageIDDigest(imageID string) string {
+	if i := strings.Index(imageID, "://"); i >= 0 {
+		imageID = imageID[i+len("://"):]
+	}
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		imageID = imageID[i+1:]
+	}
+	return imageID
+}
+
 func createPodContainerInfoFamilyGenerator() generator.FamilyGenerator {
 	return *generator.NewFamilyGeneratorWithStability(
 		"kube_pod_container_info",
@@ -143,7 +274,7 @@ func createPodContainerInfoFamilyGenerator() generator.FamilyGenerator {
 		"",
 		wrapPodFunc(func(p *v1.Pod) *metric.Family {
 			ms := []*metric.Metric{}
-			labelKeys := []string{"container", "image_spec", "image", "image_id", "container_id"}
+			labelKeys := []string{"container", "image_spec", "image", "image_id", "container_id", "image_pull_policy"}
 
 			for _, c := range p.Spec.Containers {
 				for _, cs := range p.Status.ContainerStatuses {
@@ -152,11 +283,75 @@ func createPodContainerInfoFamilyGenerator() generator.FamilyGenerator {
 					}
 					ms = append(ms, &metric.Metric{
 						LabelKeys:   labelKeys,
-						LabelValues: []string{cs.Name, c.Image, cs.Image, cs.ImageID, cs.ContainerID},
+						LabelValues: []string{cs.Name, c.Image, cs.Image, cs.ImageID, cs.ContainerID, string(c.ImagePullPolicy)},
+						Value:       1,
+					})
+				}
+			}
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
+func createPodContainerProbeFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_container_probe",
+		"Describes whether a liveness, readiness or startup probe is configured for a container in a pod.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := make([]*metric.Metric, 0, len(p.Spec.Containers)*3)
+
+			for _, c := range p.Spec.Containers {
+				for _, probe := range containerProbes(c) {
+					ms = append(ms, &metric.Metric{
+						LabelKeys:   []string{"container", "probe_type", "configured"},
+						LabelValues: []string{c.Name, probe.probeType, strconv.FormatBool(probe.probe != nil)},
 						Value:       1,
 					})
 				}
 			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
+func createPodContainerProbeSettingsFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_container_probe_settings_seconds",
+		"The initial delay and period in seconds of the probes configured for a container in a pod.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := []*metric.Metric{}
+
+			for _, c := range p.Spec.Containers {
+				for _, probe := range containerProbes(c) {
+					if probe.probe == nil {
+						continue
+					}
+					ms = append(ms,
+						&metric.Metric{
+							LabelKeys:   []string{"container", "probe_type", "setting"},
+							LabelValues: []string{c.Name, probe.probeType, "initial_delay"},
+							Value:       float64(probe.probe.InitialDelaySeconds),
+						},
+						&metric.Metric{
+							LabelKeys:   []string{"container", "probe_type", "setting"},
+							LabelValues: []string{c.Name, probe.probeType, "period"},
+							Value:       float64(probe.probe.PeriodSeconds),
+						},
+					)
+				}
+			}
+
 			return &metric.Family{
 				Metrics: ms,
 			}
@@ -291,6 +486,55 @@ func createPodContainerResourceRequestsFamilyGenerator() generator.FamilyGenerat
 	)
 }
 
+func createPodContainerSecurityContextFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_container_security_context",
+		"Describes the security context settings of a container in a pod.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := make([]*metric.Metric, 0, len(p.Spec.Containers))
+
+			for _, c := range p.Spec.Containers {
+				privileged, runAsNonRoot, readOnlyRootFS := containerSecurityContext(p, c)
+				ms = append(ms, &metric.Metric{
+					LabelKeys:   []string{"container", "privileged", "run_as_non_root", "read_only_root_fs"},
+					LabelValues: []string{c.Name, privileged, runAsNonRoot, readOnlyRootFS},
+					Value:       1,
+				})
+			}
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
+func createPodContainerSpecTerminationMessagePolicyFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_container_spec_termination_message_policy",
+		"Describes how the termination message of a container is populated.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := make([]*metric.Metric, 0, len(p.Spec.Containers))
+
+			for _, c := range p.Spec.Containers {
+				ms = append(ms, &metric.Metric{
+					LabelKeys:   []string{"container", "policy"},
+					LabelValues: []string{c.Name, string(c.TerminationMessagePolicy)},
+					Value:       1,
+				})
+			}
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
 func createPodContainerStateStartedFamilyGenerator() generator.FamilyGenerator {
 	return *generator.NewFamilyGeneratorWithStability(
 		"kube_pod_container_state_started",
@@ -324,6 +568,30 @@ func createPodContainerStateStartedFamilyGenerator() generator.FamilyGenerator {
 	)
 }
 
+func createPodContainerStatusImagePinnedFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_container_status_image_pinned",
+		"Describes whether the image of a container in a pod is pinned by a digest rather than a mutable tag.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := make([]*metric.Metric, len(p.Spec.Containers))
+			for i, c := range p.Spec.Containers {
+				ms[i] = &metric.Metric{
+					LabelKeys:   []string{"container", "image_spec"},
+					LabelValues: []string{c.Name, c.Image},
+					Value:       boolFloat64(strings.Contains(c.Image, "@sha256:")),
+				}
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
 func createPodContainerStatusLastTerminatedReasonFamilyGenerator() generator.FamilyGenerator {
 	return *generator.NewFamilyGeneratorWithStability(
 		"kube_pod_container_status_last_terminated_reason",
@@ -402,6 +670,43 @@ func createPodContainerStatusLastTerminatedTimestampFamilyGenerator() generator.
 	)
 }
 
+func createPodContainerStatusLastTerminatedMessageFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewOptInFamilyGenerator(
+		"kube_pod_container_status_last_terminated_message",
+		"Describes the message of the last termination of the container, truncated to 256 bytes.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := make([]*metric.Metric, 0, len(p.Status.ContainerStatuses))
+			for _, cs := range p.Status.ContainerStatuses {
+				terminated := cs.LastTerminationState.Terminated
+				if terminated == nil || terminated.Message == "" {
+					continue
+				}
+				ms = append(ms, &metric.Metric{
+					LabelKeys:   []string{"container", "message"},
+					LabelValues: []string{cs.Name, truncateTerminationMessage(terminated.Message)},
+					Value:       1,
+				})
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
+// truncateTerminationMessage truncates a termination message to podContainerTerminationMessageMaxLength
+// bytes, without splitting a multi-byte character.
+func truncateTerminationMessage(message string) string {
+	if len(message) <= podContainerTerminationMessageMaxLength {
+		return message
+	}
+	return strings.ToValidUTF8(message[:podContainerTerminationMessageMaxLength], "")
+}
+
 func createPodContainerStatusReadyFamilyGenerator() generator.FamilyGenerator {
 	return *generator.NewFamilyGeneratorWithStability(
 		"kube_pod_container_status_ready",
@@ -1108,6 +1413,40 @@ func createPodLabelsGenerator(allowLabelsList []string) generator.FamilyGenerato
 	)
 }
 
+func createPodManagedByFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewOptInFamilyGenerator(
+		"kube_pod_managed_by",
+		"The field manager which most recently changed a pod.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := []*metric.Metric{}
+
+			var latest *metav1.ManagedFieldsEntry
+			for i, e := range p.ManagedFields {
+				if e.Time == nil {
+					continue
+				}
+				if latest == nil || latest.Time.Before(e.Time) {
+					latest = &p.ManagedFields[i]
+				}
+			}
+			if latest != nil {
+				ms = append(ms, &metric.Metric{
+					LabelKeys:   []string{"manager"},
+					LabelValues: []string{latest.Manager},
+					Value:       1,
+				})
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
 func createPodOverheadCPUCoresFamilyGenerator() generator.FamilyGenerator {
 	return *generator.NewFamilyGeneratorWithStability(
 		"kube_pod_overhead_cpu_cores",
@@ -1209,90 +1548,521 @@ func createPodOwnerFamilyGenerator() generator.FamilyGenerator {
 	)
 }
 
-func createPodRestartPolicyFamilyGenerator() generator.FamilyGenerator {
-	return *generator.NewFamilyGeneratorWithStability(
-		"kube_pod_restart_policy",
-		"Describes the restart policy in use by this pod.",
+func createPodResourceRequestsFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewOptInFamilyGenerator(
+		"kube_pod_resource_requests",
+		"The effective resources requested by a pod, accounting for init containers, sidecar containers and pod overhead the same way the scheduler does.",
 		metric.Gauge,
-		basemetrics.STABLE,
+		basemetrics.ALPHA,
 		"",
 		wrapPodFunc(func(p *v1.Pod) *metric.Family {
 			return &metric.Family{
-				Metrics: []*metric.Metric{
-					{
-						LabelKeys:   []string{"type"},
-						LabelValues: []string{string(p.Spec.RestartPolicy)},
-						Value:       float64(1),
-					},
-				},
+				Metrics: podResourceListMetrics(p, podEffectiveRequests(p)),
 			}
 		}),
 	)
 }
 
-func createPodRuntimeClassNameInfoFamilyGenerator() generator.FamilyGenerator {
-	return *generator.NewFamilyGeneratorWithStability(
-		"kube_pod_runtimeclass_name_info",
-		"The runtimeclass associated with the pod.",
+func createPodContainerResourceRequestsWithDefaultsFamilyGenerator(limitRanges limitRangeLister) generator.FamilyGenerator {
+	return *generator.NewOptInFamilyGenerator(
+		descPodContainerResourceRequestsWithDefaultsName,
+		"The resources requested by a container, with the requests it does not set filled in from the container defaults of the LimitRanges in its namespace.",
 		metric.Gauge,
 		basemetrics.ALPHA,
 		"",
 		wrapPodFunc(func(p *v1.Pod) *metric.Family {
-			ms := []*metric.Metric{}
-
-			if p.Spec.RuntimeClassName != nil {
-				ms = append(ms, &metric.Metric{
-					LabelKeys:   []string{"runtimeclass_name"},
-					LabelValues: []string{*p.Spec.RuntimeClassName},
-					Value:       1,
-				})
+			if limitRanges == nil {
+				return &metric.Family{Metrics: []*metric.Metric{}}
 			}
 
+			// The LimitRanges are looked up when the metrics are written out, as they change independently of the pod.
+			namespace, pod := p.Namespace, podContainerResources(p)
 			return &metric.Family{
-				Metrics: ms,
+				Deferred: func() []*metric.Metric {
+					ms := []*metric.Metric{}
+					lrs, known := limitRanges(namespace)
+					if !known {
+						return ms
+					}
+					for _, c := range pod.Spec.Containers {
+						requests, _ := containerResourcesWithDefaults(c, lrs)
+						ms = append(ms, containerResourceListMetrics(pod, c, requests)...)
+					}
+					return ms
+				},
 			}
 		}),
 	)
 }
 
-func createPodSpecVolumesPersistentVolumeClaimsInfoFamilyGenerator() generator.FamilyGenerator {
-	return *generator.NewFamilyGeneratorWithStability(
-		"kube_pod_spec_volumes_persistentvolumeclaims_info",
-		"Information about persistentvolumeclaim volumes in a pod.",
+func createPodContainerResourceLimitsWithDefaultsFamilyGenerator(limitRanges limitRangeLister) generator.FamilyGenerator {
+	return *generator.NewOptInFamilyGenerator(
+		descPodContainerResourceLimitsWithDefaultsName,
+		"The resource limits of a container, with the limits it does not set filled in from the container defaults of the LimitRanges in its namespace.",
 		metric.Gauge,
-		basemetrics.STABLE,
+		basemetrics.ALPHA,
 		"",
 		wrapPodFunc(func(p *v1.Pod) *metric.Family {
-			ms := []*metric.Metric{}
-
-			for _, v := range p.Spec.Volumes {
-				if v.PersistentVolumeClaim != nil {
-					ms = append(ms, &metric.Metric{
-						LabelKeys:   []string{"volume", "persistentvolumeclaim"},
-						LabelValues: []string{v.Name, v.PersistentVolumeClaim.ClaimName},
-						Value:       1,
-					})
-				}
+			if limitRanges == nil {
+				return &metric.Family{Metrics: []*metric.Metric{}}
 			}
 
+			// The LimitRanges are looked up when the metrics are written out, as they change independently of the pod.
+			namespace, pod := p.Namespace, podContainerResources(p)
 			return &metric.Family{
-				Metrics: ms,
+				Deferred: func() []*metric.Metric {
+					ms := []*metric.Metric{}
+					lrs, known := limitRanges(namespace)
+					if !known {
+						return ms
+					}
+					for _, c := range pod.Spec.Containers {
+						_, limits := containerResourcesWithDefaults(c, lrs)
+						ms = append(ms, containerResourceListMetrics(pod, c, limits)...)
+					}
+					return ms
+				},
 			}
 		}),
 	)
 }
 
-func createPodSpecVolumesPersistentVolumeClaimsReadonlyFamilyGenerator() generator.FamilyGenerator {
-	return *generator.NewFamilyGeneratorWithStability(
-		"kube_pod_spec_volumes_persistentvolumeclaims_readonly",
-		"Describes whether a persistentvolumeclaim is mounted read only.",
-		metric.Gauge,
-		basemetrics.STABLE,
-		"",
-		wrapPodFunc(func(p *v1.Pod) *metric.Family {
-			ms := []*metric.Metric{}
+// podContainerResources returns a copy of the pod holding only the node, and the names
+// and resources of the containers, to be retained by deferred metric families.
+func podContainerResources(p *v1.Pod) *v1.Pod {
+	containers := make([]v1.Container, len(p.Spec.Containers))
+	for i, c := range p.Spec.Containers {
+		containers[i] = v1.Container{Name: c.Name, Resources: c.Resources}
+	}
+	return &v1.Pod{Spec: v1.PodSpec{NodeName: p.Spec.NodeName, Containers: containers}}
+}
 
-			for _, v := range p.Spec.Volumes {
+// containerResourcesWithDefaults returns the requests and limits of the container, with the
+// resources it does not set filled in from the container defaults of the given LimitRanges,
+// the same way the LimitRanger admission plugin does when a pod is created. As the API
+// server defaults the requests a container does not set to its limits before admission,
+// only requests without a limit are taken from the LimitRanges.
+func containerResourcesWithDefaults(c v1.Container, limitRanges []*v1.LimitRange) (v1.ResourceList, v1.ResourceList) {
+	requests := v1.ResourceList{}
+	limits := v1.ResourceList{}
+	addResourceList(limits, c.Resources.Limits)
+	addResourceList(requests, limits)
+	for name, val := range c.Resources.Requests {
+		requests[name] = val.DeepCopy()
+	}
+
+	for _, lr := range limitRanges {
+		for _, item := range lr.Spec.Limits {
+			if item.Type != v1.LimitTypeContainer {
+				continue
+			}
+			for name, val := range item.DefaultRequest {
+				if _, ok := requests[name]; !ok {
+					requests[name] = val
+				}
+			}
+			for name, val := range item.Default {
+				if _, ok := limits[name]; !ok {
+					limits[name] = val
+				}
+			}
+		}
+	}
+
+	return requests, limits
+}
+
+// containerResourceListMetrics converts the resources in rl into metrics labelled
+// with the container, the node of the pod, the resource name and its unit.
+func containerResourceListMetrics(p *v1.Pod, c v1.Container, rl v1.ResourceList) []*metric.Metric {
+	ms := podResourceListMetrics(p, rl)
+	for _, m := range ms {
+		m.LabelKeys = append([]string{"container"}, m.LabelKeys...)
+		m.LabelValues = append([]string{c.Name}, m.LabelValues...)
+	}
+	return ms
+}
+
+func createPodResourceRequestsSumFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewOptInFamilyGenerator(
+		"kube_pod_resource_requests_sum",
+		"The sum of the resources requested by the containers of a pod.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			reqs := v1.ResourceList{}
+			for _, c := range p.Spec.Containers {
+				addResourceList(reqs, c.Resources.Requests)
+			}
+
+			return &metric.Family{
+				Metrics: podResourceListMetrics(p, reqs),
+			}
+		}),
+	)
+}
+
+// podResourceListMetrics converts the resources in rl into metrics labelled
+// with the node of the pod, the resource name and its unit.
+func podResourceListMetrics(p *v1.Pod, rl v1.ResourceList) []*metric.Metric {
+	ms := []*metric.Metric{}
+
+	for resourceName, val := range rl {
+		switch resourceName {
+		case v1.ResourceCPU:
+			ms = append(ms, &metric.Metric{
+				LabelValues: []string{p.Spec.NodeName, SanitizeLabelName(string(resourceName)), string(constant.UnitCore)},
+				Value:       convertValueToFloat64(&val),
+			})
+		case v1.ResourceStorage:
+			fallthrough
+		case v1.ResourceEphemeralStorage:
+			fallthrough
+		case v1.ResourceMemory:
+			ms = append(ms, &metric.Metric{
+				LabelValues: []string{p.Spec.NodeName, SanitizeLabelName(string(resourceName)), string(constant.UnitByte)},
+				Value:       float64(val.Value()),
+			})
+		default:
+			if isHugePageResourceName(resourceName) {
+				ms = append(ms, &metric.Metric{
+					LabelValues: []string{p.Spec.NodeName, SanitizeLabelName(string(resourceName)), string(constant.UnitByte)},
+					Value:       float64(val.Value()),
+				})
+			}
+			if isAttachableVolumeResourceName(resourceName) {
+				ms = append(ms, &metric.Metric{
+					LabelValues: []string{p.Spec.NodeName, SanitizeLabelName(string(resourceName)), string(constant.UnitByte)},
+					Value:       float64(val.Value()),
+				})
+			}
+			if isExtendedResourceName(resourceName) {
+				ms = append(ms, &metric.Metric{
+					LabelValues: []string{p.Spec.NodeName, SanitizeLabelName(string(resourceName)), string(constant.UnitInteger)},
+					Value:       float64(val.Value()),
+				})
+			}
+		}
+	}
+
+	for _, metric := range ms {
+		metric.LabelKeys = []string{"node", "resource", "unit"}
+	}
+
+	return ms
+}
+
+// podEffectiveRequests computes the resources requested by a pod the same way
+// the scheduler does: the larger of the sum of all app containers and the
+// largest init container, where sidecar (restartable init) containers are
+// added to both as they keep running, plus the pod overhead.
+// See https://kubernetes.io/docs/concepts/workloads/pods/sidecar-containers/#resource-sharing-within-containers.
+func podEffectiveRequests(p *v1.Pod) v1.ResourceList {
+	reqs := v1.ResourceList{}
+	for _, c := range p.Spec.Containers {
+		addResourceList(reqs, c.Resources.Requests)
+	}
+
+	restartableInitContainerReqs := v1.ResourceList{}
+	initContainerReqs := v1.ResourceList{}
+	for _, c := range p.Spec.InitContainers {
+		containerReqs := v1.ResourceList{}
+		if c.RestartPolicy != nil && *c.RestartPolicy == v1.ContainerRestartPolicyAlways {
+			addResourceList(reqs, c.Resources.Requests)
+			addResourceList(restartableInitContainerReqs, c.Resources.Requests)
+			addResourceList(containerReqs, restartableInitContainerReqs)
+		} else {
+			addResourceList(containerReqs, c.Resources.Requests)
+			addResourceList(containerReqs, restartableInitContainerReqs)
+		}
+		maxResourceList(initContainerReqs, containerReqs)
+	}
+
+	maxResourceList(reqs, initContainerReqs)
+	addResourceList(reqs, p.Spec.Overhead)
+
+	return reqs
+}
+
+// addResourceList adds the resources in newList to list.
+func addResourceList(list, newList v1.ResourceList) {
+	for name, quantity := range newList {
+		if value, ok := list[name]; !ok {
+			list[name] = quantity.DeepCopy()
+		} else {
+			value.Add(quantity)
+			list[name] = value
+		}
+	}
+}
+
+// maxResourceList sets list to the greater of itself and newList for every
+// resource in newList.
+func maxResourceList(list, newList v1.ResourceList) {
+	for name, quantity := range newList {
+		if value, ok := list[name]; !ok || quantity.Cmp(value) > 0 {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func createPodRestartPolicyFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_restart_policy",
+		"Describes the restart policy in use by this pod.",
+		metric.Gauge,
+		basemetrics.STABLE,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			return &metric.Family{
+				Metrics: []*metric.Metric{
+					{
+						LabelKeys:   []string{"type"},
+						LabelValues: []string{string(p.Spec.RestartPolicy)},
+						Value:       float64(1),
+					},
+				},
+			}
+		}),
+	)
+}
+
+func createPodRuntimeClassNameInfoFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_runtimeclass_name_info",
+		"The runtimeclass associated with the pod.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := []*metric.Metric{}
+
+			if p.Spec.RuntimeClassName != nil {
+				ms = append(ms, &metric.Metric{
+					LabelKeys:   []string{"runtimeclass_name"},
+					LabelValues: []string{*p.Spec.RuntimeClassName},
+					Value:       1,
+				})
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
+func createPodSpecAffinityFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_spec_affinity",
+		"The number of required and preferred node affinity, pod affinity and pod anti-affinity terms of a pod.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := []*metric.Metric{}
+
+			a := p.Spec.Affinity
+			if a == nil {
+				return &metric.Family{
+					Metrics: ms,
+				}
+			}
+
+			var nodeRequired, nodePreferred int
+			if a.NodeAffinity != nil {
+				if a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+					nodeRequired = len(a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+				}
+				nodePreferred = len(a.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+			}
+			var podRequired, podPreferred int
+			if a.PodAffinity != nil {
+				podRequired = len(a.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+				podPreferred = len(a.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+			}
+			var podAntiRequired, podAntiPreferred int
+			if a.PodAntiAffinity != nil {
+				podAntiRequired = len(a.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+				podAntiPreferred = len(a.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+			}
+
+			for _, t := range []struct {
+				affinityType string
+				requirement  string
+				count        int
+			}{
+				{"node_affinity", "required", nodeRequired},
+				{"node_affinity", "preferred", nodePreferred},
+				{"pod_affinity", "required", podRequired},
+				{"pod_affinity", "preferred", podPreferred},
+				{"pod_anti_affinity", "required", podAntiRequired},
+				{"pod_anti_affinity", "preferred", podAntiPreferred},
+			} {
+				ms = append(ms, &metric.Metric{
+					LabelKeys:   []string{"type", "requirement"},
+					LabelValues: []string{t.affinityType, t.requirement},
+					Value:       float64(t.count),
+				})
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
+func createPodSpecPriorityFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_spec_priority",
+		"The priority value of a pod. Only emitted if the priority of the pod is set.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := []*metric.Metric{}
+
+			if p.Spec.Priority != nil {
+				ms = append(ms, &metric.Metric{
+					Value: float64(*p.Spec.Priority),
+				})
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
+func createPodSpecHostNamespacesFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_spec_host_namespaces",
+		"Describes whether a pod uses the network, PID and IPC namespaces of its host.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			return &metric.Family{
+				Metrics: []*metric.Metric{
+					{
+						LabelKeys:   []string{"host_network", "host_pid", "host_ipc"},
+						LabelValues: []string{strconv.FormatBool(p.Spec.HostNetwork), strconv.FormatBool(p.Spec.HostPID), strconv.FormatBool(p.Spec.HostIPC)},
+						Value:       1,
+					},
+				},
+			}
+		}),
+	)
+}
+
+func createPodSpecSecurityContextFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_spec_security_context",
+		"Describes the pod-level security context settings of a pod.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := []*metric.Metric{}
+
+			if sc := p.Spec.SecurityContext; sc != nil {
+				runAsUser, fsGroup, runAsNonRoot := "", "", ""
+				if sc.RunAsUser != nil {
+					runAsUser = strconv.FormatInt(*sc.RunAsUser, 10)
+				}
+				if sc.FSGroup != nil {
+					fsGroup = strconv.FormatInt(*sc.FSGroup, 10)
+				}
+				if sc.RunAsNonRoot != nil {
+					runAsNonRoot = strconv.FormatBool(*sc.RunAsNonRoot)
+				}
+				ms = append(ms, &metric.Metric{
+					LabelKeys:   []string{"run_as_user", "fs_group", "run_as_non_root"},
+					LabelValues: []string{runAsUser, fsGroup, runAsNonRoot},
+					Value:       1,
+				})
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
+func createPodSpecTopologySpreadConstraintFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_spec_topology_spread_constraint",
+		"The maximum skew of the topology spread constraints of a pod.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := make([]*metric.Metric, 0, len(p.Spec.TopologySpreadConstraints))
+			seen := map[[3]string]struct{}{}
+
+			for _, c := range p.Spec.TopologySpreadConstraints {
+				labelValues := [3]string{c.TopologyKey, string(c.WhenUnsatisfiable), metav1.FormatLabelSelector(c.LabelSelector)}
+				// Identical constraints would result in duplicate series.
+				if _, ok := seen[labelValues]; ok {
+					continue
+				}
+				seen[labelValues] = struct{}{}
+				ms = append(ms, &metric.Metric{
+					LabelKeys:   []string{"topology_key", "when_unsatisfiable", "label_selector"},
+					LabelValues: labelValues[:],
+					Value:       float64(c.MaxSkew),
+				})
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
+func createPodSpecVolumesPersistentVolumeClaimsInfoFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_spec_volumes_persistentvolumeclaims_info",
+		"Information about persistentvolumeclaim volumes in a pod.",
+		metric.Gauge,
+		basemetrics.STABLE,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := []*metric.Metric{}
+
+			for _, v := range p.Spec.Volumes {
+				if v.PersistentVolumeClaim != nil {
+					ms = append(ms, &metric.Metric{
+						LabelKeys:   []string{"volume", "persistentvolumeclaim"},
+						LabelValues: []string{v.Name, v.PersistentVolumeClaim.ClaimName},
+						Value:       1,
+					})
+				}
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
+func createPodSpecVolumesPersistentVolumeClaimsReadonlyFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_spec_volumes_persistentvolumeclaims_readonly",
+		"Describes whether a persistentvolumeclaim is mounted read only.",
+		metric.Gauge,
+		basemetrics.STABLE,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := []*metric.Metric{}
+
+			for _, v := range p.Spec.Volumes {
 				if v.PersistentVolumeClaim != nil {
 					ms = append(ms, &metric.Metric{
 						LabelKeys:   []string{"volume", "persistentvolumeclaim"},
@@ -1501,6 +2271,33 @@ func createPodStatusQosClassFamilyGenerator() generator.FamilyGenerator {
 	)
 }
 
+func createPodStatusNotReadyFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_status_not_ready",
+		"Describes whether the pod is not ready to serve requests, i.e. its Ready condition is False, Unknown or not reported yet.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			notReady := true
+
+			for _, c := range p.Status.Conditions {
+				if c.Type == v1.PodReady {
+					notReady = c.Status != v1.ConditionTrue
+				}
+			}
+
+			return &metric.Family{
+				Metrics: []*metric.Metric{
+					{
+						Value: boolFloat64(notReady),
+					},
+				},
+			}
+		}),
+	)
+}
+
 func createPodStatusReadyFamilyGenerator() generator.FamilyGenerator {
 	return *generator.NewFamilyGeneratorWithStability(
 		"kube_pod_status_ready",
@@ -1559,6 +2356,33 @@ func createPodStatusReasonFamilyGenerator() generator.FamilyGenerator {
 	)
 }
 
+// createPodStatusConditionFamilyGenerator returns a family generator exposing all
+// conditions of a pod, including custom conditions set for readiness gates.
+func createPodStatusConditionFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewOptInFamilyGenerator(
+		"kube_pod_status_condition",
+		"The condition of a pod.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := make([]*metric.Metric, 0, len(p.Status.Conditions)*len(conditionStatuses))
+
+			for _, c := range p.Status.Conditions {
+				for _, m := range addConditionMetrics(c.Status) {
+					m.LabelKeys = []string{"condition", "status"}
+					m.LabelValues = append([]string{string(c.Type)}, m.LabelValues...)
+					ms = append(ms, m)
+				}
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
 func createPodStatusScheduledFamilyGenerator() generator.FamilyGenerator {
 	return *generator.NewFamilyGeneratorWithStability(
 		"kube_pod_status_scheduled",
@@ -1642,6 +2466,69 @@ func createPodStatusUnschedulableFamilyGenerator() generator.FamilyGenerator {
 	)
 }
 
+func createPodStatusUnscheduledTimeFamilyGenerator() generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_status_unscheduled_time",
+		"Unix timestamp when pod moved into unscheduled status",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			ms := []*metric.Metric{}
+
+			for _, c := range p.Status.Conditions {
+				if c.Type == v1.PodScheduled && c.Status == v1.ConditionFalse && !c.LastTransitionTime.IsZero() {
+					ms = append(ms, &metric.Metric{
+						LabelKeys:   []string{},
+						LabelValues: []string{},
+						Value:       float64(c.LastTransitionTime.Unix()),
+					})
+				}
+			}
+
+			return &metric.Family{
+				Metrics: ms,
+			}
+		}),
+	)
+}
+
+func createPodStatusUnscheduledDurationFamilyGenerator(c clock.PassiveClock) generator.FamilyGenerator {
+	return *generator.NewFamilyGeneratorWithStability(
+		"kube_pod_status_unscheduled_duration_seconds",
+		"The number of seconds since the pod moved into unscheduled status.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			for _, cond := range p.Status.Conditions {
+				if cond.Type != v1.PodScheduled || cond.Status != v1.ConditionFalse || cond.LastTransitionTime.IsZero() {
+					continue
+				}
+
+				// The duration is computed when the metrics are written out, so that it keeps
+				// increasing while the pod is not updated.
+				since := cond.LastTransitionTime.Time
+				return &metric.Family{
+					Deferred: func() []*metric.Metric {
+						return []*metric.Metric{
+							{
+								LabelKeys:   []string{},
+								LabelValues: []string{},
+								Value:       max(c.Since(since).Seconds(), 0),
+							},
+						}
+					},
+				}
+			}
+
+			return &metric.Family{
+				Metrics: []*metric.Metric{},
+			}
+		}),
+	)
+}
+
 // getUniqueTolerations takes an array
 func getUniqueTolerations(tolerations []v1.Toleration) []v1.Toleration {
 	uniqueTolerationsMap := make(map[v1.Toleration]struct{})
@@ -1769,9 +2656,11 @@ func wrapPodFunc(f func(*v1.Pod) *metric.Family) func(interface{}) *metric.Famil
 
 		metricFamily := f(pod)
 
-		for _, m := range metricFamily.Metrics {
-			m.LabelKeys, m.LabelValues = mergeKeyValues(descPodLabelsDefaultLabels, []string{pod.Namespace, pod.Name, string(pod.UID)}, m.LabelKeys, m.LabelValues)
-		}
+		// Deferred metrics are generated later on, so only the label values are retained, not the pod.
+		labelValues := []string{pod.Namespace, pod.Name, string(pod.UID)}
+		metricFamily.Each(func(m *metric.Metric) {
+			m.LabelKeys, m.LabelValues = mergeKeyValues(descPodLabelsDefaultLabels, labelValues, m.LabelKeys, m.LabelValues)
+		})
 
 		return metricFamily
 	}
@@ -1789,3 +2678,125 @@ func createPodListWatch(kubeClient clientset.Interface, ns string, fieldSelector
 		},
 	}
 }
+
+type containerProbe struct {
+	probeType string
+	probe     *v1.Probe
+}
+
+// containerSecurityContext returns the effective privileged, runAsNonRoot and
+// readOnlyRootFilesystem settings of the given container. Settings which are not
+// set are empty, with runAsNonRoot falling back to the pod security context.
+func containerSecurityContext(p *v1.Pod, c v1.Container) (privileged, runAsNonRoot, readOnlyRootFS string) {
+	if p.Spec.SecurityContext != nil && p.Spec.SecurityContext.RunAsNonRoot != nil {
+		runAsNonRoot = strconv.FormatBool(*p.Spec.SecurityContext.RunAsNonRoot)
+	}
+	sc := c.SecurityContext
+	if sc == nil {
+		return privileged, runAsNonRoot, readOnlyRootFS
+	}
+	if sc.Privileged != nil {
+		privileged = strconv.FormatBool(*sc.Privileged)
+	}
+	if sc.RunAsNonRoot != nil {
+		runAsNonRoot = strconv.FormatBool(*sc.RunAsNonRoot)
+	}
+	if sc.ReadOnlyRootFilesystem != nil {
+		readOnlyRootFS = strconv.FormatBool(*sc.ReadOnlyRootFilesystem)
+	}
+	return privileged, runAsNonRoot, readOnlyRootFS
+}
+
+// containerProbes returns the liveness, readiness and startup probes of the given
+// container, with a nil probe for each probe type which is not configured.
+func containerProbes(c v1.Container) []containerProbe {
+	return []containerProbe{
+		{probeType: "liveness", probe: c.LivenessProbe},
+		{probeType: "readiness", probe: c.ReadinessProbe},
+		{probeType: "startup", probe: c.StartupProbe},
+	}
+}
+
+func createPodNodeTopologyFamilyGenerator(nodes nodeGetter) generator.FamilyGenerator {
+	return *generator.NewOptInFamilyGenerator(
+		descPodNodeTopologyName,
+		"The zone and region of the node a pod is assigned to, taken from the topology labels of the node.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			if nodes == nil || p.Spec.NodeName == "" {
+				return &metric.Family{Metrics: []*metric.Metric{}}
+			}
+
+			// The node is looked up when the metrics are written out, as its labels change independently of the pod.
+			nodeName := p.Spec.NodeName
+			return &metric.Family{
+				Deferred: func() []*metric.Metric {
+					node := nodes(nodeName)
+					if node == nil {
+						return []*metric.Metric{}
+					}
+					return []*metric.Metric{
+						{
+							LabelKeys:   []string{"node", "zone", "region"},
+							LabelValues: []string{node.Name, nodeTopologyLabel(node, v1.LabelTopologyZone, v1.LabelFailureDomainBetaZone), nodeTopologyLabel(node, v1.LabelTopologyRegion, v1.LabelFailureDomainBetaRegion)},
+							Value:       1,
+						},
+					}
+				},
+			}
+		}),
+	)
+}
+
+func createPodOrphanedFamilyGenerator(owners ownerGetter) generator.FamilyGenerator {
+	return *generator.NewOptInFamilyGenerator(
+		descPodOrphanedName,
+		"Whether the controller owner of the pod no longer exists. Pods without a controller owner are not orphaned.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapPodFunc(func(p *v1.Pod) *metric.Family {
+			if owners == nil {
+				return &metric.Family{Metrics: []*metric.Metric{}}
+			}
+
+			owner := metav1.GetControllerOfNoCopy(p)
+			if owner == nil {
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							Value: 0,
+						},
+					},
+				}
+			}
+
+			// The owner is looked up when the metrics are written out, as it may be deleted while the pod is not updated.
+			namespace, ref := p.Namespace, *owner
+			return &metric.Family{
+				Deferred: func() []*metric.Metric {
+					exists, known := owners(namespace, ref)
+					if !known {
+						return []*metric.Metric{}
+					}
+					return []*metric.Metric{
+						{
+							Value: boolFloat64(!exists),
+						},
+					}
+				},
+			}
+		}),
+	)
+}
+
+// nodeTopologyLabel returns the value of the given topology label of the node,
+// falling back to the deprecated failure-domain label set by older clusters.
+func nodeTopologyLabel(node *v1.Node, label, deprecatedLabel string) string {
+	if value, ok := node.Labels[label]; ok {
+		return value
+	}
+	return node.Labels[deprecatedLabel]
+}
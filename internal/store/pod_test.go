@@ -17,12 +17,17 @@ limitations under the License.
 package store
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 
 	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
 	"k8s.io/kube-state-metrics/v2/pkg/options"
@@ -65,9 +70,54 @@ func TestPodStore(t *testing.T) {
 			Want: `
 			# HELP kube_pod_container_info [STABLE] Information about a container in a pod.
 			# TYPE kube_pod_container_info gauge
-			kube_pod_container_info{container="container1",container_id="docker://ab123",image="k8s.gcr.io/hyperkube1",image_spec="k8s.gcr.io/hyperkube1_spec",image_id="docker://sha256:aaa",namespace="ns1",pod="pod1",uid="uid1"} 1`,
+			kube_pod_container_info{container="container1",container_id="docker://ab123",image="k8s.gcr.io/hyperkube1",image_spec="k8s.gcr.io/hyperkube1_spec",image_id="docker://sha256:aaa",namespace="ns1",pod="pod1",uid="uid1",image_pull_policy=""} 1`,
 			MetricNames: []string{"kube_pod_container_info"},
 		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+				},
+				Status: v1.PodStatus{
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							Name:    "containerd",
+							Image:   "docker.io/library/nginx:1.27",
+							ImageID: "docker.io/library/nginx@sha256:aaa",
+						},
+						{
+							Name:    "containerd-local",
+							Image:   "registry.local/app:dev",
+							ImageID: "sha256:bbb",
+						},
+						{
+							Name:    "docker",
+							Image:   "nginx:1.27",
+							ImageID: "docker-pullable://nginx@sha256:ccc",
+						},
+						{
+							Name:    "docker-local",
+							Image:   "app:dev",
+							ImageID: "docker://sha256:ddd",
+						},
+						{
+							Name:  "pulling",
+							Image: "nginx:1.27",
+						},
+					},
+				},
+			},
+			Want: `
+			# HELP kube_pod_container_image The image a container in a pod is running and the digest it was resolved to by the container runtime.
+			# TYPE kube_pod_container_image gauge
+			kube_pod_container_image{container="containerd",image="docker.io/library/nginx:1.27",image_id="sha256:aaa",namespace="ns1",pod="pod1",uid="uid1"} 1
+			kube_pod_container_image{container="containerd-local",image="registry.local/app:dev",image_id="sha256:bbb",namespace="ns1",pod="pod1",uid="uid1"} 1
+			kube_pod_container_image{container="docker",image="nginx:1.27",image_id="sha256:ccc",namespace="ns1",pod="pod1",uid="uid1"} 1
+			kube_pod_container_image{container="docker-local",image="app:dev",image_id="sha256:ddd",namespace="ns1",pod="pod1",uid="uid1"} 1`,
+			MetricNames: []string{"kube_pod_container_image"},
+		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
@@ -124,8 +174,8 @@ func TestPodStore(t *testing.T) {
 				# HELP kube_pod_init_container_info [STABLE] Information about an init container in a pod.
 				# TYPE kube_pod_container_info gauge
 				# TYPE kube_pod_init_container_info gauge
-				kube_pod_container_info{container="container2",container_id="docker://cd456",image_spec="k8s.gcr.io/hyperkube2_spec",image="k8s.gcr.io/hyperkube2",image_id="docker://sha256:bbb",namespace="ns2",pod="pod2",uid="uid2"} 1
-				kube_pod_container_info{container="container3",container_id="docker://ef789",image_spec="k8s.gcr.io/hyperkube3_spec",image="k8s.gcr.io/hyperkube3",image_id="docker://sha256:ccc",namespace="ns2",pod="pod2",uid="uid2"} 1
+				kube_pod_container_info{container="container2",container_id="docker://cd456",image_spec="k8s.gcr.io/hyperkube2_spec",image="k8s.gcr.io/hyperkube2",image_id="docker://sha256:bbb",namespace="ns2",pod="pod2",uid="uid2",image_pull_policy=""} 1
+				kube_pod_container_info{container="container3",container_id="docker://ef789",image_spec="k8s.gcr.io/hyperkube3_spec",image="k8s.gcr.io/hyperkube3",image_id="docker://sha256:ccc",namespace="ns2",pod="pod2",uid="uid2",image_pull_policy=""} 1
 				kube_pod_init_container_info{container="initContainer",container_id="docker://ef123",image_spec="k8s.gcr.io/initfoo_spec",image="k8s.gcr.io/initfoo",image_id="docker://sha256:wxyz",namespace="ns2",pod="pod2",uid="uid2",restart_policy="Always"} 1`,
 			MetricNames: []string{"kube_pod_container_info", "kube_pod_init_container_info"},
 		},
@@ -139,125 +189,92 @@ func TestPodStore(t *testing.T) {
 				Spec: v1.PodSpec{
 					Containers: []v1.Container{
 						{
-							Name:  "container1",
-							Image: "k8s.gcr.io/hyperkube1_spec",
+							Name: "container1",
+							LivenessProbe: &v1.Probe{
+								InitialDelaySeconds: 15,
+								PeriodSeconds:       20,
+							},
 						},
-					},
-				},
-				Status: v1.PodStatus{
-					ContainerStatuses: []v1.ContainerStatus{
 						{
-							Name:  "container1",
-							Ready: true,
+							Name: "container2",
 						},
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_container_status_ready [STABLE] Describes whether the containers readiness check succeeded.
-				# TYPE kube_pod_container_status_ready gauge
-				kube_pod_container_status_ready{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 1`,
-			MetricNames: []string{"kube_pod_container_status_ready"},
+			# HELP kube_pod_container_probe Describes whether a liveness, readiness or startup probe is configured for a container in a pod.
+			# HELP kube_pod_container_probe_settings_seconds The initial delay and period in seconds of the probes configured for a container in a pod.
+			# TYPE kube_pod_container_probe gauge
+			# TYPE kube_pod_container_probe_settings_seconds gauge
+			kube_pod_container_probe{configured="false",container="container1",namespace="ns1",pod="pod1",probe_type="readiness",uid="uid1"} 1
+			kube_pod_container_probe{configured="false",container="container1",namespace="ns1",pod="pod1",probe_type="startup",uid="uid1"} 1
+			kube_pod_container_probe{configured="true",container="container1",namespace="ns1",pod="pod1",probe_type="liveness",uid="uid1"} 1
+			kube_pod_container_probe{configured="false",container="container2",namespace="ns1",pod="pod1",probe_type="liveness",uid="uid1"} 1
+			kube_pod_container_probe{configured="false",container="container2",namespace="ns1",pod="pod1",probe_type="readiness",uid="uid1"} 1
+			kube_pod_container_probe{configured="false",container="container2",namespace="ns1",pod="pod1",probe_type="startup",uid="uid1"} 1
+			kube_pod_container_probe_settings_seconds{container="container1",namespace="ns1",pod="pod1",probe_type="liveness",setting="initial_delay",uid="uid1"} 15
+			kube_pod_container_probe_settings_seconds{container="container1",namespace="ns1",pod="pod1",probe_type="liveness",setting="period",uid="uid1"} 20
+			`,
+			MetricNames: []string{"kube_pod_container_probe", "kube_pod_container_probe_settings_seconds"},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod2",
-					Namespace: "ns2",
-					UID:       "uid2",
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
 				},
 				Spec: v1.PodSpec{
-					Containers: []v1.Container{
-						{
-							Name:  "container2",
-							Image: "k8s.gcr.io/hyperkube2_spec",
-						},
-						{
-							Name:  "container3",
-							Image: "k8s.gcr.io/hyperkube3_spec",
-						},
+					SecurityContext: &v1.PodSecurityContext{
+						RunAsNonRoot: ptr.To(true),
 					},
-				},
-				Status: v1.PodStatus{
-					ContainerStatuses: []v1.ContainerStatus{
+					Containers: []v1.Container{
 						{
-							Name:  "container2",
-							Ready: true,
+							Name: "privileged",
+							SecurityContext: &v1.SecurityContext{
+								Privileged:   ptr.To(true),
+								RunAsNonRoot: ptr.To(false),
+							},
 						},
 						{
-							Name:  "container3",
-							Ready: false,
+							Name: "hardened",
+							SecurityContext: &v1.SecurityContext{
+								Privileged:             ptr.To(false),
+								ReadOnlyRootFilesystem: ptr.To(true),
+							},
 						},
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_container_status_ready [STABLE] Describes whether the containers readiness check succeeded.
-				# TYPE kube_pod_container_status_ready gauge
-				kube_pod_container_status_ready{container="container2",namespace="ns2",pod="pod2",uid="uid2"} 1
-				kube_pod_container_status_ready{container="container3",namespace="ns2",pod="pod2",uid="uid2"} 0
-				`,
-			MetricNames: []string{"kube_pod_container_status_ready"},
+			# HELP kube_pod_container_security_context Describes the security context settings of a container in a pod.
+			# TYPE kube_pod_container_security_context gauge
+			kube_pod_container_security_context{container="hardened",namespace="ns1",pod="pod1",privileged="false",read_only_root_fs="true",run_as_non_root="true",uid="uid1"} 1
+			kube_pod_container_security_context{container="privileged",namespace="ns1",pod="pod1",privileged="true",read_only_root_fs="",run_as_non_root="false",uid="uid1"} 1
+			`,
+			MetricNames: []string{"kube_pod_container_security_context"},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod3",
-					Namespace: "ns3",
-					UID:       "uid3",
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
 				},
 				Spec: v1.PodSpec{
 					Containers: []v1.Container{
 						{
-							Name:  "container2",
-							Image: "k8s.gcr.io/hyperkube2_spec",
-						},
-						{
-							Name:  "container3",
-							Image: "k8s.gcr.io/hyperkube3_spec",
-						},
-					},
-					InitContainers: []v1.Container{
-						{
-							Name:  "initcontainer1",
-							Image: "k8s.gcr.io/initfoo_spec",
-						},
-						{
-							Name:  "initcontainer2",
-							Image: "k8s.gcr.io/initfoo_spec",
-						},
-					},
-				},
-				Status: v1.PodStatus{
-					ContainerStatuses: []v1.ContainerStatus{
-						{
-							Name:  "container2",
-							Ready: true,
-						},
-						{
-							Name:  "container3",
-							Ready: false,
-						},
-					},
-					InitContainerStatuses: []v1.ContainerStatus{
-						{
-							Name:  "initcontainer1",
-							Ready: true,
-						},
-						{
-							Name:  "initcontainer2",
-							Ready: false,
+							Name: "container1",
 						},
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_init_container_status_ready [STABLE] Describes whether the init containers readiness check succeeded.
-				# TYPE kube_pod_init_container_status_ready gauge
-				kube_pod_init_container_status_ready{container="initcontainer1",namespace="ns3",pod="pod3",uid="uid3"} 1
-				kube_pod_init_container_status_ready{container="initcontainer2",namespace="ns3",pod="pod3",uid="uid3"} 0
-				`,
-			MetricNames: []string{"kube_pod_init_container_status_ready"},
+			# HELP kube_pod_container_security_context Describes the security context settings of a container in a pod.
+			# TYPE kube_pod_container_security_context gauge
+			kube_pod_container_security_context{container="container1",namespace="ns1",pod="pod1",privileged="",read_only_root_fs="",run_as_non_root="",uid="uid1"} 1
+			`,
+			MetricNames: []string{"kube_pod_container_security_context"},
 		},
 		{
 			Obj: &v1.Pod{
@@ -267,139 +284,124 @@ func TestPodStore(t *testing.T) {
 					UID:       "uid1",
 				},
 				Spec: v1.PodSpec{
-					Containers: []v1.Container{
-						{
-							Name:  "container1",
-							Image: "k8s.gcr.io/hyperkube1_spec",
-						},
-					},
-				},
-				Status: v1.PodStatus{
-					ContainerStatuses: []v1.ContainerStatus{
-						{
-							Name:         "container1",
-							RestartCount: 0,
-						},
+					SecurityContext: &v1.PodSecurityContext{
+						RunAsUser: ptr.To[int64](1000),
+						FSGroup:   ptr.To[int64](2000),
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_container_status_restarts_total [STABLE] The number of container restarts per container.
-				# TYPE kube_pod_container_status_restarts_total counter
-				kube_pod_container_status_restarts_total{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 0
-				`,
-			MetricNames: []string{"kube_pod_container_status_restarts_total"},
+			# HELP kube_pod_spec_security_context Describes the pod-level security context settings of a pod.
+			# TYPE kube_pod_spec_security_context gauge
+			kube_pod_spec_security_context{fs_group="2000",namespace="ns1",pod="pod1",run_as_non_root="",run_as_user="1000",uid="uid1"} 1
+			`,
+			MetricNames: []string{"kube_pod_spec_security_context"},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod2",
-					Namespace: "ns2",
-					UID:       "uid2",
-				},
-				Spec: v1.PodSpec{
-					InitContainers: []v1.Container{
-						{
-							Name:  "initcontainer1",
-							Image: "k8s.gcr.io/initfoo_spec",
-						},
-					},
-				},
-				Status: v1.PodStatus{
-					InitContainerStatuses: []v1.ContainerStatus{
-						{
-							Name:         "initcontainer1",
-							RestartCount: 1,
-						},
-					},
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
 				},
 			},
 			Want: `
-				# HELP kube_pod_init_container_status_restarts_total [STABLE] The number of restarts for the init container.
-				# TYPE kube_pod_init_container_status_restarts_total counter
-				kube_pod_init_container_status_restarts_total{container="initcontainer1",namespace="ns2",pod="pod2",uid="uid2"} 1
-				`,
-			MetricNames: []string{"kube_pod_init_container_status_restarts_total"},
+			# HELP kube_pod_spec_security_context Describes the pod-level security context settings of a pod.
+			# TYPE kube_pod_spec_security_context gauge
+			`,
+			MetricNames: []string{"kube_pod_spec_security_context"},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod2",
-					Namespace: "ns2",
-					UID:       "uid2",
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
 				},
 				Spec: v1.PodSpec{
 					Containers: []v1.Container{
 						{
-							Name:  "container2",
-							Image: "k8s.gcr.io/hyperkube2_spec",
+							Name:            "pinned",
+							Image:           "registry.k8s.io/pause@sha256:7031c1b283388d2c2e09b57badb803c05ebed362dc88d84b480cc47f72a21097",
+							ImagePullPolicy: v1.PullIfNotPresent,
 						},
 						{
-							Name:  "container3",
-							Image: "k8s.gcr.io/hyperkube3_spec",
+							Name:            "tagged",
+							Image:           "registry.k8s.io/pause:latest",
+							ImagePullPolicy: v1.PullAlways,
 						},
 					},
 				},
 				Status: v1.PodStatus{
 					ContainerStatuses: []v1.ContainerStatus{
 						{
-							Name:         "container2",
-							RestartCount: 0,
+							Name:  "pinned",
+							Image: "registry.k8s.io/pause@sha256:7031c1b283388d2c2e09b57badb803c05ebed362dc88d84b480cc47f72a21097",
 						},
 						{
-							Name:         "container3",
-							RestartCount: 1,
+							Name:  "tagged",
+							Image: "registry.k8s.io/pause:latest",
 						},
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_container_status_restarts_total [STABLE] The number of container restarts per container.
-				# TYPE kube_pod_container_status_restarts_total counter
-				kube_pod_container_status_restarts_total{container="container2",namespace="ns2",pod="pod2",uid="uid2"} 0
-				kube_pod_container_status_restarts_total{container="container3",namespace="ns2",pod="pod2",uid="uid2"} 1
-				`,
-			MetricNames: []string{"kube_pod_container_status_restarts_total"},
+			# HELP kube_pod_container_info [STABLE] Information about a container in a pod.
+			# HELP kube_pod_container_status_image_pinned Describes whether the image of a container in a pod is pinned by a digest rather than a mutable tag.
+			# TYPE kube_pod_container_info gauge
+			# TYPE kube_pod_container_status_image_pinned gauge
+			kube_pod_container_info{container="pinned",container_id="",image="registry.k8s.io/pause@sha256:7031c1b283388d2c2e09b57badb803c05ebed362dc88d84b480cc47f72a21097",image_id="",image_pull_policy="IfNotPresent",image_spec="registry.k8s.io/pause@sha256:7031c1b283388d2c2e09b57badb803c05ebed362dc88d84b480cc47f72a21097",namespace="ns1",pod="pod1",uid="uid1"} 1
+			kube_pod_container_info{container="tagged",container_id="",image="registry.k8s.io/pause:latest",image_id="",image_pull_policy="Always",image_spec="registry.k8s.io/pause:latest",namespace="ns1",pod="pod1",uid="uid1"} 1
+			kube_pod_container_status_image_pinned{container="pinned",image_spec="registry.k8s.io/pause@sha256:7031c1b283388d2c2e09b57badb803c05ebed362dc88d84b480cc47f72a21097",namespace="ns1",pod="pod1",uid="uid1"} 1
+			kube_pod_container_status_image_pinned{container="tagged",image_spec="registry.k8s.io/pause:latest",namespace="ns1",pod="pod1",uid="uid1"} 0
+			`,
+			MetricNames: []string{"kube_pod_container_info", "kube_pod_container_status_image_pinned"},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod2",
-					Namespace: "ns2",
-					UID:       "uid2",
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
 				},
-				Spec: v1.PodSpec{
-					InitContainers: []v1.Container{
-						{
-							Name:  "initcontainer2",
-							Image: "k8s.gcr.io/initfoo_spec",
-						},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
 						{
-							Name:  "initcontainer3",
-							Image: "k8s.gcr.io/initfoo_spec",
+							Type:   v1.PodReady,
+							Status: v1.ConditionFalse,
 						},
 					},
 				},
+			},
+			Want: `
+			# HELP kube_pod_status_not_ready Describes whether the pod is not ready to serve requests, i.e. its Ready condition is False, Unknown or not reported yet.
+			# TYPE kube_pod_status_not_ready gauge
+			kube_pod_status_not_ready{namespace="ns1",pod="pod1",uid="uid1"} 1
+			`,
+			MetricNames: []string{"kube_pod_status_not_ready"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod2",
+					Namespace: "ns1",
+					UID:       "uid2",
+				},
 				Status: v1.PodStatus{
-					InitContainerStatuses: []v1.ContainerStatus{
-						{
-							Name:         "initcontainer2",
-							RestartCount: 0,
-						},
+					Conditions: []v1.PodCondition{
 						{
-							Name:         "initcontainer3",
-							RestartCount: 1,
+							Type:   v1.PodReady,
+							Status: v1.ConditionTrue,
 						},
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_init_container_status_restarts_total [STABLE] The number of restarts for the init container.
-				# TYPE kube_pod_init_container_status_restarts_total counter
-				kube_pod_init_container_status_restarts_total{container="initcontainer2",namespace="ns2",pod="pod2",uid="uid2"} 0
-				kube_pod_init_container_status_restarts_total{container="initcontainer3",namespace="ns2",pod="pod2",uid="uid2"} 1
-				`,
-			MetricNames: []string{"kube_pod_init_container_status_restarts_total"},
+			# HELP kube_pod_status_not_ready Describes whether the pod is not ready to serve requests, i.e. its Ready condition is False, Unknown or not reported yet.
+			# TYPE kube_pod_status_not_ready gauge
+			kube_pod_status_not_ready{namespace="ns1",pod="pod2",uid="uid2"} 0
+			`,
+			MetricNames: []string{"kube_pod_status_not_ready"},
 		},
 		{
 			Obj: &v1.Pod{
@@ -409,85 +411,154 @@ func TestPodStore(t *testing.T) {
 					UID:       "uid1",
 				},
 				Spec: v1.PodSpec{
-					Containers: []v1.Container{
-						{
-							Name:  "container1",
-							Image: "k8s.gcr.io/hyperkube2_spec",
-						},
-					},
-					InitContainers: []v1.Container{
-						{
-							Name:  "initcontainer1",
-							Image: "k8s.gcr.io/initfoo_spec",
-						},
-					},
+					Priority: ptr.To[int32](1000),
 				},
-				Status: v1.PodStatus{
-					ContainerStatuses: []v1.ContainerStatus{
-						{
-							Name: "container1",
-							State: v1.ContainerState{
-								Running: &v1.ContainerStateRunning{
-									StartedAt: metav1.Time{
-										Time: time.Unix(1501777018, 0),
+			},
+			Want: `
+			# HELP kube_pod_spec_priority The priority value of a pod. Only emitted if the priority of the pod is set.
+			# TYPE kube_pod_spec_priority gauge
+			kube_pod_spec_priority{namespace="ns1",pod="pod1",uid="uid1"} 1000
+			`,
+			MetricNames: []string{"kube_pod_spec_priority"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod2",
+					Namespace: "ns1",
+					UID:       "uid2",
+				},
+			},
+			Want: `
+			# HELP kube_pod_spec_priority The priority value of a pod. Only emitted if the priority of the pod is set.
+			# TYPE kube_pod_spec_priority gauge
+			`,
+			MetricNames: []string{"kube_pod_spec_priority"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+				},
+				Spec: v1.PodSpec{
+					Affinity: &v1.Affinity{
+						NodeAffinity: &v1.NodeAffinity{
+							RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+								NodeSelectorTerms: []v1.NodeSelectorTerm{
+									{
+										MatchExpressions: []v1.NodeSelectorRequirement{
+											{Key: "kubernetes.io/arch", Operator: v1.NodeSelectorOpIn, Values: []string{"amd64"}},
+										},
+									},
+								},
+							},
+						},
+						PodAntiAffinity: &v1.PodAntiAffinity{
+							PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+								{
+									Weight: 100,
+									PodAffinityTerm: v1.PodAffinityTerm{
+										LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+										TopologyKey:   "kubernetes.io/hostname",
+									},
+								},
+								{
+									Weight: 50,
+									PodAffinityTerm: v1.PodAffinityTerm{
+										LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+										TopologyKey:   "topology.kubernetes.io/zone",
 									},
 								},
 							},
 						},
 					},
-					InitContainerStatuses: []v1.ContainerStatus{
+				},
+			},
+			Want: `
+			# HELP kube_pod_spec_affinity The number of required and preferred node affinity, pod affinity and pod anti-affinity terms of a pod.
+			# TYPE kube_pod_spec_affinity gauge
+			kube_pod_spec_affinity{namespace="ns1",pod="pod1",requirement="preferred",type="node_affinity",uid="uid1"} 0
+			kube_pod_spec_affinity{namespace="ns1",pod="pod1",requirement="preferred",type="pod_affinity",uid="uid1"} 0
+			kube_pod_spec_affinity{namespace="ns1",pod="pod1",requirement="preferred",type="pod_anti_affinity",uid="uid1"} 2
+			kube_pod_spec_affinity{namespace="ns1",pod="pod1",requirement="required",type="node_affinity",uid="uid1"} 1
+			kube_pod_spec_affinity{namespace="ns1",pod="pod1",requirement="required",type="pod_affinity",uid="uid1"} 0
+			kube_pod_spec_affinity{namespace="ns1",pod="pod1",requirement="required",type="pod_anti_affinity",uid="uid1"} 0
+			`,
+			MetricNames: []string{"kube_pod_spec_affinity"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod2",
+					Namespace: "ns1",
+					UID:       "uid2",
+				},
+			},
+			Want: `
+			# HELP kube_pod_spec_affinity The number of required and preferred node affinity, pod affinity and pod anti-affinity terms of a pod.
+			# TYPE kube_pod_spec_affinity gauge
+			`,
+			MetricNames: []string{"kube_pod_spec_affinity"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+				},
+				Spec: v1.PodSpec{
+					TopologySpreadConstraints: []v1.TopologySpreadConstraint{
 						{
-							Name: "initcontainer1",
-							State: v1.ContainerState{
-								Running: &v1.ContainerStateRunning{},
-							},
+							MaxSkew:           2,
+							TopologyKey:       "topology.kubernetes.io/zone",
+							WhenUnsatisfiable: v1.DoNotSchedule,
+							LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+						},
+						{
+							MaxSkew:           1,
+							TopologyKey:       "topology.kubernetes.io/zone",
+							WhenUnsatisfiable: v1.DoNotSchedule,
+							LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "bar"}},
+						},
+						{
+							MaxSkew:           3,
+							TopologyKey:       "topology.kubernetes.io/zone",
+							WhenUnsatisfiable: v1.DoNotSchedule,
+							LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "bar"}},
+						},
+						{
+							MaxSkew:           1,
+							TopologyKey:       "kubernetes.io/hostname",
+							WhenUnsatisfiable: v1.ScheduleAnyway,
 						},
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
-				# HELP kube_pod_container_state_started [STABLE] Start time in unix timestamp for a pod container.
-				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
-				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
-				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
-				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
-				# HELP kube_pod_init_container_status_running [STABLE] Describes whether the init container is currently in running state.
-				# HELP kube_pod_init_container_status_terminated [STABLE] Describes whether the init container is currently in terminated state.
-				# HELP kube_pod_init_container_status_terminated_reason Describes the reason the init container is currently in terminated state.
-				# HELP kube_pod_init_container_status_waiting [STABLE] Describes whether the init container is currently in waiting state.
-				# HELP kube_pod_init_container_status_waiting_reason Describes the reason the init container is currently in waiting state.
-				# TYPE kube_pod_container_status_running gauge
-				# TYPE kube_pod_container_state_started gauge
-				# TYPE kube_pod_container_status_terminated gauge
-				# TYPE kube_pod_container_status_terminated_reason gauge
-				# TYPE kube_pod_container_status_waiting gauge
-				# TYPE kube_pod_container_status_waiting_reason gauge
-				# TYPE kube_pod_init_container_status_running gauge
-				# TYPE kube_pod_init_container_status_terminated gauge
-				# TYPE kube_pod_init_container_status_terminated_reason gauge
-				# TYPE kube_pod_init_container_status_waiting gauge
-				# TYPE kube_pod_init_container_status_waiting_reason gauge
-				kube_pod_container_state_started{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 1.501777018e+09
-				kube_pod_container_status_running{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 1
-				kube_pod_container_status_terminated{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 0
-				kube_pod_container_status_waiting{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 0
-				kube_pod_init_container_status_running{container="initcontainer1",namespace="ns1",pod="pod1",uid="uid1"} 1
-				kube_pod_init_container_status_terminated{container="initcontainer1",namespace="ns1",pod="pod1",uid="uid1"} 0
-				kube_pod_init_container_status_waiting{container="initcontainer1",namespace="ns1",pod="pod1",uid="uid1"} 0
+			# HELP kube_pod_spec_topology_spread_constraint The maximum skew of the topology spread constraints of a pod.
+			# TYPE kube_pod_spec_topology_spread_constraint gauge
+			kube_pod_spec_topology_spread_constraint{label_selector="<none>",namespace="ns1",pod="pod1",topology_key="kubernetes.io/hostname",uid="uid1",when_unsatisfiable="ScheduleAnyway"} 1
+			kube_pod_spec_topology_spread_constraint{label_selector="app=bar",namespace="ns1",pod="pod1",topology_key="topology.kubernetes.io/zone",uid="uid1",when_unsatisfiable="DoNotSchedule"} 1
+			kube_pod_spec_topology_spread_constraint{label_selector="app=foo",namespace="ns1",pod="pod1",topology_key="topology.kubernetes.io/zone",uid="uid1",when_unsatisfiable="DoNotSchedule"} 2
 			`,
-			MetricNames: []string{
-				"kube_pod_container_status_running",
-				"kube_pod_container_state_started",
-				"kube_pod_container_status_waiting",
-				"kube_pod_container_status_terminated",
-				"kube_pod_container_status_terminated_reason",
-				"kube_pod_init_container_status_running",
-				"kube_pod_init_container_status_waiting",
-				"kube_pod_init_container_status_waiting_reason",
-				"kube_pod_init_container_status_terminated",
-				"kube_pod_init_container_status_terminated_reason",
+			MetricNames: []string{"kube_pod_spec_topology_spread_constraint"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod2",
+					Namespace: "ns1",
+					UID:       "uid2",
+				},
 			},
+			Want: `
+			# HELP kube_pod_spec_topology_spread_constraint The maximum skew of the topology spread constraints of a pod.
+			# TYPE kube_pod_spec_topology_spread_constraint gauge
+			`,
+			MetricNames: []string{"kube_pod_spec_topology_spread_constraint"},
 		},
 		{
 			Obj: &v1.Pod{
@@ -507,45 +578,17 @@ func TestPodStore(t *testing.T) {
 				Status: v1.PodStatus{
 					ContainerStatuses: []v1.ContainerStatus{
 						{
-							Name: "container1",
-							State: v1.ContainerState{
-								Terminated: &v1.ContainerStateTerminated{
-									StartedAt: metav1.Time{
-										Time: time.Unix(1501777018, 0),
-									},
-									Reason: "Completed",
-								},
-							},
+							Name:  "container1",
+							Ready: true,
 						},
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
-				# HELP kube_pod_container_state_started [STABLE] Start time in unix timestamp for a pod container.
-				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
-				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
-				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
-				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
-				# TYPE kube_pod_container_status_running gauge
-				# TYPE kube_pod_container_state_started gauge
-				# TYPE kube_pod_container_status_terminated gauge
-				# TYPE kube_pod_container_status_terminated_reason gauge
-				# TYPE kube_pod_container_status_waiting gauge
-				# TYPE kube_pod_container_status_waiting_reason gauge
-				kube_pod_container_state_started{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 1.501777018e+09
-				kube_pod_container_status_running{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 0
-				kube_pod_container_status_terminated_reason{container="container1",namespace="ns1",pod="pod1",reason="Completed",uid="uid1"} 1
-				kube_pod_container_status_terminated{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 1
-				kube_pod_container_status_waiting{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 0
-			`,
-			MetricNames: []string{
-				"kube_pod_container_status_running",
-				"kube_pod_container_state_started",
-				"kube_pod_container_status_waiting",
-				"kube_pod_container_status_terminated",
-				"kube_pod_container_status_terminated_reason",
-			},
+				# HELP kube_pod_container_status_ready [STABLE] Describes whether the containers readiness check succeeded.
+				# TYPE kube_pod_container_status_ready gauge
+				kube_pod_container_status_ready{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 1`,
+			MetricNames: []string{"kube_pod_container_status_ready"},
 		},
 		{
 			Obj: &v1.Pod{
@@ -569,58 +612,23 @@ func TestPodStore(t *testing.T) {
 				Status: v1.PodStatus{
 					ContainerStatuses: []v1.ContainerStatus{
 						{
-							Name: "container2",
-							State: v1.ContainerState{
-								Terminated: &v1.ContainerStateTerminated{
-									StartedAt: metav1.Time{
-										Time: time.Unix(1501777018, 0),
-									},
-									Reason:   "OOMKilled",
-									ExitCode: 137,
-								},
-							},
+							Name:  "container2",
+							Ready: true,
 						},
 						{
-							Name: "container3",
-							State: v1.ContainerState{
-								Waiting: &v1.ContainerStateWaiting{
-									Reason: "ContainerCreating",
-								},
-							},
+							Name:  "container3",
+							Ready: false,
 						},
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
-				# HELP kube_pod_container_state_started [STABLE] Start time in unix timestamp for a pod container.
-				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
-				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
-				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
-				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
-				# TYPE kube_pod_container_status_running gauge
-				# TYPE kube_pod_container_state_started gauge
-				# TYPE kube_pod_container_status_terminated gauge
-				# TYPE kube_pod_container_status_terminated_reason gauge
-				# TYPE kube_pod_container_status_waiting gauge
-				# TYPE kube_pod_container_status_waiting_reason gauge
-				kube_pod_container_status_running{container="container2",namespace="ns2",pod="pod2",uid="uid2"} 0
-				kube_pod_container_status_running{container="container3",namespace="ns2",pod="pod2",uid="uid2"} 0
-				kube_pod_container_state_started{container="container2",namespace="ns2",pod="pod2",uid="uid2"} 1.501777018e+09
-				kube_pod_container_status_terminated_reason{container="container2",namespace="ns2",pod="pod2",reason="OOMKilled",uid="uid2"} 1
-				kube_pod_container_status_terminated{container="container2",namespace="ns2",pod="pod2",uid="uid2"} 1
-				kube_pod_container_status_terminated{container="container3",namespace="ns2",pod="pod2",uid="uid2"} 0
-				kube_pod_container_status_waiting_reason{container="container3",namespace="ns2",pod="pod2",reason="ContainerCreating",uid="uid2"} 1
-				kube_pod_container_status_waiting{container="container2",namespace="ns2",pod="pod2",uid="uid2"} 0
-				kube_pod_container_status_waiting{container="container3",namespace="ns2",pod="pod2",uid="uid2"} 1
-`,
-			MetricNames: []string{
-				"kube_pod_container_status_running",
-				"kube_pod_container_state_started",
-				"kube_pod_container_status_waiting",
-				"kube_pod_container_status_terminated",
-				"kube_pod_container_status_terminated_reason",
-			},
+				# HELP kube_pod_container_status_ready [STABLE] Describes whether the containers readiness check succeeded.
+				# TYPE kube_pod_container_status_ready gauge
+				kube_pod_container_status_ready{container="container2",namespace="ns2",pod="pod2",uid="uid2"} 1
+				kube_pod_container_status_ready{container="container3",namespace="ns2",pod="pod2",uid="uid2"} 0
+				`,
+			MetricNames: []string{"kube_pod_container_status_ready"},
 		},
 		{
 			Obj: &v1.Pod{
@@ -632,332 +640,297 @@ func TestPodStore(t *testing.T) {
 				Spec: v1.PodSpec{
 					Containers: []v1.Container{
 						{
-							Name:  "container4",
-							Image: "k8s.gcr.io/hyperkube4_spec",
+							Name:  "container2",
+							Image: "k8s.gcr.io/hyperkube2_spec",
+						},
+						{
+							Name:  "container3",
+							Image: "k8s.gcr.io/hyperkube3_spec",
+						},
+					},
+					InitContainers: []v1.Container{
+						{
+							Name:  "initcontainer1",
+							Image: "k8s.gcr.io/initfoo_spec",
+						},
+						{
+							Name:  "initcontainer2",
+							Image: "k8s.gcr.io/initfoo_spec",
 						},
 					},
 				},
 				Status: v1.PodStatus{
 					ContainerStatuses: []v1.ContainerStatus{
 						{
-							Name: "container4",
-							State: v1.ContainerState{
-								Waiting: &v1.ContainerStateWaiting{
-									Reason: "CrashLoopBackOff",
-								},
-							},
+							Name:  "container2",
+							Ready: true,
+						},
+						{
+							Name:  "container3",
+							Ready: false,
+						},
+					},
+					InitContainerStatuses: []v1.ContainerStatus{
+						{
+							Name:  "initcontainer1",
+							Ready: true,
+						},
+						{
+							Name:  "initcontainer2",
+							Ready: false,
 						},
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_container_status_last_terminated_exitcode Describes the exit code for the last container in terminated state.
-				# HELP kube_pod_container_status_last_terminated_reason Describes the last reason the container was in terminated state.
-				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
-				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
-				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
-				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
-				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
-				# TYPE kube_pod_container_status_last_terminated_exitcode gauge
-				# TYPE kube_pod_container_status_last_terminated_reason gauge
-				# TYPE kube_pod_container_status_running gauge
-				# TYPE kube_pod_container_status_terminated gauge
-				# TYPE kube_pod_container_status_terminated_reason gauge
-				# TYPE kube_pod_container_status_waiting gauge
-				# TYPE kube_pod_container_status_waiting_reason gauge
-				kube_pod_container_status_running{container="container4",namespace="ns3",pod="pod3",uid="uid3"} 0
-				kube_pod_container_status_terminated{container="container4",namespace="ns3",pod="pod3",uid="uid3"} 0
-				kube_pod_container_status_waiting_reason{container="container4",namespace="ns3",pod="pod3",reason="CrashLoopBackOff",uid="uid3"} 1
-				kube_pod_container_status_waiting{container="container4",namespace="ns3",pod="pod3",uid="uid3"} 1
-`,
-			MetricNames: []string{
-				"kube_pod_container_status_running",
-				"kube_pod_container_status_terminated",
-				"kube_pod_container_status_terminated_reason",
-				"kube_pod_container_status_terminated_reason",
-				"kube_pod_container_status_terminated_reason",
-				"kube_pod_container_status_terminated_reason",
-				"kube_pod_container_status_waiting",
-				"kube_pod_container_status_waiting_reason",
-				"kube_pod_container_status_last_terminated_reason",
-				"kube_pod_container_status_last_terminated_reason",
-				"kube_pod_container_status_last_terminated_reason",
-				"kube_pod_container_status_last_terminated_reason",
-				"kube_pod_container_status_last_terminated_exitcode",
-				"kube_pod_container_status_last_terminated_exitcode",
-				"kube_pod_container_status_last_terminated_exitcode",
-				"kube_pod_container_status_last_terminated_exitcode",
-			},
+				# HELP kube_pod_init_container_status_ready [STABLE] Describes whether the init containers readiness check succeeded.
+				# TYPE kube_pod_init_container_status_ready gauge
+				kube_pod_init_container_status_ready{container="initcontainer1",namespace="ns3",pod="pod3",uid="uid3"} 1
+				kube_pod_init_container_status_ready{container="initcontainer2",namespace="ns3",pod="pod3",uid="uid3"} 0
+				`,
+			MetricNames: []string{"kube_pod_init_container_status_ready"},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod6",
-					Namespace: "ns6",
-					UID:       "uid6",
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
 				},
 				Spec: v1.PodSpec{
 					Containers: []v1.Container{
 						{
-							Name:  "container7",
-							Image: "k8s.gcr.io/hyperkube7_spec",
+							Name:  "container1",
+							Image: "k8s.gcr.io/hyperkube1_spec",
 						},
 					},
 				},
 				Status: v1.PodStatus{
 					ContainerStatuses: []v1.ContainerStatus{
 						{
-							Name: "container7",
-							State: v1.ContainerState{
-								Running: &v1.ContainerStateRunning{
-									StartedAt: metav1.Time{
-										Time: time.Unix(1501777018, 0),
-									},
-								},
-							},
-							LastTerminationState: v1.ContainerState{
-								Terminated: &v1.ContainerStateTerminated{
-									Reason:   "OOMKilled",
-									ExitCode: 137,
-									FinishedAt: metav1.Time{
-										Time: time.Unix(1501779547, 0),
-									},
-								},
-							},
+							Name:         "container1",
+							RestartCount: 0,
 						},
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_container_status_last_terminated_reason Describes the last reason the container was in terminated state.
-				# HELP kube_pod_container_status_last_terminated_exitcode Describes the exit code for the last container in terminated state.
-				# HELP kube_pod_container_status_last_terminated_timestamp Last terminated time for a pod container in unix timestamp.
-				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
-				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
-				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
-				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
-				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
-				# HELP kube_pod_container_state_started [STABLE] Start time in unix timestamp for a pod container.
-				# TYPE kube_pod_container_status_last_terminated_reason gauge
-				# TYPE kube_pod_container_status_last_terminated_exitcode gauge
-				# TYPE kube_pod_container_status_last_terminated_timestamp gauge
-				# TYPE kube_pod_container_status_running gauge
-				# TYPE kube_pod_container_status_terminated gauge
-				# TYPE kube_pod_container_status_terminated_reason gauge
-				# TYPE kube_pod_container_status_waiting gauge
-				# TYPE kube_pod_container_status_waiting_reason gauge
-				# TYPE kube_pod_container_state_started gauge
-				kube_pod_container_status_running{container="container7",namespace="ns6",pod="pod6",uid="uid6"} 1
-				kube_pod_container_state_started{container="container7",namespace="ns6",pod="pod6",uid="uid6"} 1.501777018e+09
-				kube_pod_container_status_terminated{container="container7",namespace="ns6",pod="pod6",uid="uid6"} 0
-				kube_pod_container_status_waiting{container="container7",namespace="ns6",pod="pod6",uid="uid6"} 0
-				kube_pod_container_status_last_terminated_reason{container="container7",namespace="ns6",pod="pod6",reason="OOMKilled",uid="uid6"} 1
-				kube_pod_container_status_last_terminated_exitcode{container="container7",namespace="ns6",pod="pod6",uid="uid6"} 137
-				kube_pod_container_status_last_terminated_timestamp{container="container7",namespace="ns6",pod="pod6",uid="uid6"} 1.501779547e+09
-			`,
-			MetricNames: []string{
-				"kube_pod_container_status_last_terminated_reason",
-				"kube_pod_container_status_last_terminated_exitcode",
-				"kube_pod_container_status_last_terminated_timestamp",
-				"kube_pod_container_status_running",
-				"kube_pod_container_state_started",
-				"kube_pod_container_status_terminated",
-				"kube_pod_container_status_terminated_reason",
-				"kube_pod_container_status_waiting",
-			},
+				# HELP kube_pod_container_status_restarts_total [STABLE] The number of container restarts per container.
+				# TYPE kube_pod_container_status_restarts_total counter
+				kube_pod_container_status_restarts_total{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 0
+				`,
+			MetricNames: []string{"kube_pod_container_status_restarts_total"},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod7",
-					Namespace: "ns7",
-					UID:       "uid7",
+					Name:      "pod2",
+					Namespace: "ns2",
+					UID:       "uid2",
 				},
 				Spec: v1.PodSpec{
-					Containers: []v1.Container{
+					InitContainers: []v1.Container{
 						{
-							Name:  "container7",
-							Image: "k8s.gcr.io/hyperkube7_spec",
+							Name:  "initcontainer1",
+							Image: "k8s.gcr.io/initfoo_spec",
 						},
 					},
 				},
 				Status: v1.PodStatus{
-					ContainerStatuses: []v1.ContainerStatus{
+					InitContainerStatuses: []v1.ContainerStatus{
 						{
-							Name: "container7",
-							State: v1.ContainerState{
-								Running: &v1.ContainerStateRunning{
-									StartedAt: metav1.Time{
-										Time: time.Unix(1501777018, 0),
-									},
-								},
-							},
-							LastTerminationState: v1.ContainerState{
-								Terminated: &v1.ContainerStateTerminated{
-									Reason:   "DeadlineExceeded",
-									ExitCode: 143,
-									FinishedAt: metav1.Time{
-										Time: time.Unix(1501779547, 0),
-									},
-								},
-							},
+							Name:         "initcontainer1",
+							RestartCount: 1,
 						},
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_container_status_last_terminated_exitcode Describes the exit code for the last container in terminated state.
-				# HELP kube_pod_container_status_last_terminated_reason Describes the last reason the container was in terminated state.
-				# HELP kube_pod_container_status_last_terminated_timestamp Last terminated time for a pod container in unix timestamp.
-				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
-				# HELP kube_pod_container_state_started [STABLE] Start time in unix timestamp for a pod container.
-				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
-				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
-				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
-				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
-				# TYPE kube_pod_container_status_last_terminated_exitcode gauge
-				# TYPE kube_pod_container_status_last_terminated_reason gauge
-				# TYPE kube_pod_container_status_last_terminated_timestamp gauge
-				# TYPE kube_pod_container_status_running gauge
-				# TYPE kube_pod_container_state_started gauge
-				# TYPE kube_pod_container_status_terminated gauge
-				# TYPE kube_pod_container_status_terminated_reason gauge
-				# TYPE kube_pod_container_status_waiting gauge
-				# TYPE kube_pod_container_status_waiting_reason gauge
-				kube_pod_container_state_started{container="container7",namespace="ns7",pod="pod7",uid="uid7"} 1.501777018e+09
-				kube_pod_container_status_last_terminated_exitcode{container="container7",namespace="ns7",pod="pod7",uid="uid7"} 143
-				kube_pod_container_status_last_terminated_reason{container="container7",namespace="ns7",pod="pod7",reason="DeadlineExceeded",uid="uid7"} 1
-				kube_pod_container_status_last_terminated_timestamp{container="container7",namespace="ns7",pod="pod7",uid="uid7"} 1.501779547e+09
-				kube_pod_container_status_running{container="container7",namespace="ns7",pod="pod7",uid="uid7"} 1
-				kube_pod_container_status_terminated{container="container7",namespace="ns7",pod="pod7",uid="uid7"} 0
-				kube_pod_container_status_waiting{container="container7",namespace="ns7",pod="pod7",uid="uid7"} 0
-			`,
-			MetricNames: []string{
-				"kube_pod_container_status_running",
-				"kube_pod_container_state_started",
-				"kube_pod_container_status_terminated",
-				"kube_pod_container_status_terminated_reason",
-				"kube_pod_container_status_last_terminated_timestamp",
-				"kube_pod_container_status_waiting",
-				"kube_pod_container_status_last_terminated_reason",
-				"kube_pod_container_status_last_terminated_exitcode",
-			},
+				# HELP kube_pod_init_container_status_restarts_total [STABLE] The number of restarts for the init container.
+				# TYPE kube_pod_init_container_status_restarts_total counter
+				kube_pod_init_container_status_restarts_total{container="initcontainer1",namespace="ns2",pod="pod2",uid="uid2"} 1
+				`,
+			MetricNames: []string{"kube_pod_init_container_status_restarts_total"},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod4",
-					Namespace: "ns4",
-					UID:       "uid4",
+					Name:      "pod2",
+					Namespace: "ns2",
+					UID:       "uid2",
 				},
 				Spec: v1.PodSpec{
 					Containers: []v1.Container{
 						{
-							Name:  "container5",
-							Image: "k8s.gcr.io/hyperkube5_spec",
+							Name:  "container2",
+							Image: "k8s.gcr.io/hyperkube2_spec",
+						},
+						{
+							Name:  "container3",
+							Image: "k8s.gcr.io/hyperkube3_spec",
 						},
 					},
 				},
 				Status: v1.PodStatus{
 					ContainerStatuses: []v1.ContainerStatus{
 						{
-							Name: "container5",
-							State: v1.ContainerState{
-								Waiting: &v1.ContainerStateWaiting{
-									Reason: "ImagePullBackOff",
-								},
-							},
+							Name:         "container2",
+							RestartCount: 0,
+						},
+						{
+							Name:         "container3",
+							RestartCount: 1,
 						},
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
-				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
-				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
-				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
-				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
-				# TYPE kube_pod_container_status_running gauge
-				# TYPE kube_pod_container_status_terminated gauge
-				# TYPE kube_pod_container_status_terminated_reason gauge
-				# TYPE kube_pod_container_status_waiting gauge
-				# TYPE kube_pod_container_status_waiting_reason gauge
-				kube_pod_container_status_running{container="container5",namespace="ns4",pod="pod4",uid="uid4"} 0
-				kube_pod_container_status_terminated{container="container5",namespace="ns4",pod="pod4",uid="uid4"} 0
-				kube_pod_container_status_waiting_reason{container="container5",namespace="ns4",pod="pod4",reason="ImagePullBackOff",uid="uid4"} 1
-				kube_pod_container_status_waiting{container="container5",namespace="ns4",pod="pod4",uid="uid4"} 1
-`,
-			MetricNames: []string{
-				"kube_pod_container_status_running",
-				"kube_pod_container_status_waiting",
-				"kube_pod_container_status_waiting_reason",
-				"kube_pod_container_status_terminated",
-				"kube_pod_container_status_terminated_reason",
-			},
+				# HELP kube_pod_container_status_restarts_total [STABLE] The number of container restarts per container.
+				# TYPE kube_pod_container_status_restarts_total counter
+				kube_pod_container_status_restarts_total{container="container2",namespace="ns2",pod="pod2",uid="uid2"} 0
+				kube_pod_container_status_restarts_total{container="container3",namespace="ns2",pod="pod2",uid="uid2"} 1
+				`,
+			MetricNames: []string{"kube_pod_container_status_restarts_total"},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod5",
-					Namespace: "ns5",
-					UID:       "uid5",
+					Name:      "pod2",
+					Namespace: "ns2",
+					UID:       "uid2",
 				},
 				Spec: v1.PodSpec{
-					Containers: []v1.Container{
+					InitContainers: []v1.Container{
 						{
-							Name:  "container6",
-							Image: "k8s.gcr.io/hyperkube1_spec",
+							Name:  "initcontainer2",
+							Image: "k8s.gcr.io/initfoo_spec",
+						},
+						{
+							Name:  "initcontainer3",
+							Image: "k8s.gcr.io/initfoo_spec",
 						},
 					},
 				},
 				Status: v1.PodStatus{
-					ContainerStatuses: []v1.ContainerStatus{
+					InitContainerStatuses: []v1.ContainerStatus{
 						{
-							Name: "container6",
+							Name:         "initcontainer2",
+							RestartCount: 0,
+						},
+						{
+							Name:         "initcontainer3",
+							RestartCount: 1,
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_init_container_status_restarts_total [STABLE] The number of restarts for the init container.
+				# TYPE kube_pod_init_container_status_restarts_total counter
+				kube_pod_init_container_status_restarts_total{container="initcontainer2",namespace="ns2",pod="pod2",uid="uid2"} 0
+				kube_pod_init_container_status_restarts_total{container="initcontainer3",namespace="ns2",pod="pod2",uid="uid2"} 1
+				`,
+			MetricNames: []string{"kube_pod_init_container_status_restarts_total"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  "container1",
+							Image: "k8s.gcr.io/hyperkube2_spec",
+						},
+					},
+					InitContainers: []v1.Container{
+						{
+							Name:  "initcontainer1",
+							Image: "k8s.gcr.io/initfoo_spec",
+						},
+					},
+				},
+				Status: v1.PodStatus{
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							Name: "container1",
 							State: v1.ContainerState{
-								Waiting: &v1.ContainerStateWaiting{
-									Reason: "ErrImagePull",
+								Running: &v1.ContainerStateRunning{
+									StartedAt: metav1.Time{
+										Time: time.Unix(1501777018, 0),
+									},
 								},
 							},
 						},
 					},
+					InitContainerStatuses: []v1.ContainerStatus{
+						{
+							Name: "initcontainer1",
+							State: v1.ContainerState{
+								Running: &v1.ContainerStateRunning{},
+							},
+						},
+					},
 				},
 			},
 			Want: `
 				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
+				# HELP kube_pod_container_state_started [STABLE] Start time in unix timestamp for a pod container.
 				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
 				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
 				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
 				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
+				# HELP kube_pod_init_container_status_running [STABLE] Describes whether the init container is currently in running state.
+				# HELP kube_pod_init_container_status_terminated [STABLE] Describes whether the init container is currently in terminated state.
+				# HELP kube_pod_init_container_status_terminated_reason Describes the reason the init container is currently in terminated state.
+				# HELP kube_pod_init_container_status_waiting [STABLE] Describes whether the init container is currently in waiting state.
+				# HELP kube_pod_init_container_status_waiting_reason Describes the reason the init container is currently in waiting state.
 				# TYPE kube_pod_container_status_running gauge
+				# TYPE kube_pod_container_state_started gauge
 				# TYPE kube_pod_container_status_terminated gauge
 				# TYPE kube_pod_container_status_terminated_reason gauge
 				# TYPE kube_pod_container_status_waiting gauge
 				# TYPE kube_pod_container_status_waiting_reason gauge
-				kube_pod_container_status_running{container="container6",namespace="ns5",pod="pod5",uid="uid5"} 0
-				kube_pod_container_status_terminated{container="container6",namespace="ns5",pod="pod5",uid="uid5"} 0
-				kube_pod_container_status_waiting_reason{container="container6",namespace="ns5",pod="pod5",reason="ErrImagePull",uid="uid5"} 1
-				kube_pod_container_status_waiting{container="container6",namespace="ns5",pod="pod5",uid="uid5"} 1
+				# TYPE kube_pod_init_container_status_running gauge
+				# TYPE kube_pod_init_container_status_terminated gauge
+				# TYPE kube_pod_init_container_status_terminated_reason gauge
+				# TYPE kube_pod_init_container_status_waiting gauge
+				# TYPE kube_pod_init_container_status_waiting_reason gauge
+				kube_pod_container_state_started{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 1.501777018e+09
+				kube_pod_container_status_running{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 1
+				kube_pod_container_status_terminated{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 0
+				kube_pod_container_status_waiting{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 0
+				kube_pod_init_container_status_running{container="initcontainer1",namespace="ns1",pod="pod1",uid="uid1"} 1
+				kube_pod_init_container_status_terminated{container="initcontainer1",namespace="ns1",pod="pod1",uid="uid1"} 0
+				kube_pod_init_container_status_waiting{container="initcontainer1",namespace="ns1",pod="pod1",uid="uid1"} 0
 			`,
 			MetricNames: []string{
 				"kube_pod_container_status_running",
+				"kube_pod_container_state_started",
 				"kube_pod_container_status_waiting",
-				"kube_pod_container_status_waiting_reason",
 				"kube_pod_container_status_terminated",
 				"kube_pod_container_status_terminated_reason",
+				"kube_pod_init_container_status_running",
+				"kube_pod_init_container_status_waiting",
+				"kube_pod_init_container_status_waiting_reason",
+				"kube_pod_init_container_status_terminated",
+				"kube_pod_init_container_status_terminated_reason",
 			},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod7",
-					Namespace: "ns7",
-					UID:       "uid7",
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
 				},
 				Spec: v1.PodSpec{
 					Containers: []v1.Container{
 						{
-							Name:  "container8",
+							Name:  "container1",
 							Image: "k8s.gcr.io/hyperkube1_spec",
 						},
 					},
@@ -965,10 +938,13 @@ func TestPodStore(t *testing.T) {
 				Status: v1.PodStatus{
 					ContainerStatuses: []v1.ContainerStatus{
 						{
-							Name: "container8",
+							Name: "container1",
 							State: v1.ContainerState{
-								Waiting: &v1.ContainerStateWaiting{
-									Reason: "CreateContainerConfigError",
+								Terminated: &v1.ContainerStateTerminated{
+									StartedAt: metav1.Time{
+										Time: time.Unix(1501777018, 0),
+									},
+									Reason: "Completed",
 								},
 							},
 						},
@@ -976,218 +952,271 @@ func TestPodStore(t *testing.T) {
 				},
 			},
 			Want: `
-					# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
-					# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
-					# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
-					# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
-					# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
-					# TYPE kube_pod_container_status_running gauge
-					# TYPE kube_pod_container_status_terminated gauge
-					# TYPE kube_pod_container_status_terminated_reason gauge
-					# TYPE kube_pod_container_status_waiting gauge
-					# TYPE kube_pod_container_status_waiting_reason gauge
-					kube_pod_container_status_running{container="container8",namespace="ns7",pod="pod7",uid="uid7"} 0
-					kube_pod_container_status_terminated{container="container8",namespace="ns7",pod="pod7",uid="uid7"} 0
-					kube_pod_container_status_waiting_reason{container="container8",namespace="ns7",pod="pod7",reason="CreateContainerConfigError",uid="uid7"} 1
-					kube_pod_container_status_waiting{container="container8",namespace="ns7",pod="pod7",uid="uid7"} 1
+				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
+				# HELP kube_pod_container_state_started [STABLE] Start time in unix timestamp for a pod container.
+				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
+				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
+				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
+				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
+				# TYPE kube_pod_container_status_running gauge
+				# TYPE kube_pod_container_state_started gauge
+				# TYPE kube_pod_container_status_terminated gauge
+				# TYPE kube_pod_container_status_terminated_reason gauge
+				# TYPE kube_pod_container_status_waiting gauge
+				# TYPE kube_pod_container_status_waiting_reason gauge
+				kube_pod_container_state_started{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 1.501777018e+09
+				kube_pod_container_status_running{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 0
+				kube_pod_container_status_terminated_reason{container="container1",namespace="ns1",pod="pod1",reason="Completed",uid="uid1"} 1
+				kube_pod_container_status_terminated{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 1
+				kube_pod_container_status_waiting{container="container1",namespace="ns1",pod="pod1",uid="uid1"} 0
 			`,
 			MetricNames: []string{
 				"kube_pod_container_status_running",
+				"kube_pod_container_state_started",
+				"kube_pod_container_status_waiting",
 				"kube_pod_container_status_terminated",
 				"kube_pod_container_status_terminated_reason",
-				"kube_pod_container_status_waiting",
-				"kube_pod_container_status_waiting_reason",
 			},
 		},
 		{
-
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:              "pod1",
-					CreationTimestamp: metav1.Time{Time: time.Unix(1500000000, 0)},
-					Namespace:         "ns1",
-					UID:               "abc-123-xxx",
+					Name:      "pod2",
+					Namespace: "ns2",
+					UID:       "uid2",
 				},
 				Spec: v1.PodSpec{
-					NodeName:          "node1",
-					PriorityClassName: "system-node-critical",
-					HostNetwork:       true,
 					Containers: []v1.Container{
 						{
+							Name:  "container2",
 							Image: "k8s.gcr.io/hyperkube2_spec",
 						},
+						{
+							Name:  "container3",
+							Image: "k8s.gcr.io/hyperkube3_spec",
+						},
 					},
 				},
 				Status: v1.PodStatus{
-					HostIP: "1.1.1.1",
-					PodIP:  "1.2.3.4",
-					PodIPs: []v1.PodIP{
+					ContainerStatuses: []v1.ContainerStatus{
 						{
-							IP: "1.2.3.4",
+							Name: "container2",
+							State: v1.ContainerState{
+								Terminated: &v1.ContainerStateTerminated{
+									StartedAt: metav1.Time{
+										Time: time.Unix(1501777018, 0),
+									},
+									Reason:   "OOMKilled",
+									ExitCode: 137,
+								},
+							},
 						},
 						{
-							IP: "fc00:1234:5678:90ab:cdef:cafe:f00d:d00d",
+							Name: "container3",
+							State: v1.ContainerState{
+								Waiting: &v1.ContainerStateWaiting{
+									Reason: "ContainerCreating",
+								},
+							},
 						},
 					},
-					StartTime: &metav1StartTime,
 				},
 			},
-			// TODO: Should it be '1501569018' instead?
 			Want: `
-				# HELP kube_pod_completion_time [STABLE] Completion time in unix timestamp for a pod.
-				# HELP kube_pod_created [STABLE] Unix creation timestamp
-				# HELP kube_pod_info [STABLE] Information about pod.
-				# HELP kube_pod_ips Pod IP addresses
-				# HELP kube_pod_owner [STABLE] Information about the Pod's owner.
-				# HELP kube_pod_start_time [STABLE] Start time in unix timestamp for a pod.
-				# TYPE kube_pod_completion_time gauge
-				# TYPE kube_pod_created gauge
-				# TYPE kube_pod_info gauge
-				# TYPE kube_pod_ips gauge
-				# TYPE kube_pod_owner gauge
-				# TYPE kube_pod_start_time gauge
-				kube_pod_created{namespace="ns1",pod="pod1",uid="abc-123-xxx"} 1.5e+09
-				kube_pod_info{created_by_kind="",created_by_name="",host_ip="1.1.1.1",namespace="ns1",node="node1",pod="pod1",pod_ip="1.2.3.4",uid="abc-123-xxx",priority_class="system-node-critical",host_network="true"} 1
-				kube_pod_ips{namespace="ns1",pod="pod1",uid="abc-123-xxx",ip="1.2.3.4",ip_family="4"} 1
-				kube_pod_ips{namespace="ns1",pod="pod1",uid="abc-123-xxx",ip="fc00:1234:5678:90ab:cdef:cafe:f00d:d00d",ip_family="6"} 1
-				kube_pod_start_time{namespace="ns1",pod="pod1",uid="abc-123-xxx"} 1.501569018e+09
-				kube_pod_owner{namespace="ns1",owner_is_controller="",owner_kind="",owner_name="",pod="pod1",uid="abc-123-xxx"} 1
+				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
+				# HELP kube_pod_container_state_started [STABLE] Start time in unix timestamp for a pod container.
+				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
+				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
+				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
+				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
+				# TYPE kube_pod_container_status_running gauge
+				# TYPE kube_pod_container_state_started gauge
+				# TYPE kube_pod_container_status_terminated gauge
+				# TYPE kube_pod_container_status_terminated_reason gauge
+				# TYPE kube_pod_container_status_waiting gauge
+				# TYPE kube_pod_container_status_waiting_reason gauge
+				kube_pod_container_status_running{container="container2",namespace="ns2",pod="pod2",uid="uid2"} 0
+				kube_pod_container_status_running{container="container3",namespace="ns2",pod="pod2",uid="uid2"} 0
+				kube_pod_container_state_started{container="container2",namespace="ns2",pod="pod2",uid="uid2"} 1.501777018e+09
+				kube_pod_container_status_terminated_reason{container="container2",namespace="ns2",pod="pod2",reason="OOMKilled",uid="uid2"} 1
+				kube_pod_container_status_terminated{container="container2",namespace="ns2",pod="pod2",uid="uid2"} 1
+				kube_pod_container_status_terminated{container="container3",namespace="ns2",pod="pod2",uid="uid2"} 0
+				kube_pod_container_status_waiting_reason{container="container3",namespace="ns2",pod="pod2",reason="ContainerCreating",uid="uid2"} 1
+				kube_pod_container_status_waiting{container="container2",namespace="ns2",pod="pod2",uid="uid2"} 0
+				kube_pod_container_status_waiting{container="container3",namespace="ns2",pod="pod2",uid="uid2"} 1
 `,
-			MetricNames: []string{"kube_pod_created", "kube_pod_info", "kube_pod_ips", "kube_pod_start_time", "kube_pod_completion_time", "kube_pod_owner"},
+			MetricNames: []string{
+				"kube_pod_container_status_running",
+				"kube_pod_container_state_started",
+				"kube_pod_container_status_waiting",
+				"kube_pod_container_status_terminated",
+				"kube_pod_container_status_terminated_reason",
+			},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:              "pod1",
-					CreationTimestamp: metav1.Time{Time: time.Unix(1500000000, 0)},
-					Namespace:         "ns1",
-					UID:               "abc-123-xxx",
-					DeletionTimestamp: &metav1.Time{Time: time.Unix(1800000000, 0)},
+					Name:      "pod3",
+					Namespace: "ns3",
+					UID:       "uid3",
 				},
 				Spec: v1.PodSpec{
-					NodeName:          "node1",
-					PriorityClassName: "system-node-critical",
+					Containers: []v1.Container{
+						{
+							Name:  "container4",
+							Image: "k8s.gcr.io/hyperkube4_spec",
+						},
+					},
 				},
 				Status: v1.PodStatus{
-					HostIP:    "1.1.1.1",
-					PodIP:     "1.2.3.4",
-					StartTime: &metav1StartTime,
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							Name: "container4",
+							State: v1.ContainerState{
+								Waiting: &v1.ContainerStateWaiting{
+									Reason: "CrashLoopBackOff",
+								},
+							},
+						},
+					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_deletion_timestamp Unix deletion timestamp
-				# TYPE kube_pod_deletion_timestamp gauge
-				kube_pod_deletion_timestamp{namespace="ns1",pod="pod1",uid="abc-123-xxx"} 1.8e+09
+				# HELP kube_pod_container_status_last_terminated_exitcode Describes the exit code for the last container in terminated state.
+				# HELP kube_pod_container_status_last_terminated_reason Describes the last reason the container was in terminated state.
+				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
+				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
+				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
+				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
+				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
+				# TYPE kube_pod_container_status_last_terminated_exitcode gauge
+				# TYPE kube_pod_container_status_last_terminated_reason gauge
+				# TYPE kube_pod_container_status_running gauge
+				# TYPE kube_pod_container_status_terminated gauge
+				# TYPE kube_pod_container_status_terminated_reason gauge
+				# TYPE kube_pod_container_status_waiting gauge
+				# TYPE kube_pod_container_status_waiting_reason gauge
+				kube_pod_container_status_running{container="container4",namespace="ns3",pod="pod3",uid="uid3"} 0
+				kube_pod_container_status_terminated{container="container4",namespace="ns3",pod="pod3",uid="uid3"} 0
+				kube_pod_container_status_waiting_reason{container="container4",namespace="ns3",pod="pod3",reason="CrashLoopBackOff",uid="uid3"} 1
+				kube_pod_container_status_waiting{container="container4",namespace="ns3",pod="pod3",uid="uid3"} 1
 `,
-			MetricNames: []string{"kube_pod_deletion_timestamp"},
-		},
-		{
-			Obj: &v1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod2",
-					Namespace: "ns2",
-					UID:       "uid2",
-				},
-				Spec: v1.PodSpec{
-					RestartPolicy: v1.RestartPolicyAlways,
-				},
-			},
-			Want: `
-				# HELP kube_pod_restart_policy [STABLE] Describes the restart policy in use by this pod.
-				# TYPE kube_pod_restart_policy gauge
-				kube_pod_restart_policy{namespace="ns2",pod="pod2",type="Always",uid="uid2"} 1
-				`,
-			MetricNames: []string{"kube_pod_restart_policy"},
-		},
-		{
-			Obj: &v1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod2",
-					Namespace: "ns2",
-					UID:       "uid2",
-				},
-				Spec: v1.PodSpec{
-					RestartPolicy: v1.RestartPolicyOnFailure,
-				},
+			MetricNames: []string{
+				"kube_pod_container_status_running",
+				"kube_pod_container_status_terminated",
+				"kube_pod_container_status_terminated_reason",
+				"kube_pod_container_status_terminated_reason",
+				"kube_pod_container_status_terminated_reason",
+				"kube_pod_container_status_terminated_reason",
+				"kube_pod_container_status_waiting",
+				"kube_pod_container_status_waiting_reason",
+				"kube_pod_container_status_last_terminated_reason",
+				"kube_pod_container_status_last_terminated_reason",
+				"kube_pod_container_status_last_terminated_reason",
+				"kube_pod_container_status_last_terminated_reason",
+				"kube_pod_container_status_last_terminated_exitcode",
+				"kube_pod_container_status_last_terminated_exitcode",
+				"kube_pod_container_status_last_terminated_exitcode",
+				"kube_pod_container_status_last_terminated_exitcode",
 			},
-			Want: `
-				# HELP kube_pod_restart_policy [STABLE] Describes the restart policy in use by this pod.
-				# TYPE kube_pod_restart_policy gauge
-				kube_pod_restart_policy{namespace="ns2",pod="pod2",type="OnFailure",uid="uid2"} 1
-				`,
-			MetricNames: []string{"kube_pod_restart_policy"},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod2",
-					Namespace: "ns2",
-					UID:       "abc-456-xxx",
-					OwnerReferences: []metav1.OwnerReference{
-						{
-							Kind:       "ReplicaSet",
-							Name:       "rs-name",
-							Controller: &test,
-						},
-					},
+					Name:      "pod6",
+					Namespace: "ns6",
+					UID:       "uid6",
 				},
 				Spec: v1.PodSpec{
-					NodeName: "node2",
 					Containers: []v1.Container{
 						{
-							Name:  "container2_1",
-							Image: "k8s.gcr.io/hyperkube2_spec",
-						},
-						{
-							Name:  "container2_2",
-							Image: "k8s.gcr.io/hyperkube2_spec",
-						},
-						{
-							Name:  "container2_3",
-							Image: "k8s.gcr.io/hyperkube2_spec",
+							Name:  "container7",
+							Image: "k8s.gcr.io/hyperkube7_spec",
 						},
 					},
 				},
 				Status: v1.PodStatus{
-					HostIP: "1.1.1.1",
-					PodIP:  "2.3.4.5",
 					ContainerStatuses: []v1.ContainerStatus{
 						{
-							Name:        "container2_1",
-							Image:       "k8s.gcr.io/hyperkube2",
-							ImageID:     "docker://sha256:bbb",
-							ContainerID: "docker://cd456",
+							Name: "container7",
 							State: v1.ContainerState{
-								Terminated: &v1.ContainerStateTerminated{
-									FinishedAt: metav1.Time{
+								Running: &v1.ContainerStateRunning{
+									StartedAt: metav1.Time{
 										Time: time.Unix(1501777018, 0),
 									},
 								},
 							},
-						},
-						{
-							Name:        "container2_2",
-							Image:       "k8s.gcr.io/hyperkube2",
-							ImageID:     "docker://sha256:bbb",
-							ContainerID: "docker://cd456",
-							State: v1.ContainerState{
+							LastTerminationState: v1.ContainerState{
 								Terminated: &v1.ContainerStateTerminated{
+									Reason:   "OOMKilled",
+									ExitCode: 137,
 									FinishedAt: metav1.Time{
-										Time: time.Unix(1501888018, 0),
+										Time: time.Unix(1501779547, 0),
 									},
 								},
 							},
 						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_container_status_last_terminated_reason Describes the last reason the container was in terminated state.
+				# HELP kube_pod_container_status_last_terminated_exitcode Describes the exit code for the last container in terminated state.
+				# HELP kube_pod_container_status_last_terminated_timestamp Last terminated time for a pod container in unix timestamp.
+				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
+				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
+				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
+				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
+				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
+				# HELP kube_pod_container_state_started [STABLE] Start time in unix timestamp for a pod container.
+				# TYPE kube_pod_container_status_last_terminated_reason gauge
+				# TYPE kube_pod_container_status_last_terminated_exitcode gauge
+				# TYPE kube_pod_container_status_last_terminated_timestamp gauge
+				# TYPE kube_pod_container_status_running gauge
+				# TYPE kube_pod_container_status_terminated gauge
+				# TYPE kube_pod_container_status_terminated_reason gauge
+				# TYPE kube_pod_container_status_waiting gauge
+				# TYPE kube_pod_container_status_waiting_reason gauge
+				# TYPE kube_pod_container_state_started gauge
+				kube_pod_container_status_running{container="container7",namespace="ns6",pod="pod6",uid="uid6"} 1
+				kube_pod_container_state_started{container="container7",namespace="ns6",pod="pod6",uid="uid6"} 1.501777018e+09
+				kube_pod_container_status_terminated{container="container7",namespace="ns6",pod="pod6",uid="uid6"} 0
+				kube_pod_container_status_waiting{container="container7",namespace="ns6",pod="pod6",uid="uid6"} 0
+				kube_pod_container_status_last_terminated_reason{container="container7",namespace="ns6",pod="pod6",reason="OOMKilled",uid="uid6"} 1
+				kube_pod_container_status_last_terminated_exitcode{container="container7",namespace="ns6",pod="pod6",uid="uid6"} 137
+				kube_pod_container_status_last_terminated_timestamp{container="container7",namespace="ns6",pod="pod6",uid="uid6"} 1.501779547e+09
+			`,
+			MetricNames: []string{
+				"kube_pod_container_status_last_terminated_reason",
+				"kube_pod_container_status_last_terminated_exitcode",
+				"kube_pod_container_status_last_terminated_timestamp",
+				"kube_pod_container_status_running",
+				"kube_pod_container_state_started",
+				"kube_pod_container_status_terminated",
+				"kube_pod_container_status_terminated_reason",
+				"kube_pod_container_status_waiting",
+			},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+				},
+				Status: v1.PodStatus{
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							Name: "container1",
+							State: v1.ContainerState{
+								Running: &v1.ContainerStateRunning{},
+							},
+						},
 						{
-							Name:        "container2_3",
-							Image:       "k8s.gcr.io/hyperkube2",
-							ImageID:     "docker://sha256:bbb",
-							ContainerID: "docker://cd456",
+							Name: "container2",
 							State: v1.ContainerState{
-								Terminated: &v1.ContainerStateTerminated{
-									FinishedAt: metav1.Time{
-										Time: time.Unix(1501666018, 0),
-									},
+								Waiting: &v1.ContainerStateWaiting{
+									Reason: "CrashLoopBackOff",
 								},
 							},
 						},
@@ -1195,21 +1224,15 @@ func TestPodStore(t *testing.T) {
 				},
 			},
 			Want: `
-				# HELP kube_pod_completion_time [STABLE] Completion time in unix timestamp for a pod.
-				# HELP kube_pod_created [STABLE] Unix creation timestamp
-				# HELP kube_pod_info [STABLE] Information about pod.
-				# HELP kube_pod_owner [STABLE] Information about the Pod's owner.
-				# HELP kube_pod_start_time [STABLE] Start time in unix timestamp for a pod.
-				# TYPE kube_pod_completion_time gauge
-				# TYPE kube_pod_created gauge
-				# TYPE kube_pod_info gauge
-				# TYPE kube_pod_owner gauge
-				# TYPE kube_pod_start_time gauge
-				kube_pod_info{created_by_kind="ReplicaSet",created_by_name="rs-name",host_ip="1.1.1.1",namespace="ns2",node="node2",pod="pod2",pod_ip="2.3.4.5",uid="abc-456-xxx",priority_class="",host_network="false"} 1
-				kube_pod_completion_time{namespace="ns2",pod="pod2",uid="abc-456-xxx"} 1.501888018e+09
-				kube_pod_owner{namespace="ns2",owner_is_controller="true",owner_kind="ReplicaSet",owner_name="rs-name",pod="pod2",uid="abc-456-xxx"} 1
-				`,
-			MetricNames: []string{"kube_pod_created", "kube_pod_info", "kube_pod_start_time", "kube_pod_completion_time", "kube_pod_owner"},
+				# HELP kube_pod_container_count The number of containers of a pod by their current state.
+				# TYPE kube_pod_container_count gauge
+				kube_pod_container_count{namespace="ns1",pod="pod1",state="running",uid="uid1"} 1
+				kube_pod_container_count{namespace="ns1",pod="pod1",state="terminated",uid="uid1"} 0
+				kube_pod_container_count{namespace="ns1",pod="pod1",state="waiting",uid="uid1"} 1
+			`,
+			MetricNames: []string{
+				"kube_pod_container_count",
+			},
 		},
 		{
 			Obj: &v1.Pod{
@@ -1218,20 +1241,18 @@ func TestPodStore(t *testing.T) {
 					Namespace: "ns1",
 					UID:       "uid1",
 				},
-				Status: v1.PodStatus{
-					Phase: v1.PodRunning,
+				Spec: v1.PodSpec{
+					HostPID: true,
 				},
 			},
 			Want: `
-				# HELP kube_pod_status_phase [STABLE] The pods current phase.
-				# TYPE kube_pod_status_phase gauge
-				kube_pod_status_phase{namespace="ns1",phase="Failed",pod="pod1",uid="uid1"} 0
-				kube_pod_status_phase{namespace="ns1",phase="Pending",pod="pod1",uid="uid1"} 0
-				kube_pod_status_phase{namespace="ns1",phase="Running",pod="pod1",uid="uid1"} 1
-				kube_pod_status_phase{namespace="ns1",phase="Succeeded",pod="pod1",uid="uid1"} 0
-				kube_pod_status_phase{namespace="ns1",phase="Unknown",pod="pod1",uid="uid1"} 0
-`,
-			MetricNames: []string{"kube_pod_status_phase"},
+				# HELP kube_pod_spec_host_namespaces Describes whether a pod uses the network, PID and IPC namespaces of its host.
+				# TYPE kube_pod_spec_host_namespaces gauge
+				kube_pod_spec_host_namespaces{host_ipc="false",host_network="false",host_pid="true",namespace="ns1",pod="pod1",uid="uid1"} 1
+			`,
+			MetricNames: []string{
+				"kube_pod_spec_host_namespaces",
+			},
 		},
 		{
 			Obj: &v1.Pod{
@@ -1240,74 +1261,60 @@ func TestPodStore(t *testing.T) {
 					Namespace: "ns2",
 					UID:       "uid2",
 				},
-				Status: v1.PodStatus{
-					Phase: v1.PodPending,
+				Spec: v1.PodSpec{
+					HostNetwork: true,
+					HostIPC:     true,
 				},
 			},
 			Want: `
-				# HELP kube_pod_status_phase [STABLE] The pods current phase.
-				# TYPE kube_pod_status_phase gauge
-				kube_pod_status_phase{namespace="ns2",phase="Failed",pod="pod2",uid="uid2"} 0
-				kube_pod_status_phase{namespace="ns2",phase="Pending",pod="pod2",uid="uid2"} 1
-				kube_pod_status_phase{namespace="ns2",phase="Running",pod="pod2",uid="uid2"} 0
-				kube_pod_status_phase{namespace="ns2",phase="Succeeded",pod="pod2",uid="uid2"} 0
-				kube_pod_status_phase{namespace="ns2",phase="Unknown",pod="pod2",uid="uid2"} 0
-`,
-			MetricNames: []string{"kube_pod_status_phase"},
+				# HELP kube_pod_spec_host_namespaces Describes whether a pod uses the network, PID and IPC namespaces of its host.
+				# TYPE kube_pod_spec_host_namespaces gauge
+				kube_pod_spec_host_namespaces{host_ipc="true",host_network="true",host_pid="false",namespace="ns2",pod="pod2",uid="uid2"} 1
+			`,
+			MetricNames: []string{
+				"kube_pod_spec_host_namespaces",
+			},
 		},
 		{
-
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod3",
-					Namespace: "ns3",
-					UID:       "uid3",
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
 				},
 				Status: v1.PodStatus{
-					Phase: v1.PodUnknown,
-				},
-			},
-			Want: `
-				# HELP kube_pod_status_phase [STABLE] The pods current phase.
-				# TYPE kube_pod_status_phase gauge
-				kube_pod_status_phase{namespace="ns3",phase="Failed",pod="pod3",uid="uid3"} 0
-				kube_pod_status_phase{namespace="ns3",phase="Pending",pod="pod3",uid="uid3"} 0
-				kube_pod_status_phase{namespace="ns3",phase="Running",pod="pod3",uid="uid3"} 0
-				kube_pod_status_phase{namespace="ns3",phase="Succeeded",pod="pod3",uid="uid3"} 0
-				kube_pod_status_phase{namespace="ns3",phase="Unknown",pod="pod3",uid="uid3"} 1
-`,
-			MetricNames: []string{"kube_pod_status_phase"},
-		},
-		{
-			Obj: &v1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:              "pod4",
-					Namespace:         "ns4",
-					UID:               "uid4",
-					DeletionTimestamp: &metav1.Time{},
-				},
-				Status: v1.PodStatus{
-					Phase:  v1.PodRunning,
-					Reason: "NodeLost",
+					Conditions: []v1.PodCondition{
+						{
+							Type:   v1.PodReady,
+							Status: v1.ConditionTrue,
+						},
+						{
+							Type:   "example.com/feature-ready",
+							Status: v1.ConditionFalse,
+						},
+						{
+							Type:   "keda.sh/scaled",
+							Status: v1.ConditionUnknown,
+						},
+					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_status_phase [STABLE] The pods current phase.
-				# HELP kube_pod_status_reason The pod status reasons
-				# TYPE kube_pod_status_phase gauge
-				# TYPE kube_pod_status_reason gauge
-				kube_pod_status_phase{namespace="ns4",phase="Failed",pod="pod4",uid="uid4"} 0
-				kube_pod_status_phase{namespace="ns4",phase="Pending",pod="pod4",uid="uid4"} 0
-				kube_pod_status_phase{namespace="ns4",phase="Running",pod="pod4",uid="uid4"} 1
-				kube_pod_status_phase{namespace="ns4",phase="Succeeded",pod="pod4",uid="uid4"} 0
-				kube_pod_status_phase{namespace="ns4",phase="Unknown",pod="pod4",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Evicted",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeAffinity",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeLost",uid="uid4"} 1
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Shutdown",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="UnexpectedAdmissionError",uid="uid4"} 0
-`,
-			MetricNames: []string{"kube_pod_status_phase", "kube_pod_status_reason"},
+				# HELP kube_pod_status_condition The condition of a pod.
+				# TYPE kube_pod_status_condition gauge
+				kube_pod_status_condition{condition="Ready",namespace="ns1",pod="pod1",status="false",uid="uid1"} 0
+				kube_pod_status_condition{condition="Ready",namespace="ns1",pod="pod1",status="true",uid="uid1"} 1
+				kube_pod_status_condition{condition="Ready",namespace="ns1",pod="pod1",status="unknown",uid="uid1"} 0
+				kube_pod_status_condition{condition="example.com/feature-ready",namespace="ns1",pod="pod1",status="false",uid="uid1"} 1
+				kube_pod_status_condition{condition="example.com/feature-ready",namespace="ns1",pod="pod1",status="true",uid="uid1"} 0
+				kube_pod_status_condition{condition="example.com/feature-ready",namespace="ns1",pod="pod1",status="unknown",uid="uid1"} 0
+				kube_pod_status_condition{condition="keda.sh/scaled",namespace="ns1",pod="pod1",status="false",uid="uid1"} 0
+				kube_pod_status_condition{condition="keda.sh/scaled",namespace="ns1",pod="pod1",status="true",uid="uid1"} 0
+				kube_pod_status_condition{condition="keda.sh/scaled",namespace="ns1",pod="pod1",status="unknown",uid="uid1"} 1
+			`,
+			MetricNames: []string{
+				"kube_pod_status_condition",
+			},
 		},
 		{
 			Obj: &v1.Pod{
@@ -1316,164 +1323,1308 @@ func TestPodStore(t *testing.T) {
 					Namespace: "ns1",
 					UID:       "uid1",
 				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:                     "container1",
+							TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
+						},
+						{
+							Name:                     "container2",
+							TerminationMessagePolicy: v1.TerminationMessageReadFile,
+						},
+						{
+							Name:                     "container3",
+							TerminationMessagePolicy: v1.TerminationMessageReadFile,
+						},
+					},
+				},
 				Status: v1.PodStatus{
-					QOSClass: v1.PodQOSBestEffort,
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							Name: "container1",
+							LastTerminationState: v1.ContainerState{
+								Terminated: &v1.ContainerStateTerminated{
+									Reason:   "OOMKilled",
+									ExitCode: 137,
+									Message:  "java.lang.OutOfMemoryError: Java heap space\nat Main.main(Main.java:5)",
+								},
+							},
+						},
+						{
+							Name: "container2",
+							LastTerminationState: v1.ContainerState{
+								Terminated: &v1.ContainerStateTerminated{
+									Reason:   "Error",
+									ExitCode: 1,
+								},
+							},
+						},
+						{
+							Name: "container3",
+							LastTerminationState: v1.ContainerState{
+								Terminated: &v1.ContainerStateTerminated{
+									Reason:   "Error",
+									ExitCode: 1,
+									Message:  strings.Repeat("x", 300),
+								},
+							},
+						},
+					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_status_qos_class The pods current qosClass.
-				# TYPE kube_pod_status_qos_class gauge
-				kube_pod_status_qos_class{namespace="ns1",qos_class="BestEffort",pod="pod1",uid="uid1"} 1
-				kube_pod_status_qos_class{namespace="ns1",qos_class="Burstable",pod="pod1",uid="uid1"} 0
-				kube_pod_status_qos_class{namespace="ns1",qos_class="Guaranteed",pod="pod1",uid="uid1"} 0
-`,
-			MetricNames: []string{"kube_pod_status_qos_class"},
+				# HELP kube_pod_container_spec_termination_message_policy Describes how the termination message of a container is populated.
+				# HELP kube_pod_container_status_last_terminated_message Describes the message of the last termination of the container, truncated to 256 bytes.
+				# TYPE kube_pod_container_spec_termination_message_policy gauge
+				# TYPE kube_pod_container_status_last_terminated_message gauge
+				kube_pod_container_spec_termination_message_policy{container="container1",namespace="ns1",pod="pod1",policy="FallbackToLogsOnError",uid="uid1"} 1
+				kube_pod_container_spec_termination_message_policy{container="container2",namespace="ns1",pod="pod1",policy="File",uid="uid1"} 1
+				kube_pod_container_spec_termination_message_policy{container="container3",namespace="ns1",pod="pod1",policy="File",uid="uid1"} 1
+				kube_pod_container_status_last_terminated_message{container="container1",message="java.lang.OutOfMemoryError: Java heap space\nat Main.main(Main.java:5)",namespace="ns1",pod="pod1",uid="uid1"} 1
+				kube_pod_container_status_last_terminated_message{container="container3",message="` + strings.Repeat("x", 256) + `",namespace="ns1",pod="pod1",uid="uid1"} 1
+			`,
+			MetricNames: []string{
+				"kube_pod_container_spec_termination_message_policy",
+				"kube_pod_container_status_last_terminated_message",
+			},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:              "pod4",
-					Namespace:         "ns4",
-					UID:               "uid4",
-					DeletionTimestamp: &metav1.Time{},
+					Name:      "pod7",
+					Namespace: "ns7",
+					UID:       "uid7",
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  "container7",
+							Image: "k8s.gcr.io/hyperkube7_spec",
+						},
+					},
 				},
 				Status: v1.PodStatus{
-					Phase:  v1.PodRunning,
-					Reason: "Evicted",
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							Name: "container7",
+							State: v1.ContainerState{
+								Running: &v1.ContainerStateRunning{
+									StartedAt: metav1.Time{
+										Time: time.Unix(1501777018, 0),
+									},
+								},
+							},
+							LastTerminationState: v1.ContainerState{
+								Terminated: &v1.ContainerStateTerminated{
+									Reason:   "DeadlineExceeded",
+									ExitCode: 143,
+									FinishedAt: metav1.Time{
+										Time: time.Unix(1501779547, 0),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_status_reason The pod status reasons
-				# TYPE kube_pod_status_reason gauge
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Evicted",uid="uid4"} 1
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeAffinity",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeLost",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Shutdown",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="UnexpectedAdmissionError",uid="uid4"} 0
-`,
-			MetricNames: []string{"kube_pod_status_reason"},
+				# HELP kube_pod_container_status_last_terminated_exitcode Describes the exit code for the last container in terminated state.
+				# HELP kube_pod_container_status_last_terminated_reason Describes the last reason the container was in terminated state.
+				# HELP kube_pod_container_status_last_terminated_timestamp Last terminated time for a pod container in unix timestamp.
+				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
+				# HELP kube_pod_container_state_started [STABLE] Start time in unix timestamp for a pod container.
+				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
+				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
+				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
+				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
+				# TYPE kube_pod_container_status_last_terminated_exitcode gauge
+				# TYPE kube_pod_container_status_last_terminated_reason gauge
+				# TYPE kube_pod_container_status_last_terminated_timestamp gauge
+				# TYPE kube_pod_container_status_running gauge
+				# TYPE kube_pod_container_state_started gauge
+				# TYPE kube_pod_container_status_terminated gauge
+				# TYPE kube_pod_container_status_terminated_reason gauge
+				# TYPE kube_pod_container_status_waiting gauge
+				# TYPE kube_pod_container_status_waiting_reason gauge
+				kube_pod_container_state_started{container="container7",namespace="ns7",pod="pod7",uid="uid7"} 1.501777018e+09
+				kube_pod_container_status_last_terminated_exitcode{container="container7",namespace="ns7",pod="pod7",uid="uid7"} 143
+				kube_pod_container_status_last_terminated_reason{container="container7",namespace="ns7",pod="pod7",reason="DeadlineExceeded",uid="uid7"} 1
+				kube_pod_container_status_last_terminated_timestamp{container="container7",namespace="ns7",pod="pod7",uid="uid7"} 1.501779547e+09
+				kube_pod_container_status_running{container="container7",namespace="ns7",pod="pod7",uid="uid7"} 1
+				kube_pod_container_status_terminated{container="container7",namespace="ns7",pod="pod7",uid="uid7"} 0
+				kube_pod_container_status_waiting{container="container7",namespace="ns7",pod="pod7",uid="uid7"} 0
+			`,
+			MetricNames: []string{
+				"kube_pod_container_status_running",
+				"kube_pod_container_state_started",
+				"kube_pod_container_status_terminated",
+				"kube_pod_container_status_terminated_reason",
+				"kube_pod_container_status_last_terminated_timestamp",
+				"kube_pod_container_status_waiting",
+				"kube_pod_container_status_last_terminated_reason",
+				"kube_pod_container_status_last_terminated_exitcode",
+			},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:              "pod4",
-					Namespace:         "ns4",
-					UID:               "uid4",
-					DeletionTimestamp: &metav1.Time{},
+					Name:      "pod4",
+					Namespace: "ns4",
+					UID:       "uid4",
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  "container5",
+							Image: "k8s.gcr.io/hyperkube5_spec",
+						},
+					},
 				},
 				Status: v1.PodStatus{
-					Phase:  v1.PodRunning,
-					Reason: "UnexpectedAdmissionError",
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							Name: "container5",
+							State: v1.ContainerState{
+								Waiting: &v1.ContainerStateWaiting{
+									Reason: "ImagePullBackOff",
+								},
+							},
+						},
+					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_status_reason The pod status reasons
-				# TYPE kube_pod_status_reason gauge
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Evicted",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeAffinity",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeLost",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Shutdown",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="UnexpectedAdmissionError",uid="uid4"} 1
+				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
+				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
+				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
+				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
+				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
+				# TYPE kube_pod_container_status_running gauge
+				# TYPE kube_pod_container_status_terminated gauge
+				# TYPE kube_pod_container_status_terminated_reason gauge
+				# TYPE kube_pod_container_status_waiting gauge
+				# TYPE kube_pod_container_status_waiting_reason gauge
+				kube_pod_container_status_running{container="container5",namespace="ns4",pod="pod4",uid="uid4"} 0
+				kube_pod_container_status_terminated{container="container5",namespace="ns4",pod="pod4",uid="uid4"} 0
+				kube_pod_container_status_waiting_reason{container="container5",namespace="ns4",pod="pod4",reason="ImagePullBackOff",uid="uid4"} 1
+				kube_pod_container_status_waiting{container="container5",namespace="ns4",pod="pod4",uid="uid4"} 1
 `,
-			MetricNames: []string{"kube_pod_status_reason"},
+			MetricNames: []string{
+				"kube_pod_container_status_running",
+				"kube_pod_container_status_waiting",
+				"kube_pod_container_status_waiting_reason",
+				"kube_pod_container_status_terminated",
+				"kube_pod_container_status_terminated_reason",
+			},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:              "pod4",
-					Namespace:         "ns4",
-					UID:               "uid4",
-					DeletionTimestamp: &metav1.Time{},
+					Name:      "pod5",
+					Namespace: "ns5",
+					UID:       "uid5",
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  "container6",
+							Image: "k8s.gcr.io/hyperkube1_spec",
+						},
+					},
 				},
 				Status: v1.PodStatus{
-					Phase:  v1.PodRunning,
-					Reason: "NodeAffinity",
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							Name: "container6",
+							State: v1.ContainerState{
+								Waiting: &v1.ContainerStateWaiting{
+									Reason: "ErrImagePull",
+								},
+							},
+						},
+					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_status_reason The pod status reasons
-				# TYPE kube_pod_status_reason gauge
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Evicted",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeAffinity",uid="uid4"} 1
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeLost",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Shutdown",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="UnexpectedAdmissionError",uid="uid4"} 0
-`,
-			MetricNames: []string{"kube_pod_status_reason"},
+				# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
+				# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
+				# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
+				# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
+				# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
+				# TYPE kube_pod_container_status_running gauge
+				# TYPE kube_pod_container_status_terminated gauge
+				# TYPE kube_pod_container_status_terminated_reason gauge
+				# TYPE kube_pod_container_status_waiting gauge
+				# TYPE kube_pod_container_status_waiting_reason gauge
+				kube_pod_container_status_running{container="container6",namespace="ns5",pod="pod5",uid="uid5"} 0
+				kube_pod_container_status_terminated{container="container6",namespace="ns5",pod="pod5",uid="uid5"} 0
+				kube_pod_container_status_waiting_reason{container="container6",namespace="ns5",pod="pod5",reason="ErrImagePull",uid="uid5"} 1
+				kube_pod_container_status_waiting{container="container6",namespace="ns5",pod="pod5",uid="uid5"} 1
+			`,
+			MetricNames: []string{
+				"kube_pod_container_status_running",
+				"kube_pod_container_status_waiting",
+				"kube_pod_container_status_waiting_reason",
+				"kube_pod_container_status_terminated",
+				"kube_pod_container_status_terminated_reason",
+			},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:              "pod4",
-					Namespace:         "ns4",
-					UID:               "uid4",
-					DeletionTimestamp: &metav1.Time{},
+					Name:      "pod7",
+					Namespace: "ns7",
+					UID:       "uid7",
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  "container8",
+							Image: "k8s.gcr.io/hyperkube1_spec",
+						},
+					},
 				},
 				Status: v1.PodStatus{
-					Phase:  v1.PodRunning,
-					Reason: "Shutdown",
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							Name: "container8",
+							State: v1.ContainerState{
+								Waiting: &v1.ContainerStateWaiting{
+									Reason: "CreateContainerConfigError",
+								},
+							},
+						},
+					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_status_reason The pod status reasons
-				# TYPE kube_pod_status_reason gauge
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Evicted",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeAffinity",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeLost",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Shutdown",uid="uid4"} 1
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="UnexpectedAdmissionError",uid="uid4"} 0
-`,
-			MetricNames: []string{"kube_pod_status_reason"},
+					# HELP kube_pod_container_status_running [STABLE] Describes whether the container is currently in running state.
+					# HELP kube_pod_container_status_terminated [STABLE] Describes whether the container is currently in terminated state.
+					# HELP kube_pod_container_status_terminated_reason Describes the reason the container is currently in terminated state.
+					# HELP kube_pod_container_status_waiting [STABLE] Describes whether the container is currently in waiting state.
+					# HELP kube_pod_container_status_waiting_reason [STABLE] Describes the reason the container is currently in waiting state.
+					# TYPE kube_pod_container_status_running gauge
+					# TYPE kube_pod_container_status_terminated gauge
+					# TYPE kube_pod_container_status_terminated_reason gauge
+					# TYPE kube_pod_container_status_waiting gauge
+					# TYPE kube_pod_container_status_waiting_reason gauge
+					kube_pod_container_status_running{container="container8",namespace="ns7",pod="pod7",uid="uid7"} 0
+					kube_pod_container_status_terminated{container="container8",namespace="ns7",pod="pod7",uid="uid7"} 0
+					kube_pod_container_status_waiting_reason{container="container8",namespace="ns7",pod="pod7",reason="CreateContainerConfigError",uid="uid7"} 1
+					kube_pod_container_status_waiting{container="container8",namespace="ns7",pod="pod7",uid="uid7"} 1
+			`,
+			MetricNames: []string{
+				"kube_pod_container_status_running",
+				"kube_pod_container_status_terminated",
+				"kube_pod_container_status_terminated_reason",
+				"kube_pod_container_status_waiting",
+				"kube_pod_container_status_waiting_reason",
+			},
 		},
 		{
+
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:              "pod4",
-					Namespace:         "ns4",
-					UID:               "uid4",
-					DeletionTimestamp: &metav1.Time{},
+					Name:              "pod1",
+					CreationTimestamp: metav1.Time{Time: time.Unix(1500000000, 0)},
+					Namespace:         "ns1",
+					UID:               "abc-123-xxx",
+				},
+				Spec: v1.PodSpec{
+					NodeName:          "node1",
+					PriorityClassName: "system-node-critical",
+					HostNetwork:       true,
+					Containers: []v1.Container{
+						{
+							Image: "k8s.gcr.io/hyperkube2_spec",
+						},
+					},
 				},
 				Status: v1.PodStatus{
-					Phase:  v1.PodRunning,
-					Reason: "other reason",
+					HostIP: "1.1.1.1",
+					PodIP:  "1.2.3.4",
+					PodIPs: []v1.PodIP{
+						{
+							IP: "1.2.3.4",
+						},
+						{
+							IP: "fc00:1234:5678:90ab:cdef:cafe:f00d:d00d",
+						},
+					},
+					StartTime: &metav1StartTime,
 				},
 			},
+			// TODO: Should it be '1501569018' instead?
 			Want: `
-				# HELP kube_pod_status_reason The pod status reasons
-				# TYPE kube_pod_status_reason gauge
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Evicted",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeAffinity",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeLost",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Shutdown",uid="uid4"} 0
-				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="UnexpectedAdmissionError",uid="uid4"} 0
+				# HELP kube_pod_completion_time [STABLE] Completion time in unix timestamp for a pod.
+				# HELP kube_pod_created [STABLE] Unix creation timestamp
+				# HELP kube_pod_info [STABLE] Information about pod.
+				# HELP kube_pod_ips Pod IP addresses
+				# HELP kube_pod_owner [STABLE] Information about the Pod's owner.
+				# HELP kube_pod_start_time [STABLE] Start time in unix timestamp for a pod.
+				# TYPE kube_pod_completion_time gauge
+				# TYPE kube_pod_created gauge
+				# TYPE kube_pod_info gauge
+				# TYPE kube_pod_ips gauge
+				# TYPE kube_pod_owner gauge
+				# TYPE kube_pod_start_time gauge
+				kube_pod_created{namespace="ns1",pod="pod1",uid="abc-123-xxx"} 1.5e+09
+				kube_pod_info{created_by_kind="",created_by_name="",host_ip="1.1.1.1",namespace="ns1",node="node1",pod="pod1",pod_ip="1.2.3.4",uid="abc-123-xxx",priority_class="system-node-critical",host_network="true"} 1
+				kube_pod_ips{namespace="ns1",pod="pod1",uid="abc-123-xxx",ip="1.2.3.4",ip_family="4"} 1
+				kube_pod_ips{namespace="ns1",pod="pod1",uid="abc-123-xxx",ip="fc00:1234:5678:90ab:cdef:cafe:f00d:d00d",ip_family="6"} 1
+				kube_pod_start_time{namespace="ns1",pod="pod1",uid="abc-123-xxx"} 1.501569018e+09
+				kube_pod_owner{namespace="ns1",owner_is_controller="",owner_kind="",owner_name="",pod="pod1",uid="abc-123-xxx"} 1
 `,
-			MetricNames: []string{"kube_pod_status_reason"},
+			MetricNames: []string{"kube_pod_created", "kube_pod_info", "kube_pod_ips", "kube_pod_start_time", "kube_pod_completion_time", "kube_pod_owner"},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod1",
-					Namespace: "ns1",
-					UID:       "uid1",
+					Name:              "pod1",
+					CreationTimestamp: metav1.Time{Time: time.Unix(1500000000, 0)},
+					Namespace:         "ns1",
+					UID:               "abc-123-xxx",
+					DeletionTimestamp: &metav1.Time{Time: time.Unix(1800000000, 0)},
+				},
+				Spec: v1.PodSpec{
+					NodeName:          "node1",
+					PriorityClassName: "system-node-critical",
 				},
 				Status: v1.PodStatus{
-					Conditions: []v1.PodCondition{
+					HostIP:    "1.1.1.1",
+					PodIP:     "1.2.3.4",
+					StartTime: &metav1StartTime,
+				},
+			},
+			Want: `
+				# HELP kube_pod_deletion_timestamp Unix deletion timestamp
+				# TYPE kube_pod_deletion_timestamp gauge
+				kube_pod_deletion_timestamp{namespace="ns1",pod="pod1",uid="abc-123-xxx"} 1.8e+09
+`,
+			MetricNames: []string{"kube_pod_deletion_timestamp"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod2",
+					Namespace: "ns2",
+					UID:       "uid2",
+				},
+				Spec: v1.PodSpec{
+					RestartPolicy: v1.RestartPolicyAlways,
+				},
+			},
+			Want: `
+				# HELP kube_pod_restart_policy [STABLE] Describes the restart policy in use by this pod.
+				# TYPE kube_pod_restart_policy gauge
+				kube_pod_restart_policy{namespace="ns2",pod="pod2",type="Always",uid="uid2"} 1
+				`,
+			MetricNames: []string{"kube_pod_restart_policy"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod2",
+					Namespace: "ns2",
+					UID:       "uid2",
+				},
+				Spec: v1.PodSpec{
+					RestartPolicy: v1.RestartPolicyOnFailure,
+				},
+			},
+			Want: `
+				# HELP kube_pod_restart_policy [STABLE] Describes the restart policy in use by this pod.
+				# TYPE kube_pod_restart_policy gauge
+				kube_pod_restart_policy{namespace="ns2",pod="pod2",type="OnFailure",uid="uid2"} 1
+				`,
+			MetricNames: []string{"kube_pod_restart_policy"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod2",
+					Namespace: "ns2",
+					UID:       "abc-456-xxx",
+					OwnerReferences: []metav1.OwnerReference{
 						{
-							Type:   v1.PodInitialized,
-							Status: v1.ConditionTrue,
-							LastTransitionTime: metav1.Time{
-								Time: time.Unix(1501666018, 0),
-							},
+							Kind:       "ReplicaSet",
+							Name:       "rs-name",
+							Controller: &test,
+						},
+					},
+				},
+				Spec: v1.PodSpec{
+					NodeName: "node2",
+					Containers: []v1.Container{
+						{
+							Name:  "container2_1",
+							Image: "k8s.gcr.io/hyperkube2_spec",
+						},
+						{
+							Name:  "container2_2",
+							Image: "k8s.gcr.io/hyperkube2_spec",
+						},
+						{
+							Name:  "container2_3",
+							Image: "k8s.gcr.io/hyperkube2_spec",
 						},
 					},
 				},
+				Status: v1.PodStatus{
+					HostIP: "1.1.1.1",
+					PodIP:  "2.3.4.5",
+					ContainerStatuses: []v1.ContainerStatus{
+						{
+							Name:        "container2_1",
+							Image:       "k8s.gcr.io/hyperkube2",
+							ImageID:     "docker://sha256:bbb",
+							ContainerID: "docker://cd456",
+							State: v1.ContainerState{
+								Terminated: &v1.ContainerStateTerminated{
+									FinishedAt: metav1.Time{
+										Time: time.Unix(1501777018, 0),
+									},
+								},
+							},
+						},
+						{
+							Name:        "container2_2",
+							Image:       "k8s.gcr.io/hyperkube2",
+							ImageID:     "docker://sha256:bbb",
+							ContainerID: "docker://cd456",
+							State: v1.ContainerState{
+								Terminated: &v1.ContainerStateTerminated{
+									FinishedAt: metav1.Time{
+										Time: time.Unix(1501888018, 0),
+									},
+								},
+							},
+						},
+						{
+							Name:        "container2_3",
+							Image:       "k8s.gcr.io/hyperkube2",
+							ImageID:     "docker://sha256:bbb",
+							ContainerID: "docker://cd456",
+							State: v1.ContainerState{
+								Terminated: &v1.ContainerStateTerminated{
+									FinishedAt: metav1.Time{
+										Time: time.Unix(1501666018, 0),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_completion_time [STABLE] Completion time in unix timestamp for a pod.
+				# HELP kube_pod_created [STABLE] Unix creation timestamp
+				# HELP kube_pod_info [STABLE] Information about pod.
+				# HELP kube_pod_owner [STABLE] Information about the Pod's owner.
+				# HELP kube_pod_start_time [STABLE] Start time in unix timestamp for a pod.
+				# TYPE kube_pod_completion_time gauge
+				# TYPE kube_pod_created gauge
+				# TYPE kube_pod_info gauge
+				# TYPE kube_pod_owner gauge
+				# TYPE kube_pod_start_time gauge
+				kube_pod_info{created_by_kind="ReplicaSet",created_by_name="rs-name",host_ip="1.1.1.1",namespace="ns2",node="node2",pod="pod2",pod_ip="2.3.4.5",uid="abc-456-xxx",priority_class="",host_network="false"} 1
+				kube_pod_completion_time{namespace="ns2",pod="pod2",uid="abc-456-xxx"} 1.501888018e+09
+				kube_pod_owner{namespace="ns2",owner_is_controller="true",owner_kind="ReplicaSet",owner_name="rs-name",pod="pod2",uid="abc-456-xxx"} 1
+				`,
+			MetricNames: []string{"kube_pod_created", "kube_pod_info", "kube_pod_start_time", "kube_pod_completion_time", "kube_pod_owner"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+				},
+				Status: v1.PodStatus{
+					Phase: v1.PodRunning,
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_phase [STABLE] The pods current phase.
+				# TYPE kube_pod_status_phase gauge
+				kube_pod_status_phase{namespace="ns1",phase="Failed",pod="pod1",uid="uid1"} 0
+				kube_pod_status_phase{namespace="ns1",phase="Pending",pod="pod1",uid="uid1"} 0
+				kube_pod_status_phase{namespace="ns1",phase="Running",pod="pod1",uid="uid1"} 1
+				kube_pod_status_phase{namespace="ns1",phase="Succeeded",pod="pod1",uid="uid1"} 0
+				kube_pod_status_phase{namespace="ns1",phase="Unknown",pod="pod1",uid="uid1"} 0
+`,
+			MetricNames: []string{"kube_pod_status_phase"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod2",
+					Namespace: "ns2",
+					UID:       "uid2",
+				},
+				Status: v1.PodStatus{
+					Phase: v1.PodPending,
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_phase [STABLE] The pods current phase.
+				# TYPE kube_pod_status_phase gauge
+				kube_pod_status_phase{namespace="ns2",phase="Failed",pod="pod2",uid="uid2"} 0
+				kube_pod_status_phase{namespace="ns2",phase="Pending",pod="pod2",uid="uid2"} 1
+				kube_pod_status_phase{namespace="ns2",phase="Running",pod="pod2",uid="uid2"} 0
+				kube_pod_status_phase{namespace="ns2",phase="Succeeded",pod="pod2",uid="uid2"} 0
+				kube_pod_status_phase{namespace="ns2",phase="Unknown",pod="pod2",uid="uid2"} 0
+`,
+			MetricNames: []string{"kube_pod_status_phase"},
+		},
+		{
+
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod3",
+					Namespace: "ns3",
+					UID:       "uid3",
+				},
+				Status: v1.PodStatus{
+					Phase: v1.PodUnknown,
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_phase [STABLE] The pods current phase.
+				# TYPE kube_pod_status_phase gauge
+				kube_pod_status_phase{namespace="ns3",phase="Failed",pod="pod3",uid="uid3"} 0
+				kube_pod_status_phase{namespace="ns3",phase="Pending",pod="pod3",uid="uid3"} 0
+				kube_pod_status_phase{namespace="ns3",phase="Running",pod="pod3",uid="uid3"} 0
+				kube_pod_status_phase{namespace="ns3",phase="Succeeded",pod="pod3",uid="uid3"} 0
+				kube_pod_status_phase{namespace="ns3",phase="Unknown",pod="pod3",uid="uid3"} 1
+`,
+			MetricNames: []string{"kube_pod_status_phase"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "pod4",
+					Namespace:         "ns4",
+					UID:               "uid4",
+					DeletionTimestamp: &metav1.Time{},
+				},
+				Status: v1.PodStatus{
+					Phase:  v1.PodRunning,
+					Reason: "NodeLost",
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_phase [STABLE] The pods current phase.
+				# HELP kube_pod_status_reason The pod status reasons
+				# TYPE kube_pod_status_phase gauge
+				# TYPE kube_pod_status_reason gauge
+				kube_pod_status_phase{namespace="ns4",phase="Failed",pod="pod4",uid="uid4"} 0
+				kube_pod_status_phase{namespace="ns4",phase="Pending",pod="pod4",uid="uid4"} 0
+				kube_pod_status_phase{namespace="ns4",phase="Running",pod="pod4",uid="uid4"} 1
+				kube_pod_status_phase{namespace="ns4",phase="Succeeded",pod="pod4",uid="uid4"} 0
+				kube_pod_status_phase{namespace="ns4",phase="Unknown",pod="pod4",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Evicted",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeAffinity",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeLost",uid="uid4"} 1
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Shutdown",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="UnexpectedAdmissionError",uid="uid4"} 0
+`,
+			MetricNames: []string{"kube_pod_status_phase", "kube_pod_status_reason"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+				},
+				Status: v1.PodStatus{
+					QOSClass: v1.PodQOSBestEffort,
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_qos_class The pods current qosClass.
+				# TYPE kube_pod_status_qos_class gauge
+				kube_pod_status_qos_class{namespace="ns1",qos_class="BestEffort",pod="pod1",uid="uid1"} 1
+				kube_pod_status_qos_class{namespace="ns1",qos_class="Burstable",pod="pod1",uid="uid1"} 0
+				kube_pod_status_qos_class{namespace="ns1",qos_class="Guaranteed",pod="pod1",uid="uid1"} 0
+`,
+			MetricNames: []string{"kube_pod_status_qos_class"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "pod4",
+					Namespace:         "ns4",
+					UID:               "uid4",
+					DeletionTimestamp: &metav1.Time{},
+				},
+				Status: v1.PodStatus{
+					Phase:  v1.PodRunning,
+					Reason: "Evicted",
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_reason The pod status reasons
+				# TYPE kube_pod_status_reason gauge
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Evicted",uid="uid4"} 1
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeAffinity",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeLost",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Shutdown",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="UnexpectedAdmissionError",uid="uid4"} 0
+`,
+			MetricNames: []string{"kube_pod_status_reason"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "pod4",
+					Namespace:         "ns4",
+					UID:               "uid4",
+					DeletionTimestamp: &metav1.Time{},
+				},
+				Status: v1.PodStatus{
+					Phase:  v1.PodRunning,
+					Reason: "UnexpectedAdmissionError",
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_reason The pod status reasons
+				# TYPE kube_pod_status_reason gauge
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Evicted",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeAffinity",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeLost",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Shutdown",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="UnexpectedAdmissionError",uid="uid4"} 1
+`,
+			MetricNames: []string{"kube_pod_status_reason"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "pod4",
+					Namespace:         "ns4",
+					UID:               "uid4",
+					DeletionTimestamp: &metav1.Time{},
+				},
+				Status: v1.PodStatus{
+					Phase:  v1.PodRunning,
+					Reason: "NodeAffinity",
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_reason The pod status reasons
+				# TYPE kube_pod_status_reason gauge
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Evicted",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeAffinity",uid="uid4"} 1
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeLost",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Shutdown",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="UnexpectedAdmissionError",uid="uid4"} 0
+`,
+			MetricNames: []string{"kube_pod_status_reason"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "pod4",
+					Namespace:         "ns4",
+					UID:               "uid4",
+					DeletionTimestamp: &metav1.Time{},
+				},
+				Status: v1.PodStatus{
+					Phase:  v1.PodRunning,
+					Reason: "Shutdown",
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_reason The pod status reasons
+				# TYPE kube_pod_status_reason gauge
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Evicted",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeAffinity",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeLost",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Shutdown",uid="uid4"} 1
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="UnexpectedAdmissionError",uid="uid4"} 0
+`,
+			MetricNames: []string{"kube_pod_status_reason"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "pod4",
+					Namespace:         "ns4",
+					UID:               "uid4",
+					DeletionTimestamp: &metav1.Time{},
+				},
+				Status: v1.PodStatus{
+					Phase:  v1.PodRunning,
+					Reason: "other reason",
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_reason The pod status reasons
+				# TYPE kube_pod_status_reason gauge
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Evicted",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeAffinity",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="NodeLost",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="Shutdown",uid="uid4"} 0
+				kube_pod_status_reason{namespace="ns4",pod="pod4",reason="UnexpectedAdmissionError",uid="uid4"} 0
+`,
+			MetricNames: []string{"kube_pod_status_reason"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
+						{
+							Type:   v1.PodInitialized,
+							Status: v1.ConditionTrue,
+							LastTransitionTime: metav1.Time{
+								Time: time.Unix(1501666018, 0),
+							},
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_initialized_time Initialized time in unix timestamp for a pod.
+				# TYPE kube_pod_status_initialized_time gauge
+				kube_pod_status_initialized_time{namespace="ns1",pod="pod1",uid="uid1"} 1.501666018e+09
+			`,
+			MetricNames: []string{"kube_pod_status_initialized_time"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
+						{
+							Type:   v1.PodInitialized,
+							Status: v1.ConditionFalse,
+							LastTransitionTime: metav1.Time{
+								Time: time.Unix(1501666018, 0),
+							},
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_initialized_time Initialized time in unix timestamp for a pod.
+				# TYPE kube_pod_status_initialized_time gauge
+			`,
+			MetricNames: []string{"kube_pod_status_initialized_time"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
+						{
+							Type:   v1.ContainersReady,
+							Status: v1.ConditionTrue,
+							LastTransitionTime: metav1.Time{
+								Time: time.Unix(1501666018, 0),
+							},
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_container_ready_time Readiness achieved time in unix timestamp for a pod containers.
+				# TYPE kube_pod_status_container_ready_time gauge
+				kube_pod_status_container_ready_time{namespace="ns1",pod="pod1",uid="uid1"} 1.501666018e+09
+			`,
+			MetricNames: []string{"kube_pod_status_container_ready_time"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
+						{
+							Type:   v1.ContainersReady,
+							Status: v1.ConditionFalse,
+							LastTransitionTime: metav1.Time{
+								Time: time.Unix(1501666018, 0),
+							},
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_container_ready_time Readiness achieved time in unix timestamp for a pod containers.
+				# TYPE kube_pod_status_container_ready_time gauge
+			`,
+			MetricNames: []string{"kube_pod_status_container_ready_time"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
+						{
+							Type:   v1.PodReady,
+							Status: v1.ConditionTrue,
+							LastTransitionTime: metav1.Time{
+								Time: time.Unix(1501666018, 0),
+							},
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_ready [STABLE] Describes whether the pod is ready to serve requests.
+				# HELP kube_pod_status_ready_time Readiness achieved time in unix timestamp for a pod.
+				# TYPE kube_pod_status_ready gauge
+				# TYPE kube_pod_status_ready_time gauge
+				kube_pod_status_ready_time{namespace="ns1",pod="pod1",uid="uid1"} 1.501666018e+09
+				kube_pod_status_ready{condition="false",namespace="ns1",pod="pod1",uid="uid1"} 0
+				kube_pod_status_ready{condition="true",namespace="ns1",pod="pod1",uid="uid1"} 1
+				kube_pod_status_ready{condition="unknown",namespace="ns1",pod="pod1",uid="uid1"} 0
+			`,
+			MetricNames: []string{"kube_pod_status_ready_time", "kube_pod_status_ready"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod2",
+					Namespace: "ns2",
+					UID:       "uid2",
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
+						{
+							Type:   v1.PodReady,
+							Status: v1.ConditionFalse,
+							LastTransitionTime: metav1.Time{
+								Time: time.Unix(1501666018, 0),
+							},
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_ready [STABLE] Describes whether the pod is ready to serve requests.
+				# HELP kube_pod_status_ready_time Readiness achieved time in unix timestamp for a pod.
+				# TYPE kube_pod_status_ready gauge
+				# TYPE kube_pod_status_ready_time gauge
+				kube_pod_status_ready{condition="false",namespace="ns2",pod="pod2",uid="uid2"} 1
+				kube_pod_status_ready{condition="true",namespace="ns2",pod="pod2",uid="uid2"} 0
+				kube_pod_status_ready{condition="unknown",namespace="ns2",pod="pod2",uid="uid2"} 0
+			`,
+			MetricNames: []string{"kube_pod_status_ready_time", "kube_pod_status_ready"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
+						{
+							Type:   v1.PodScheduled,
+							Status: v1.ConditionTrue,
+							LastTransitionTime: metav1.Time{
+								Time: time.Unix(1501666018, 0),
+							},
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_scheduled [STABLE] Describes the status of the scheduling process for the pod.
+				# HELP kube_pod_status_scheduled_time [STABLE] Unix timestamp when pod moved into scheduled status
+				# TYPE kube_pod_status_scheduled gauge
+				# TYPE kube_pod_status_scheduled_time gauge
+				kube_pod_status_scheduled_time{namespace="ns1",pod="pod1",uid="uid1"} 1.501666018e+09
+				kube_pod_status_scheduled{condition="false",namespace="ns1",pod="pod1",uid="uid1"} 0
+				kube_pod_status_scheduled{condition="true",namespace="ns1",pod="pod1",uid="uid1"} 1
+				kube_pod_status_scheduled{condition="unknown",namespace="ns1",pod="pod1",uid="uid1"} 0
+			`,
+			MetricNames: []string{"kube_pod_status_scheduled", "kube_pod_status_scheduled_time"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod2",
+					Namespace: "ns2",
+					UID:       "uid2",
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
+						{
+							Type:   v1.PodScheduled,
+							Status: v1.ConditionFalse,
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_scheduled [STABLE] Describes the status of the scheduling process for the pod.
+				# HELP kube_pod_status_scheduled_time [STABLE] Unix timestamp when pod moved into scheduled status
+				# TYPE kube_pod_status_scheduled gauge
+				# TYPE kube_pod_status_scheduled_time gauge
+				kube_pod_status_scheduled{condition="false",namespace="ns2",pod="pod2",uid="uid2"} 1
+				kube_pod_status_scheduled{condition="true",namespace="ns2",pod="pod2",uid="uid2"} 0
+				kube_pod_status_scheduled{condition="unknown",namespace="ns2",pod="pod2",uid="uid2"} 0
+			`,
+			MetricNames: []string{"kube_pod_status_scheduled", "kube_pod_status_scheduled_time"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod2",
+					Namespace: "ns2",
+					UID:       "uid2",
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
+						{
+							Type:    v1.PodScheduled,
+							Status:  v1.ConditionFalse,
+							Reason:  "Unschedulable",
+							Message: "0/3 nodes are available: 3 Insufficient cpu.",
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_unschedulable [STABLE] Describes the unschedulable status for the pod.
+				# TYPE kube_pod_status_unschedulable gauge
+				kube_pod_status_unschedulable{namespace="ns2",pod="pod2",uid="uid2"} 1
+			`,
+			MetricNames: []string{"kube_pod_status_unschedulable"},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "pod1_con1",
+							Resources: v1.ResourceRequirements{
+								Requests: map[v1.ResourceName]resource.Quantity{
+									v1.ResourceCPU:                    resource.MustParse("200m"),
+									v1.ResourceMemory:                 resource.MustParse("100M"),
+									v1.ResourceEphemeralStorage:       resource.MustParse("300M"),
+									v1.ResourceStorage:                resource.MustParse("400M"),
+									v1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
+								},
+								Limits: map[v1.ResourceName]resource.Quantity{
+									v1.ResourceCPU:                    resource.MustParse("200m"),
+									v1.ResourceMemory:                 resource.MustParse("100M"),
+									v1.ResourceEphemeralStorage:       resource.MustParse("300M"),
+									v1.ResourceStorage:                resource.MustParse("400M"),
+									v1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
+								},
+							},
+						},
+						{
+							Name: "pod1_con2",
+							Resources: v1.ResourceRequirements{
+								Requests: map[v1.ResourceName]resource.Quantity{
+									v1.ResourceCPU:    resource.MustParse("300m"),
+									v1.ResourceMemory: resource.MustParse("200M"),
+								},
+								Limits: map[v1.ResourceName]resource.Quantity{
+									v1.ResourceCPU:    resource.MustParse("300m"),
+									v1.ResourceMemory: resource.MustParse("200M"),
+								},
+							},
+						},
+					},
+					InitContainers: []v1.Container{
+						{
+							Name: "pod1_initcon1",
+							Resources: v1.ResourceRequirements{
+								Requests: map[v1.ResourceName]resource.Quantity{
+									v1.ResourceCPU:                    resource.MustParse("200m"),
+									v1.ResourceMemory:                 resource.MustParse("100M"),
+									v1.ResourceEphemeralStorage:       resource.MustParse("300M"),
+									v1.ResourceStorage:                resource.MustParse("400M"),
+									v1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
+								},
+								Limits: map[v1.ResourceName]resource.Quantity{
+									v1.ResourceCPU:                    resource.MustParse("200m"),
+									v1.ResourceMemory:                 resource.MustParse("100M"),
+									v1.ResourceEphemeralStorage:       resource.MustParse("300M"),
+									v1.ResourceStorage:                resource.MustParse("400M"),
+									v1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
+								},
+							},
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_container_resource_limits The number of requested limit resource by a container. It is recommended to use the kube_pod_resource_limits metric exposed by kube-scheduler instead, as it is more precise.
+				# HELP kube_pod_container_resource_limits_with_defaults The resource limits of a container, with the limits it does not set filled in from the container defaults of the LimitRanges in its namespace.
+				# HELP kube_pod_container_resource_requests The number of requested request resource by a container. It is recommended to use the kube_pod_resource_requests metric exposed by kube-scheduler instead, as it is more precise.
+				# HELP kube_pod_container_resource_requests_with_defaults The resources requested by a container, with the requests it does not set filled in from the container defaults of the LimitRanges in its namespace.
+				# HELP kube_pod_init_container_resource_limits The number of requested limit resource by an init container.
+				# HELP kube_pod_init_container_resource_requests The number of requested request resource by an init container.
+				# HELP kube_pod_init_container_status_last_terminated_reason Describes the last reason the init container was in terminated state.
+				# TYPE kube_pod_container_resource_limits gauge
+				# TYPE kube_pod_container_resource_limits_with_defaults gauge
+				# TYPE kube_pod_container_resource_requests gauge
+				# TYPE kube_pod_container_resource_requests_with_defaults gauge
+				# TYPE kube_pod_init_container_resource_limits gauge
+				# TYPE kube_pod_init_container_resource_requests gauge
+				# TYPE kube_pod_init_container_status_last_terminated_reason gauge
+				kube_pod_container_resource_limits{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="cpu",unit="core",uid="uid1"} 0.2
+				kube_pod_container_resource_limits{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="ephemeral_storage",unit="byte",uid="uid1"} 3e+08
+				kube_pod_container_resource_limits{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="memory",unit="byte",uid="uid1"} 1e+08
+				kube_pod_container_resource_limits{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="nvidia_com_gpu",unit="integer",uid="uid1"} 1
+				kube_pod_container_resource_limits{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="storage",unit="byte",uid="uid1"} 4e+08
+				kube_pod_container_resource_limits{container="pod1_con2",namespace="ns1",node="",pod="pod1",resource="cpu",unit="core",uid="uid1"} 0.3
+				kube_pod_container_resource_limits{container="pod1_con2",namespace="ns1",node="",pod="pod1",resource="memory",unit="byte",uid="uid1"} 2e+08
+				kube_pod_container_resource_requests{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="cpu",unit="core",uid="uid1"} 0.2
+				kube_pod_container_resource_requests{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="ephemeral_storage",unit="byte",uid="uid1"} 3e+08
+				kube_pod_container_resource_requests{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="memory",unit="byte",uid="uid1"} 1e+08
+				kube_pod_container_resource_requests{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="nvidia_com_gpu",unit="integer",uid="uid1"} 1
+				kube_pod_container_resource_requests{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="storage",unit="byte",uid="uid1"} 4e+08
+				kube_pod_container_resource_requests{container="pod1_con2",namespace="ns1",node="",pod="pod1",resource="cpu",unit="core",uid="uid1"} 0.3
+				kube_pod_container_resource_requests{container="pod1_con2",namespace="ns1",node="",pod="pod1",resource="memory",unit="byte",uid="uid1"} 2e+08
+				kube_pod_init_container_resource_limits{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="cpu",unit="core",uid="uid1"} 0.2
+				kube_pod_init_container_resource_limits{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="ephemeral_storage",unit="byte",uid="uid1"} 3e+08
+				kube_pod_init_container_resource_limits{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="memory",unit="byte",uid="uid1"} 1e+08
+				kube_pod_init_container_resource_limits{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="nvidia_com_gpu",unit="integer",uid="uid1"} 1
+				kube_pod_init_container_resource_limits{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="storage",unit="byte",uid="uid1"} 4e+08
+				kube_pod_init_container_resource_requests{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="cpu",unit="core",uid="uid1"} 0.2
+				kube_pod_init_container_resource_requests{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="ephemeral_storage",unit="byte",uid="uid1"} 3e+08
+				kube_pod_init_container_resource_requests{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="memory",unit="byte",uid="uid1"} 1e+08
+				kube_pod_init_container_resource_requests{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="nvidia_com_gpu",unit="integer",uid="uid1"} 1
+				kube_pod_init_container_resource_requests{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="storage",unit="byte",uid="uid1"} 4e+08
+			`,
+			MetricNames: []string{
+				"kube_pod_container_resource_requests",
+				"kube_pod_container_resource_limits",
+				"kube_pod_init_container_resource_limits",
+				"kube_pod_init_container_resource_requests",
+				"kube_pod_init_container_status_last_terminated_reason",
+			},
+		},
+		{
+
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod2",
+					Namespace: "ns2",
+					UID:       "uid2",
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "pod2_con1",
+							Resources: v1.ResourceRequirements{
+								Requests: map[v1.ResourceName]resource.Quantity{
+									v1.ResourceCPU:    resource.MustParse("400m"),
+									v1.ResourceMemory: resource.MustParse("300M"),
+								},
+								Limits: map[v1.ResourceName]resource.Quantity{
+									v1.ResourceCPU:    resource.MustParse("400m"),
+									v1.ResourceMemory: resource.MustParse("300M"),
+								},
+							},
+						},
+						{
+							Name: "pod2_con2",
+							Resources: v1.ResourceRequirements{
+								Requests: map[v1.ResourceName]resource.Quantity{
+									v1.ResourceCPU:    resource.MustParse("500m"),
+									v1.ResourceMemory: resource.MustParse("400M"),
+								},
+								Limits: map[v1.ResourceName]resource.Quantity{
+									v1.ResourceCPU:    resource.MustParse("500m"),
+									v1.ResourceMemory: resource.MustParse("400M"),
+								},
+							},
+						},
+						// A container without a resource specification. No metrics will be emitted for that.
+						{
+							Name: "pod2_con3",
+						},
+					},
+					InitContainers: []v1.Container{
+						{
+							Name: "pod2_initcon1",
+							Resources: v1.ResourceRequirements{
+								Requests: map[v1.ResourceName]resource.Quantity{
+									v1.ResourceCPU:    resource.MustParse("400m"),
+									v1.ResourceMemory: resource.MustParse("300M"),
+								},
+								Limits: map[v1.ResourceName]resource.Quantity{
+									v1.ResourceCPU:    resource.MustParse("400m"),
+									v1.ResourceMemory: resource.MustParse("300M"),
+								},
+							},
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_init_container_resource_limits The number of requested limit resource by an init container.
+				# HELP kube_pod_init_container_resource_requests The number of requested request resource by an init container.
+				# TYPE kube_pod_init_container_resource_limits gauge
+				# TYPE kube_pod_init_container_resource_requests gauge
+				kube_pod_init_container_resource_limits{container="pod2_initcon1",namespace="ns2",node="",pod="pod2",resource="cpu",uid="uid2",unit="core"} 0.4
+				kube_pod_init_container_resource_limits{container="pod2_initcon1",namespace="ns2",node="",pod="pod2",resource="memory",uid="uid2",unit="byte"} 3e+08
+				kube_pod_init_container_resource_requests{container="pod2_initcon1",namespace="ns2",node="",pod="pod2",resource="cpu",uid="uid2",unit="core"} 0.4
+				kube_pod_init_container_resource_requests{container="pod2_initcon1",namespace="ns2",node="",pod="pod2",resource="memory",uid="uid2",unit="byte"} 3e+08
+			`,
+			MetricNames: []string{
+				"kube_pod_init_container_resource_limits",
+				"kube_pod_init_container_resource_requests",
+			},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+					Labels: map[string]string{
+						"app": "example",
+					},
+				},
+				Spec: v1.PodSpec{},
+			},
+			Want: `
+				# HELP kube_pod_labels [STABLE] Kubernetes labels converted to Prometheus labels.
+				# TYPE kube_pod_labels gauge
+		`,
+			MetricNames: []string{
+				"kube_pod_labels",
+			},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+					Labels: map[string]string{
+						"app": "example",
+					},
+				},
+				Spec: v1.PodSpec{
+					Volumes: []v1.Volume{
+						{
+							Name: "myvol",
+							VolumeSource: v1.VolumeSource{
+								PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+									ClaimName: "claim1",
+									ReadOnly:  false,
+								},
+							},
+						},
+						{
+							Name: "my-readonly-vol",
+							VolumeSource: v1.VolumeSource{
+								PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+									ClaimName: "claim2",
+									ReadOnly:  true,
+								},
+							},
+						},
+						{
+							Name: "not-pvc-vol",
+							VolumeSource: v1.VolumeSource{
+								EmptyDir: &v1.EmptyDirVolumeSource{
+									Medium: "memory",
+								},
+							},
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_spec_volumes_persistentvolumeclaims_info [STABLE] Information about persistentvolumeclaim volumes in a pod.
+				# HELP kube_pod_spec_volumes_persistentvolumeclaims_readonly [STABLE] Describes whether a persistentvolumeclaim is mounted read only.
+				# TYPE kube_pod_spec_volumes_persistentvolumeclaims_info gauge
+				# TYPE kube_pod_spec_volumes_persistentvolumeclaims_readonly gauge
+				kube_pod_spec_volumes_persistentvolumeclaims_info{namespace="ns1",persistentvolumeclaim="claim1",pod="pod1",volume="myvol",uid="uid1"} 1
+				kube_pod_spec_volumes_persistentvolumeclaims_info{namespace="ns1",persistentvolumeclaim="claim2",pod="pod1",volume="my-readonly-vol",uid="uid1"} 1
+				kube_pod_spec_volumes_persistentvolumeclaims_readonly{namespace="ns1",persistentvolumeclaim="claim1",pod="pod1",volume="myvol",uid="uid1"} 0
+				kube_pod_spec_volumes_persistentvolumeclaims_readonly{namespace="ns1",persistentvolumeclaim="claim2",pod="pod1",volume="my-readonly-vol",uid="uid1"} 1
+
+		`,
+			MetricNames: []string{
+				"kube_pod_spec_volumes_persistentvolumeclaims_info",
+				"kube_pod_spec_volumes_persistentvolumeclaims_readonly",
+			},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+					Labels: map[string]string{
+						"app": "example",
+					},
+				},
+				Spec: v1.PodSpec{
+					RuntimeClassName: &runtimeclass,
+				},
+			},
+			Want: `
+				# HELP kube_pod_runtimeclass_name_info The runtimeclass associated with the pod.
+				# TYPE kube_pod_runtimeclass_name_info gauge
+				kube_pod_runtimeclass_name_info{namespace="ns1",pod="pod1",runtimeclass_name="foo",uid="uid1"} 1
+			`,
+			MetricNames: []string{
+				"kube_pod_runtimeclass_name_info",
+			},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+					Labels: map[string]string{
+						"app": "example",
+					},
+				},
+				Spec: v1.PodSpec{},
+			},
+			AllowLabelsList: []string{"wildcard-not-first", options.LabelWildcard},
+			Want: `
+				# HELP kube_pod_labels [STABLE] Kubernetes labels converted to Prometheus labels.
+				# TYPE kube_pod_labels gauge
+				kube_pod_labels{namespace="ns1",pod="pod1",uid="uid1"} 1
+		`,
+			MetricNames: []string{
+				"kube_pod_labels",
 			},
-			Want: `
-				# HELP kube_pod_status_initialized_time Initialized time in unix timestamp for a pod.
-				# TYPE kube_pod_status_initialized_time gauge
-				kube_pod_status_initialized_time{namespace="ns1",pod="pod1",uid="uid1"} 1.501666018e+09
-			`,
-			MetricNames: []string{"kube_pod_status_initialized_time"},
 		},
 		{
 			Obj: &v1.Pod{
@@ -1481,24 +2632,21 @@ func TestPodStore(t *testing.T) {
 					Name:      "pod1",
 					Namespace: "ns1",
 					UID:       "uid1",
-				},
-				Status: v1.PodStatus{
-					Conditions: []v1.PodCondition{
-						{
-							Type:   v1.PodInitialized,
-							Status: v1.ConditionFalse,
-							LastTransitionTime: metav1.Time{
-								Time: time.Unix(1501666018, 0),
-							},
-						},
+					Labels: map[string]string{
+						"app": "example",
 					},
 				},
+				Spec: v1.PodSpec{},
 			},
+			AllowLabelsList: []string{options.LabelWildcard},
 			Want: `
-				# HELP kube_pod_status_initialized_time Initialized time in unix timestamp for a pod.
-				# TYPE kube_pod_status_initialized_time gauge
-			`,
-			MetricNames: []string{"kube_pod_status_initialized_time"},
+				# HELP kube_pod_labels [STABLE] Kubernetes labels converted to Prometheus labels.
+				# TYPE kube_pod_labels gauge
+				kube_pod_labels{label_app="example",namespace="ns1",pod="pod1",uid="uid1"} 1
+		`,
+			MetricNames: []string{
+				"kube_pod_labels",
+			},
 		},
 		{
 			Obj: &v1.Pod{
@@ -1507,49 +2655,45 @@ func TestPodStore(t *testing.T) {
 					Namespace: "ns1",
 					UID:       "uid1",
 				},
-				Status: v1.PodStatus{
-					Conditions: []v1.PodCondition{
-						{
-							Type:   v1.ContainersReady,
-							Status: v1.ConditionTrue,
-							LastTransitionTime: metav1.Time{
-								Time: time.Unix(1501666018, 0),
-							},
-						},
+				Spec: v1.PodSpec{
+					NodeSelector: map[string]string{
+						"a": "b",
 					},
 				},
 			},
+			AllowLabelsList: []string{options.LabelWildcard},
 			Want: `
-				# HELP kube_pod_status_container_ready_time Readiness achieved time in unix timestamp for a pod containers.
-				# TYPE kube_pod_status_container_ready_time gauge
-				kube_pod_status_container_ready_time{namespace="ns1",pod="pod1",uid="uid1"} 1.501666018e+09
-			`,
-			MetricNames: []string{"kube_pod_status_container_ready_time"},
+				# HELP kube_pod_nodeselectors Describes the Pod nodeSelectors.
+				# TYPE kube_pod_nodeselectors gauge
+				kube_pod_nodeselectors{nodeselector_a="b",namespace="ns1",pod="pod1",uid="uid1"} 1
+		`,
+			MetricNames: []string{
+				"kube_pod_nodeselectors",
+			},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod1",
+					Name:      "pod2",
 					Namespace: "ns1",
-					UID:       "uid1",
+					UID:       "uid6",
 				},
-				Status: v1.PodStatus{
-					Conditions: []v1.PodCondition{
-						{
-							Type:   v1.ContainersReady,
-							Status: v1.ConditionFalse,
-							LastTransitionTime: metav1.Time{
-								Time: time.Unix(1501666018, 0),
-							},
-						},
+				Spec: v1.PodSpec{
+					NodeSelector: map[string]string{
+						"kubernetes.io/os":                 "linux",
+						"cloud.google.com/gke-accelerator": "nvidia-tesla-t4",
 					},
 				},
 			},
+			AllowLabelsList: []string{options.LabelWildcard},
 			Want: `
-				# HELP kube_pod_status_container_ready_time Readiness achieved time in unix timestamp for a pod containers.
-				# TYPE kube_pod_status_container_ready_time gauge
-			`,
-			MetricNames: []string{"kube_pod_status_container_ready_time"},
+				# HELP kube_pod_nodeselectors Describes the Pod nodeSelectors.
+				# TYPE kube_pod_nodeselectors gauge
+				kube_pod_nodeselectors{nodeselector_kubernetes_io_os="linux",nodeselector_cloud_google_com_gke_accelerator="nvidia-tesla-t4",namespace="ns1",pod="pod2",uid="uid6"} 1
+		`,
+			MetricNames: []string{
+				"kube_pod_nodeselector",
+			},
 		},
 		{
 			Obj: &v1.Pod{
@@ -1557,60 +2701,60 @@ func TestPodStore(t *testing.T) {
 					Name:      "pod1",
 					Namespace: "ns1",
 					UID:       "uid1",
-				},
-				Status: v1.PodStatus{
-					Conditions: []v1.PodCondition{
-						{
-							Type:   v1.PodReady,
-							Status: v1.ConditionTrue,
-							LastTransitionTime: metav1.Time{
-								Time: time.Unix(1501666018, 0),
-							},
-						},
+					ManagedFields: []metav1.ManagedFieldsEntry{
+						{Manager: "kube-scheduler", Operation: metav1.ManagedFieldsOperationUpdate, Time: &metav1.Time{Time: time.Unix(1501569018, 0)}},
+						{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, Time: &metav1.Time{Time: time.Unix(1501569118, 0)}},
+						{Manager: "kubelet", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "status", Time: &metav1.Time{Time: time.Unix(1501569068, 0)}},
+						{Manager: "kubectl-client-side-apply", Operation: metav1.ManagedFieldsOperationUpdate},
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_status_ready [STABLE] Describes whether the pod is ready to serve requests.
-				# HELP kube_pod_status_ready_time Readiness achieved time in unix timestamp for a pod.
-				# TYPE kube_pod_status_ready gauge
-				# TYPE kube_pod_status_ready_time gauge
-				kube_pod_status_ready_time{namespace="ns1",pod="pod1",uid="uid1"} 1.501666018e+09
-				kube_pod_status_ready{condition="false",namespace="ns1",pod="pod1",uid="uid1"} 0
-				kube_pod_status_ready{condition="true",namespace="ns1",pod="pod1",uid="uid1"} 1
-				kube_pod_status_ready{condition="unknown",namespace="ns1",pod="pod1",uid="uid1"} 0
-			`,
-			MetricNames: []string{"kube_pod_status_ready_time", "kube_pod_status_ready"},
+				# HELP kube_pod_managed_by The field manager which most recently changed a pod.
+				# TYPE kube_pod_managed_by gauge
+				kube_pod_managed_by{manager="kubectl-edit",namespace="ns1",pod="pod1",uid="uid1"} 1
+		`,
+			MetricNames: []string{
+				"kube_pod_managed_by",
+			},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "pod2",
-					Namespace: "ns2",
+					Namespace: "ns1",
 					UID:       "uid2",
 				},
-				Status: v1.PodStatus{
-					Conditions: []v1.PodCondition{
-						{
-							Type:   v1.PodReady,
-							Status: v1.ConditionFalse,
-							LastTransitionTime: metav1.Time{
-								Time: time.Unix(1501666018, 0),
-							},
-						},
+			},
+			Want: `
+				# HELP kube_pod_managed_by The field manager which most recently changed a pod.
+				# TYPE kube_pod_managed_by gauge
+		`,
+			MetricNames: []string{
+				"kube_pod_managed_by",
+			},
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
+					Annotations: map[string]string{
+						"app": "example",
 					},
 				},
+				Spec: v1.PodSpec{},
 			},
+			AllowAnnotationsList: []string{options.LabelWildcard},
 			Want: `
-				# HELP kube_pod_status_ready [STABLE] Describes whether the pod is ready to serve requests.
-				# HELP kube_pod_status_ready_time Readiness achieved time in unix timestamp for a pod.
-				# TYPE kube_pod_status_ready gauge
-				# TYPE kube_pod_status_ready_time gauge
-				kube_pod_status_ready{condition="false",namespace="ns2",pod="pod2",uid="uid2"} 1
-				kube_pod_status_ready{condition="true",namespace="ns2",pod="pod2",uid="uid2"} 0
-				kube_pod_status_ready{condition="unknown",namespace="ns2",pod="pod2",uid="uid2"} 0
-			`,
-			MetricNames: []string{"kube_pod_status_ready_time", "kube_pod_status_ready"},
+				# HELP kube_pod_annotations Kubernetes annotations converted to Prometheus labels.
+				# TYPE kube_pod_annotations gauge
+				kube_pod_annotations{annotation_app="example",namespace="ns1",pod="pod1",uid="uid1"} 1
+		`,
+			MetricNames: []string{
+				"kube_pod_annotations",
+			},
 		},
 		{
 			Obj: &v1.Pod{
@@ -1619,81 +2763,86 @@ func TestPodStore(t *testing.T) {
 					Namespace: "ns1",
 					UID:       "uid1",
 				},
-				Status: v1.PodStatus{
-					Conditions: []v1.PodCondition{
+				Spec: v1.PodSpec{
+					Tolerations: []v1.Toleration{
 						{
-							Type:   v1.PodScheduled,
-							Status: v1.ConditionTrue,
-							LastTransitionTime: metav1.Time{
-								Time: time.Unix(1501666018, 0),
-							},
+							Key:      "key1",
+							Operator: v1.TolerationOpEqual,
+							Value:    "value1",
+							Effect:   v1.TaintEffectNoSchedule,
+						},
+						{
+							Key:      "key2",
+							Operator: v1.TolerationOpExists,
+						},
+						{
+							Key:      "key3",
+							Operator: v1.TolerationOpEqual,
+							Value:    "value3",
+						},
+						{
+							// Duplicate toleration, to ensure that doesn't result in a duplicate metric
+							Key:      "key3",
+							Operator: v1.TolerationOpEqual,
+							Value:    "value3",
+						},
+						{
+							// an empty toleration to ensure that an empty toleration does not result in a metric
 						},
 					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_status_scheduled [STABLE] Describes the status of the scheduling process for the pod.
-				# HELP kube_pod_status_scheduled_time [STABLE] Unix timestamp when pod moved into scheduled status
-				# TYPE kube_pod_status_scheduled gauge
-				# TYPE kube_pod_status_scheduled_time gauge
-				kube_pod_status_scheduled_time{namespace="ns1",pod="pod1",uid="uid1"} 1.501666018e+09
-				kube_pod_status_scheduled{condition="false",namespace="ns1",pod="pod1",uid="uid1"} 0
-				kube_pod_status_scheduled{condition="true",namespace="ns1",pod="pod1",uid="uid1"} 1
-				kube_pod_status_scheduled{condition="unknown",namespace="ns1",pod="pod1",uid="uid1"} 0
+				# HELP kube_pod_tolerations Information about the pod tolerations
+				# TYPE kube_pod_tolerations gauge
+				kube_pod_tolerations{effect="",key="",namespace="ns1",operator="",pod="pod1",toleration_seconds="",uid="uid1",value=""} 1
+				kube_pod_tolerations{effect="",key="key2",namespace="ns1",operator="Exists",pod="pod1",toleration_seconds="",uid="uid1",value=""} 1
+				kube_pod_tolerations{effect="",key="key3",namespace="ns1",operator="Equal",pod="pod1",toleration_seconds="",uid="uid1",value="value3"} 1
+				kube_pod_tolerations{effect="NoSchedule",key="key1",namespace="ns1",operator="Equal",pod="pod1",toleration_seconds="",uid="uid1",value="value1"} 1
 			`,
-			MetricNames: []string{"kube_pod_status_scheduled", "kube_pod_status_scheduled_time"},
+			MetricNames: []string{
+				"kube_pod_tolerations",
+			},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod2",
-					Namespace: "ns2",
-					UID:       "uid2",
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
 				},
-				Status: v1.PodStatus{
-					Conditions: []v1.PodCondition{
-						{
-							Type:   v1.PodScheduled,
-							Status: v1.ConditionFalse,
-						},
-					},
+				Spec: v1.PodSpec{
+					ServiceAccountName: "service-account-name",
 				},
 			},
 			Want: `
-				# HELP kube_pod_status_scheduled [STABLE] Describes the status of the scheduling process for the pod.
-				# HELP kube_pod_status_scheduled_time [STABLE] Unix timestamp when pod moved into scheduled status
-				# TYPE kube_pod_status_scheduled gauge
-				# TYPE kube_pod_status_scheduled_time gauge
-				kube_pod_status_scheduled{condition="false",namespace="ns2",pod="pod2",uid="uid2"} 1
-				kube_pod_status_scheduled{condition="true",namespace="ns2",pod="pod2",uid="uid2"} 0
-				kube_pod_status_scheduled{condition="unknown",namespace="ns2",pod="pod2",uid="uid2"} 0
+				# HELP kube_pod_service_account The service account for a pod.
+				# TYPE kube_pod_service_account gauge
+				kube_pod_service_account{namespace="ns1",pod="pod1",service_account="service-account-name",uid="uid1"} 1
 			`,
-			MetricNames: []string{"kube_pod_status_scheduled", "kube_pod_status_scheduled_time"},
+			MetricNames: []string{
+				"kube_pod_service_account",
+			},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod2",
-					Namespace: "ns2",
-					UID:       "uid2",
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
 				},
-				Status: v1.PodStatus{
-					Conditions: []v1.PodCondition{
-						{
-							Type:    v1.PodScheduled,
-							Status:  v1.ConditionFalse,
-							Reason:  "Unschedulable",
-							Message: "0/3 nodes are available: 3 Insufficient cpu.",
-						},
-					},
+				Spec: v1.PodSpec{
+					SchedulerName: "scheduler1",
 				},
 			},
 			Want: `
-				# HELP kube_pod_status_unschedulable [STABLE] Describes the unschedulable status for the pod.
-				# TYPE kube_pod_status_unschedulable gauge
-				kube_pod_status_unschedulable{namespace="ns2",pod="pod2",uid="uid2"} 1
+				# HELP kube_pod_scheduler The scheduler for a pod.
+				# TYPE kube_pod_scheduler gauge
+				kube_pod_scheduler{namespace="ns1",pod="pod1",name="scheduler1",uid="uid1"} 1
 			`,
-			MetricNames: []string{"kube_pod_status_unschedulable"},
+			MetricNames: []string{
+				"kube_pod_scheduler",
+			},
 		},
 		{
 			Obj: &v1.Pod{
@@ -1703,159 +2852,101 @@ func TestPodStore(t *testing.T) {
 					UID:       "uid1",
 				},
 				Spec: v1.PodSpec{
+					NodeName: "node1",
 					Containers: []v1.Container{
 						{
-							Name: "pod1_con1",
+							Name: "container1",
 							Resources: v1.ResourceRequirements{
 								Requests: map[v1.ResourceName]resource.Quantity{
-									v1.ResourceCPU:                    resource.MustParse("200m"),
-									v1.ResourceMemory:                 resource.MustParse("100M"),
-									v1.ResourceEphemeralStorage:       resource.MustParse("300M"),
-									v1.ResourceStorage:                resource.MustParse("400M"),
-									v1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
-								},
-								Limits: map[v1.ResourceName]resource.Quantity{
-									v1.ResourceCPU:                    resource.MustParse("200m"),
-									v1.ResourceMemory:                 resource.MustParse("100M"),
-									v1.ResourceEphemeralStorage:       resource.MustParse("300M"),
-									v1.ResourceStorage:                resource.MustParse("400M"),
-									v1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
+									v1.ResourceCPU:    resource.MustParse("100m"),
+									v1.ResourceMemory: resource.MustParse("100M"),
 								},
 							},
 						},
 						{
-							Name: "pod1_con2",
+							Name: "container2",
 							Resources: v1.ResourceRequirements{
 								Requests: map[v1.ResourceName]resource.Quantity{
-									v1.ResourceCPU:    resource.MustParse("300m"),
-									v1.ResourceMemory: resource.MustParse("200M"),
-								},
-								Limits: map[v1.ResourceName]resource.Quantity{
-									v1.ResourceCPU:    resource.MustParse("300m"),
-									v1.ResourceMemory: resource.MustParse("200M"),
+									v1.ResourceCPU: resource.MustParse("200m"),
 								},
 							},
 						},
 					},
 					InitContainers: []v1.Container{
 						{
-							Name: "pod1_initcon1",
+							Name:          "sidecar",
+							RestartPolicy: &restartPolicyAlways,
 							Resources: v1.ResourceRequirements{
 								Requests: map[v1.ResourceName]resource.Quantity{
-									v1.ResourceCPU:                    resource.MustParse("200m"),
-									v1.ResourceMemory:                 resource.MustParse("100M"),
-									v1.ResourceEphemeralStorage:       resource.MustParse("300M"),
-									v1.ResourceStorage:                resource.MustParse("400M"),
-									v1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
+									v1.ResourceCPU: resource.MustParse("50m"),
 								},
-								Limits: map[v1.ResourceName]resource.Quantity{
-									v1.ResourceCPU:                    resource.MustParse("200m"),
-									v1.ResourceMemory:                 resource.MustParse("100M"),
-									v1.ResourceEphemeralStorage:       resource.MustParse("300M"),
-									v1.ResourceStorage:                resource.MustParse("400M"),
-									v1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
+							},
+						},
+						{
+							Name: "init",
+							Resources: v1.ResourceRequirements{
+								Requests: map[v1.ResourceName]resource.Quantity{
+									v1.ResourceCPU:    resource.MustParse("500m"),
+									v1.ResourceMemory: resource.MustParse("50M"),
 								},
 							},
 						},
 					},
+					Overhead: map[v1.ResourceName]resource.Quantity{
+						v1.ResourceCPU: resource.MustParse("10m"),
+					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_container_resource_limits The number of requested limit resource by a container. It is recommended to use the kube_pod_resource_limits metric exposed by kube-scheduler instead, as it is more precise.
-				# HELP kube_pod_container_resource_requests The number of requested request resource by a container. It is recommended to use the kube_pod_resource_requests metric exposed by kube-scheduler instead, as it is more precise.
-				# HELP kube_pod_init_container_resource_limits The number of requested limit resource by an init container.
-				# HELP kube_pod_init_container_resource_requests The number of requested request resource by an init container.
-				# HELP kube_pod_init_container_status_last_terminated_reason Describes the last reason the init container was in terminated state.
-				# TYPE kube_pod_container_resource_limits gauge
-				# TYPE kube_pod_container_resource_requests gauge
-				# TYPE kube_pod_init_container_resource_limits gauge
-				# TYPE kube_pod_init_container_resource_requests gauge
-				# TYPE kube_pod_init_container_status_last_terminated_reason gauge
-				kube_pod_container_resource_limits{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="cpu",unit="core",uid="uid1"} 0.2
-				kube_pod_container_resource_limits{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="ephemeral_storage",unit="byte",uid="uid1"} 3e+08
-				kube_pod_container_resource_limits{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="memory",unit="byte",uid="uid1"} 1e+08
-				kube_pod_container_resource_limits{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="nvidia_com_gpu",unit="integer",uid="uid1"} 1
-				kube_pod_container_resource_limits{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="storage",unit="byte",uid="uid1"} 4e+08
-				kube_pod_container_resource_limits{container="pod1_con2",namespace="ns1",node="",pod="pod1",resource="cpu",unit="core",uid="uid1"} 0.3
-				kube_pod_container_resource_limits{container="pod1_con2",namespace="ns1",node="",pod="pod1",resource="memory",unit="byte",uid="uid1"} 2e+08
-				kube_pod_container_resource_requests{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="cpu",unit="core",uid="uid1"} 0.2
-				kube_pod_container_resource_requests{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="ephemeral_storage",unit="byte",uid="uid1"} 3e+08
-				kube_pod_container_resource_requests{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="memory",unit="byte",uid="uid1"} 1e+08
-				kube_pod_container_resource_requests{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="nvidia_com_gpu",unit="integer",uid="uid1"} 1
-				kube_pod_container_resource_requests{container="pod1_con1",namespace="ns1",node="",pod="pod1",resource="storage",unit="byte",uid="uid1"} 4e+08
-				kube_pod_container_resource_requests{container="pod1_con2",namespace="ns1",node="",pod="pod1",resource="cpu",unit="core",uid="uid1"} 0.3
-				kube_pod_container_resource_requests{container="pod1_con2",namespace="ns1",node="",pod="pod1",resource="memory",unit="byte",uid="uid1"} 2e+08
-				kube_pod_init_container_resource_limits{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="cpu",unit="core",uid="uid1"} 0.2
-				kube_pod_init_container_resource_limits{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="ephemeral_storage",unit="byte",uid="uid1"} 3e+08
-				kube_pod_init_container_resource_limits{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="memory",unit="byte",uid="uid1"} 1e+08
-				kube_pod_init_container_resource_limits{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="nvidia_com_gpu",unit="integer",uid="uid1"} 1
-				kube_pod_init_container_resource_limits{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="storage",unit="byte",uid="uid1"} 4e+08
-				kube_pod_init_container_resource_requests{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="cpu",unit="core",uid="uid1"} 0.2
-				kube_pod_init_container_resource_requests{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="ephemeral_storage",unit="byte",uid="uid1"} 3e+08
-				kube_pod_init_container_resource_requests{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="memory",unit="byte",uid="uid1"} 1e+08
-				kube_pod_init_container_resource_requests{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="nvidia_com_gpu",unit="integer",uid="uid1"} 1
-				kube_pod_init_container_resource_requests{container="pod1_initcon1",namespace="ns1",node="",pod="pod1",resource="storage",unit="byte",uid="uid1"} 4e+08
+				# HELP kube_pod_resource_requests The effective resources requested by a pod, accounting for init containers, sidecar containers and pod overhead the same way the scheduler does.
+				# HELP kube_pod_resource_requests_sum The sum of the resources requested by the containers of a pod.
+				# TYPE kube_pod_resource_requests gauge
+				# TYPE kube_pod_resource_requests_sum gauge
+				kube_pod_resource_requests{namespace="ns1",node="node1",pod="pod1",resource="cpu",uid="uid1",unit="core"} 0.56
+				kube_pod_resource_requests{namespace="ns1",node="node1",pod="pod1",resource="memory",uid="uid1",unit="byte"} 1e+08
+				kube_pod_resource_requests_sum{namespace="ns1",node="node1",pod="pod1",resource="cpu",uid="uid1",unit="core"} 0.3
+				kube_pod_resource_requests_sum{namespace="ns1",node="node1",pod="pod1",resource="memory",uid="uid1",unit="byte"} 1e+08
 			`,
 			MetricNames: []string{
-				"kube_pod_container_resource_requests",
-				"kube_pod_container_resource_limits",
-				"kube_pod_init_container_resource_limits",
-				"kube_pod_init_container_resource_requests",
-				"kube_pod_init_container_status_last_terminated_reason",
+				"kube_pod_resource_requests",
 			},
 		},
 		{
-
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod2",
-					Namespace: "ns2",
-					UID:       "uid2",
+					Name:      "pod1",
+					Namespace: "ns1",
+					UID:       "uid1",
 				},
 				Spec: v1.PodSpec{
+					NodeName: "node1",
 					Containers: []v1.Container{
 						{
-							Name: "pod2_con1",
+							Name: "container1",
 							Resources: v1.ResourceRequirements{
 								Requests: map[v1.ResourceName]resource.Quantity{
-									v1.ResourceCPU:    resource.MustParse("400m"),
-									v1.ResourceMemory: resource.MustParse("300M"),
-								},
-								Limits: map[v1.ResourceName]resource.Quantity{
-									v1.ResourceCPU:    resource.MustParse("400m"),
-									v1.ResourceMemory: resource.MustParse("300M"),
+									v1.ResourceCPU:    resource.MustParse("100m"),
+									v1.ResourceMemory: resource.MustParse("100M"),
 								},
 							},
 						},
 						{
-							Name: "pod2_con2",
+							Name: "container2",
 							Resources: v1.ResourceRequirements{
 								Requests: map[v1.ResourceName]resource.Quantity{
-									v1.ResourceCPU:    resource.MustParse("500m"),
-									v1.ResourceMemory: resource.MustParse("400M"),
-								},
-								Limits: map[v1.ResourceName]resource.Quantity{
-									v1.ResourceCPU:    resource.MustParse("500m"),
-									v1.ResourceMemory: resource.MustParse("400M"),
+									v1.ResourceCPU:    resource.MustParse("250m"),
+									v1.ResourceMemory: resource.MustParse("50M"),
+									"nvidia.com/gpu":  resource.MustParse("1"),
 								},
 							},
 						},
-						// A container without a resource specification. No metrics will be emitted for that.
-						{
-							Name: "pod2_con3",
-						},
 					},
 					InitContainers: []v1.Container{
 						{
-							Name: "pod2_initcon1",
+							Name: "init",
 							Resources: v1.ResourceRequirements{
 								Requests: map[v1.ResourceName]resource.Quantity{
-									v1.ResourceCPU:    resource.MustParse("400m"),
-									v1.ResourceMemory: resource.MustParse("300M"),
-								},
-								Limits: map[v1.ResourceName]resource.Quantity{
-									v1.ResourceCPU:    resource.MustParse("400m"),
-									v1.ResourceMemory: resource.MustParse("300M"),
+									v1.ResourceCPU: resource.MustParse("1"),
 								},
 							},
 						},
@@ -1863,336 +2954,523 @@ func TestPodStore(t *testing.T) {
 				},
 			},
 			Want: `
-				# HELP kube_pod_init_container_resource_limits The number of requested limit resource by an init container.
-				# HELP kube_pod_init_container_resource_requests The number of requested request resource by an init container.
-				# TYPE kube_pod_init_container_resource_limits gauge
-				# TYPE kube_pod_init_container_resource_requests gauge
-				kube_pod_init_container_resource_limits{container="pod2_initcon1",namespace="ns2",node="",pod="pod2",resource="cpu",uid="uid2",unit="core"} 0.4
-				kube_pod_init_container_resource_limits{container="pod2_initcon1",namespace="ns2",node="",pod="pod2",resource="memory",uid="uid2",unit="byte"} 3e+08
-				kube_pod_init_container_resource_requests{container="pod2_initcon1",namespace="ns2",node="",pod="pod2",resource="cpu",uid="uid2",unit="core"} 0.4
-				kube_pod_init_container_resource_requests{container="pod2_initcon1",namespace="ns2",node="",pod="pod2",resource="memory",uid="uid2",unit="byte"} 3e+08
+				# HELP kube_pod_resource_requests_sum The sum of the resources requested by the containers of a pod.
+				# TYPE kube_pod_resource_requests_sum gauge
+				kube_pod_resource_requests_sum{namespace="ns1",node="node1",pod="pod1",resource="cpu",uid="uid1",unit="core"} 0.35
+				kube_pod_resource_requests_sum{namespace="ns1",node="node1",pod="pod1",resource="memory",uid="uid1",unit="byte"} 1.5e+08
+				kube_pod_resource_requests_sum{namespace="ns1",node="node1",pod="pod1",resource="nvidia_com_gpu",uid="uid1",unit="integer"} 1
 			`,
 			MetricNames: []string{
-				"kube_pod_init_container_resource_limits",
-				"kube_pod_init_container_resource_requests",
+				"kube_pod_resource_requests_sum",
 			},
 		},
+	}
+
+	for i, c := range cases {
+		c.Func = generator.ComposeMetricGenFuncs(podMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList, clock.RealClock{}, nil, nil, nil))
+		c.Headers = generator.ExtractMetricFamilyHeaders(podMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList, clock.RealClock{}, nil, nil, nil))
+		if err := c.run(); err != nil {
+			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
+		}
+	}
+}
+
+func TestPodStatusUnscheduledTime(t *testing.T) {
+	cases := []generateMetricsTestCase{
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "pod1",
 					Namespace: "ns1",
 					UID:       "uid1",
-					Labels: map[string]string{
-						"app": "example",
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
+						{
+							Type:               v1.PodScheduled,
+							Status:             v1.ConditionFalse,
+							LastTransitionTime: metav1.Unix(1501568418, 0),
+						},
 					},
 				},
-				Spec: v1.PodSpec{},
 			},
 			Want: `
-				# HELP kube_pod_labels [STABLE] Kubernetes labels converted to Prometheus labels.
-				# TYPE kube_pod_labels gauge
-		`,
-			MetricNames: []string{
-				"kube_pod_labels",
+				# HELP kube_pod_status_unscheduled_time Unix timestamp when pod moved into unscheduled status
+				# TYPE kube_pod_status_unscheduled_time gauge
+				kube_pod_status_unscheduled_time{namespace="ns1",pod="pod1",uid="uid1"} 1.501568418e+09
+			`,
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod2",
+					Namespace: "ns1",
+					UID:       "uid2",
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
+						{
+							Type:               v1.PodScheduled,
+							Status:             v1.ConditionTrue,
+							LastTransitionTime: metav1.Unix(1501568418, 0),
+						},
+					},
+				},
 			},
+			Want: `
+				# HELP kube_pod_status_unscheduled_time Unix timestamp when pod moved into unscheduled status
+				# TYPE kube_pod_status_unscheduled_time gauge
+			`,
 		},
+	}
+
+	families := []generator.FamilyGenerator{createPodStatusUnscheduledTimeFamilyGenerator()}
+	for i, c := range cases {
+		c.Func = generator.ComposeMetricGenFuncs(families)
+		c.Headers = generator.ExtractMetricFamilyHeaders(families)
+		if err := c.run(); err != nil {
+			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
+		}
+	}
+}
+
+func TestPodStatusUnscheduledDuration(t *testing.T) {
+	fakeClock := testingclock.NewFakePassiveClock(time.Unix(1501569018, 0))
+
+	cases := []generateMetricsTestCase{
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "pod1",
 					Namespace: "ns1",
 					UID:       "uid1",
-					Labels: map[string]string{
-						"app": "example",
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
+						{
+							Type:               v1.PodScheduled,
+							Status:             v1.ConditionFalse,
+							LastTransitionTime: metav1.Unix(1501568418, 0),
+						},
 					},
 				},
-				Spec: v1.PodSpec{
-					Volumes: []v1.Volume{
+			},
+			Want: `
+				# HELP kube_pod_status_unscheduled_duration_seconds The number of seconds since the pod moved into unscheduled status.
+				# TYPE kube_pod_status_unscheduled_duration_seconds gauge
+				kube_pod_status_unscheduled_duration_seconds{namespace="ns1",pod="pod1",uid="uid1"} 600
+			`,
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod2",
+					Namespace: "ns1",
+					UID:       "uid2",
+				},
+				Status: v1.PodStatus{
+					Conditions: []v1.PodCondition{
 						{
-							Name: "myvol",
-							VolumeSource: v1.VolumeSource{
-								PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
-									ClaimName: "claim1",
-									ReadOnly:  false,
-								},
+							Type:               v1.PodScheduled,
+							Status:             v1.ConditionTrue,
+							LastTransitionTime: metav1.Unix(1501568418, 0),
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_pod_status_unscheduled_duration_seconds The number of seconds since the pod moved into unscheduled status.
+				# TYPE kube_pod_status_unscheduled_duration_seconds gauge
+			`,
+		},
+	}
+
+	families := []generator.FamilyGenerator{createPodStatusUnscheduledDurationFamilyGenerator(fakeClock)}
+	for i, c := range cases {
+		c.Func = generator.ComposeMetricGenFuncs(families)
+		c.Headers = generator.ExtractMetricFamilyHeaders(families)
+		if err := c.run(); err != nil {
+			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
+		}
+	}
+
+	// The duration is computed when the family is written out, not when the pod is added.
+	family := families[0].Generate(cases[0].Obj)
+	fakeClock.SetTime(time.Unix(1501569078, 0))
+	want := `kube_pod_status_unscheduled_duration_seconds{namespace="ns1",pod="pod1",uid="uid1"} 660`
+	if got := string(family.ByteSlice()); !strings.Contains(got, want) {
+		t.Errorf("expected the duration to follow the clock, want %s, got %s", want, got)
+	}
+}
+
+func TestPodContainerResourcesWithDefaults(t *testing.T) {
+	limitRanges := map[string][]*v1.LimitRange{
+		"ns1": {
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "ns1"},
+				Spec: v1.LimitRangeSpec{
+					Limits: []v1.LimitRangeItem{
+						{
+							Type: v1.LimitTypePod,
+							Max: v1.ResourceList{
+								v1.ResourceCPU: resource.MustParse("4"),
 							},
 						},
 						{
-							Name: "my-readonly-vol",
-							VolumeSource: v1.VolumeSource{
-								PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
-									ClaimName: "claim2",
-									ReadOnly:  true,
-								},
+							Type: v1.LimitTypeContainer,
+							Default: v1.ResourceList{
+								v1.ResourceCPU:    resource.MustParse("500m"),
+								v1.ResourceMemory: resource.MustParse("256Mi"),
+							},
+							DefaultRequest: v1.ResourceList{
+								v1.ResourceCPU:    resource.MustParse("100m"),
+								v1.ResourceMemory: resource.MustParse("128Mi"),
 							},
 						},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "storage-defaults", Namespace: "ns1"},
+				Spec: v1.LimitRangeSpec{
+					Limits: []v1.LimitRangeItem{
 						{
-							Name: "not-pvc-vol",
-							VolumeSource: v1.VolumeSource{
-								EmptyDir: &v1.EmptyDirVolumeSource{
-									Medium: "memory",
-								},
+							Type: v1.LimitTypeContainer,
+							DefaultRequest: v1.ResourceList{
+								v1.ResourceCPU:              resource.MustParse("200m"),
+								v1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
 							},
 						},
 					},
 				},
 			},
-			Want: `
-				# HELP kube_pod_spec_volumes_persistentvolumeclaims_info [STABLE] Information about persistentvolumeclaim volumes in a pod.
-				# HELP kube_pod_spec_volumes_persistentvolumeclaims_readonly [STABLE] Describes whether a persistentvolumeclaim is mounted read only.
-				# TYPE kube_pod_spec_volumes_persistentvolumeclaims_info gauge
-				# TYPE kube_pod_spec_volumes_persistentvolumeclaims_readonly gauge
-				kube_pod_spec_volumes_persistentvolumeclaims_info{namespace="ns1",persistentvolumeclaim="claim1",pod="pod1",volume="myvol",uid="uid1"} 1
-				kube_pod_spec_volumes_persistentvolumeclaims_info{namespace="ns1",persistentvolumeclaim="claim2",pod="pod1",volume="my-readonly-vol",uid="uid1"} 1
-				kube_pod_spec_volumes_persistentvolumeclaims_readonly{namespace="ns1",persistentvolumeclaim="claim1",pod="pod1",volume="myvol",uid="uid1"} 0
-				kube_pod_spec_volumes_persistentvolumeclaims_readonly{namespace="ns1",persistentvolumeclaim="claim2",pod="pod1",volume="my-readonly-vol",uid="uid1"} 1
-
-		`,
-			MetricNames: []string{
-				"kube_pod_spec_volumes_persistentvolumeclaims_info",
-				"kube_pod_spec_volumes_persistentvolumeclaims_readonly",
-			},
 		},
+	}
+	// The LimitRanges of namespace ns3 were not listed yet.
+	lister := func(namespace string) ([]*v1.LimitRange, bool) {
+		return limitRanges[namespace], namespace != "ns3"
+	}
+
+	cases := []generateMetricsTestCase{
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "pod1",
 					Namespace: "ns1",
 					UID:       "uid1",
-					Labels: map[string]string{
-						"app": "example",
-					},
 				},
 				Spec: v1.PodSpec{
-					RuntimeClassName: &runtimeclass,
+					NodeName: "node1",
+					Containers: []v1.Container{
+						{
+							Name: "app",
+						},
+						{
+							// The API server defaults the memory request to the limit.
+							Name: "sidecar",
+							Resources: v1.ResourceRequirements{
+								Requests: v1.ResourceList{
+									v1.ResourceCPU:    resource.MustParse("50m"),
+									v1.ResourceMemory: resource.MustParse("64Mi"),
+								},
+								Limits: v1.ResourceList{
+									v1.ResourceMemory: resource.MustParse("64Mi"),
+								},
+							},
+						},
+					},
 				},
 			},
 			Want: `
-				# HELP kube_pod_runtimeclass_name_info The runtimeclass associated with the pod.
-				# TYPE kube_pod_runtimeclass_name_info gauge
-				kube_pod_runtimeclass_name_info{namespace="ns1",pod="pod1",runtimeclass_name="foo",uid="uid1"} 1
+				# HELP kube_pod_container_resource_limits_with_defaults The resource limits of a container, with the limits it does not set filled in from the container defaults of the LimitRanges in its namespace.
+				# HELP kube_pod_container_resource_requests_with_defaults The resources requested by a container, with the requests it does not set filled in from the container defaults of the LimitRanges in its namespace.
+				# TYPE kube_pod_container_resource_limits_with_defaults gauge
+				# TYPE kube_pod_container_resource_requests_with_defaults gauge
+				kube_pod_container_resource_limits_with_defaults{container="app",namespace="ns1",node="node1",pod="pod1",resource="cpu",uid="uid1",unit="core"} 0.5
+				kube_pod_container_resource_limits_with_defaults{container="app",namespace="ns1",node="node1",pod="pod1",resource="memory",uid="uid1",unit="byte"} 2.68435456e+08
+				kube_pod_container_resource_limits_with_defaults{container="sidecar",namespace="ns1",node="node1",pod="pod1",resource="cpu",uid="uid1",unit="core"} 0.5
+				kube_pod_container_resource_limits_with_defaults{container="sidecar",namespace="ns1",node="node1",pod="pod1",resource="memory",uid="uid1",unit="byte"} 6.7108864e+07
+				kube_pod_container_resource_requests_with_defaults{container="app",namespace="ns1",node="node1",pod="pod1",resource="cpu",uid="uid1",unit="core"} 0.1
+				kube_pod_container_resource_requests_with_defaults{container="app",namespace="ns1",node="node1",pod="pod1",resource="ephemeral_storage",uid="uid1",unit="byte"} 1.073741824e+09
+				kube_pod_container_resource_requests_with_defaults{container="app",namespace="ns1",node="node1",pod="pod1",resource="memory",uid="uid1",unit="byte"} 1.34217728e+08
+				kube_pod_container_resource_requests_with_defaults{container="sidecar",namespace="ns1",node="node1",pod="pod1",resource="cpu",uid="uid1",unit="core"} 0.05
+				kube_pod_container_resource_requests_with_defaults{container="sidecar",namespace="ns1",node="node1",pod="pod1",resource="ephemeral_storage",uid="uid1",unit="byte"} 1.073741824e+09
+				kube_pod_container_resource_requests_with_defaults{container="sidecar",namespace="ns1",node="node1",pod="pod1",resource="memory",uid="uid1",unit="byte"} 6.7108864e+07
 			`,
-			MetricNames: []string{
-				"kube_pod_runtimeclass_name_info",
-			},
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod1",
-					Namespace: "ns1",
-					UID:       "uid1",
-					Labels: map[string]string{
-						"app": "example",
+					Name:      "pod2",
+					Namespace: "ns2",
+					UID:       "uid2",
+				},
+				Spec: v1.PodSpec{
+					NodeName: "node1",
+					Containers: []v1.Container{
+						{
+							Name: "app",
+							Resources: v1.ResourceRequirements{
+								Requests: v1.ResourceList{
+									v1.ResourceCPU: resource.MustParse("1"),
+								},
+							},
+						},
 					},
 				},
-				Spec: v1.PodSpec{},
 			},
-			AllowLabelsList: []string{"wildcard-not-first", options.LabelWildcard},
 			Want: `
-				# HELP kube_pod_labels [STABLE] Kubernetes labels converted to Prometheus labels.
-				# TYPE kube_pod_labels gauge
-				kube_pod_labels{namespace="ns1",pod="pod1",uid="uid1"} 1
-		`,
-			MetricNames: []string{
-				"kube_pod_labels",
-			},
+				# HELP kube_pod_container_resource_limits_with_defaults The resource limits of a container, with the limits it does not set filled in from the container defaults of the LimitRanges in its namespace.
+				# HELP kube_pod_container_resource_requests_with_defaults The resources requested by a container, with the requests it does not set filled in from the container defaults of the LimitRanges in its namespace.
+				# TYPE kube_pod_container_resource_limits_with_defaults gauge
+				# TYPE kube_pod_container_resource_requests_with_defaults gauge
+				kube_pod_container_resource_requests_with_defaults{container="app",namespace="ns2",node="node1",pod="pod2",resource="cpu",uid="uid2",unit="core"} 1
+			`,
 		},
 		{
 			Obj: &v1.Pod{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod1",
-					Namespace: "ns1",
-					UID:       "uid1",
-					Labels: map[string]string{
-						"app": "example",
+					Name:      "pod3",
+					Namespace: "ns3",
+					UID:       "uid3",
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "app",
+						},
 					},
 				},
-				Spec: v1.PodSpec{},
 			},
-			AllowLabelsList: []string{options.LabelWildcard},
 			Want: `
-				# HELP kube_pod_labels [STABLE] Kubernetes labels converted to Prometheus labels.
-				# TYPE kube_pod_labels gauge
-				kube_pod_labels{label_app="example",namespace="ns1",pod="pod1",uid="uid1"} 1
-		`,
-			MetricNames: []string{
-				"kube_pod_labels",
+				# HELP kube_pod_container_resource_limits_with_defaults The resource limits of a container, with the limits it does not set filled in from the container defaults of the LimitRanges in its namespace.
+				# HELP kube_pod_container_resource_requests_with_defaults The resources requested by a container, with the requests it does not set filled in from the container defaults of the LimitRanges in its namespace.
+				# TYPE kube_pod_container_resource_limits_with_defaults gauge
+				# TYPE kube_pod_container_resource_requests_with_defaults gauge
+			`,
+		},
+	}
+
+	families := []generator.FamilyGenerator{
+		createPodContainerResourceLimitsWithDefaultsFamilyGenerator(lister),
+		createPodContainerResourceRequestsWithDefaultsFamilyGenerator(lister),
+	}
+	for i, c := range cases {
+		c.Func = generator.ComposeMetricGenFuncs(families)
+		c.Headers = generator.ExtractMetricFamilyHeaders(families)
+		if err := c.run(); err != nil {
+			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
+		}
+	}
+}
+
+func TestPodNodeTopology(t *testing.T) {
+	nodes := map[string]*v1.Node{
+		"node1": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node1",
+				Labels: map[string]string{
+					"topology.kubernetes.io/zone":   "eu-west-1a",
+					"topology.kubernetes.io/region": "eu-west-1",
+				},
 			},
 		},
+		"node2": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node2",
+				Labels: map[string]string{
+					"failure-domain.beta.kubernetes.io/zone":   "us-east-1b",
+					"failure-domain.beta.kubernetes.io/region": "us-east-1",
+				},
+			},
+		},
+		"node3": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node3",
+			},
+		},
+	}
+	getter := func(name string) *v1.Node {
+		return nodes[name]
+	}
+
+	cases := []generateMetricsTestCase{
 		{
 			Obj: &v1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod1",
-					Namespace: "ns1",
-					UID:       "uid1",
-				},
-				Spec: v1.PodSpec{
-					NodeSelector: map[string]string{
-						"a": "b",
-					},
-				},
+				ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1", UID: "uid1"},
+				Spec:       v1.PodSpec{NodeName: "node1"},
 			},
-			AllowLabelsList: []string{options.LabelWildcard},
 			Want: `
-				# HELP kube_pod_nodeselectors Describes the Pod nodeSelectors.
-				# TYPE kube_pod_nodeselectors gauge
-				kube_pod_nodeselectors{nodeselector_a="b",namespace="ns1",pod="pod1",uid="uid1"} 1
-		`,
-			MetricNames: []string{
-				"kube_pod_nodeselectors",
-			},
+				# HELP kube_pod_node_topology The zone and region of the node a pod is assigned to, taken from the topology labels of the node.
+				# TYPE kube_pod_node_topology gauge
+				kube_pod_node_topology{namespace="ns1",node="node1",pod="pod1",region="eu-west-1",uid="uid1",zone="eu-west-1a"} 1
+			`,
 		},
 		{
 			Obj: &v1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod2",
-					Namespace: "ns1",
-					UID:       "uid6",
-				},
-				Spec: v1.PodSpec{
-					NodeSelector: map[string]string{
-						"kubernetes.io/os":                 "linux",
-						"cloud.google.com/gke-accelerator": "nvidia-tesla-t4",
-					},
-				},
+				ObjectMeta: metav1.ObjectMeta{Name: "pod2", Namespace: "ns1", UID: "uid2"},
+				Spec:       v1.PodSpec{NodeName: "node2"},
 			},
-			AllowLabelsList: []string{options.LabelWildcard},
 			Want: `
-				# HELP kube_pod_nodeselectors Describes the Pod nodeSelectors.
-				# TYPE kube_pod_nodeselectors gauge
-				kube_pod_nodeselectors{nodeselector_kubernetes_io_os="linux",nodeselector_cloud_google_com_gke_accelerator="nvidia-tesla-t4",namespace="ns1",pod="pod2",uid="uid6"} 1
-		`,
-			MetricNames: []string{
-				"kube_pod_nodeselector",
+				# HELP kube_pod_node_topology The zone and region of the node a pod is assigned to, taken from the topology labels of the node.
+				# TYPE kube_pod_node_topology gauge
+				kube_pod_node_topology{namespace="ns1",node="node2",pod="pod2",region="us-east-1",uid="uid2",zone="us-east-1b"} 1
+			`,
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod3", Namespace: "ns1", UID: "uid3"},
+				Spec:       v1.PodSpec{NodeName: "node3"},
 			},
+			Want: `
+				# HELP kube_pod_node_topology The zone and region of the node a pod is assigned to, taken from the topology labels of the node.
+				# TYPE kube_pod_node_topology gauge
+				kube_pod_node_topology{namespace="ns1",node="node3",pod="pod3",region="",uid="uid3",zone=""} 1
+			`,
 		},
 		{
+			// Unscheduled pods have no node to take the topology from.
 			Obj: &v1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod1",
-					Namespace: "ns1",
-					UID:       "uid1",
-					Annotations: map[string]string{
-						"app": "example",
-					},
-				},
-				Spec: v1.PodSpec{},
+				ObjectMeta: metav1.ObjectMeta{Name: "pod4", Namespace: "ns1", UID: "uid4"},
 			},
-			AllowAnnotationsList: []string{options.LabelWildcard},
 			Want: `
-				# HELP kube_pod_annotations Kubernetes annotations converted to Prometheus labels.
-				# TYPE kube_pod_annotations gauge
-				kube_pod_annotations{annotation_app="example",namespace="ns1",pod="pod1",uid="uid1"} 1
-		`,
-			MetricNames: []string{
-				"kube_pod_annotations",
+				# HELP kube_pod_node_topology The zone and region of the node a pod is assigned to, taken from the topology labels of the node.
+				# TYPE kube_pod_node_topology gauge
+			`,
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod5", Namespace: "ns1", UID: "uid5"},
+				Spec:       v1.PodSpec{NodeName: "unknown"},
 			},
+			Want: `
+				# HELP kube_pod_node_topology The zone and region of the node a pod is assigned to, taken from the topology labels of the node.
+				# TYPE kube_pod_node_topology gauge
+			`,
 		},
+	}
+
+	families := []generator.FamilyGenerator{
+		createPodNodeTopologyFamilyGenerator(getter),
+	}
+	for i, c := range cases {
+		c.Func = generator.ComposeMetricGenFuncs(families)
+		c.Headers = generator.ExtractMetricFamilyHeaders(families)
+		if err := c.run(); err != nil {
+			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
+		}
+	}
+}
+
+func TestPodOrphaned(t *testing.T) {
+	// owners is a fake owner index of the ReplicaSets in namespace ns1.
+	owners := map[string]types.UID{
+		"rs-live": "rs-live-uid",
+	}
+	getter := func(namespace string, owner metav1.OwnerReference) (bool, bool) {
+		if namespace != "ns1" || owner.Kind != "ReplicaSet" {
+			return false, false
+		}
+		uid, ok := owners[owner.Name]
+		return ok && uid == owner.UID, true
+	}
+	controllerOf := func(kind, name string, uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{
+			{APIVersion: "apps/v1", Kind: kind, Name: name, UID: uid, Controller: ptr.To(true)},
+		}
+	}
+
+	cases := []generateMetricsTestCase{
 		{
 			Obj: &v1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod1",
-					Namespace: "ns1",
-					UID:       "uid1",
-				},
-				Spec: v1.PodSpec{
-					Tolerations: []v1.Toleration{
-						{
-							Key:      "key1",
-							Operator: v1.TolerationOpEqual,
-							Value:    "value1",
-							Effect:   v1.TaintEffectNoSchedule,
-						},
-						{
-							Key:      "key2",
-							Operator: v1.TolerationOpExists,
-						},
-						{
-							Key:      "key3",
-							Operator: v1.TolerationOpEqual,
-							Value:    "value3",
-						},
-						{
-							// Duplicate toleration, to ensure that doesn't result in a duplicate metric
-							Key:      "key3",
-							Operator: v1.TolerationOpEqual,
-							Value:    "value3",
-						},
-						{
-							// an empty toleration to ensure that an empty toleration does not result in a metric
-						},
-					},
-				},
+				ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns1", UID: "uid1", OwnerReferences: controllerOf("ReplicaSet", "rs-live", "rs-live-uid")},
 			},
 			Want: `
-				# HELP kube_pod_tolerations Information about the pod tolerations
-				# TYPE kube_pod_tolerations gauge
-				kube_pod_tolerations{effect="",key="",namespace="ns1",operator="",pod="pod1",toleration_seconds="",uid="uid1",value=""} 1
-				kube_pod_tolerations{effect="",key="key2",namespace="ns1",operator="Exists",pod="pod1",toleration_seconds="",uid="uid1",value=""} 1
-				kube_pod_tolerations{effect="",key="key3",namespace="ns1",operator="Equal",pod="pod1",toleration_seconds="",uid="uid1",value="value3"} 1
-				kube_pod_tolerations{effect="NoSchedule",key="key1",namespace="ns1",operator="Equal",pod="pod1",toleration_seconds="",uid="uid1",value="value1"} 1
+				# HELP kube_pod_orphaned Whether the controller owner of the pod no longer exists. Pods without a controller owner are not orphaned.
+				# TYPE kube_pod_orphaned gauge
+				kube_pod_orphaned{namespace="ns1",pod="pod1",uid="uid1"} 0
 			`,
-			MetricNames: []string{
-				"kube_pod_tolerations",
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod2", Namespace: "ns1", UID: "uid2", OwnerReferences: controllerOf("ReplicaSet", "rs-deleted", "rs-deleted-uid")},
 			},
+			Want: `
+				# HELP kube_pod_orphaned Whether the controller owner of the pod no longer exists. Pods without a controller owner are not orphaned.
+				# TYPE kube_pod_orphaned gauge
+				kube_pod_orphaned{namespace="ns1",pod="pod2",uid="uid2"} 1
+			`,
 		},
 		{
+			// The owner was recreated under the same name.
 			Obj: &v1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod1",
-					Namespace: "ns1",
-					UID:       "uid1",
-				},
-				Spec: v1.PodSpec{
-					ServiceAccountName: "service-account-name",
-				},
+				ObjectMeta: metav1.ObjectMeta{Name: "pod3", Namespace: "ns1", UID: "uid3", OwnerReferences: controllerOf("ReplicaSet", "rs-live", "rs-old-uid")},
 			},
 			Want: `
-				# HELP kube_pod_service_account The service account for a pod.
-				# TYPE kube_pod_service_account gauge
-				kube_pod_service_account{namespace="ns1",pod="pod1",service_account="service-account-name",uid="uid1"} 1
+				# HELP kube_pod_orphaned Whether the controller owner of the pod no longer exists. Pods without a controller owner are not orphaned.
+				# TYPE kube_pod_orphaned gauge
+				kube_pod_orphaned{namespace="ns1",pod="pod3",uid="uid3"} 1
 			`,
-			MetricNames: []string{
-				"kube_pod_service_account",
+		},
+		{
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod4", Namespace: "ns1", UID: "uid4"},
 			},
+			Want: `
+				# HELP kube_pod_orphaned Whether the controller owner of the pod no longer exists. Pods without a controller owner are not orphaned.
+				# TYPE kube_pod_orphaned gauge
+				kube_pod_orphaned{namespace="ns1",pod="pod4",uid="uid4"} 0
+			`,
 		},
 		{
+			// Owners which are not controllers are ignored.
 			Obj: &v1.Pod{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "pod1",
-					Namespace: "ns1",
-					UID:       "uid1",
-				},
-				Spec: v1.PodSpec{
-					SchedulerName: "scheduler1",
-				},
+				ObjectMeta: metav1.ObjectMeta{Name: "pod5", Namespace: "ns1", UID: "uid5", OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs-deleted", UID: "rs-deleted-uid"},
+				}},
 			},
 			Want: `
-				# HELP kube_pod_scheduler The scheduler for a pod.
-				# TYPE kube_pod_scheduler gauge
-				kube_pod_scheduler{namespace="ns1",pod="pod1",name="scheduler1",uid="uid1"} 1
+				# HELP kube_pod_orphaned Whether the controller owner of the pod no longer exists. Pods without a controller owner are not orphaned.
+				# TYPE kube_pod_orphaned gauge
+				kube_pod_orphaned{namespace="ns1",pod="pod5",uid="uid5"} 0
 			`,
-			MetricNames: []string{
-				"kube_pod_scheduler",
+		},
+		{
+			// The existence of owners of untracked kinds is unknown.
+			Obj: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod6", Namespace: "ns1", UID: "uid6", OwnerReferences: controllerOf("Rollout", "rollout", "rollout-uid")},
 			},
+			Want: `
+				# HELP kube_pod_orphaned Whether the controller owner of the pod no longer exists. Pods without a controller owner are not orphaned.
+				# TYPE kube_pod_orphaned gauge
+			`,
 		},
 	}
 
+	families := []generator.FamilyGenerator{
+		createPodOrphanedFamilyGenerator(getter),
+	}
 	for i, c := range cases {
-		c.Func = generator.ComposeMetricGenFuncs(podMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
-		c.Headers = generator.ExtractMetricFamilyHeaders(podMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
+		c.Func = generator.ComposeMetricGenFuncs(families)
+		c.Headers = generator.ExtractMetricFamilyHeaders(families)
 		if err := c.run(); err != nil {
 			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
 		}
 	}
+
+	// The owner is looked up when the family is written out, so its deletion shows up without the pod being updated.
+	family := families[0].Generate(cases[0].Obj)
+	delete(owners, "rs-live")
+	want := `kube_pod_orphaned{namespace="ns1",pod="pod1",uid="uid1"} 1`
+	if got := string(family.ByteSlice()); !strings.Contains(got, want) {
+		t.Errorf("expected the deleted owner to be picked up, want %s, got %s", want, got)
+	}
 }
 
 func BenchmarkPodStore(b *testing.B) {
 	b.ReportAllocs()
 
-	f := generator.ComposeMetricGenFuncs(podMetricFamilies(nil, nil))
+	f := generator.ComposeMetricGenFuncs(podMetricFamilies(nil, nil, clock.RealClock{}, nil, nil, nil))
 
 	pod := &v1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -2282,7 +3560,7 @@ func BenchmarkPodStore(b *testing.B) {
 		},
 	}
 
-	expectedFamilies := 54
+	expectedFamilies := 60
 	for n := 0; n < b.N; n++ {
 		families := f(pod)
 		if len(families) != expectedFamilies {
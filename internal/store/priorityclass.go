@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"context"
+	"strconv"
+
+	basemetrics "k8s.io/component-base/metrics"
+
+	"k8s.io/kube-state-metrics/v2/pkg/metric"
+	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	descPriorityClassAnnotationsName     = "kube_priorityclass_annotations"
+	descPriorityClassAnnotationsHelp     = "Kubernetes annotations converted to Prometheus labels."
+	descPriorityClassLabelsName          = "kube_priorityclass_labels"
+	descPriorityClassLabelsHelp          = "Kubernetes labels converted to Prometheus labels."
+	descPriorityClassLabelsDefaultLabels = []string{"priorityclass"}
+)
+
+func priorityClassMetricFamilies(allowAnnotationsList, allowLabelsList []string) []generator.FamilyGenerator {
+	return []generator.FamilyGenerator{
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_priorityclass_info",
+			"Information about priorityclass.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapPriorityClassFunc(func(p *schedulingv1.PriorityClass) *metric.Family {
+				preemptionPolicy := ""
+				if p.PreemptionPolicy != nil {
+					preemptionPolicy = string(*p.PreemptionPolicy)
+				}
+				m := metric.Metric{
+					LabelKeys:   []string{"preemption_policy", "global_default"},
+					LabelValues: []string{preemptionPolicy, strconv.FormatBool(p.GlobalDefault)},
+					Value:       1,
+				}
+				return &metric.Family{Metrics: []*metric.Metric{&m}}
+			}),
+		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_priorityclass_created",
+			"Unix creation timestamp",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapPriorityClassFunc(func(p *schedulingv1.PriorityClass) *metric.Family {
+				ms := []*metric.Metric{}
+				if !p.CreationTimestamp.IsZero() {
+					ms = append(ms, &metric.Metric{
+						Value: float64(p.CreationTimestamp.Unix()),
+					})
+				}
+				return &metric.Family{
+					Metrics: ms,
+				}
+			}),
+		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_priorityclass_value",
+			"The integer value of priority that pods receive with the priorityclass.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapPriorityClassFunc(func(p *schedulingv1.PriorityClass) *metric.Family {
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							Value: float64(p.Value),
+						},
+					},
+				}
+			}),
+		),
+		*generator.NewFamilyGeneratorWithStability(
+			descPriorityClassAnnotationsName,
+			descPriorityClassAnnotationsHelp,
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapPriorityClassFunc(func(p *schedulingv1.PriorityClass) *metric.Family {
+				if len(allowAnnotationsList) == 0 {
+					return &metric.Family{}
+				}
+				annotationKeys, annotationValues := createPrometheusLabelKeysValues("annotation", p.Annotations, allowAnnotationsList)
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							LabelKeys:   annotationKeys,
+							LabelValues: annotationValues,
+							Value:       1,
+						},
+					},
+				}
+			}),
+		),
+		*generator.NewFamilyGeneratorWithStability(
+			descPriorityClassLabelsName,
+			descPriorityClassLabelsHelp,
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapPriorityClassFunc(func(p *schedulingv1.PriorityClass) *metric.Family {
+				if len(allowLabelsList) == 0 {
+					return &metric.Family{}
+				}
+				labelKeys, labelValues := createPrometheusLabelKeysValues("label", p.Labels, allowLabelsList)
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							LabelKeys:   labelKeys,
+							LabelValues: labelValues,
+							Value:       1,
+						},
+					},
+				}
+			}),
+		),
+	}
+}
+
+func wrapPriorityClassFunc(f func(*schedulingv1.PriorityClass) *metric.Family) func(interface{}) *metric.Family {
+	return func(obj interface{}) *metric.Family {
+		priorityClass := obj.(*schedulingv1.PriorityClass)
+
+		metricFamily := f(priorityClass)
+
+		for _, m := range metricFamily.Metrics {
+			m.LabelKeys, m.LabelValues = mergeKeyValues(descPriorityClassLabelsDefaultLabels, []string{priorityClass.Name}, m.LabelKeys, m.LabelValues)
+		}
+
+		return metricFamily
+	}
+}
+
+func createPriorityClassListWatch(kubeClient clientset.Interface, _ string, _ string) cache.ListerWatcher {
+	return &cache.ListWatch{
+		ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+			return kubeClient.SchedulingV1().PriorityClasses().List(context.TODO(), opts)
+		},
+		WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+			return kubeClient.SchedulingV1().PriorityClasses().Watch(context.TODO(), opts)
+		},
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+)
+
+func TestPriorityClassStore(t *testing.T) {
+	startTime := 1501569018
+	metav1StartTime := metav1.Unix(int64(startTime), 0)
+
+	cases := []generateMetricsTestCase{
+		{
+			Obj: &schedulingv1.PriorityClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "default-priority",
+					CreationTimestamp: metav1StartTime,
+				},
+				Value:            1000,
+				GlobalDefault:    true,
+				PreemptionPolicy: ptr.To(v1.PreemptLowerPriority),
+			},
+			Want: `
+					# HELP kube_priorityclass_created Unix creation timestamp
+					# HELP kube_priorityclass_info Information about priorityclass.
+					# HELP kube_priorityclass_value The integer value of priority that pods receive with the priorityclass.
+					# TYPE kube_priorityclass_created gauge
+					# TYPE kube_priorityclass_info gauge
+					# TYPE kube_priorityclass_value gauge
+					kube_priorityclass_created{priorityclass="default-priority"} 1.501569018e+09
+					kube_priorityclass_info{priorityclass="default-priority",preemption_policy="PreemptLowerPriority",global_default="true"} 1
+					kube_priorityclass_value{priorityclass="default-priority"} 1000
+				`,
+			MetricNames: []string{
+				"kube_priorityclass_created",
+				"kube_priorityclass_info",
+				"kube_priorityclass_value",
+			},
+		},
+		{
+			Obj: &schedulingv1.PriorityClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "batch-low",
+				},
+				Value:            -10,
+				PreemptionPolicy: ptr.To(v1.PreemptNever),
+			},
+			Want: `
+					# HELP kube_priorityclass_info Information about priorityclass.
+					# HELP kube_priorityclass_value The integer value of priority that pods receive with the priorityclass.
+					# TYPE kube_priorityclass_info gauge
+					# TYPE kube_priorityclass_value gauge
+					kube_priorityclass_info{priorityclass="batch-low",preemption_policy="Never",global_default="false"} 1
+					kube_priorityclass_value{priorityclass="batch-low"} -10
+				`,
+			MetricNames: []string{
+				"kube_priorityclass_info",
+				"kube_priorityclass_value",
+			},
+		},
+		{
+			AllowAnnotationsList: []string{
+				"app.kubernetes.io/managed-by",
+			},
+			AllowLabelsList: []string{
+				"foo",
+			},
+			Obj: &schedulingv1.PriorityClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default-priority",
+					Annotations: map[string]string{
+						"app.kubernetes.io/managed-by": "helm",
+					},
+					Labels: map[string]string{
+						"foo": "bar",
+					},
+				},
+				Value: 1000,
+			},
+			Want: `
+					# HELP kube_priorityclass_annotations Kubernetes annotations converted to Prometheus labels.
+					# HELP kube_priorityclass_labels Kubernetes labels converted to Prometheus labels.
+					# TYPE kube_priorityclass_annotations gauge
+					# TYPE kube_priorityclass_labels gauge
+					kube_priorityclass_annotations{priorityclass="default-priority",annotation_app_kubernetes_io_managed_by="helm"} 1
+					kube_priorityclass_labels{priorityclass="default-priority",label_foo="bar"} 1
+				`,
+			MetricNames: []string{
+				"kube_priorityclass_annotations", "kube_priorityclass_labels",
+			},
+		},
+	}
+	for i, c := range cases {
+		c.Func = generator.ComposeMetricGenFuncs(priorityClassMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
+		c.Headers = generator.ExtractMetricFamilyHeaders(priorityClassMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
+		if err := c.run(); err != nil {
+			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
+		}
+	}
+}
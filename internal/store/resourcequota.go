@@ -93,6 +93,32 @@ func resourceQuotaMetricFamilies(allowAnnotationsList, allowLabelsList []string)
 				}
 			}),
 		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_resourcequota_utilization_ratio",
+			"The ratio of used to hard resource quota. Only emitted for resources with a non-zero hard limit.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapResourceQuotaFunc(func(r *v1.ResourceQuota) *metric.Family {
+				ms := []*metric.Metric{}
+
+				for res, hard := range r.Status.Hard {
+					used, ok := r.Status.Used[res]
+					if !ok || hard.IsZero() {
+						continue
+					}
+					ms = append(ms, &metric.Metric{
+						LabelKeys:   []string{"resource"},
+						LabelValues: []string{string(res)},
+						Value:       convertValueToFloat64(&used) / convertValueToFloat64(&hard),
+					})
+				}
+
+				return &metric.Family{
+					Metrics: ms,
+				}
+			}),
+		),
 		*generator.NewFamilyGeneratorWithStability(
 			descResourceQuotaAnnotationsName,
 			descResourceQuotaAnnotationsHelp,
@@ -39,6 +39,8 @@ func TestResourceQuotaStore(t *testing.T) {
 	# TYPE kube_resourcequota_annotations gauge
 	# TYPE kube_resourcequota_created gauge
 	# TYPE kube_resourcequota_labels gauge
+	# HELP kube_resourcequota_utilization_ratio The ratio of used to hard resource quota. Only emitted for resources with a non-zero hard limit.
+	# TYPE kube_resourcequota_utilization_ratio gauge
 	`
 	cases := []generateMetricsTestCase{
 		// Verify populating base metric and that metric for unset fields are skipped.
@@ -134,6 +136,53 @@ func TestResourceQuotaStore(t *testing.T) {
 			kube_resourcequota{namespace="testNS",resource="services.nodeports",resourcequota="quotaTest",type="used"} 1
 			kube_resourcequota{namespace="testNS",resource="storage",resourcequota="quotaTest",type="hard"} 1e+10
 			kube_resourcequota{namespace="testNS",resource="storage",resourcequota="quotaTest",type="used"} 9e+09
+			kube_resourcequota_utilization_ratio{namespace="testNS",resource="configmaps",resourcequota="quotaTest"} 0.75
+			kube_resourcequota_utilization_ratio{namespace="testNS",resource="cpu",resourcequota="quotaTest"} 0.48837209302325585
+			kube_resourcequota_utilization_ratio{namespace="testNS",resource="memory",resourcequota="quotaTest"} 0.23809523809523808
+			kube_resourcequota_utilization_ratio{namespace="testNS",resource="persistentvolumeclaims",resourcequota="quotaTest"} 0.6666666666666666
+			kube_resourcequota_utilization_ratio{namespace="testNS",resource="pods",resourcequota="quotaTest"} 0.8888888888888888
+			kube_resourcequota_utilization_ratio{namespace="testNS",resource="replicationcontrollers",resourcequota="quotaTest"} 0.8571428571428571
+			kube_resourcequota_utilization_ratio{namespace="testNS",resource="resourcequotas",resourcequota="quotaTest"} 0.8333333333333334
+			kube_resourcequota_utilization_ratio{namespace="testNS",resource="secrets",resourcequota="quotaTest"} 0.8
+			kube_resourcequota_utilization_ratio{namespace="testNS",resource="services",resourcequota="quotaTest"} 0.875
+			kube_resourcequota_utilization_ratio{namespace="testNS",resource="services.loadbalancers",resourcequota="quotaTest"} 0
+			kube_resourcequota_utilization_ratio{namespace="testNS",resource="services.nodeports",resourcequota="quotaTest"} 0.5
+			kube_resourcequota_utilization_ratio{namespace="testNS",resource="storage",resourcequota="quotaTest"} 0.9
+			`,
+		},
+		// Verify the utilization ratio is only computed for resources with a hard limit.
+		{
+			Obj: &v1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "quotaTest",
+					Namespace: "testNS",
+				},
+				Status: v1.ResourceQuotaStatus{
+					Hard: v1.ResourceList{
+						v1.ResourcePods:                   resource.MustParse("10"),
+						v1.ResourceCPU:                    resource.MustParse("2"),
+						v1.ResourceServices:               resource.MustParse("0"),
+						v1.ResourcePersistentVolumeClaims: resource.MustParse("5"),
+					},
+					Used: v1.ResourceList{
+						v1.ResourcePods:     resource.MustParse("4"),
+						v1.ResourceCPU:      resource.MustParse("1500m"),
+						v1.ResourceServices: resource.MustParse("0"),
+						v1.ResourceMemory:   resource.MustParse("1G"),
+					},
+				},
+			},
+			Want: metadata + `
+			kube_resourcequota{namespace="testNS",resource="cpu",resourcequota="quotaTest",type="hard"} 2
+			kube_resourcequota{namespace="testNS",resource="cpu",resourcequota="quotaTest",type="used"} 1.5
+			kube_resourcequota{namespace="testNS",resource="memory",resourcequota="quotaTest",type="used"} 1e+09
+			kube_resourcequota{namespace="testNS",resource="persistentvolumeclaims",resourcequota="quotaTest",type="hard"} 5
+			kube_resourcequota{namespace="testNS",resource="pods",resourcequota="quotaTest",type="hard"} 10
+			kube_resourcequota{namespace="testNS",resource="pods",resourcequota="quotaTest",type="used"} 4
+			kube_resourcequota{namespace="testNS",resource="services",resourcequota="quotaTest",type="hard"} 0
+			kube_resourcequota{namespace="testNS",resource="services",resourcequota="quotaTest",type="used"} 0
+			kube_resourcequota_utilization_ratio{namespace="testNS",resource="cpu",resourcequota="quotaTest"} 0.75
+			kube_resourcequota_utilization_ratio{namespace="testNS",resource="pods",resourcequota="quotaTest"} 0.4
 			`,
 		},
 		// Verify kube_resourcequota_annotations and kube_resourcequota_labels are shown.
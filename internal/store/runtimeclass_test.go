@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	nodev1 "k8s.io/api/node/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+)
+
+func TestRuntimeClassStore(t *testing.T) {
+	startTime := 1501569018
+	metav1StartTime := metav1.Unix(int64(startTime), 0)
+
+	cases := []generateMetricsTestCase{
+		{
+			Obj: &nodev1.RuntimeClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "gvisor",
+					CreationTimestamp: metav1StartTime,
+				},
+				Handler: "runsc",
+				Overhead: &nodev1.Overhead{
+					PodFixed: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("250m"),
+						v1.ResourceMemory: resource.MustParse("120Mi"),
+					},
+				},
+			},
+			Want: `
+					# HELP kube_runtimeclass_created Unix creation timestamp
+					# HELP kube_runtimeclass_info Information about runtimeclass.
+					# HELP kube_runtimeclass_overhead_cpu_cores The fixed cpu overhead added to pods running with the runtimeclass.
+					# HELP kube_runtimeclass_overhead_memory_bytes The fixed memory overhead added to pods running with the runtimeclass.
+					# TYPE kube_runtimeclass_created gauge
+					# TYPE kube_runtimeclass_info gauge
+					# TYPE kube_runtimeclass_overhead_cpu_cores gauge
+					# TYPE kube_runtimeclass_overhead_memory_bytes gauge
+					kube_runtimeclass_created{runtimeclass="gvisor"} 1.501569018e+09
+					kube_runtimeclass_info{runtimeclass="gvisor",handler="runsc"} 1
+					kube_runtimeclass_overhead_cpu_cores{runtimeclass="gvisor"} 0.25
+					kube_runtimeclass_overhead_memory_bytes{runtimeclass="gvisor"} 1.2582912e+08
+				`,
+			MetricNames: []string{
+				"kube_runtimeclass_created",
+				"kube_runtimeclass_info",
+				"kube_runtimeclass_overhead_cpu_cores",
+				"kube_runtimeclass_overhead_memory_bytes",
+			},
+		},
+		{
+			Obj: &nodev1.RuntimeClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "runc",
+				},
+				Handler: "runc",
+			},
+			Want: `
+					# HELP kube_runtimeclass_info Information about runtimeclass.
+					# HELP kube_runtimeclass_overhead_cpu_cores The fixed cpu overhead added to pods running with the runtimeclass.
+					# HELP kube_runtimeclass_overhead_memory_bytes The fixed memory overhead added to pods running with the runtimeclass.
+					# TYPE kube_runtimeclass_info gauge
+					# TYPE kube_runtimeclass_overhead_cpu_cores gauge
+					# TYPE kube_runtimeclass_overhead_memory_bytes gauge
+					kube_runtimeclass_info{runtimeclass="runc",handler="runc"} 1
+				`,
+			MetricNames: []string{
+				"kube_runtimeclass_info",
+				"kube_runtimeclass_overhead_cpu_cores",
+				"kube_runtimeclass_overhead_memory_bytes",
+			},
+		},
+		{
+			AllowAnnotationsList: []string{
+				"app.kubernetes.io/managed-by",
+			},
+			AllowLabelsList: []string{
+				"foo",
+			},
+			Obj: &nodev1.RuntimeClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gvisor",
+					Annotations: map[string]string{
+						"app.kubernetes.io/managed-by": "helm",
+					},
+					Labels: map[string]string{
+						"foo": "bar",
+					},
+				},
+				Handler: "runsc",
+			},
+			Want: `
+					# HELP kube_runtimeclass_annotations Kubernetes annotations converted to Prometheus labels.
+					# HELP kube_runtimeclass_labels Kubernetes labels converted to Prometheus labels.
+					# TYPE kube_runtimeclass_annotations gauge
+					# TYPE kube_runtimeclass_labels gauge
+					kube_runtimeclass_annotations{runtimeclass="gvisor",annotation_app_kubernetes_io_managed_by="helm"} 1
+					kube_runtimeclass_labels{runtimeclass="gvisor",label_foo="bar"} 1
+				`,
+			MetricNames: []string{
+				"kube_runtimeclass_annotations", "kube_runtimeclass_labels",
+			},
+		},
+	}
+	for i, c := range cases {
+		c.Func = generator.ComposeMetricGenFuncs(runtimeClassMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
+		c.Headers = generator.ExtractMetricFamilyHeaders(runtimeClassMetricFamilies(c.AllowAnnotationsList, c.AllowLabelsList))
+		if err := c.run(); err != nil {
+			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
+		}
+	}
+}
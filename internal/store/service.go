@@ -20,6 +20,7 @@ import (
 	"context"
 
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
@@ -37,9 +38,14 @@ var (
 	descServiceLabelsName          = "kube_service_labels"
 	descServiceLabelsHelp          = "Kubernetes labels converted to Prometheus labels."
 	descServiceLabelsDefaultLabels = []string{"namespace", "service", "uid"}
+	descServiceReadyEndpointsName  = "kube_service_status_ready_endpoints"
 )
 
-func serviceMetricFamilies(allowAnnotationsList, allowLabelsList []string) []generator.FamilyGenerator {
+// endpointSliceLister returns the EndpointSlices of the given service, and whether they
+// are known, i.e. the EndpointSlices of its namespace have been listed.
+type endpointSliceLister func(namespace, service string) (slices []*discoveryv1.EndpointSlice, known bool)
+
+func serviceMetricFamilies(allowAnnotationsList, allowLabelsList []string, endpointSlices endpointSliceLister) []generator.FamilyGenerator {
 	return []generator.FamilyGenerator{
 		*generator.NewFamilyGeneratorWithStability(
 			"kube_service_info",
@@ -90,6 +96,57 @@ func serviceMetricFamilies(allowAnnotationsList, allowLabelsList []string) []gen
 				return &metric.Family{Metrics: []*metric.Metric{&m}}
 			}),
 		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_service_spec_headless",
+			"Whether the service is headless, i.e. its cluster IP is None.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapSvcFunc(func(s *v1.Service) *metric.Family {
+				// ExternalName services have no cluster IP and are not considered headless.
+				m := metric.Metric{
+					Value: boolFloat64(s.Spec.ClusterIP == v1.ClusterIPNone),
+				}
+				return &metric.Family{Metrics: []*metric.Metric{&m}}
+			}),
+		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_service_spec_external_traffic_policy",
+			"The policy routing external traffic of a NodePort or LoadBalancer service to node-local or cluster-wide endpoints.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapSvcFunc(func(s *v1.Service) *metric.Family {
+				// The policy only applies to services reachable from outside the cluster.
+				if s.Spec.ExternalTrafficPolicy == "" || (s.Spec.Type != v1.ServiceTypeNodePort && s.Spec.Type != v1.ServiceTypeLoadBalancer) {
+					return &metric.Family{Metrics: []*metric.Metric{}}
+				}
+				m := metric.Metric{
+					LabelKeys:   []string{"policy"},
+					LabelValues: []string{string(s.Spec.ExternalTrafficPolicy)},
+					Value:       1,
+				}
+				return &metric.Family{Metrics: []*metric.Metric{&m}}
+			}),
+		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_service_spec_internal_traffic_policy",
+			"The policy routing internal traffic of a service to node-local or cluster-wide endpoints.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapSvcFunc(func(s *v1.Service) *metric.Family {
+				if s.Spec.InternalTrafficPolicy == nil {
+					return &metric.Family{Metrics: []*metric.Metric{}}
+				}
+				m := metric.Metric{
+					LabelKeys:   []string{"policy"},
+					LabelValues: []string{string(*s.Spec.InternalTrafficPolicy)},
+					Value:       1,
+				}
+				return &metric.Family{Metrics: []*metric.Metric{&m}}
+			}),
+		),
 		*generator.NewFamilyGeneratorWithStability(
 			descServiceAnnotationsName,
 			descServiceAnnotationsHelp,
@@ -184,18 +241,62 @@ func serviceMetricFamilies(allowAnnotationsList, allowLabelsList []string) []gen
 				}
 			}),
 		),
+		createServiceReadyEndpointsFamilyGenerator(endpointSlices),
 	}
 }
 
+func createServiceReadyEndpointsFamilyGenerator(endpointSlices endpointSliceLister) generator.FamilyGenerator {
+	return *generator.NewOptInFamilyGenerator(
+		descServiceReadyEndpointsName,
+		"The number of ready addresses across the EndpointSlices of the service.",
+		metric.Gauge,
+		basemetrics.ALPHA,
+		"",
+		wrapSvcFunc(func(s *v1.Service) *metric.Family {
+			// ExternalName services are resolved through DNS and have no endpoints.
+			if endpointSlices == nil || s.Spec.Type == v1.ServiceTypeExternalName {
+				return &metric.Family{Metrics: []*metric.Metric{}}
+			}
+
+			// The EndpointSlices are looked up when the metrics are written out, as they change independently of the service.
+			namespace, name := s.Namespace, s.Name
+			return &metric.Family{
+				Deferred: func() []*metric.Metric {
+					slices, known := endpointSlices(namespace, name)
+					if !known {
+						return []*metric.Metric{}
+					}
+					ready := 0
+					for _, slice := range slices {
+						for _, endpoint := range slice.Endpoints {
+							// A nil ready condition is to be interpreted as ready.
+							if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+								ready += len(endpoint.Addresses)
+							}
+						}
+					}
+					return []*metric.Metric{
+						{
+							Value: float64(ready),
+						},
+					}
+				},
+			}
+		}),
+	)
+}
+
 func wrapSvcFunc(f func(*v1.Service) *metric.Family) func(interface{}) *metric.Family {
 	return func(obj interface{}) *metric.Family {
 		svc := obj.(*v1.Service)
 
 		metricFamily := f(svc)
 
-		for _, m := range metricFamily.Metrics {
-			m.LabelKeys, m.LabelValues = mergeKeyValues(descServiceLabelsDefaultLabels, []string{svc.Namespace, svc.Name, string(svc.UID)}, m.LabelKeys, m.LabelValues)
-		}
+		// Deferred metrics are generated later on, so only the label values are retained, not the service.
+		labelValues := []string{svc.Namespace, svc.Name, string(svc.UID)}
+		metricFamily.Each(func(m *metric.Metric) {
+			m.LabelKeys, m.LabelValues = mergeKeyValues(descServiceLabelsDefaultLabels, labelValues, m.LabelKeys, m.LabelValues)
+		})
 
 		return metricFamily
 	}
@@ -21,7 +21,10 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 
 	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
 )
@@ -40,10 +43,18 @@ func TestServiceStore(t *testing.T) {
 		# TYPE kube_service_labels gauge
 		# HELP kube_service_spec_type [STABLE] Type about service.
 		# TYPE kube_service_spec_type gauge
+		# HELP kube_service_spec_headless Whether the service is headless, i.e. its cluster IP is None.
+		# TYPE kube_service_spec_headless gauge
+		# HELP kube_service_spec_external_traffic_policy The policy routing external traffic of a NodePort or LoadBalancer service to node-local or cluster-wide endpoints.
+		# TYPE kube_service_spec_external_traffic_policy gauge
+		# HELP kube_service_spec_internal_traffic_policy The policy routing internal traffic of a service to node-local or cluster-wide endpoints.
+		# TYPE kube_service_spec_internal_traffic_policy gauge
 		# HELP kube_service_spec_external_ip [STABLE] Service external ips. One series for each ip
 		# TYPE kube_service_spec_external_ip gauge
 		# HELP kube_service_status_load_balancer_ingress [STABLE] Service load balancer ingress status
+		# HELP kube_service_status_ready_endpoints The number of ready addresses across the EndpointSlices of the service.
 		# TYPE kube_service_status_load_balancer_ingress gauge
+		# TYPE kube_service_status_ready_endpoints gauge
 	`
 	cases := []generateMetricsTestCase{
 		{
@@ -106,6 +117,7 @@ func TestServiceStore(t *testing.T) {
 				kube_service_created{namespace="default",service="test-service2",uid="uid2"} 1.5e+09
 				kube_service_info{cluster_ip="1.2.3.5",external_name="",external_traffic_policy="",load_balancer_ip="",namespace="default",service="test-service2",uid="uid2"} 1
 				kube_service_spec_type{namespace="default",service="test-service2",uid="uid2",type="NodePort"} 1
+				kube_service_spec_headless{namespace="default",service="test-service2",uid="uid2"} 0
 `,
 		},
 		{
@@ -129,6 +141,7 @@ func TestServiceStore(t *testing.T) {
 				kube_service_created{namespace="default",service="test-service3",uid="uid3"} 1.5e+09
 				kube_service_info{cluster_ip="1.2.3.6",external_name="",external_traffic_policy="",load_balancer_ip="1.2.3.7",namespace="default",service="test-service3",uid="uid3"} 1
 				kube_service_spec_type{namespace="default",service="test-service3",type="LoadBalancer",uid="uid3"} 1
+				kube_service_spec_headless{namespace="default",service="test-service3",uid="uid3"} 0
 `,
 		},
 		{
@@ -151,6 +164,7 @@ func TestServiceStore(t *testing.T) {
 				kube_service_created{namespace="default",service="test-service4",uid="uid4"} 1.5e+09
 				kube_service_info{cluster_ip="",external_name="www.example.com",external_traffic_policy="",load_balancer_ip="",namespace="default",service="test-service4",uid="uid4"} 1
 				kube_service_spec_type{namespace="default",service="test-service4",uid="uid4",type="ExternalName"} 1
+				kube_service_spec_headless{namespace="default",service="test-service4",uid="uid4"} 0
 			`,
 		},
 		{
@@ -182,6 +196,7 @@ func TestServiceStore(t *testing.T) {
 				kube_service_created{namespace="default",service="test-service5",uid="uid5"} 1.5e+09
 				kube_service_info{cluster_ip="",external_name="",external_traffic_policy="",load_balancer_ip="",namespace="default",service="test-service5",uid="uid5"} 1
 				kube_service_spec_type{namespace="default",service="test-service5",type="LoadBalancer",uid="uid5"} 1
+				kube_service_spec_headless{namespace="default",service="test-service5",uid="uid5"} 0
 				kube_service_status_load_balancer_ingress{hostname="www.example.com",ip="1.2.3.8",namespace="default",service="test-service5",uid="uid5"} 1
 			`,
 		},
@@ -208,6 +223,7 @@ func TestServiceStore(t *testing.T) {
 				kube_service_created{namespace="default",service="test-service6",uid="uid6"} 1.5e+09
 				kube_service_info{cluster_ip="",external_name="",external_traffic_policy="",load_balancer_ip="",namespace="default",service="test-service6",uid="uid6"} 1
 				kube_service_spec_type{namespace="default",service="test-service6",uid="uid6",type="ClusterIP"} 1
+				kube_service_spec_headless{namespace="default",service="test-service6",uid="uid6"} 0
 				kube_service_spec_external_ip{external_ip="1.2.3.9",namespace="default",service="test-service6",uid="uid6"} 1
 				kube_service_spec_external_ip{external_ip="1.2.3.10",namespace="default",service="test-service6",uid="uid6"} 1
 			`,
@@ -233,6 +249,7 @@ func TestServiceStore(t *testing.T) {
 				kube_service_created{namespace="default",service="test-service7",uid="uid7"} 1.5e+09
 				kube_service_info{cluster_ip="1.2.3.11",external_name="",external_traffic_policy="Cluster",load_balancer_ip="",namespace="default",service="test-service7",uid="uid7"} 1
 				kube_service_spec_type{namespace="default",service="test-service7",uid="uid7",type="ClusterIP"} 1
+				kube_service_spec_headless{namespace="default",service="test-service7",uid="uid7"} 0
 			`,
 		},
 		{
@@ -257,12 +274,248 @@ func TestServiceStore(t *testing.T) {
 				kube_service_created{namespace="default",service="test-service8",uid="uid8"} 1.5e+09
 				kube_service_info{cluster_ip="1.2.3.12",external_name="",external_traffic_policy="Local",load_balancer_ip="1.2.3.13",namespace="default",service="test-service8",uid="uid8"} 1
 				kube_service_spec_type{namespace="default",service="test-service8",uid="uid8",type="LoadBalancer"} 1
+				kube_service_spec_headless{namespace="default",service="test-service8",uid="uid8"} 0
+				kube_service_spec_external_traffic_policy{namespace="default",service="test-service8",uid="uid8",policy="Local"} 1
 			`,
 		},
+		{
+			Obj: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-service-headless",
+					Namespace: "default",
+					UID:       "uid9",
+				},
+				Spec: v1.ServiceSpec{
+					ClusterIP: v1.ClusterIPNone,
+					Type:      v1.ServiceTypeClusterIP,
+				},
+			},
+			Want: `
+				# HELP kube_service_spec_headless Whether the service is headless, i.e. its cluster IP is None.
+				# HELP kube_service_spec_type [STABLE] Type about service.
+				# TYPE kube_service_spec_headless gauge
+				# TYPE kube_service_spec_type gauge
+				kube_service_spec_headless{namespace="default",service="test-service-headless",uid="uid9"} 1
+				kube_service_spec_type{namespace="default",service="test-service-headless",uid="uid9",type="ClusterIP"} 1
+			`,
+			MetricNames: []string{
+				"kube_service_spec_headless",
+				"kube_service_spec_type",
+			},
+		},
+		{
+			Obj: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-service-lb-ip",
+					Namespace: "default",
+					UID:       "uid10",
+				},
+				Spec: v1.ServiceSpec{
+					Type: v1.ServiceTypeLoadBalancer,
+				},
+				Status: v1.ServiceStatus{
+					LoadBalancer: v1.LoadBalancerStatus{
+						Ingress: []v1.LoadBalancerIngress{
+							{IP: "203.0.113.10"},
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_service_status_load_balancer_ingress [STABLE] Service load balancer ingress status
+				# TYPE kube_service_status_load_balancer_ingress gauge
+				kube_service_status_load_balancer_ingress{hostname="",ip="203.0.113.10",namespace="default",service="test-service-lb-ip",uid="uid10"} 1
+			`,
+			MetricNames: []string{"kube_service_status_load_balancer_ingress"},
+		},
+		{
+			Obj: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-service-lb-hostname",
+					Namespace: "default",
+					UID:       "uid11",
+				},
+				Spec: v1.ServiceSpec{
+					Type: v1.ServiceTypeLoadBalancer,
+				},
+				Status: v1.ServiceStatus{
+					LoadBalancer: v1.LoadBalancerStatus{
+						Ingress: []v1.LoadBalancerIngress{
+							{Hostname: "a1b2c3.elb.example.com"},
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_service_status_load_balancer_ingress [STABLE] Service load balancer ingress status
+				# TYPE kube_service_status_load_balancer_ingress gauge
+				kube_service_status_load_balancer_ingress{hostname="a1b2c3.elb.example.com",ip="",namespace="default",service="test-service-lb-hostname",uid="uid11"} 1
+			`,
+			MetricNames: []string{"kube_service_status_load_balancer_ingress"},
+		},
+		{
+			Obj: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-service-lb-pending",
+					Namespace: "default",
+					UID:       "uid12",
+				},
+				Spec: v1.ServiceSpec{
+					Type: v1.ServiceTypeLoadBalancer,
+				},
+			},
+			Want: `
+				# HELP kube_service_status_load_balancer_ingress [STABLE] Service load balancer ingress status
+				# TYPE kube_service_status_load_balancer_ingress gauge
+			`,
+			MetricNames: []string{"kube_service_status_load_balancer_ingress"},
+		},
+		{
+			Obj: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-service-local",
+					Namespace: "default",
+					UID:       "uid13",
+				},
+				Spec: v1.ServiceSpec{
+					ClusterIP:             "1.2.3.14",
+					Type:                  v1.ServiceTypeNodePort,
+					ExternalTrafficPolicy: v1.ServiceExternalTrafficPolicyLocal,
+					InternalTrafficPolicy: ptr.To(v1.ServiceInternalTrafficPolicyLocal),
+				},
+			},
+			Want: `
+				# HELP kube_service_spec_external_traffic_policy The policy routing external traffic of a NodePort or LoadBalancer service to node-local or cluster-wide endpoints.
+				# HELP kube_service_spec_internal_traffic_policy The policy routing internal traffic of a service to node-local or cluster-wide endpoints.
+				# TYPE kube_service_spec_external_traffic_policy gauge
+				# TYPE kube_service_spec_internal_traffic_policy gauge
+				kube_service_spec_external_traffic_policy{namespace="default",service="test-service-local",uid="uid13",policy="Local"} 1
+				kube_service_spec_internal_traffic_policy{namespace="default",service="test-service-local",uid="uid13",policy="Local"} 1
+			`,
+			MetricNames: []string{
+				"kube_service_spec_external_traffic_policy",
+				"kube_service_spec_internal_traffic_policy",
+			},
+		},
+		{
+			Obj: &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-service-cluster-ip",
+					Namespace: "default",
+					UID:       "uid14",
+				},
+				Spec: v1.ServiceSpec{
+					ClusterIP:             "1.2.3.15",
+					Type:                  v1.ServiceTypeClusterIP,
+					InternalTrafficPolicy: ptr.To(v1.ServiceInternalTrafficPolicyCluster),
+				},
+			},
+			Want: `
+				# HELP kube_service_spec_external_traffic_policy The policy routing external traffic of a NodePort or LoadBalancer service to node-local or cluster-wide endpoints.
+				# HELP kube_service_spec_internal_traffic_policy The policy routing internal traffic of a service to node-local or cluster-wide endpoints.
+				# TYPE kube_service_spec_external_traffic_policy gauge
+				# TYPE kube_service_spec_internal_traffic_policy gauge
+				kube_service_spec_internal_traffic_policy{namespace="default",service="test-service-cluster-ip",uid="uid14",policy="Cluster"} 1
+			`,
+			MetricNames: []string{
+				"kube_service_spec_external_traffic_policy",
+				"kube_service_spec_internal_traffic_policy",
+			},
+		},
+	}
+	for i, c := range cases {
+		c.Func = generator.ComposeMetricGenFuncs(serviceMetricFamilies(nil, nil, nil))
+		c.Headers = generator.ExtractMetricFamilyHeaders(serviceMetricFamilies(nil, nil, nil))
+		if err := c.run(); err != nil {
+			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
+		}
+	}
+}
+
+func TestServiceReadyEndpoints(t *testing.T) {
+	endpoint := func(ready *bool, addresses ...string) discoveryv1.Endpoint {
+		return discoveryv1.Endpoint{Addresses: addresses, Conditions: discoveryv1.EndpointConditions{Ready: ready}}
+	}
+	// slices is a fake EndpointSlice index of the services in namespace ns1.
+	slices := map[string][]*discoveryv1.EndpointSlice{
+		"svc-ready": {
+			{Endpoints: []discoveryv1.Endpoint{
+				endpoint(ptr.To(true), "10.0.0.1"),
+				endpoint(nil, "10.0.0.2"),
+				endpoint(ptr.To(false), "10.0.0.3"),
+			}},
+			{Endpoints: []discoveryv1.Endpoint{
+				endpoint(ptr.To(true), "10.0.1.1"),
+			}},
+		},
+		"svc-unready": {
+			{Endpoints: []discoveryv1.Endpoint{
+				endpoint(ptr.To(false), "10.0.0.4"),
+			}},
+		},
+	}
+	lister := func(namespace, service string) ([]*discoveryv1.EndpointSlice, bool) {
+		if namespace != "ns1" {
+			return nil, false
+		}
+		return slices[service], true
+	}
+	service := func(namespace, name, uid string, serviceType v1.ServiceType) *v1.Service {
+		return &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(uid)},
+			Spec:       v1.ServiceSpec{Type: serviceType},
+		}
+	}
+
+	cases := []generateMetricsTestCase{
+		{
+			Obj: service("ns1", "svc-ready", "uid1", v1.ServiceTypeClusterIP),
+			Want: `
+				# HELP kube_service_status_ready_endpoints The number of ready addresses across the EndpointSlices of the service.
+				# TYPE kube_service_status_ready_endpoints gauge
+				kube_service_status_ready_endpoints{namespace="ns1",service="svc-ready",uid="uid1"} 3
+			`,
+		},
+		{
+			Obj: service("ns1", "svc-unready", "uid2", v1.ServiceTypeClusterIP),
+			Want: `
+				# HELP kube_service_status_ready_endpoints The number of ready addresses across the EndpointSlices of the service.
+				# TYPE kube_service_status_ready_endpoints gauge
+				kube_service_status_ready_endpoints{namespace="ns1",service="svc-unready",uid="uid2"} 0
+			`,
+		},
+		{
+			// Services without EndpointSlices have no ready endpoints.
+			Obj: service("ns1", "svc-no-slices", "uid3", v1.ServiceTypeClusterIP),
+			Want: `
+				# HELP kube_service_status_ready_endpoints The number of ready addresses across the EndpointSlices of the service.
+				# TYPE kube_service_status_ready_endpoints gauge
+				kube_service_status_ready_endpoints{namespace="ns1",service="svc-no-slices",uid="uid3"} 0
+			`,
+		},
+		{
+			Obj: service("ns1", "svc-external-name", "uid4", v1.ServiceTypeExternalName),
+			Want: `
+				# HELP kube_service_status_ready_endpoints The number of ready addresses across the EndpointSlices of the service.
+				# TYPE kube_service_status_ready_endpoints gauge
+			`,
+		},
+		{
+			// The EndpointSlices of the namespace have not been listed yet.
+			Obj: service("ns2", "svc-ready", "uid5", v1.ServiceTypeClusterIP),
+			Want: `
+				# HELP kube_service_status_ready_endpoints The number of ready addresses across the EndpointSlices of the service.
+				# TYPE kube_service_status_ready_endpoints gauge
+			`,
+		},
+	}
+
+	families := []generator.FamilyGenerator{
+		createServiceReadyEndpointsFamilyGenerator(lister),
 	}
 	for i, c := range cases {
-		c.Func = generator.ComposeMetricGenFuncs(serviceMetricFamilies(nil, nil))
-		c.Headers = generator.ExtractMetricFamilyHeaders(serviceMetricFamilies(nil, nil))
+		c.Func = generator.ComposeMetricGenFuncs(families)
+		c.Headers = generator.ExtractMetricFamilyHeaders(families)
 		if err := c.run(); err != nil {
 			t.Errorf("unexpected collecting result in %vth run:\n%s", i, err)
 		}
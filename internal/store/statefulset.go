@@ -94,6 +94,29 @@ func statefulSetMetricFamilies(allowAnnotationsList, allowLabelsList []string) [
 				}
 			}),
 		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_statefulset_status_replicas_unavailable",
+			"The number of unavailable replicas per StatefulSet.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapStatefulSetFunc(func(s *v1.StatefulSet) *metric.Family {
+				// StatefulSets do not report unavailable replicas, so they are derived
+				// the same way the deployment controller does for Deployments.
+				replicas := int32(1)
+				if s.Spec.Replicas != nil {
+					replicas = *s.Spec.Replicas
+				}
+
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							Value: float64(max(replicas-s.Status.AvailableReplicas, 0)),
+						},
+					},
+				}
+			}),
+		),
 		*generator.NewFamilyGeneratorWithStability(
 			"kube_statefulset_status_replicas_current",
 			"The number of current replicas per StatefulSet.",
@@ -178,6 +201,18 @@ func statefulSetMetricFamilies(allowAnnotationsList, allowLabelsList []string) [
 				}
 			}),
 		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_statefulset_spec_template_container_image",
+			"The image of each container in the pod template of a StatefulSet.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapStatefulSetFunc(func(s *v1.StatefulSet) *metric.Family {
+				return &metric.Family{
+					Metrics: podTemplateContainerImageMetrics(s.Spec.Template),
+				}
+			}),
+		),
 		*generator.NewFamilyGeneratorWithStability(
 			"kube_statefulset_ordinals_start",
 			"Start ordinal of the StatefulSet.",
@@ -214,6 +249,22 @@ func statefulSetMetricFamilies(allowAnnotationsList, allowLabelsList []string) [
 				}
 			}),
 		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_statefulset_metadata_generation_matches_observed",
+			"Whether the generation of the StatefulSet was observed by the StatefulSet controller.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapStatefulSetFunc(func(s *v1.StatefulSet) *metric.Family {
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							Value: boolFloat64(s.ObjectMeta.Generation == s.Status.ObservedGeneration),
+						},
+					},
+				}
+			}),
+		),
 		*generator.NewFamilyGeneratorWithStability(
 			"kube_statefulset_persistentvolumeclaim_retention_policy",
 			"Count of retention policy for StatefulSet template PVCs",
@@ -321,6 +372,30 @@ func statefulSetMetricFamilies(allowAnnotationsList, allowLabelsList []string) [
 				}
 			}),
 		),
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_statefulset_rollout_complete",
+			"Whether the latest revision of the StatefulSet has been rolled out to all of its replicas.",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			wrapStatefulSetFunc(func(s *v1.StatefulSet) *metric.Family {
+				replicas := int32(1)
+				if s.Spec.Replicas != nil {
+					replicas = *s.Spec.Replicas
+				}
+				complete := s.Status.ObservedGeneration >= s.Generation &&
+					s.Status.CurrentRevision == s.Status.UpdateRevision &&
+					s.Status.UpdatedReplicas == replicas
+
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							Value: boolFloat64(complete),
+						},
+					},
+				}
+			}),
+		),
 	}
 }
 
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
@@ -63,6 +64,7 @@ func TestStatefulSetStore(t *testing.T) {
 				# HELP kube_statefulset_created [STABLE] Unix creation timestamp
 				# HELP kube_statefulset_labels [STABLE] Kubernetes labels converted to Prometheus labels.
 				# HELP kube_statefulset_metadata_generation [STABLE] Sequence number representing a specific generation of the desired state for the StatefulSet.
+				# HELP kube_statefulset_metadata_generation_matches_observed Whether the generation of the StatefulSet was observed by the StatefulSet controller.
 				# HELP kube_statefulset_persistentvolumeclaim_retention_policy Count of retention policy for StatefulSet template PVCs
 				# HELP kube_statefulset_replicas [STABLE] Number of desired pods for a StatefulSet.
 				# HELP kube_statefulset_ordinals_start [STABLE] Start ordinal of the StatefulSet.
@@ -72,11 +74,13 @@ func TestStatefulSetStore(t *testing.T) {
 				# HELP kube_statefulset_status_replicas_available The number of available replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_current [STABLE] The number of current replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_ready [STABLE] The number of ready replicas per StatefulSet.
+				# HELP kube_statefulset_status_replicas_unavailable The number of unavailable replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_updated [STABLE] The number of updated replicas per StatefulSet.
 				# HELP kube_statefulset_status_update_revision [STABLE] Indicates the version of the StatefulSet used to generate Pods in the sequence [replicas-updatedReplicas,replicas)
 				# TYPE kube_statefulset_created gauge
 				# TYPE kube_statefulset_labels gauge
 				# TYPE kube_statefulset_metadata_generation gauge
+				# TYPE kube_statefulset_metadata_generation_matches_observed gauge
 				# TYPE kube_statefulset_persistentvolumeclaim_retention_policy gauge
 				# TYPE kube_statefulset_replicas gauge
 				# TYPE kube_statefulset_ordinals_start gauge
@@ -86,6 +90,7 @@ func TestStatefulSetStore(t *testing.T) {
 				# TYPE kube_statefulset_status_replicas_available gauge
 				# TYPE kube_statefulset_status_replicas_current gauge
 				# TYPE kube_statefulset_status_replicas_ready gauge
+				# TYPE kube_statefulset_status_replicas_unavailable gauge
 				# TYPE kube_statefulset_status_replicas_updated gauge
 				# TYPE kube_statefulset_status_update_revision gauge
 				kube_statefulset_status_update_revision{namespace="ns1",revision="ur1",statefulset="statefulset1"} 1
@@ -95,10 +100,12 @@ func TestStatefulSetStore(t *testing.T) {
 				kube_statefulset_status_replicas_available{namespace="ns1",statefulset="statefulset1"} 0
 				kube_statefulset_status_replicas_current{namespace="ns1",statefulset="statefulset1"} 0
 				kube_statefulset_status_replicas_ready{namespace="ns1",statefulset="statefulset1"} 0
+				kube_statefulset_status_replicas_unavailable{namespace="ns1",statefulset="statefulset1"} 3
 				kube_statefulset_status_replicas_updated{namespace="ns1",statefulset="statefulset1"} 0
  				kube_statefulset_status_observed_generation{namespace="ns1",statefulset="statefulset1"} 1
  				kube_statefulset_replicas{namespace="ns1",statefulset="statefulset1"} 3
  				kube_statefulset_metadata_generation{namespace="ns1",statefulset="statefulset1"} 3
+ 				kube_statefulset_metadata_generation_matches_observed{namespace="ns1",statefulset="statefulset1"} 0
 `,
 			MetricNames: []string{
 				"kube_statefulset_created",
@@ -145,6 +152,7 @@ func TestStatefulSetStore(t *testing.T) {
 			Want: `
 				# HELP kube_statefulset_labels [STABLE] Kubernetes labels converted to Prometheus labels.
 				# HELP kube_statefulset_metadata_generation [STABLE] Sequence number representing a specific generation of the desired state for the StatefulSet.
+				# HELP kube_statefulset_metadata_generation_matches_observed Whether the generation of the StatefulSet was observed by the StatefulSet controller.
 				# HELP kube_statefulset_persistentvolumeclaim_retention_policy Count of retention policy for StatefulSet template PVCs
 				# HELP kube_statefulset_replicas [STABLE] Number of desired pods for a StatefulSet.
 				# HELP kube_statefulset_status_current_revision [STABLE] Indicates the version of the StatefulSet used to generate Pods in the sequence [0,currentReplicas).
@@ -153,10 +161,12 @@ func TestStatefulSetStore(t *testing.T) {
 				# HELP kube_statefulset_status_replicas_available The number of available replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_current [STABLE] The number of current replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_ready [STABLE] The number of ready replicas per StatefulSet.
+				# HELP kube_statefulset_status_replicas_unavailable The number of unavailable replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_updated [STABLE] The number of updated replicas per StatefulSet.
 				# HELP kube_statefulset_status_update_revision [STABLE] Indicates the version of the StatefulSet used to generate Pods in the sequence [replicas-updatedReplicas,replicas)
 				# TYPE kube_statefulset_labels gauge
 				# TYPE kube_statefulset_metadata_generation gauge
+				# TYPE kube_statefulset_metadata_generation_matches_observed gauge
 				# TYPE kube_statefulset_persistentvolumeclaim_retention_policy gauge
 				# TYPE kube_statefulset_replicas gauge
 				# TYPE kube_statefulset_status_current_revision gauge
@@ -165,6 +175,7 @@ func TestStatefulSetStore(t *testing.T) {
 				# TYPE kube_statefulset_status_replicas_available gauge
 				# TYPE kube_statefulset_status_replicas_current gauge
 				# TYPE kube_statefulset_status_replicas_ready gauge
+				# TYPE kube_statefulset_status_replicas_unavailable gauge
 				# TYPE kube_statefulset_status_replicas_updated gauge
 				# TYPE kube_statefulset_status_update_revision gauge
 				kube_statefulset_status_update_revision{namespace="ns2",revision="ur2",statefulset="statefulset2"} 1
@@ -172,10 +183,12 @@ func TestStatefulSetStore(t *testing.T) {
 				kube_statefulset_status_replicas_available{namespace="ns2",statefulset="statefulset2"} 4
 				kube_statefulset_status_replicas_current{namespace="ns2",statefulset="statefulset2"} 2
 				kube_statefulset_status_replicas_ready{namespace="ns2",statefulset="statefulset2"} 5
+				kube_statefulset_status_replicas_unavailable{namespace="ns2",statefulset="statefulset2"} 2
 				kube_statefulset_status_replicas_updated{namespace="ns2",statefulset="statefulset2"} 3
 				kube_statefulset_status_observed_generation{namespace="ns2",statefulset="statefulset2"} 2
 				kube_statefulset_replicas{namespace="ns2",statefulset="statefulset2"} 6
 				kube_statefulset_metadata_generation{namespace="ns2",statefulset="statefulset2"} 21
+				kube_statefulset_metadata_generation_matches_observed{namespace="ns2",statefulset="statefulset2"} 0
 				kube_statefulset_status_current_revision{namespace="ns2",revision="cr2",statefulset="statefulset2"} 1
 `,
 			MetricNames: []string{
@@ -217,6 +230,7 @@ func TestStatefulSetStore(t *testing.T) {
 			Want: `
 				# HELP kube_statefulset_labels [STABLE] Kubernetes labels converted to Prometheus labels.
 				# HELP kube_statefulset_metadata_generation [STABLE] Sequence number representing a specific generation of the desired state for the StatefulSet.
+				# HELP kube_statefulset_metadata_generation_matches_observed Whether the generation of the StatefulSet was observed by the StatefulSet controller.
 				# HELP kube_statefulset_persistentvolumeclaim_retention_policy Count of retention policy for StatefulSet template PVCs
 				# HELP kube_statefulset_replicas [STABLE] Number of desired pods for a StatefulSet.
 				# HELP kube_statefulset_status_current_revision [STABLE] Indicates the version of the StatefulSet used to generate Pods in the sequence [0,currentReplicas).
@@ -224,10 +238,12 @@ func TestStatefulSetStore(t *testing.T) {
 				# HELP kube_statefulset_status_replicas_available The number of available replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_current [STABLE] The number of current replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_ready [STABLE] The number of ready replicas per StatefulSet.
+				# HELP kube_statefulset_status_replicas_unavailable The number of unavailable replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_updated [STABLE] The number of updated replicas per StatefulSet.
 				# HELP kube_statefulset_status_update_revision [STABLE] Indicates the version of the StatefulSet used to generate Pods in the sequence [replicas-updatedReplicas,replicas)
 				# TYPE kube_statefulset_labels gauge
 				# TYPE kube_statefulset_metadata_generation gauge
+				# TYPE kube_statefulset_metadata_generation_matches_observed gauge
 				# TYPE kube_statefulset_persistentvolumeclaim_retention_policy gauge
 				# TYPE kube_statefulset_replicas gauge
 				# TYPE kube_statefulset_status_current_revision gauge
@@ -235,6 +251,7 @@ func TestStatefulSetStore(t *testing.T) {
 				# TYPE kube_statefulset_status_replicas_available gauge
 				# TYPE kube_statefulset_status_replicas_current gauge
 				# TYPE kube_statefulset_status_replicas_ready gauge
+				# TYPE kube_statefulset_status_replicas_unavailable gauge
 				# TYPE kube_statefulset_status_replicas_updated gauge
 				# TYPE kube_statefulset_status_update_revision gauge
 				kube_statefulset_status_update_revision{namespace="ns3",revision="ur3",statefulset="statefulset3"} 1
@@ -242,9 +259,11 @@ func TestStatefulSetStore(t *testing.T) {
 				kube_statefulset_status_replicas_available{namespace="ns3",statefulset="statefulset3"} 0
 				kube_statefulset_status_replicas_current{namespace="ns3",statefulset="statefulset3"} 0
 				kube_statefulset_status_replicas_ready{namespace="ns3",statefulset="statefulset3"} 0
+				kube_statefulset_status_replicas_unavailable{namespace="ns3",statefulset="statefulset3"} 9
 				kube_statefulset_status_replicas_updated{namespace="ns3",statefulset="statefulset3"} 0
 				kube_statefulset_replicas{namespace="ns3",statefulset="statefulset3"} 9
 				kube_statefulset_metadata_generation{namespace="ns3",statefulset="statefulset3"} 36
+				kube_statefulset_metadata_generation_matches_observed{namespace="ns3",statefulset="statefulset3"} 0
 				kube_statefulset_status_current_revision{namespace="ns3",revision="cr3",statefulset="statefulset3"} 1
  			`,
 			MetricNames: []string{
@@ -289,6 +308,7 @@ func TestStatefulSetStore(t *testing.T) {
 			Want: `
 				# HELP kube_statefulset_labels [STABLE] Kubernetes labels converted to Prometheus labels.
 				# HELP kube_statefulset_metadata_generation [STABLE] Sequence number representing a specific generation of the desired state for the StatefulSet.
+				# HELP kube_statefulset_metadata_generation_matches_observed Whether the generation of the StatefulSet was observed by the StatefulSet controller.
 				# HELP kube_statefulset_persistentvolumeclaim_retention_policy Count of retention policy for StatefulSet template PVCs
 				# HELP kube_statefulset_replicas [STABLE] Number of desired pods for a StatefulSet.
 				# HELP kube_statefulset_status_current_revision [STABLE] Indicates the version of the StatefulSet used to generate Pods in the sequence [0,currentReplicas).
@@ -296,10 +316,12 @@ func TestStatefulSetStore(t *testing.T) {
 				# HELP kube_statefulset_status_replicas_available The number of available replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_current [STABLE] The number of current replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_ready [STABLE] The number of ready replicas per StatefulSet.
+				# HELP kube_statefulset_status_replicas_unavailable The number of unavailable replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_updated [STABLE] The number of updated replicas per StatefulSet.
 				# HELP kube_statefulset_status_update_revision [STABLE] Indicates the version of the StatefulSet used to generate Pods in the sequence [replicas-updatedReplicas,replicas)
 				# TYPE kube_statefulset_labels gauge
 				# TYPE kube_statefulset_metadata_generation gauge
+				# TYPE kube_statefulset_metadata_generation_matches_observed gauge
 				# TYPE kube_statefulset_persistentvolumeclaim_retention_policy gauge
 				# TYPE kube_statefulset_replicas gauge
 				# TYPE kube_statefulset_status_current_revision gauge
@@ -307,6 +329,7 @@ func TestStatefulSetStore(t *testing.T) {
 				# TYPE kube_statefulset_status_replicas_available gauge
 				# TYPE kube_statefulset_status_replicas_current gauge
 				# TYPE kube_statefulset_status_replicas_ready gauge
+				# TYPE kube_statefulset_status_replicas_unavailable gauge
 				# TYPE kube_statefulset_status_replicas_updated gauge
 				# TYPE kube_statefulset_status_update_revision gauge
 				kube_statefulset_status_update_revision{namespace="ns4",revision="ur3",statefulset="statefulset4"} 1
@@ -314,9 +337,11 @@ func TestStatefulSetStore(t *testing.T) {
 				kube_statefulset_status_replicas_available{namespace="ns4",statefulset="statefulset4"} 0
 				kube_statefulset_status_replicas_current{namespace="ns4",statefulset="statefulset4"} 0
 				kube_statefulset_status_replicas_ready{namespace="ns4",statefulset="statefulset4"} 0
+				kube_statefulset_status_replicas_unavailable{namespace="ns4",statefulset="statefulset4"} 3
 				kube_statefulset_status_replicas_updated{namespace="ns4",statefulset="statefulset4"} 0
 				kube_statefulset_replicas{namespace="ns4",statefulset="statefulset4"} 3
  				kube_statefulset_metadata_generation{namespace="ns4",statefulset="statefulset4"} 1
+ 				kube_statefulset_metadata_generation_matches_observed{namespace="ns4",statefulset="statefulset4"} 0
  				kube_statefulset_persistentvolumeclaim_retention_policy{namespace="ns4",statefulset="statefulset4",when_deleted="Retain",when_scaled="Delete"} 1
 				kube_statefulset_status_current_revision{namespace="ns4",revision="cr3",statefulset="statefulset4"} 1
  			`,
@@ -362,6 +387,7 @@ func TestStatefulSetStore(t *testing.T) {
 			Want: `
 				# HELP kube_statefulset_labels [STABLE] Kubernetes labels converted to Prometheus labels.
 				# HELP kube_statefulset_metadata_generation [STABLE] Sequence number representing a specific generation of the desired state for the StatefulSet.
+				# HELP kube_statefulset_metadata_generation_matches_observed Whether the generation of the StatefulSet was observed by the StatefulSet controller.
 				# HELP kube_statefulset_persistentvolumeclaim_retention_policy Count of retention policy for StatefulSet template PVCs
 				# HELP kube_statefulset_replicas [STABLE] Number of desired pods for a StatefulSet.
 				# HELP kube_statefulset_ordinals_start [STABLE] Start ordinal of the StatefulSet.
@@ -370,10 +396,12 @@ func TestStatefulSetStore(t *testing.T) {
 				# HELP kube_statefulset_status_replicas_available The number of available replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_current [STABLE] The number of current replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_ready [STABLE] The number of ready replicas per StatefulSet.
+				# HELP kube_statefulset_status_replicas_unavailable The number of unavailable replicas per StatefulSet.
 				# HELP kube_statefulset_status_replicas_updated [STABLE] The number of updated replicas per StatefulSet.
 				# HELP kube_statefulset_status_update_revision [STABLE] Indicates the version of the StatefulSet used to generate Pods in the sequence [replicas-updatedReplicas,replicas)
 				# TYPE kube_statefulset_labels gauge
 				# TYPE kube_statefulset_metadata_generation gauge
+				# TYPE kube_statefulset_metadata_generation_matches_observed gauge
 				# TYPE kube_statefulset_persistentvolumeclaim_retention_policy gauge
 				# TYPE kube_statefulset_replicas gauge
 				# TYPE kube_statefulset_ordinals_start gauge
@@ -382,6 +410,7 @@ func TestStatefulSetStore(t *testing.T) {
 				# TYPE kube_statefulset_status_replicas_available gauge
 				# TYPE kube_statefulset_status_replicas_current gauge
 				# TYPE kube_statefulset_status_replicas_ready gauge
+				# TYPE kube_statefulset_status_replicas_unavailable gauge
 				# TYPE kube_statefulset_status_replicas_updated gauge
 				# TYPE kube_statefulset_status_update_revision gauge
 				kube_statefulset_status_update_revision{namespace="ns5",revision="ur5",statefulset="statefulset5"} 1
@@ -389,10 +418,12 @@ func TestStatefulSetStore(t *testing.T) {
 				kube_statefulset_status_replicas_available{namespace="ns5",statefulset="statefulset5"} 0
 				kube_statefulset_status_replicas_current{namespace="ns5",statefulset="statefulset5"} 0
 				kube_statefulset_status_replicas_ready{namespace="ns5",statefulset="statefulset5"} 0
+				kube_statefulset_status_replicas_unavailable{namespace="ns5",statefulset="statefulset5"} 3
 				kube_statefulset_status_replicas_updated{namespace="ns5",statefulset="statefulset5"} 0
 				kube_statefulset_replicas{namespace="ns5",statefulset="statefulset5"} 3
 				kube_statefulset_ordinals_start{namespace="ns5",statefulset="statefulset5"} 2
  				kube_statefulset_metadata_generation{namespace="ns5",statefulset="statefulset5"} 1
+ 				kube_statefulset_metadata_generation_matches_observed{namespace="ns5",statefulset="statefulset5"} 0
 				kube_statefulset_status_current_revision{namespace="ns5",revision="cr5",statefulset="statefulset5"} 1
  			`,
 			MetricNames: []string{
@@ -410,6 +441,154 @@ func TestStatefulSetStore(t *testing.T) {
 				"kube_statefulset_persistentvolumeclaim_retention_policy",
 			},
 		},
+		{
+			Obj: &v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "statefulset-rolling",
+					Namespace:  "ns1",
+					Generation: 2,
+				},
+				Spec: v1.StatefulSetSpec{
+					Replicas: &statefulSet1Replicas,
+				},
+				Status: v1.StatefulSetStatus{
+					ObservedGeneration: 2,
+					Replicas:           3,
+					UpdatedReplicas:    1,
+					CurrentRevision:    "rev1",
+					UpdateRevision:     "rev2",
+				},
+			},
+			Want: `
+				# HELP kube_statefulset_rollout_complete Whether the latest revision of the StatefulSet has been rolled out to all of its replicas.
+				# TYPE kube_statefulset_rollout_complete gauge
+				kube_statefulset_rollout_complete{namespace="ns1",statefulset="statefulset-rolling"} 0
+			`,
+			MetricNames: []string{"kube_statefulset_rollout_complete"},
+		},
+		{
+			Obj: &v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "statefulset-rolled-out",
+					Namespace:  "ns1",
+					Generation: 2,
+				},
+				Spec: v1.StatefulSetSpec{
+					Replicas: &statefulSet1Replicas,
+				},
+				Status: v1.StatefulSetStatus{
+					ObservedGeneration: 2,
+					Replicas:           3,
+					UpdatedReplicas:    3,
+					CurrentRevision:    "rev2",
+					UpdateRevision:     "rev2",
+				},
+			},
+			Want: `
+				# HELP kube_statefulset_rollout_complete Whether the latest revision of the StatefulSet has been rolled out to all of its replicas.
+				# HELP kube_statefulset_status_current_revision [STABLE] Indicates the version of the StatefulSet used to generate Pods in the sequence [0,currentReplicas).
+				# HELP kube_statefulset_status_update_revision [STABLE] Indicates the version of the StatefulSet used to generate Pods in the sequence [replicas-updatedReplicas,replicas)
+				# TYPE kube_statefulset_rollout_complete gauge
+				# TYPE kube_statefulset_status_current_revision gauge
+				# TYPE kube_statefulset_status_update_revision gauge
+				kube_statefulset_rollout_complete{namespace="ns1",statefulset="statefulset-rolled-out"} 1
+				kube_statefulset_status_current_revision{namespace="ns1",revision="rev2",statefulset="statefulset-rolled-out"} 1
+				kube_statefulset_status_update_revision{namespace="ns1",revision="rev2",statefulset="statefulset-rolled-out"} 1
+			`,
+			MetricNames: []string{
+				"kube_statefulset_rollout_complete",
+				"kube_statefulset_status_current_revision",
+				"kube_statefulset_status_update_revision",
+			},
+		},
+		{
+			Obj: &v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "statefulset-unavailable",
+					Namespace: "ns1",
+				},
+				Spec: v1.StatefulSetSpec{
+					Replicas: &statefulSet1Replicas,
+				},
+				Status: v1.StatefulSetStatus{
+					Replicas:          3,
+					AvailableReplicas: 1,
+				},
+			},
+			Want: `
+				# HELP kube_statefulset_status_replicas_available The number of available replicas per StatefulSet.
+				# HELP kube_statefulset_status_replicas_unavailable The number of unavailable replicas per StatefulSet.
+				# TYPE kube_statefulset_status_replicas_available gauge
+				# TYPE kube_statefulset_status_replicas_unavailable gauge
+				kube_statefulset_status_replicas_available{namespace="ns1",statefulset="statefulset-unavailable"} 1
+				kube_statefulset_status_replicas_unavailable{namespace="ns1",statefulset="statefulset-unavailable"} 2
+			`,
+			MetricNames: []string{
+				"kube_statefulset_status_replicas_available",
+				"kube_statefulset_status_replicas_unavailable",
+			},
+		},
+		{
+			Obj: &v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "statefulset-observed",
+					Namespace:  "ns1",
+					Generation: 2,
+				},
+				Status: v1.StatefulSetStatus{
+					ObservedGeneration: 2,
+				},
+			},
+			Want: `
+				# HELP kube_statefulset_metadata_generation_matches_observed Whether the generation of the StatefulSet was observed by the StatefulSet controller.
+				# TYPE kube_statefulset_metadata_generation_matches_observed gauge
+				kube_statefulset_metadata_generation_matches_observed{namespace="ns1",statefulset="statefulset-observed"} 1
+			`,
+			MetricNames: []string{"kube_statefulset_metadata_generation_matches_observed"},
+		},
+		{
+			Obj: &v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "statefulset-not-observed",
+					Namespace:  "ns1",
+					Generation: 3,
+				},
+				Status: v1.StatefulSetStatus{
+					ObservedGeneration: 2,
+				},
+			},
+			Want: `
+				# HELP kube_statefulset_metadata_generation_matches_observed Whether the generation of the StatefulSet was observed by the StatefulSet controller.
+				# TYPE kube_statefulset_metadata_generation_matches_observed gauge
+				kube_statefulset_metadata_generation_matches_observed{namespace="ns1",statefulset="statefulset-not-observed"} 0
+			`,
+			MetricNames: []string{"kube_statefulset_metadata_generation_matches_observed"},
+		},
+		{
+			Obj: &v1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "statefulset-images",
+					Namespace: "ns1",
+				},
+				Spec: v1.StatefulSetSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{Name: "db", Image: "postgres:16"},
+								{Name: "exporter", Image: "postgres-exporter:v0.15.0"},
+							},
+						},
+					},
+				},
+			},
+			Want: `
+				# HELP kube_statefulset_spec_template_container_image The image of each container in the pod template of a StatefulSet.
+				# TYPE kube_statefulset_spec_template_container_image gauge
+				kube_statefulset_spec_template_container_image{namespace="ns1",statefulset="statefulset-images",container="db",image="postgres:16"} 1
+				kube_statefulset_spec_template_container_image{namespace="ns1",statefulset="statefulset-images",container="exporter",image="postgres-exporter:v0.15.0"} 1
+			`,
+			MetricNames: []string{"kube_statefulset_spec_template_container_image"},
+		},
 	}
 	for i, c := range cases {
 		c.Func = generator.ComposeMetricGenFuncs(statefulSetMetricFamilies(nil, nil))
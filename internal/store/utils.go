@@ -19,6 +19,7 @@ package store
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -27,6 +28,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
 
 	"k8s.io/kube-state-metrics/v2/pkg/metric"
 	"k8s.io/kube-state-metrics/v2/pkg/options"
@@ -75,6 +77,22 @@ func addConditionMetrics(cs v1.ConditionStatus) []*metric.Metric {
 	return ms
 }
 
+// podTemplateContainerImageMetrics generates one metric with the container and
+// image labels for each container of the given pod template.
+func podTemplateContainerImageMetrics(t v1.PodTemplateSpec) []*metric.Metric {
+	ms := make([]*metric.Metric, len(t.Spec.Containers))
+
+	for i, c := range t.Spec.Containers {
+		ms[i] = &metric.Metric{
+			LabelKeys:   []string{"container", "image"},
+			LabelValues: []string{c.Name, c.Image},
+			Value:       1,
+		}
+	}
+
+	return ms
+}
+
 func kubeMapToPrometheusLabels(prefix string, input map[string]string) ([]string, []string) {
 	return mapToPrometheusLabels(input, prefix)
 }
@@ -100,6 +118,7 @@ func mapToPrometheusLabels(labels map[string]string, prefix string) ([]string, [
 	}
 
 	conflicts := make(map[string]*conflictDesc)
+	hasConflicts := false
 	for _, k := range sortedKeys {
 		labelKey := labelName(prefix, k)
 		if conflict, ok := conflicts[labelKey]; ok {
@@ -110,6 +129,7 @@ func mapToPrometheusLabels(labels map[string]string, prefix string) ([]string, [
 			}
 
 			conflict.count++
+			hasConflicts = true
 			labelKey = labelConflictSuffix(labelKey, conflict.count)
 		} else {
 			// we'll need this info later in case there are conflicts
@@ -121,6 +141,16 @@ func mapToPrometheusLabels(labels map[string]string, prefix string) ([]string, [
 		labelKeys = append(labelKeys, labelKey)
 		labelValues = append(labelValues, labels[k])
 	}
+	if hasConflicts && klog.V(2).Enabled() {
+		renamed := make([]string, 0, len(conflicts))
+		for labelKey, conflict := range conflicts {
+			if conflict.count > 1 {
+				renamed = append(renamed, labelKey)
+			}
+		}
+		sort.Strings(renamed)
+		klog.V(2).InfoS("Kubernetes keys sanitize to an already used Prometheus label name, adding a numeric suffix", "labels", renamed)
+	}
 	return labelKeys, labelValues
 }
 
@@ -178,12 +208,21 @@ func isPrefixedNativeResource(name v1.ResourceName) bool {
 // createPrometheusLabelKeysValues takes in passed kubernetes annotations/labels
 // and associated allowed list in kubernetes label format.
 // It returns only those allowed annotations/labels that exist in the list and converts them to Prometheus labels.
+// A leading wildcard allows all annotations/labels but the ones following it, see Builder.allowedLabels.
 func createPrometheusLabelKeysValues(prefix string, allKubeData map[string]string, allowList []string) ([]string, []string) {
 	allowedKubeData := make(map[string]string)
 
 	if len(allowList) > 0 {
 		if allowList[0] == options.LabelWildcard {
-			return kubeMapToPrometheusLabels(prefix, allKubeData)
+			if len(allowList) == 1 {
+				return kubeMapToPrometheusLabels(prefix, allKubeData)
+			}
+			for k, v := range allKubeData {
+				if !slices.Contains(allowList[1:], k) {
+					allowedKubeData[k] = v
+				}
+			}
+			return kubeMapToPrometheusLabels(prefix, allowedKubeData)
 		}
 
 		for _, l := range allowList {
@@ -218,6 +257,15 @@ func mergeKeyValues(keyValues ...[]string) (keys, values []string) {
 	return keys, values
 }
 
+// withoutLabel returns copies of the given label keys and values without the given label.
+func withoutLabel(keys, values []string, label string) ([]string, []string) {
+	i := slices.Index(keys, label)
+	if i < 0 {
+		return keys, values
+	}
+	return slices.Delete(slices.Clone(keys), i, i+1), slices.Delete(slices.Clone(values), i, i+1)
+}
+
 // convertValueToFloat64 converts a resource.Quantity to a float64 and checks for a possible overflow in the value.
 func convertValueToFloat64(q *resource.Quantity) float64 {
 	if q.Value() > resource.MaxMilliValue {
@@ -215,6 +215,20 @@ func TestKubeLabelsToPrometheusLabels(t *testing.T) {
 				"underscore",
 			},
 		},
+		{
+			kubeLabels: map[string]string{
+				"a_b/c": "underscore",
+				"a.b/c": "dot",
+			},
+			expectKeys: []string{
+				"label_a_b_c_conflict1",
+				"label_a_b_c_conflict2",
+			},
+			expectValues: []string{
+				"dot",
+				"underscore",
+			},
+		},
 		{
 			kubeLabels: map[string]string{
 				"camelCase": "camel_case",
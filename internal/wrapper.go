@@ -73,28 +73,8 @@ func RunKubeStateMetricsWrapper(opts *options.Options) {
 
 		yaml.Unmarshal(configFile, opts)
 	}
-	if opts.CustomResourceConfigFile != "" {
-		crcViper := viper.New()
-		crcViper.SetConfigType("yaml")
-		crcViper.SetConfigFile(opts.CustomResourceConfigFile)
-		if err := crcViper.ReadInConfig(); err != nil {
-			if errors.Is(err, viper.ConfigFileNotFoundError{}) {
-				klog.ErrorS(err, "Custom resource configuration file not found", "file", opts.CustomResourceConfigFile)
-			} else {
-				klog.ErrorS(err, "Error reading Custom resource configuration file", "file", opts.CustomResourceConfigFile)
-			}
-			klog.FlushAndExit(klog.ExitFlushTimeout, 1)
-		}
-		crcViper.OnConfigChange(func(e fsnotify.Event) {
-			klog.InfoS("Changes detected", "name", e.Name)
-			cancel()
-			// Wait for the ports to be released.
-			<-time.After(3 * time.Second)
-			ctx, cancel = context.WithCancel(context.Background())
-			go KSMRunOrDie(ctx)
-		})
-		crcViper.WatchConfig()
-	}
+	// Changes to the custom resource state configuration file are reloaded by kube-state-metrics itself,
+	// without restarting the stores of other resources.
 	if opts.Kubeconfig != "" {
 		kubecfgViper := viper.New()
 		kubecfgViper.SetConfigType("yaml")
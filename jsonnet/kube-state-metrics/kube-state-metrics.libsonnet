@@ -124,6 +124,9 @@
       {
         apiGroups: ['storage.k8s.io'],
         resources: [
+          'csidrivers',
+          'csinodes',
+          'csistoragecapacities',
           'storageclasses',
           'volumeattachments',
         ],
@@ -146,6 +149,20 @@
         ],
         verbs: ['list', 'watch'],
       },
+      {
+        apiGroups: ['node.k8s.io'],
+        resources: [
+          'runtimeclasses',
+        ],
+        verbs: ['list', 'watch'],
+      },
+      {
+        apiGroups: ['scheduling.k8s.io'],
+        resources: [
+          'priorityclasses',
+        ],
+        verbs: ['list', 'watch'],
+      },
       {
         apiGroups: ['coordination.k8s.io'],
         resources: [
@@ -21,6 +21,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"k8s.io/kube-state-metrics/v2/internal"
+	"k8s.io/kube-state-metrics/v2/pkg/app"
 	"k8s.io/kube-state-metrics/v2/pkg/options"
 )
 
@@ -28,6 +29,14 @@ func main() {
 	opts := options.NewOptions()
 	cmd := options.InitCommand
 	cmd.Run = func(_ *cobra.Command, _ []string) {
+		if opts.ValidateConfig {
+			if err := app.ValidateConfig(opts); err != nil {
+				klog.ErrorS(err, "Invalid configuration")
+				klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+			}
+			klog.InfoS("Configuration is valid")
+			klog.FlushAndExit(klog.ExitFlushTimeout, 0)
+		}
 		internal.RunKubeStateMetricsWrapper(opts)
 	}
 	opts.AddFlags(cmd)
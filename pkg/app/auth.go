@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/prometheus/exporter-toolkit/web"
+	"gopkg.in/yaml.v3"
+)
+
+// webConfigFile returns the web configuration file to pass to the exporter-toolkit,
+// preferring --web.config-file over the deprecated --tls-config.
+func webConfigFile(webConfig, tlsConfig string) string {
+	if webConfig != "" {
+		return webConfig
+	}
+	return tlsConfig
+}
+
+// loadBearerToken reads the bearer token from the given file. It fails if the
+// web configuration file configures basic authentication as well, since a request
+// can only carry one set of credentials in its Authorization header.
+func loadBearerToken(bearerTokenFile, webConfigFile string) (string, error) {
+	if webConfigFile != "" {
+		if err := web.Validate(webConfigFile); err != nil {
+			return "", fmt.Errorf("invalid web configuration file %s: %w", webConfigFile, err)
+		}
+		content, err := os.ReadFile(webConfigFile)
+		if err != nil {
+			return "", err
+		}
+		c := &web.Config{}
+		if err := yaml.Unmarshal(content, c); err != nil {
+			return "", err
+		}
+		if len(c.Users) > 0 {
+			return "", errors.New("bearer token authentication can not be combined with basic_auth_users in the web configuration file")
+		}
+	}
+
+	content, err := os.ReadFile(bearerTokenFile)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("bearer token file %s is empty", bearerTokenFile)
+	}
+	return token, nil
+}
+
+// bearerTokenHandler only passes requests carrying the given bearer token in
+// their Authorization header on to the given handler, rejecting all others with 401.
+// Like basic authentication, which the exporter-toolkit applies to every path, this
+// includes the health endpoints, so probes have to send the token as well.
+func bearerTokenHandler(token string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/exporter-toolkit/web"
+)
+
+// basicAuthWebConfig configures the user "admin" with the password "secret".
+const basicAuthWebConfig = `basic_auth_users:
+  admin: $2a$04$1.Z9gpDnURqXVdJ9L72PquW81HECjCCDzWsT1CWZLcbMvK0Dty/Da
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestBearerTokenHandler(t *testing.T) {
+	handler := bearerTokenHandler("s3cr3t", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		want          int
+	}{
+		{name: "valid token", authorization: "Bearer s3cr3t", want: http.StatusOK},
+		{name: "missing credentials", authorization: "", want: http.StatusUnauthorized},
+		{name: "invalid token", authorization: "Bearer wrong", want: http.StatusUnauthorized},
+		{name: "basic auth", authorization: "Basic YWRtaW46c2VjcmV0", want: http.StatusUnauthorized},
+		{name: "healthz probe", path: healthzPath, want: http.StatusUnauthorized},
+		{name: "livez probe", path: livezPath, want: http.StatusUnauthorized},
+		{name: "readyz probe", path: readyzPath, want: http.StatusUnauthorized},
+		{name: "probe with invalid token", path: readyzPath, authorization: "Bearer wrong", want: http.StatusUnauthorized},
+		{name: "probe with valid token", path: readyzPath, authorization: "Bearer s3cr3t", want: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := test.path
+			if path == "" {
+				path = "/metrics"
+			}
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != test.want {
+				t.Errorf("want status %d, got %d", test.want, rec.Code)
+			}
+			if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Errorf("expected a WWW-Authenticate header, got %q", rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestLoadBearerToken(t *testing.T) {
+	tokenFile := writeFile(t, "token", "s3cr3t\n")
+
+	token, err := loadBearerToken(tokenFile, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "s3cr3t" {
+		t.Errorf("want token %q, got %q", "s3cr3t", token)
+	}
+
+	if _, err := loadBearerToken(writeFile(t, "empty", "\n"), ""); err == nil {
+		t.Error("expected an error for an empty token file")
+	}
+
+	if _, err := loadBearerToken(tokenFile, writeFile(t, "web-config.yml", basicAuthWebConfig)); err == nil {
+		t.Error("expected an error when combining bearer token and basic authentication")
+	}
+}
+
+func TestWebConfigBasicAuth(t *testing.T) {
+	webConfig := writeFile(t, "web-config.yml", basicAuthWebConfig)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	for _, path := range []string{"/metrics", healthzPath} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+	server := &http.Server{Handler: mux}
+	flags := &web.FlagConfig{
+		WebListenAddresses: &[]string{listener.Addr().String()},
+		WebSystemdSocket:   new(bool),
+		WebConfigFile:      &webConfig,
+	}
+	go func() {
+		_ = web.Serve(listener, server, flags, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	}()
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		path     string
+		user     string
+		password string
+		want     int
+	}{
+		{name: "valid credentials", user: "admin", password: "secret", want: http.StatusOK},
+		{name: "missing credentials", want: http.StatusUnauthorized},
+		{name: "invalid password", user: "admin", password: "wrong", want: http.StatusUnauthorized},
+		{name: "healthz probe", path: healthzPath, want: http.StatusUnauthorized},
+		{name: "healthz probe with valid credentials", path: healthzPath, user: "admin", password: "secret", want: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := test.path
+			if path == "" {
+				path = "/metrics"
+			}
+			req, err := http.NewRequest(http.MethodGet, "http://"+listener.Addr().String()+path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.user != "" {
+				req.SetBasicAuth(test.user, test.password)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != test.want {
+				t.Errorf("want status %d, got %d", test.want, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestWebConfigFile(t *testing.T) {
+	if got := webConfigFile("web.yml", "tls.yml"); got != "web.yml" {
+		t.Errorf("expected --web.config-file to take precedence, got %q", got)
+	}
+	if got := webConfigFile("", "tls.yml"); got != "tls.yml" {
+		t.Errorf("expected a fallback to --tls-config, got %q", got)
+	}
+}
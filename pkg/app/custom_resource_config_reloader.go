@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v3"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kube-state-metrics/v2/internal/discovery"
+	"k8s.io/kube-state-metrics/v2/pkg/customresource"
+	"k8s.io/kube-state-metrics/v2/pkg/customresourcestate"
+)
+
+// customResourceConfigReloader reloads the custom resource state configuration file whenever it
+// changes. An invalid configuration is rejected, keeping the last valid one in place.
+type customResourceConfigReloader struct {
+	file       string
+	discoverer *discovery.CRDiscoverer
+
+	configHash        *prometheus.GaugeVec
+	configSuccess     *prometheus.GaugeVec
+	configSuccessTime *prometheus.GaugeVec
+
+	// mtx protects hash and factories
+	mtx  sync.Mutex
+	hash float64
+	// factories generates the factories of the last valid configuration.
+	factories func() ([]customresource.RegistryFactory, error)
+}
+
+// Factories generates the custom resource store factories of the last valid configuration.
+func (r *customResourceConfigReloader) Factories() ([]customresource.RegistryFactory, error) {
+	r.mtx.Lock()
+	factories := r.factories
+	r.mtx.Unlock()
+
+	if factories == nil {
+		return nil, nil
+	}
+	return factories()
+}
+
+// reload reads the configuration file and, if it changed and is valid, replaces the current
+// configuration and flags the discoverer as updated, so that the custom resource stores are rebuilt.
+func (r *customResourceConfigReloader) reload() error {
+	file := filepath.Clean(r.file)
+	content, err := os.ReadFile(file)
+	if err != nil {
+		r.configSuccess.WithLabelValues("customresourceconfig", file).Set(0)
+		return fmt.Errorf("failed to read custom resource config file: %v", err)
+	}
+
+	hash := md5HashAsMetricValue(content)
+	r.mtx.Lock()
+	unchanged := r.factories != nil && r.hash == hash
+	r.mtx.Unlock()
+	if unchanged {
+		return nil
+	}
+
+	if err := customresourcestate.ValidateConfig(yaml.NewDecoder(bytes.NewReader(content))); err != nil {
+		r.configSuccess.WithLabelValues("customresourceconfig", file).Set(0)
+		return fmt.Errorf("invalid custom resource state configuration: %w", err)
+	}
+	factories, err := customresourcestate.FromConfig(yaml.NewDecoder(bytes.NewReader(content)), r.discoverer)
+	if err != nil {
+		r.configSuccess.WithLabelValues("customresourceconfig", file).Set(0)
+		return err
+	}
+
+	r.mtx.Lock()
+	r.hash = hash
+	r.factories = factories
+	r.mtx.Unlock()
+
+	r.configSuccess.WithLabelValues("customresourceconfig", file).Set(1)
+	r.configSuccessTime.WithLabelValues("customresourceconfig", file).SetToCurrentTime()
+	r.configHash.WithLabelValues("customresourceconfig", file).Set(hash)
+	klog.InfoS("Loaded custom resource state configuration", "file", file)
+	r.discoverer.SafeWrite(func() {
+		r.discoverer.WasUpdated = true
+	})
+	return nil
+}
+
+// Run reloads the configuration on every change of the configuration file until the context is cancelled.
+func (r *customResourceConfigReloader) Run(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	// Watch the directory rather than the file, so that atomic replacements of the file,
+	// like the symlink swaps of mounted ConfigMaps, are noticed as well.
+	if err := watcher.Add(filepath.Dir(filepath.Clean(r.file))); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+				continue
+			}
+			if err := r.reload(); err != nil {
+				klog.ErrorS(err, "Failed to reload custom resource state configuration, keeping the last valid configuration", "file", r.file)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			klog.ErrorS(err, "Failed to watch custom resource state configuration", "file", r.file)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"k8s.io/kube-state-metrics/v2/internal/discovery"
+)
+
+func TestCustomResourceConfigReloader(t *testing.T) {
+	file := writeFile(t, "custom-resource-state.yml", validCustomResourceConfig)
+	labels := []string{"type", "filename"}
+	r := &customResourceConfigReloader{
+		file:              file,
+		discoverer:        &discovery.CRDiscoverer{},
+		configHash:        prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "config_hash"}, labels),
+		configSuccess:     prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "config_success"}, labels),
+		configSuccessTime: prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "config_success_time"}, labels),
+	}
+	success := r.configSuccess.WithLabelValues("customresourceconfig", filepath.Clean(file))
+	hash := r.configHash.WithLabelValues("customresourceconfig", filepath.Clean(file))
+
+	if err := r.reload(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Factories(); err != nil {
+		t.Fatal(err)
+	}
+	r.discoverer.WasUpdated = false
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = r.Run(ctx)
+	}()
+
+	// writeUntil writes the file until cond holds, as changes made before the
+	// watcher started watching the directory are not noticed.
+	writeUntil := func(desc string, content string, cond func() bool) {
+		t.Helper()
+		err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 10*time.Second, false, func(context.Context) (bool, error) {
+			if cond() {
+				return true, nil
+			}
+			return false, os.WriteFile(file, []byte(content), 0o600)
+		})
+		if err != nil {
+			t.Fatalf("timed out waiting for %s: %v", desc, err)
+		}
+	}
+
+	// A valid change replaces the configuration and triggers a rebuild of the custom resource stores.
+	changed := validCustomResourceConfig + `
+        - name: replicas
+          help: Foo replicas
+          each:
+            type: Gauge
+            gauge:
+              path: [spec, replicas]
+`
+	wantHash := md5HashAsMetricValue([]byte(changed))
+	writeUntil("the changed configuration to be loaded", changed, func() bool {
+		return testutil.ToFloat64(hash) == wantHash
+	})
+	r.discoverer.SafeRead(func() {
+		if !r.discoverer.WasUpdated {
+			t.Error("expected the discoverer to be flagged as updated")
+		}
+	})
+	if testutil.ToFloat64(success) != 1 {
+		t.Error("expected the reload to be successful")
+	}
+
+	// An invalid change is rejected and the last valid configuration is kept.
+	writeUntil("the invalid configuration to be rejected", "spec:\n  resources: [\n", func() bool {
+		return testutil.ToFloat64(success) == 0
+	})
+	if testutil.ToFloat64(hash) != wantHash {
+		t.Error("expected the hash of the last valid configuration to be kept")
+	}
+	r.mtx.Lock()
+	if r.factories == nil {
+		t.Error("expected the last valid configuration to be kept")
+	}
+	r.mtx.Unlock()
+
+	cancel()
+	<-done
+}
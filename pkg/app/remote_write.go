@@ -0,0 +1,300 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/version"
+	"google.golang.org/protobuf/encoding/protowire"
+	"k8s.io/klog/v2"
+)
+
+const (
+	remoteWriteInitialBackoff = 500 * time.Millisecond
+	remoteWriteMaxRetries     = 5
+	// remoteWriteMaxSamplesPerRequest is the number of samples after which a push is split
+	// into another request, the same as the default of Prometheus.
+	remoteWriteMaxSamplesPerRequest = 2000
+)
+
+// remoteWriter periodically pushes the generated metrics to a Prometheus
+// remote-write endpoint.
+type remoteWriter struct {
+	url      string
+	headers  map[string]string
+	interval time.Duration
+	client   *http.Client
+	// maxSamplesPerRequest is the maximum number of samples sent in a single request.
+	maxSamplesPerRequest int
+	// writeMetrics writes the generated metrics in the Prometheus text format.
+	writeMetrics func(w io.Writer)
+}
+
+// Run pushes the metrics every interval until the context is cancelled.
+func (r *remoteWriter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := r.push(ctx); err != nil {
+				klog.ErrorS(err, "Failed to push metrics", "url", r.url)
+			}
+		}
+	}
+}
+
+// push serializes the current metrics into remote-write requests and sends them,
+// retrying each with an exponential backoff on recoverable errors.
+func (r *remoteWriter) push(ctx context.Context) error {
+	requests, err := r.encodeWriteRequests(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to encode remote-write request: %w", err)
+	}
+
+	for _, body := range requests {
+		if err := r.sendWithRetries(ctx, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeWriteRequests encodes the metrics, as they are written, into compressed remote-write
+// requests. The requests are only sent once all metrics are written, so that the stores are not
+// held up by a slow remote-write endpoint.
+func (r *remoteWriter) encodeWriteRequests(now time.Time) ([][]byte, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		r.writeMetrics(pw)
+		pw.Close()
+	}()
+
+	requests, err := encodeWriteRequests(pr, now, r.maxSamplesPerRequest)
+	// Unblock the writer if the metrics could not be encoded.
+	pr.CloseWithError(err)
+	return requests, err
+}
+
+// sendWithRetries sends the compressed request, retrying with an exponential backoff on recoverable errors.
+func (r *remoteWriter) sendWithRetries(ctx context.Context, body []byte) error {
+	backoff := remoteWriteInitialBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := r.send(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= remoteWriteMaxRetries {
+			return err
+		}
+		klog.V(2).InfoS("Retrying to push metrics", "url", r.url, "backoff", backoff, "err", err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, r.interval)
+	}
+}
+
+// send posts the compressed request. It reports whether a failed request may be retried.
+func (r *remoteWriter) send(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	for k, v := range r.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", version.ComponentUserAgent("kube-state-metrics"))
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	err = fmt.Errorf("remote-write endpoint returned HTTP status %s", resp.Status)
+	return resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests, err
+}
+
+// encodeWriteRequests reads the given metrics in the Prometheus text format line by line and
+// encodes them as snappy-compressed remote-write WriteRequest protobufs of at most maxSamples
+// samples each, with all samples at the given time.
+func encodeWriteRequests(r io.Reader, now time.Time, maxSamples int) ([][]byte, error) {
+	timestamp := now.UnixMilli()
+	var requests [][]byte
+	var req []byte
+	samples := 0
+	flush := func() {
+		requests = append(requests, s2.EncodeSnappy(nil, req))
+		req = req[:0]
+		samples = 0
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), math.MaxInt32)
+	for scanner.Scan() {
+		line := scanner.Text()
+		// HELP and TYPE lines are dropped, as remote-write only carries samples.
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		labels, value, err := parseSample(line)
+		if err != nil {
+			return nil, err
+		}
+		req = protowire.AppendTag(req, 1, protowire.BytesType)
+		req = protowire.AppendBytes(req, encodeTimeSeries(labels, value, timestamp))
+		samples++
+		if maxSamples > 0 && samples >= maxSamples {
+			flush()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if samples > 0 {
+		flush()
+	}
+	return requests, nil
+}
+
+type labelPair struct {
+	name, value string
+}
+
+// parseSample parses a sample line of the Prometheus text format, as written by the metric
+// stores, into its labels, including the metric name, and its value. Exemplars are ignored.
+func parseSample(line string) ([]labelPair, float64, error) {
+	end := strings.IndexAny(line, "{ ")
+	if end <= 0 {
+		return nil, 0, fmt.Errorf("invalid sample %q", line)
+	}
+	labels := []labelPair{{name: model.MetricNameLabel, value: line[:end]}}
+	rest := line[end:]
+
+	if rest[0] == '{' {
+		rest = rest[1:]
+		for len(rest) > 0 && rest[0] != '}' {
+			eq := strings.Index(rest, `="`)
+			if eq <= 0 {
+				return nil, 0, fmt.Errorf("invalid labels in sample %q", line)
+			}
+			name := rest[:eq]
+			value, n, err := unquoteLabelValue(rest[eq+2:])
+			if err != nil {
+				return nil, 0, fmt.Errorf("invalid labels in sample %q: %w", line, err)
+			}
+			labels = append(labels, labelPair{name: name, value: value})
+			rest = strings.TrimPrefix(rest[eq+2+n:], ",")
+		}
+		if len(rest) == 0 {
+			return nil, 0, fmt.Errorf("invalid labels in sample %q", line)
+		}
+		rest = rest[1:]
+	}
+
+	rest = strings.TrimLeft(rest, " ")
+	if i := strings.IndexByte(rest, ' '); i >= 0 {
+		rest = rest[:i]
+	}
+	value, err := strconv.ParseFloat(rest, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid value in sample %q: %w", line, err)
+	}
+
+	slices.SortFunc(labels, func(a, b labelPair) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return labels, value, nil
+}
+
+// unquoteLabelValue unescapes the label value at the start of s, up to its closing quote.
+// It returns the value and the number of bytes consumed, including the closing quote.
+func unquoteLabelValue(s string) (string, int, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			i++
+			if i == len(s) {
+				return "", 0, errors.New("unterminated escape sequence")
+			}
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, errors.New("unterminated label value")
+}
+
+func encodeTimeSeries(labels []labelPair, value float64, timestamp int64) []byte {
+	var ts []byte
+	for _, l := range labels {
+		var label []byte
+		label = protowire.AppendTag(label, 1, protowire.BytesType)
+		label = protowire.AppendString(label, l.name)
+		label = protowire.AppendTag(label, 2, protowire.BytesType)
+		label = protowire.AppendString(label, l.value)
+
+		ts = protowire.AppendTag(ts, 1, protowire.BytesType)
+		ts = protowire.AppendBytes(ts, label)
+	}
+
+	var sample []byte
+	sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+	sample = protowire.AppendFixed64(sample, math.Float64bits(value))
+	sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+	sample = protowire.AppendVarint(sample, uint64(timestamp)) //nolint:gosec
+
+	ts = protowire.AppendTag(ts, 2, protowire.BytesType)
+	ts = protowire.AppendBytes(ts, sample)
+	return ts
+}
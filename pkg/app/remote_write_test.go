@@ -0,0 +1,337 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type remoteWriteSample struct {
+	labels    string
+	value     float64
+	timestamp int64
+}
+
+// decodeWriteRequest decodes a remote-write WriteRequest into its samples,
+// with the labels of each sample formatted as name="value" pairs.
+func decodeWriteRequest(t *testing.T, b []byte) []remoteWriteSample {
+	t.Helper()
+
+	var samples []remoteWriteSample
+	forEachField(t, b, func(num protowire.Number, ts []byte) {
+		if num != 1 {
+			return
+		}
+		var labels []string
+		var sample remoteWriteSample
+		forEachField(t, ts, func(num protowire.Number, v []byte) {
+			switch num {
+			case 1:
+				var name, value string
+				forEachField(t, v, func(num protowire.Number, s []byte) {
+					if num == 1 {
+						name = string(s)
+					} else {
+						value = string(s)
+					}
+				})
+				labels = append(labels, fmt.Sprintf("%s=%q", name, value))
+			case 2:
+				for len(v) > 0 {
+					num, typ, n := protowire.ConsumeTag(v)
+					v = v[n:]
+					switch {
+					case num == 1 && typ == protowire.Fixed64Type:
+						bits, n := protowire.ConsumeFixed64(v)
+						sample.value = math.Float64frombits(bits)
+						v = v[n:]
+					case num == 2 && typ == protowire.VarintType:
+						ts, n := protowire.ConsumeVarint(v)
+						sample.timestamp = int64(ts) //nolint:gosec
+						v = v[n:]
+					default:
+						t.Fatalf("unexpected field %d in sample", num)
+					}
+				}
+			}
+		})
+		sample.labels = strings.Join(labels, ",")
+		samples = append(samples, sample)
+	})
+	return samples
+}
+
+// forEachField calls f for each length-delimited field of the given message.
+func forEachField(t *testing.T, b []byte, f func(protowire.Number, []byte)) {
+	t.Helper()
+
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 || typ != protowire.BytesType {
+			t.Fatalf("unexpected field %d of type %d", num, typ)
+		}
+		b = b[n:]
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			t.Fatal(protowire.ParseError(n))
+		}
+		f(num, v)
+		b = b[n:]
+	}
+}
+
+func TestRemoteWritePush(t *testing.T) {
+	metrics := `# HELP kube_pod_info Information about pod.
+# TYPE kube_pod_info gauge
+kube_pod_info{namespace="default",pod="pod1"} 1
+kube_pod_info{namespace="default",pod="pod2"} 1
+# HELP kube_pod_container_resource_requests The number of requested request resource by a container.
+# TYPE kube_pod_container_resource_requests gauge
+kube_pod_container_resource_requests{namespace="default",pod="pod1",resource="cpu",unit="core"} 0.25
+`
+
+	var mtx sync.Mutex
+	var requests int
+	var got []remoteWriteSample
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		requests++
+		// Fail the first request to exercise the retry path.
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("expected the configured Authorization header, got %q", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("Content-Encoding") != "snappy" {
+			t.Errorf("expected snappy content encoding, got %q", r.Header.Get("Content-Encoding"))
+		}
+		compressed, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := s2.Decode(nil, compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = decodeWriteRequest(t, body)
+	}))
+	defer receiver.Close()
+
+	rw := &remoteWriter{
+		url:      receiver.URL,
+		headers:  map[string]string{"Authorization": "Bearer secret"},
+		interval: time.Minute,
+		client:   receiver.Client(),
+		writeMetrics: func(w io.Writer) {
+			_, _ = w.Write([]byte(metrics))
+		},
+	}
+
+	start := time.Now()
+	if err := rw.push(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+
+	want := []remoteWriteSample{
+		{labels: `__name__="kube_pod_info",namespace="default",pod="pod1"`, value: 1},
+		{labels: `__name__="kube_pod_info",namespace="default",pod="pod2"`, value: 1},
+		{labels: `__name__="kube_pod_container_resource_requests",namespace="default",pod="pod1",resource="cpu",unit="core"`, value: 0.25},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d samples, got %d: %+v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i].labels != want[i].labels || got[i].value != want[i].value {
+			t.Errorf("sample %d: want %+v, got %+v", i, want[i], got[i])
+		}
+		if got[i].timestamp < start.UnixMilli() || got[i].timestamp > time.Now().UnixMilli() {
+			t.Errorf("sample %d: unexpected timestamp %d", i, got[i].timestamp)
+		}
+	}
+}
+
+func TestRemoteWritePushBatches(t *testing.T) {
+	var metrics strings.Builder
+	metrics.WriteString("# HELP kube_pod_info Information about pod.\n# TYPE kube_pod_info gauge\n")
+	for i := range 5 {
+		fmt.Fprintf(&metrics, "kube_pod_info{namespace=\"default\",pod=\"pod%d\"} 1\n", i)
+	}
+
+	var mtx sync.Mutex
+	var batches [][]remoteWriteSample
+	receiver := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		compressed, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := s2.Decode(nil, compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		batches = append(batches, decodeWriteRequest(t, body))
+	}))
+	defer receiver.Close()
+
+	rw := &remoteWriter{
+		url:                  receiver.URL,
+		interval:             time.Minute,
+		client:               receiver.Client(),
+		maxSamplesPerRequest: 2,
+		writeMetrics: func(w io.Writer) {
+			_, _ = w.Write([]byte(metrics.String()))
+		},
+	}
+	if err := rw.push(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	var sizes []int
+	var pods []string
+	for _, batch := range batches {
+		sizes = append(sizes, len(batch))
+		for _, sample := range batch {
+			pods = append(pods, sample.labels)
+		}
+	}
+	if !slices.Equal(sizes, []int{2, 2, 1}) {
+		t.Fatalf("expected requests of 2, 2 and 1 samples, got %v", sizes)
+	}
+	for i, labels := range pods {
+		if want := fmt.Sprintf(`__name__="kube_pod_info",namespace="default",pod="pod%d"`, i); labels != want {
+			t.Errorf("sample %d: want %s, got %s", i, want, labels)
+		}
+	}
+}
+
+func TestParseSample(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    []labelPair
+		value   float64
+		wantErr bool
+	}{
+		{
+			line:  "kube_node_info 1",
+			want:  []labelPair{{"__name__", "kube_node_info"}},
+			value: 1,
+		},
+		{
+			line: `kube_pod_labels{pod="pod1",label_app="a\"b\\c\nd",label_empty=""} 1`,
+			want: []labelPair{
+				{"__name__", "kube_pod_labels"},
+				{"label_app", "a\"b\\c\nd"},
+				{"label_empty", ""},
+				{"pod", "pod1"},
+			},
+			value: 1,
+		},
+		{
+			line:  `kube_pod_annotations{pod="a,b=}"} 1`,
+			want:  []labelPair{{"__name__", "kube_pod_annotations"}, {"pod", "a,b=}"}},
+			value: 1,
+		},
+		{
+			line:  `kube_cronjob_next_schedule_time{cronjob="c"} 1.7e+09`,
+			want:  []labelPair{{"__name__", "kube_cronjob_next_schedule_time"}, {"cronjob", "c"}},
+			value: 1.7e+09,
+		},
+		{
+			line:  `kube_job_failed_total{job_name="j"} 3 # {trace_id="abc"} 1 1700000000`,
+			want:  []labelPair{{"__name__", "kube_job_failed_total"}, {"job_name", "j"}},
+			value: 3,
+		},
+		{
+			line:  `kube_hpa_spec_target_metric{hpa="h"} +Inf`,
+			want:  []labelPair{{"__name__", "kube_hpa_spec_target_metric"}, {"hpa", "h"}},
+			value: math.Inf(1),
+		},
+		{line: `kube_pod_info{pod="pod1" 1`, wantErr: true},
+		{line: `kube_pod_info{pod="pod1\"} 1`, wantErr: true},
+		{line: `kube_pod_info{pod="pod1"} one`, wantErr: true},
+		{line: `{pod="pod1"} 1`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		labels, value, err := parseSample(test.line)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.line)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.line, err)
+			continue
+		}
+		if !slices.Equal(labels, test.want) || value != test.value {
+			t.Errorf("%s: want %v %v, got %v %v", test.line, test.want, test.value, labels, value)
+		}
+	}
+}
+
+func TestRemoteWritePushNonRetryableError(t *testing.T) {
+	var requests int
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer receiver.Close()
+
+	rw := &remoteWriter{
+		url:      receiver.URL,
+		interval: time.Minute,
+		client:   receiver.Client(),
+		writeMetrics: func(w io.Writer) {
+			_, _ = w.Write([]byte("kube_pod_info{pod=\"pod1\"} 1\n"))
+		},
+	}
+	if err := rw.push(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if requests != 1 {
+		t.Errorf("expected a single request without retries, got %d", requests)
+	}
+}
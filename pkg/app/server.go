@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/md5" //nolint:gosec
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
@@ -27,6 +28,7 @@ import (
 	"net/http/pprof"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -36,7 +38,6 @@ import (
 	"gopkg.in/yaml.v3"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth" // Initialize common client auth plugins.
-	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
 
 	"github.com/KimMachineGun/automemlimit/memlimit"
@@ -52,9 +53,13 @@ import (
 	"k8s.io/kube-state-metrics/v2/internal/discovery"
 	"k8s.io/kube-state-metrics/v2/internal/store"
 	"k8s.io/kube-state-metrics/v2/pkg/allowdenylist"
+	"k8s.io/kube-state-metrics/v2/pkg/customresource"
 	"k8s.io/kube-state-metrics/v2/pkg/customresourcestate"
+	"k8s.io/kube-state-metrics/v2/pkg/metric"
 	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+	"k8s.io/kube-state-metrics/v2/pkg/metricsconfig"
 	"k8s.io/kube-state-metrics/v2/pkg/metricshandler"
+	"k8s.io/kube-state-metrics/v2/pkg/metricsprofile"
 	"k8s.io/kube-state-metrics/v2/pkg/optin"
 	"k8s.io/kube-state-metrics/v2/pkg/options"
 	"k8s.io/kube-state-metrics/v2/pkg/util"
@@ -121,6 +126,10 @@ func RunKubeStateMetrics(ctx context.Context, opts *options.Options) error {
 		Name: "kube_state_metrics_custom_resource_state_cache",
 		Help: "Net amount of CRDs affecting the cache currently.",
 	})
+	crsRegisteredGauge := promauto.With(ksmMetricsRegistry).NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kube_state_metrics_custom_resource_registered",
+		Help: "Whether a custom resource configured for custom resource state metrics is registered in the cluster.",
+	}, []string{"group", "version", "kind"})
 	storeBuilder := store.NewBuilder()
 	storeBuilder.WithMetrics(ksmMetricsRegistry)
 
@@ -149,6 +158,8 @@ func RunKubeStateMetrics(ctx context.Context, opts *options.Options) error {
 		}
 	}
 
+	metric.SetExponentialNotation(!opts.DisableExponentialNotation)
+
 	if opts.AutoGoMemlimit {
 		if _, err := memlimit.SetGoMemLimitWithOpts(
 			memlimit.WithRatio(opts.AutoGoMemlimitRatio),
@@ -163,7 +174,14 @@ func RunKubeStateMetrics(ctx context.Context, opts *options.Options) error {
 		}
 	}
 
-	kubeConfig, err := clientcmd.BuildConfigFromFlags(opts.Apiserver, opts.Kubeconfig)
+	// The first context is the one of the cluster kube-state-metrics is primarily talking to,
+	// e.g. for custom resource discovery and autosharding.
+	var kubeconfigContext string
+	if len(opts.KubeconfigContexts) > 0 {
+		kubeconfigContext = opts.KubeconfigContexts[0]
+		util.SetKubeconfigContext(kubeconfigContext)
+	}
+	kubeConfig, err := util.BuildConfig(opts.Apiserver, opts.Kubeconfig, kubeconfigContext)
 	if err != nil {
 		return fmt.Errorf("failed to build config from flags: %v", err)
 	}
@@ -173,17 +191,8 @@ func RunKubeStateMetrics(ctx context.Context, opts *options.Options) error {
 	if err != nil {
 		return err
 	}
-
-	if opts.CustomResourceConfigFile != "" {
-		crcFile, err := os.ReadFile(filepath.Clean(opts.CustomResourceConfigFile))
-		if err != nil {
-			return fmt.Errorf("failed to read custom resource config file: %v", err)
-		}
-		configSuccess.WithLabelValues("customresourceconfig", filepath.Clean(opts.CustomResourceConfigFile)).Set(1)
-		configSuccessTime.WithLabelValues("customresourceconfig", filepath.Clean(opts.CustomResourceConfigFile)).SetToCurrentTime()
-		hash := md5HashAsMetricValue(crcFile)
-		configHash.WithLabelValues("customresourceconfig", filepath.Clean(opts.CustomResourceConfigFile)).Set(hash)
-
+	if config != nil && len(opts.KubeconfigContexts) > 1 {
+		return errors.New("custom resource state metrics are not supported in multi-cluster mode")
 	}
 
 	resources := []string{}
@@ -220,6 +229,29 @@ func RunKubeStateMetrics(ctx context.Context, opts *options.Options) error {
 	storeBuilder.WithNamespaces(namespaces)
 	storeBuilder.WithFieldSelectorFilter(merged)
 
+	labelSelectors := map[string]string{}
+	if opts.PodLabelSelector != "" {
+		labelSelectors["pods"] = opts.PodLabelSelector
+	}
+	if err := storeBuilder.WithLabelSelectors(labelSelectors); err != nil {
+		return fmt.Errorf("failed to set up label selectors: %v", err)
+	}
+
+	objectNames := map[string]string{}
+	for resource, name := range map[string]string{
+		"daemonsets":   opts.ObjectNameDaemonSets,
+		"deployments":  opts.ObjectNameDeployments,
+		"pods":         opts.ObjectNamePods,
+		"statefulsets": opts.ObjectNameStatefulSets,
+	} {
+		if name != "" {
+			objectNames[resource] = name
+		}
+	}
+	if err := storeBuilder.WithObjectNames(objectNames); err != nil {
+		return fmt.Errorf("failed to set up object names: %v", err)
+	}
+
 	allowDenyList, err := allowdenylist.New(opts.MetricAllowlist, opts.MetricDenylist)
 	if err != nil {
 		return err
@@ -232,7 +264,35 @@ func RunKubeStateMetrics(ctx context.Context, opts *options.Options) error {
 
 	klog.InfoS("Metric allow-denylisting", "allowDenyStatus", allowDenyList.Status())
 
-	optInMetricFamilyFilter, err := optin.NewMetricFamilyFilter(opts.MetricOptInList)
+	metricsConfigFilter, err := metricsconfig.NewMetricFamilyFilter(opts.MetricsConfig)
+	if err != nil {
+		return fmt.Errorf("error initializing the metrics config: %v", err)
+	}
+
+	optInList := opts.MetricOptInList
+	if metricsConfigFilter.Count() > 0 {
+		if err := metricsConfigFilter.Validate(store.MetricFamilyNames()); err != nil {
+			if config == nil {
+				return err
+			}
+			// The metric families of custom resources are only known once their stores are built.
+			klog.InfoS("Metrics config lists metric families which are not built-in, assuming they are custom resource metric families", "err", err)
+		}
+		optInList = metricsConfigOptInList(opts.MetricOptInList, metricsConfigFilter)
+		klog.InfoS("Metrics config", "metricsConfigStatus", strings.Join(metricsConfigFilter.Metrics(), ", "))
+	}
+
+	if len(opts.MetricOverrides) > 0 {
+		if err := opts.MetricOverrides.Validate(store.MetricFamilyNames()); err != nil {
+			if config == nil {
+				return err
+			}
+			// The metric families of custom resources are only known once their stores are built.
+			klog.InfoS("Metric overrides list metric families which are not built-in, assuming they are custom resource metric families", "err", err)
+		}
+	}
+
+	optInMetricFamilyFilter, err := optin.NewMetricFamilyFilter(optInList)
 	if err != nil {
 		return fmt.Errorf("error initializing the opt-in metric list: %v", err)
 	}
@@ -241,12 +301,27 @@ func RunKubeStateMetrics(ctx context.Context, opts *options.Options) error {
 		klog.InfoS("Metrics which were opted into", "optInMetricsFamilyStatus", optInMetricFamilyFilter.Status())
 	}
 
+	profileMetricFamilyFilter, err := metricsprofile.NewMetricFamilyFilter(opts.MetricsProfile)
+	if err != nil {
+		return fmt.Errorf("error initializing the metrics profile: %v", err)
+	}
+
+	if opts.MetricsProfile != "" && opts.MetricsProfile != metricsprofile.Full {
+		klog.InfoS("Metrics profile", "metricsProfile", opts.MetricsProfile)
+	}
+
 	storeBuilder.WithFamilyGeneratorFilter(generator.NewCompositeFamilyGeneratorFilter(
 		allowDenyList,
 		optInMetricFamilyFilter,
+		profileMetricFamilyFilter,
+		metricsConfigFilter,
 	))
 
 	storeBuilder.WithUsingAPIServerCache(opts.UseAPIServerCache)
+	storeBuilder.WithListChunkSize(opts.ListChunkSize)
+	storeBuilder.WithMetricOverrides(opts.MetricOverrides)
+	storeBuilder.WithDisableUIDLabel(opts.DisableUIDLabel)
+	storeBuilder.WithDropCompletedInitContainers(opts.DropCompletedInitContainers)
 	storeBuilder.WithGenerateStoresFunc(storeBuilder.DefaultGenerateStoresFunc())
 	proc.StartReaper()
 
@@ -257,6 +332,19 @@ func RunKubeStateMetrics(ctx context.Context, opts *options.Options) error {
 	}
 	storeBuilder.WithKubeClient(kubeClient)
 
+	if len(opts.KubeconfigContexts) > 1 {
+		clusterKubeClients := map[string]kubernetes.Interface{opts.KubeconfigContexts[0]: kubeClient}
+		for _, c := range opts.KubeconfigContexts[1:] {
+			clusterKubeClient, err := util.CreateKubeClientForContext(opts.Apiserver, opts.Kubeconfig, c)
+			if err != nil {
+				return fmt.Errorf("failed to create client for context %s: %v", c, err)
+			}
+			clusterKubeClients[c] = clusterKubeClient
+		}
+		storeBuilder.WithClusterKubeClients(clusterKubeClients)
+		klog.InfoS("Running in multi-cluster mode", "clusters", opts.KubeconfigContexts)
+	}
+
 	storeBuilder.WithSharding(opts.Shard, opts.TotalShards)
 	if err := storeBuilder.WithAllowAnnotations(opts.AnnotationsAllowList); err != nil {
 		return fmt.Errorf("failed to set up annotations allowlist: %v", err)
@@ -264,6 +352,9 @@ func RunKubeStateMetrics(ctx context.Context, opts *options.Options) error {
 	if err := storeBuilder.WithAllowLabels(opts.LabelsAllowList); err != nil {
 		return fmt.Errorf("failed to set up labels allowlist: %v", err)
 	}
+	if err := storeBuilder.WithDenyLabels(opts.LabelsDenyList); err != nil {
+		return fmt.Errorf("failed to set up labels denylist: %v", err)
+	}
 
 	ksmMetricsRegistry.MustRegister(
 		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
@@ -278,6 +369,7 @@ func RunKubeStateMetrics(ctx context.Context, opts *options.Options) error {
 		storeBuilder,
 		opts.EnableGZIPEncoding,
 	)
+	m.WithMetrics(ksmMetricsRegistry)
 	// Run MetricsHandler
 	ctxMetricsHandler, cancel := context.WithCancel(ctx)
 	g.Add(func() error {
@@ -286,24 +378,47 @@ func RunKubeStateMetrics(ctx context.Context, opts *options.Options) error {
 		cancel()
 	})
 
-	tlsConfig := opts.TLSConfig
+	webConfig := webConfigFile(opts.WebConfigFile, opts.TLSConfig)
 
 	// A nil CRS config implies that we need to hold off on all CRS operations.
 	if config != nil {
 		discovererInstance := &discovery.CRDiscoverer{
-			CRDsAddEventsCounter:    crdsAddEventsCounter,
-			CRDsDeleteEventsCounter: crdsDeleteEventsCounter,
-			CRDsCacheCountGauge:     crdsCacheCountGauge,
+			CRDsAddEventsCounter:          crdsAddEventsCounter,
+			CRDsDeleteEventsCounter:       crdsDeleteEventsCounter,
+			CRDsCacheCountGauge:           crdsCacheCountGauge,
+			CustomResourceRegisteredGauge: crsRegisteredGauge,
 		}
 		// This starts a goroutine that will watch for any new GVKs to extract from CRDs.
 		err = discovererInstance.StartDiscovery(ctx, kubeConfig)
 		if err != nil {
 			return err
 		}
-		// FromConfig will return different behaviours when a G**-based config is supplied (since that is subject to change based on the resources present in the cluster).
-		fn, err := customresourcestate.FromConfig(config, discovererInstance)
-		if err != nil {
-			return err
+		var fn func() ([]customresource.RegistryFactory, error)
+		if opts.CustomResourceConfig == "" {
+			// The configuration file is reloaded on change, only rebuilding the stores of the changed custom resources.
+			reloader := &customResourceConfigReloader{
+				file:              opts.CustomResourceConfigFile,
+				discoverer:        discovererInstance,
+				configHash:        configHash,
+				configSuccess:     configSuccess,
+				configSuccessTime: configSuccessTime,
+			}
+			if err := reloader.reload(); err != nil {
+				return err
+			}
+			fn = reloader.Factories
+			ctxReloader, cancel := context.WithCancel(ctx)
+			g.Add(func() error {
+				return reloader.Run(ctxReloader)
+			}, func(error) {
+				cancel()
+			})
+		} else {
+			// FromConfig will return different behaviours when a G**-based config is supplied (since that is subject to change based on the resources present in the cluster).
+			fn, err = customresourcestate.FromConfig(config, discovererInstance)
+			if err != nil {
+				return err
+			}
 		}
 		// This starts a goroutine that will keep the cache up to date.
 		discovererInstance.PollForCacheUpdates(
@@ -316,18 +431,18 @@ func RunKubeStateMetrics(ctx context.Context, opts *options.Options) error {
 	}
 
 	telemetryMux := buildTelemetryServer(ksmMetricsRegistry)
-	telemetryListenAddress := net.JoinHostPort(opts.TelemetryHost, strconv.Itoa(opts.TelemetryPort))
+	telemetryListenAddress := listenAddress(opts.TelemetryHost, opts.TelemetryPort)
 	telemetryServer := http.Server{
 		Handler:           telemetryMux,
 		ReadHeaderTimeout: 5 * time.Second}
 	telemetryFlags := web.FlagConfig{
 		WebListenAddresses: &[]string{telemetryListenAddress},
 		WebSystemdSocket:   new(bool),
-		WebConfigFile:      &tlsConfig,
+		WebConfigFile:      &webConfig,
 	}
 
-	metricsMux := buildMetricsServer(m, durationVec, kubeClient)
-	metricsServerListenAddress := net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port))
+	metricsMux := buildMetricsServer(m, opts.MetricsPath, opts.EnableDeltaEndpoint, durationVec, kubeClient)
+	metricsServerListenAddress := listenAddress(opts.Host, opts.Port)
 	metricsServer := http.Server{
 		Handler:           metricsMux,
 		ReadHeaderTimeout: opts.ServerReadHeaderTimeout,
@@ -338,7 +453,16 @@ func RunKubeStateMetrics(ctx context.Context, opts *options.Options) error {
 	metricsFlags := web.FlagConfig{
 		WebListenAddresses: &[]string{metricsServerListenAddress},
 		WebSystemdSocket:   new(bool),
-		WebConfigFile:      &tlsConfig,
+		WebConfigFile:      &webConfig,
+	}
+
+	if opts.WebBearerTokenFile != "" {
+		token, err := loadBearerToken(opts.WebBearerTokenFile, webConfig)
+		if err != nil {
+			return fmt.Errorf("failed to set up bearer token authentication: %v", err)
+		}
+		telemetryServer.Handler = bearerTokenHandler(token, telemetryServer.Handler)
+		metricsServer.Handler = bearerTokenHandler(token, metricsServer.Handler)
 	}
 
 	handler := logr.ToSlogHandler(klog.Background())
@@ -367,6 +491,25 @@ func RunKubeStateMetrics(ctx context.Context, opts *options.Options) error {
 		})
 	}
 
+	// Run remote-write pusher
+	if opts.RemoteWriteURL != "" {
+		rw := &remoteWriter{
+			url:                  opts.RemoteWriteURL,
+			headers:              opts.RemoteWriteHeaders,
+			interval:             opts.RemoteWriteInterval,
+			client:               &http.Client{Timeout: opts.RemoteWriteInterval},
+			maxSamplesPerRequest: remoteWriteMaxSamplesPerRequest,
+			writeMetrics:         m.WriteMetrics,
+		}
+		ctxRemoteWrite, cancel := context.WithCancel(ctx)
+		g.Add(func() error {
+			klog.InfoS("Started pushing metrics", "remoteWriteURL", opts.RemoteWriteURL, "interval", opts.RemoteWriteInterval)
+			return rw.Run(ctxRemoteWrite)
+		}, func(error) {
+			cancel()
+		})
+	}
+
 	if err := g.Run(); err != nil {
 		return fmt.Errorf("run server group error: %v", err)
 	}
@@ -429,7 +572,7 @@ func handleClusterDelegationForProber(client kubernetes.Interface, probeType str
 	}
 }
 
-func buildMetricsServer(m *metricshandler.MetricsHandler, durationObserver prometheus.ObserverVec, client kubernetes.Interface) *http.ServeMux {
+func buildMetricsServer(m *metricshandler.MetricsHandler, path string, enableDelta bool, durationObserver prometheus.ObserverVec, client kubernetes.Interface) *http.ServeMux {
 	mux := http.NewServeMux()
 
 	// TODO: This doesn't belong into serveMetrics
@@ -439,8 +582,13 @@ func buildMetricsServer(m *metricshandler.MetricsHandler, durationObserver prome
 	mux.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
 	mux.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
 
-	// Add metricsPath
-	mux.Handle(metricsPath, promhttp.InstrumentHandlerDuration(durationObserver, m))
+	// Add the metrics path, which defaults to metricsPath
+	mux.Handle(path, promhttp.InstrumentHandlerDuration(durationObserver, m))
+
+	// Add the experimental delta path, e.g. /metrics/delta
+	if enableDelta {
+		mux.Handle(deltaPath(path), http.HandlerFunc(m.ServeDeltaHTTP))
+	}
 
 	// Add livezPath
 	mux.Handle(livezPath, handleClusterDelegationForProber(client, livezPath))
@@ -451,6 +599,17 @@ func buildMetricsServer(m *metricshandler.MetricsHandler, durationObserver prome
 		w.Write([]byte(http.StatusText(http.StatusOK)))
 	})
 
+	// Add readyzPath
+	mux.HandleFunc(readyzPath, func(w http.ResponseWriter, _ *http.Request) {
+		if !m.HasSynced() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(http.StatusText(http.StatusServiceUnavailable)))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(http.StatusText(http.StatusOK)))
+	})
+
 	// Add index
 	landingConfig := web.LandingConfig{
 		Name:        "kube-state-metrics",
@@ -458,7 +617,7 @@ func buildMetricsServer(m *metricshandler.MetricsHandler, durationObserver prome
 		Version:     version.Info(),
 		Links: []web.LandingLinks{
 			{
-				Address: metricsPath,
+				Address: path,
 				Text:    "Metrics",
 			},
 			{
@@ -469,6 +628,10 @@ func buildMetricsServer(m *metricshandler.MetricsHandler, durationObserver prome
 				Address: livezPath,
 				Text:    "Livez",
 			},
+			{
+				Address: readyzPath,
+				Text:    "Readyz",
+			},
 		},
 	}
 	landingPage, err := web.NewLandingPage(landingConfig)
@@ -479,6 +642,13 @@ func buildMetricsServer(m *metricshandler.MetricsHandler, durationObserver prome
 	return mux
 }
 
+// listenAddress returns the address to listen on for the given host and port.
+// IPv6 hosts may be given with or without brackets, e.g. "[::]" or "::", the
+// latter listening on all IPv4 and IPv6 addresses on dual-stack hosts.
+func listenAddress(host string, port int) string {
+	return net.JoinHostPort(strings.TrimSuffix(strings.TrimPrefix(host, "["), "]"), strconv.Itoa(port))
+}
+
 // md5HashAsMetricValue creates an md5 hash and returns the most significant bytes that fit into a float64
 // Taken from https://github.com/prometheus/alertmanager/blob/6ef6e6868dbeb7984d2d577dd4bf75c65bf1904f/config/coordinator.go#L149
 func md5HashAsMetricValue(data []byte) float64 {
@@ -503,3 +673,21 @@ func resolveCustomResourceConfig(opts *options.Options) (customresourcestate.Con
 	}
 	return nil, nil
 }
+
+// metricsConfigOptInList returns the given opt-in list extended by the metric families
+// listed in the metrics config, so that listed opt-in metric families are enabled.
+func metricsConfigOptInList(optInList options.MetricSet, filter *metricsconfig.MetricFamilyFilter) options.MetricSet {
+	list := options.MetricSet{}
+	for m := range optInList {
+		list[m] = struct{}{}
+	}
+	for _, m := range filter.Metrics() {
+		list["^"+regexp.QuoteMeta(m)+"$"] = struct{}{}
+	}
+	return list
+}
+
+// deltaPath returns the path of the delta endpoint for the given metrics path.
+func deltaPath(path string) string {
+	return strings.TrimSuffix(path, "/") + "/delta"
+}
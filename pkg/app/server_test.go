@@ -22,6 +22,8 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"net/http/httptest"
 	"sort"
 	"strconv"
@@ -37,6 +39,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
@@ -209,10 +212,16 @@ func TestFullScrapeCycle(t *testing.T) {
 
 	expected := `# HELP kube_pod_annotations Kubernetes annotations converted to Prometheus labels.
 # HELP kube_pod_completion_time [STABLE] Completion time in unix timestamp for a pod.
+# HELP kube_pod_container_image The image a container in a pod is running and the digest it was resolved to by the container runtime.
 # HELP kube_pod_container_info [STABLE] Information about a container in a pod.
+# HELP kube_pod_container_probe Describes whether a liveness, readiness or startup probe is configured for a container in a pod.
+# HELP kube_pod_container_probe_settings_seconds The initial delay and period in seconds of the probes configured for a container in a pod.
 # HELP kube_pod_container_resource_limits The number of requested limit resource by a container. It is recommended to use the kube_pod_resource_limits metric exposed by kube-scheduler instead, as it is more precise.
 # HELP kube_pod_container_resource_requests The number of requested request resource by a container. It is recommended to use the kube_pod_resource_requests metric exposed by kube-scheduler instead, as it is more precise.
+# HELP kube_pod_container_security_context Describes the security context settings of a container in a pod.
+# HELP kube_pod_container_spec_termination_message_policy Describes how the termination message of a container is populated.
 # HELP kube_pod_container_state_started [STABLE] Start time in unix timestamp for a pod container.
+# HELP kube_pod_container_status_image_pinned Describes whether the image of a container in a pod is pinned by a digest rather than a mutable tag.
 # HELP kube_pod_container_status_last_terminated_exitcode Describes the exit code for the last container in terminated state.
 # HELP kube_pod_container_status_last_terminated_reason Describes the last reason the container was in terminated state.
 # HELP kube_pod_container_status_last_terminated_timestamp Last terminated time for a pod container in unix timestamp.
@@ -246,12 +255,18 @@ func TestFullScrapeCycle(t *testing.T) {
 # HELP kube_pod_service_account The service account for a pod.
 # HELP kube_pod_owner [STABLE] Information about the Pod's owner.
 # HELP kube_pod_restart_policy [STABLE] Describes the restart policy in use by this pod.
+# HELP kube_pod_spec_affinity The number of required and preferred node affinity, pod affinity and pod anti-affinity terms of a pod.
+# HELP kube_pod_spec_priority The priority value of a pod. Only emitted if the priority of the pod is set.
+# HELP kube_pod_spec_security_context Describes the pod-level security context settings of a pod.
+# HELP kube_pod_spec_host_namespaces Describes whether a pod uses the network, PID and IPC namespaces of its host.
+# HELP kube_pod_spec_topology_spread_constraint The maximum skew of the topology spread constraints of a pod.
 # HELP kube_pod_spec_volumes_persistentvolumeclaims_info [STABLE] Information about persistentvolumeclaim volumes in a pod.
 # HELP kube_pod_spec_volumes_persistentvolumeclaims_readonly [STABLE] Describes whether a persistentvolumeclaim is mounted read only.
 # HELP kube_pod_start_time [STABLE] Start time in unix timestamp for a pod.
 # HELP kube_pod_status_container_ready_time Readiness achieved time in unix timestamp for a pod containers.
 # HELP kube_pod_status_initialized_time Initialized time in unix timestamp for a pod.
 # HELP kube_pod_status_qos_class The pods current qosClass.
+# HELP kube_pod_status_not_ready Describes whether the pod is not ready to serve requests, i.e. its Ready condition is False, Unknown or not reported yet.
 # HELP kube_pod_status_phase [STABLE] The pods current phase.
 # HELP kube_pod_status_ready_time Readiness achieved time in unix timestamp for a pod.
 # HELP kube_pod_status_ready [STABLE] Describes whether the pod is ready to serve requests.
@@ -259,13 +274,21 @@ func TestFullScrapeCycle(t *testing.T) {
 # HELP kube_pod_status_scheduled [STABLE] Describes the status of the scheduling process for the pod.
 # HELP kube_pod_status_scheduled_time [STABLE] Unix timestamp when pod moved into scheduled status
 # HELP kube_pod_status_unschedulable [STABLE] Describes the unschedulable status for the pod.
+# HELP kube_pod_status_unscheduled_duration_seconds The number of seconds since the pod moved into unscheduled status.
+# HELP kube_pod_status_unscheduled_time Unix timestamp when pod moved into unscheduled status
 # HELP kube_pod_tolerations Information about the pod tolerations
 # TYPE kube_pod_annotations gauge
 # TYPE kube_pod_completion_time gauge
+# TYPE kube_pod_container_image gauge
 # TYPE kube_pod_container_info gauge
+# TYPE kube_pod_container_probe gauge
+# TYPE kube_pod_container_probe_settings_seconds gauge
 # TYPE kube_pod_container_resource_limits gauge
 # TYPE kube_pod_container_resource_requests gauge
+# TYPE kube_pod_container_security_context gauge
+# TYPE kube_pod_container_spec_termination_message_policy gauge
 # TYPE kube_pod_container_state_started gauge
+# TYPE kube_pod_container_status_image_pinned gauge
 # TYPE kube_pod_container_status_last_terminated_exitcode gauge
 # TYPE kube_pod_container_status_last_terminated_reason gauge
 # TYPE kube_pod_container_status_last_terminated_timestamp gauge
@@ -299,11 +322,17 @@ func TestFullScrapeCycle(t *testing.T) {
 # TYPE kube_pod_service_account gauge
 # TYPE kube_pod_owner gauge
 # TYPE kube_pod_restart_policy gauge
+# TYPE kube_pod_spec_affinity gauge
+# TYPE kube_pod_spec_priority gauge
+# TYPE kube_pod_spec_security_context gauge
+# TYPE kube_pod_spec_host_namespaces gauge
+# TYPE kube_pod_spec_topology_spread_constraint gauge
 # TYPE kube_pod_spec_volumes_persistentvolumeclaims_info gauge
 # TYPE kube_pod_spec_volumes_persistentvolumeclaims_readonly gauge
 # TYPE kube_pod_start_time gauge
 # TYPE kube_pod_status_container_ready_time gauge
 # TYPE kube_pod_status_initialized_time gauge
+# TYPE kube_pod_status_not_ready gauge
 # TYPE kube_pod_status_phase gauge
 # TYPE kube_pod_status_qos_class gauge
 # TYPE kube_pod_status_ready gauge
@@ -312,9 +341,19 @@ func TestFullScrapeCycle(t *testing.T) {
 # TYPE kube_pod_status_scheduled gauge
 # TYPE kube_pod_status_scheduled_time gauge
 # TYPE kube_pod_status_unschedulable gauge
+# TYPE kube_pod_status_unscheduled_duration_seconds gauge
+# TYPE kube_pod_status_unscheduled_time gauge
 # TYPE kube_pod_tolerations gauge
-kube_pod_container_info{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1",image_spec="k8s.gcr.io/hyperkube2_spec",image="k8s.gcr.io/hyperkube2",image_id="docker://sha256:bbb",container_id="docker://cd456"} 1
-kube_pod_container_info{namespace="default",pod="pod0",uid="abc-0",container="pod1_con2",image_spec="k8s.gcr.io/hyperkube3_spec",image="k8s.gcr.io/hyperkube3",image_id="docker://sha256:ccc",container_id="docker://ef789"} 1
+kube_pod_container_image{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1",image="k8s.gcr.io/hyperkube2",image_id="sha256:bbb"} 1
+kube_pod_container_image{namespace="default",pod="pod0",uid="abc-0",container="pod1_con2",image="k8s.gcr.io/hyperkube3",image_id="sha256:ccc"} 1
+kube_pod_container_info{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1",image_spec="k8s.gcr.io/hyperkube2_spec",image="k8s.gcr.io/hyperkube2",image_id="docker://sha256:bbb",container_id="docker://cd456",image_pull_policy=""} 1
+kube_pod_container_info{namespace="default",pod="pod0",uid="abc-0",container="pod1_con2",image_spec="k8s.gcr.io/hyperkube3_spec",image="k8s.gcr.io/hyperkube3",image_id="docker://sha256:ccc",container_id="docker://ef789",image_pull_policy=""} 1
+kube_pod_container_probe{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1",probe_type="liveness",configured="false"} 1
+kube_pod_container_probe{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1",probe_type="readiness",configured="false"} 1
+kube_pod_container_probe{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1",probe_type="startup",configured="false"} 1
+kube_pod_container_probe{namespace="default",pod="pod0",uid="abc-0",container="pod1_con2",probe_type="liveness",configured="false"} 1
+kube_pod_container_probe{namespace="default",pod="pod0",uid="abc-0",container="pod1_con2",probe_type="readiness",configured="false"} 1
+kube_pod_container_probe{namespace="default",pod="pod0",uid="abc-0",container="pod1_con2",probe_type="startup",configured="false"} 1
 kube_pod_container_resource_limits{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1",node="node1",resource="cpu",unit="core"} 0.2
 kube_pod_container_resource_limits{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1",node="node1",resource="ephemeral_storage",unit="byte"} 3e+08
 kube_pod_container_resource_limits{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1",node="node1",resource="memory",unit="byte"} 1e+08
@@ -329,6 +368,12 @@ kube_pod_container_resource_requests{namespace="default",pod="pod0",uid="abc-0",
 kube_pod_container_resource_requests{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1",node="node1",resource="storage",unit="byte"} 4e+08
 kube_pod_container_resource_requests{namespace="default",pod="pod0",uid="abc-0",container="pod1_con2",node="node1",resource="cpu",unit="core"} 0.3
 kube_pod_container_resource_requests{namespace="default",pod="pod0",uid="abc-0",container="pod1_con2",node="node1",resource="memory",unit="byte"} 2e+08
+kube_pod_container_security_context{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1",privileged="",run_as_non_root="",read_only_root_fs=""} 1
+kube_pod_container_security_context{namespace="default",pod="pod0",uid="abc-0",container="pod1_con2",privileged="",run_as_non_root="",read_only_root_fs=""} 1
+kube_pod_container_spec_termination_message_policy{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1",policy=""} 1
+kube_pod_container_spec_termination_message_policy{namespace="default",pod="pod0",uid="abc-0",container="pod1_con2",policy=""} 1
+kube_pod_container_status_image_pinned{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1",image_spec="k8s.gcr.io/hyperkube2_spec"} 0
+kube_pod_container_status_image_pinned{namespace="default",pod="pod0",uid="abc-0",container="pod1_con2",image_spec="k8s.gcr.io/hyperkube3_spec"} 0
 kube_pod_container_status_last_terminated_exitcode{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1"} 137
 kube_pod_container_status_last_terminated_reason{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1",reason="OOMKilled"} 1
 kube_pod_container_status_last_terminated_timestamp{namespace="default",pod="pod0",uid="abc-0",container="pod1_con1"} 1.501779547e+09
@@ -345,10 +390,12 @@ kube_pod_container_status_waiting{namespace="default",pod="pod0",uid="abc-0",con
 kube_pod_container_status_waiting{namespace="default",pod="pod0",uid="abc-0",container="pod1_con2"} 0
 kube_pod_created{namespace="default",pod="pod0",uid="abc-0"} 1.5e+09
 kube_pod_info{namespace="default",pod="pod0",uid="abc-0",host_ip="1.1.1.1",pod_ip="1.2.3.4",node="node1",created_by_kind="",created_by_name="",priority_class="",host_network="false"} 1
+kube_pod_spec_host_namespaces{namespace="default",pod="pod0",uid="abc-0",host_network="false",host_pid="false",host_ipc="false"} 1
 kube_pod_owner{namespace="default",pod="pod0",uid="abc-0",owner_kind="",owner_name="",owner_is_controller=""} 1
 kube_pod_restart_policy{namespace="default",pod="pod0",uid="abc-0",type="Always"} 1
 kube_pod_scheduler{namespace="default",pod="pod0",uid="abc-0",name="scheduler1"} 1
 kube_pod_service_account{namespace="default",pod="pod0",uid="abc-0",service_account=""} 1
+kube_pod_status_not_ready{namespace="default",pod="pod0",uid="abc-0"} 1
 kube_pod_status_phase{namespace="default",pod="pod0",uid="abc-0",phase="Failed"} 0
 kube_pod_status_phase{namespace="default",pod="pod0",uid="abc-0",phase="Pending"} 0
 kube_pod_status_phase{namespace="default",pod="pod0",uid="abc-0",phase="Running"} 1
@@ -979,3 +1026,283 @@ func (f *fooFactory) ListWatch(customResourceClient interface{}, ns string, fiel
 		},
 	}
 }
+
+func TestListenAddress(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "::", want: "[::]:8080"},
+		{host: "[::]", want: "[::]:8080"},
+		{host: "[::1]", want: "[::1]:8080"},
+		{host: "0.0.0.0", want: "0.0.0.0:8080"},
+		{host: "", want: ":8080"},
+	}
+	for _, test := range tests {
+		if got := listenAddress(test.host, 8080); got != test.want {
+			t.Errorf("host %q: want listen address %q, got %q", test.host, test.want, got)
+		}
+	}
+
+	l, err := net.Listen("tcp", listenAddress("[::1]", 0))
+	if err != nil {
+		t.Skipf("IPv6 loopback is not available: %v", err)
+	}
+	defer l.Close()
+	if addr := l.Addr().(*net.TCPAddr); !addr.IP.Equal(net.IPv6loopback) {
+		t.Errorf("expected the listener to bind to %s, got %s", net.IPv6loopback, addr.IP)
+	}
+}
+
+func TestBuildMetricsServerMetricsPath(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reg := prometheus.NewRegistry()
+	builder := store.NewBuilder()
+	builder.WithMetrics(reg)
+	if err := builder.WithEnabledResources([]string{"configmaps"}); err != nil {
+		t.Fatal(err)
+	}
+	builder.WithKubeClient(kubeClient)
+	builder.WithNamespaces(options.DefaultNamespaces)
+	builder.WithGenerateStoresFunc(builder.DefaultGenerateStoresFunc())
+
+	l, err := allowdenylist.New(map[string]struct{}{}, map[string]struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder.WithFamilyGeneratorFilter(l)
+
+	handler := metricshandler.New(&options.Options{}, kubeClient, builder, false)
+	handler.ConfigureSharding(ctx, 0, 1)
+
+	durationVec := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "http_request_duration_seconds"}, []string{"method"})
+	mux := buildMetricsServer(handler, "/kube-metrics", false, durationVec, kubeClient)
+
+	for path, want := range map[string]int{
+		"/kube-metrics":       http.StatusOK,
+		"/metrics":            http.StatusNotFound,
+		"/healthz":            http.StatusOK,
+		"/kube-metrics/delta": http.StatusNotFound,
+	} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", "http://localhost:8080"+path, nil))
+		if got := w.Result().StatusCode; got != want {
+			t.Errorf("%s: expected status code %d, got %d", path, want, got)
+		}
+	}
+}
+
+func TestBuildMetricsServerDelta(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "configmap1",
+			Namespace: "default",
+			UID:       "cm-1",
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reg := prometheus.NewRegistry()
+	builder := store.NewBuilder()
+	builder.WithMetrics(reg)
+	if err := builder.WithEnabledResources([]string{"configmaps"}); err != nil {
+		t.Fatal(err)
+	}
+	builder.WithKubeClient(kubeClient)
+	builder.WithNamespaces(options.DefaultNamespaces)
+	builder.WithGenerateStoresFunc(builder.DefaultGenerateStoresFunc())
+
+	l, err := allowdenylist.New(map[string]struct{}{}, map[string]struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder.WithFamilyGeneratorFilter(l)
+
+	handler := metricshandler.New(&options.Options{EnableDeltaEndpoint: true}, kubeClient, builder, false)
+	handler.ConfigureSharding(ctx, 0, 1)
+
+	durationVec := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "http_request_duration_seconds"}, []string{"method"})
+	mux := buildMetricsServer(handler, "/metrics", true, durationVec, kubeClient)
+
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		return handler.HasSynced(), nil
+	}); err != nil {
+		t.Fatal("timed out waiting for the initial sync")
+	}
+
+	// delta returns the body of the delta since the given cursor, without its trailing cursor, and the next cursor.
+	delta := func(cursor string) (string, string) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", "http://localhost:8080/metrics/delta?cursor="+cursor, nil))
+		if got := w.Result().StatusCode; got != http.StatusOK {
+			t.Fatalf("expected status code %d, got %d", http.StatusOK, got)
+		}
+		body, err := io.ReadAll(w.Result().Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		i := strings.LastIndex(string(body), "# CURSOR ")
+		if i < 0 || !strings.HasSuffix(string(body), "\n") {
+			t.Fatalf("expected the delta to end with a cursor, got:\n%s", body)
+		}
+		return string(body[:i]), strings.TrimSpace(string(body[i+len("# CURSOR "):]))
+	}
+
+	got, cursor := delta("")
+	for _, want := range []string{
+		"# RESET\n",
+		"# CHANGED configmaps cm-1\n",
+		`kube_configmap_info{namespace="default",configmap="configmap1"} 1`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected the initial delta to contain %q, got:\n%s", want, got)
+		}
+	}
+
+	if got, _ := delta(cursor); got != "" {
+		t.Errorf("expected an empty delta without changes, got:\n%s", got)
+	}
+
+	if err := kubeClient.CoreV1().ConfigMaps("default").Delete(ctx, "configmap1", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		got, _ = delta(cursor)
+		return got != "", nil
+	}); err != nil {
+		t.Fatal("timed out waiting for the deletion to show up in the delta")
+	}
+	if want := "# DELETED configmaps cm-1\n"; got != want {
+		t.Errorf("expected delta %q, got %q", want, got)
+	}
+
+	// Reading a delta does not consume it, so it can be read again, e.g. after a failed read.
+	got, next := delta(cursor)
+	if want := "# DELETED configmaps cm-1\n"; got != want {
+		t.Errorf("expected delta %q on the second read, got %q", want, got)
+	}
+	if got, _ := delta(next); got != "" {
+		t.Errorf("expected an empty delta after the deletion, got:\n%s", got)
+	}
+
+	// Cursors are no longer valid once the metrics writers were rebuilt.
+	handler.ConfigureSharding(ctx, 0, 2)
+	if got, _ := delta(next); !strings.HasPrefix(got, "# RESET\n") {
+		t.Errorf("expected a reset after the metrics writers were rebuilt, got:\n%s", got)
+	}
+}
+
+func TestBuildMetricsServerBearerToken(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reg := prometheus.NewRegistry()
+	builder := store.NewBuilder()
+	builder.WithMetrics(reg)
+	if err := builder.WithEnabledResources([]string{"configmaps"}); err != nil {
+		t.Fatal(err)
+	}
+	builder.WithKubeClient(kubeClient)
+	builder.WithNamespaces(options.DefaultNamespaces)
+	builder.WithGenerateStoresFunc(builder.DefaultGenerateStoresFunc())
+
+	l, err := allowdenylist.New(map[string]struct{}{}, map[string]struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder.WithFamilyGeneratorFilter(l)
+
+	handler := metricshandler.New(&options.Options{}, kubeClient, builder, false)
+	handler.ConfigureSharding(ctx, 0, 1)
+
+	durationVec := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "http_request_duration_seconds"}, []string{"method"})
+	metricsMux := bearerTokenHandler("s3cr3t", buildMetricsServer(handler, "/metrics", false, durationVec, kubeClient))
+	telemetryMux := bearerTokenHandler("s3cr3t", buildTelemetryServer(reg))
+
+	get := func(h http.Handler, path, token string) int {
+		req := httptest.NewRequest("GET", "http://localhost:8080"+path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w.Result().StatusCode
+	}
+
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		return handler.HasSynced(), nil
+	}); err != nil {
+		t.Fatal("timed out waiting for the initial sync")
+	}
+
+	// Probes are authenticated like any other request, as with basic authentication.
+	for _, path := range []string{"/healthz", "/readyz"} {
+		if got := get(metricsMux, path, ""); got != http.StatusUnauthorized {
+			t.Errorf("metrics server %s: expected status code %d without a token, got %d", path, http.StatusUnauthorized, got)
+		}
+		if got := get(metricsMux, path, "s3cr3t"); got != http.StatusOK {
+			t.Errorf("metrics server %s: expected status code %d with a token, got %d", path, http.StatusOK, got)
+		}
+	}
+	if got := get(telemetryMux, "/readyz", ""); got != http.StatusUnauthorized {
+		t.Errorf("telemetry server /readyz: expected status code %d without a token, got %d", http.StatusUnauthorized, got)
+	}
+
+	for name, h := range map[string]http.Handler{"metrics": metricsMux, "telemetry": telemetryMux} {
+		if got := get(h, "/metrics", ""); got != http.StatusUnauthorized {
+			t.Errorf("%s server /metrics: expected status code %d without a token, got %d", name, http.StatusUnauthorized, got)
+		}
+		if got := get(h, "/metrics", "s3cr3t"); got != http.StatusOK {
+			t.Errorf("%s server /metrics: expected status code %d with a token, got %d", name, http.StatusOK, got)
+		}
+	}
+}
+
+func TestBuildMetricsServerReadyz(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reg := prometheus.NewRegistry()
+	builder := store.NewBuilder()
+	builder.WithMetrics(reg)
+	if err := builder.WithEnabledResources([]string{"configmaps"}); err != nil {
+		t.Fatal(err)
+	}
+	builder.WithKubeClient(kubeClient)
+	builder.WithNamespaces(options.DefaultNamespaces)
+	builder.WithGenerateStoresFunc(builder.DefaultGenerateStoresFunc())
+
+	l, err := allowdenylist.New(map[string]struct{}{}, map[string]struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	builder.WithFamilyGeneratorFilter(l)
+
+	handler := metricshandler.New(&options.Options{}, kubeClient, builder, false)
+	durationVec := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "http_request_duration_seconds"}, []string{"method"})
+	mux := buildMetricsServer(handler, "/metrics", false, durationVec, kubeClient)
+
+	readyz := func() int {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", "http://localhost:8080/readyz", nil))
+		return w.Result().StatusCode
+	}
+
+	if got := readyz(); got != http.StatusServiceUnavailable {
+		t.Fatalf("expected status code %d before the initial sync, got %d", http.StatusServiceUnavailable, got)
+	}
+
+	handler.ConfigureSharding(ctx, 0, 1)
+
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		return readyz() == http.StatusOK, nil
+	}); err != nil {
+		t.Fatalf("expected status code %d after the initial sync, got %d", http.StatusOK, readyz())
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"k8s.io/kube-state-metrics/v2/internal/store"
+	"k8s.io/kube-state-metrics/v2/pkg/allowdenylist"
+	"k8s.io/kube-state-metrics/v2/pkg/customresourcestate"
+	"k8s.io/kube-state-metrics/v2/pkg/metricsconfig"
+	"k8s.io/kube-state-metrics/v2/pkg/metricsprofile"
+	"k8s.io/kube-state-metrics/v2/pkg/optin"
+	"k8s.io/kube-state-metrics/v2/pkg/options"
+)
+
+// ValidateConfig validates the configuration kube-state-metrics would be started with,
+// without connecting to a cluster. It returns the errors of all invalid parts of the configuration.
+func ValidateConfig(opts *options.Options) error {
+	if got := options.GetConfigFile(*opts); got != "" {
+		configFile, err := os.ReadFile(filepath.Clean(got))
+		if err != nil {
+			return fmt.Errorf("failed to read opts config file: %v", err)
+		}
+		if err := yaml.Unmarshal(configFile, opts); err != nil {
+			return fmt.Errorf("failed to unmarshal opts config file: %v", err)
+		}
+	}
+
+	var errs []error
+
+	allowDenyList, err := allowdenylist.New(opts.MetricAllowlist, opts.MetricDenylist)
+	if err == nil {
+		err = allowDenyList.Parse()
+	}
+	if err != nil {
+		errs = append(errs, fmt.Errorf("error initializing the allowdeny list: %v", err))
+	}
+
+	if _, err := optin.NewMetricFamilyFilter(opts.MetricOptInList); err != nil {
+		errs = append(errs, fmt.Errorf("error initializing the opt-in metric list: %v", err))
+	}
+
+	if _, err := metricsprofile.NewMetricFamilyFilter(opts.MetricsProfile); err != nil {
+		errs = append(errs, fmt.Errorf("error initializing the metrics profile: %v", err))
+	}
+
+	config, err := resolveCustomResourceConfig(opts)
+	if err != nil {
+		errs = append(errs, err)
+	} else if config != nil {
+		if err := customresourcestate.ValidateConfig(config); err != nil {
+			errs = append(errs, fmt.Errorf("invalid custom resource state configuration: %w", err))
+		}
+	}
+
+	metricsConfigFilter, err := metricsconfig.NewMetricFamilyFilter(opts.MetricsConfig)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("error initializing the metrics config: %v", err))
+	} else if metricsConfigFilter.Count() > 0 && config == nil {
+		if err := metricsConfigFilter.Validate(store.MetricFamilyNames()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(opts.MetricOverrides) > 0 && config == nil {
+		if err := opts.MetricOverrides.Validate(store.MetricFamilyNames()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
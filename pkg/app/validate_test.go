@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"testing"
+
+	"k8s.io/kube-state-metrics/v2/pkg/options"
+)
+
+const validCustomResourceConfig = `
+spec:
+  resources:
+    - groupVersionKind:
+        group: myteam.io
+        version: v1
+        kind: Foo
+      metrics:
+        - name: uptime
+          help: Foo uptime
+          each:
+            type: Gauge
+            gauge:
+              path: [status, uptime]
+`
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    func(*options.Options)
+		wantErr bool
+	}{
+		{
+			name: "valid custom resource state config",
+			opts: func(o *options.Options) {
+				o.CustomResourceConfig = validCustomResourceConfig
+			},
+		},
+		{
+			name: "malformed custom resource state yaml",
+			opts: func(o *options.Options) {
+				o.CustomResourceConfig = "spec:\n  resources: [\n"
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown custom resource state metric type",
+			opts: func(o *options.Options) {
+				o.CustomResourceConfig = `
+spec:
+  resources:
+    - groupVersionKind:
+        group: myteam.io
+        version: v1
+        kind: Foo
+      metrics:
+        - name: uptime
+          each:
+            type: Histogram
+`
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid custom resource state path",
+			opts: func(o *options.Options) {
+				o.CustomResourceConfigFile = writeFile(t, "custom-resource-state.yml", `
+spec:
+  resources:
+    - groupVersionKind:
+        group: myteam.io
+        version: v1
+        kind: Foo
+      metrics:
+        - name: uptime
+          each:
+            type: Gauge
+            gauge:
+              path: [status, "[uptime]"]
+`)
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid metric denylist",
+			opts: func(o *options.Options) {
+				o.MetricDenylist = options.MetricSet{"kube_(": struct{}{}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "metric allowlist and denylist",
+			opts: func(o *options.Options) {
+				o.MetricAllowlist = options.MetricSet{"kube_pod_info": struct{}{}}
+				o.MetricDenylist = options.MetricSet{"kube_node_info": struct{}{}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "metadata metrics profile",
+			opts: func(o *options.Options) {
+				o.MetricsProfile = "metadata"
+			},
+		},
+		{
+			name: "unknown metrics profile",
+			opts: func(o *options.Options) {
+				o.MetricsProfile = "minimal"
+			},
+			wantErr: true,
+		},
+		{
+			name: "metrics config",
+			opts: func(o *options.Options) {
+				o.MetricsConfig = writeFile(t, "metrics-config.yml", "metrics: [kube_pod_info, kube_pod_resource_requests_sum]\n")
+			},
+		},
+		{
+			name: "metrics config with unknown metric family",
+			opts: func(o *options.Options) {
+				o.MetricsConfig = writeFile(t, "metrics-config.yml", "metrics: [kube_pod_info, kube_pod_inf]\n")
+			},
+			wantErr: true,
+		},
+		{
+			name: "metric overrides",
+			opts: func(o *options.Options) {
+				o.MetricOverrides = options.MetricOverrides{"kube_pod_info": {Help: "Pod information."}}
+			},
+		},
+		{
+			name: "metric overrides with unknown metric family",
+			opts: func(o *options.Options) {
+				o.MetricOverrides = options.MetricOverrides{"kube_pod_inf": {Help: "Pod information."}}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := options.NewOptions()
+			test.opts(opts)
+			err := ValidateConfig(opts)
+			if test.wantErr && err == nil {
+				t.Error("expected an error")
+			}
+			if !test.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -18,10 +18,12 @@ package builder
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
 
 	internalstore "k8s.io/kube-state-metrics/v2/internal/store"
 	ksmtypes "k8s.io/kube-state-metrics/v2/pkg/builder/types"
@@ -31,9 +33,20 @@ import (
 	"k8s.io/kube-state-metrics/v2/pkg/options"
 )
 
-// Make sure the public Builder implements the public BuilderInterface.
-// New internal Builder methods should be added to the public BuilderInterface.
-var _ ksmtypes.BuilderInterface = &Builder{}
+// Make sure the public Builder implements the public BuilderInterface,
+// as well as the optional interfaces of the public builder types.
+// New internal Builder methods should be added to a new optional interface.
+var (
+	_ ksmtypes.BuilderInterface                   = &Builder{}
+	_ ksmtypes.ClusterKubeClientsBuilder          = &Builder{}
+	_ ksmtypes.ListChunkSizeBuilder               = &Builder{}
+	_ ksmtypes.MetricOverridesBuilder             = &Builder{}
+	_ ksmtypes.ObjectNamesBuilder                 = &Builder{}
+	_ ksmtypes.LabelSelectorsBuilder              = &Builder{}
+	_ ksmtypes.DisableUIDLabelBuilder             = &Builder{}
+	_ ksmtypes.DenyLabelsBuilder                  = &Builder{}
+	_ ksmtypes.DropCompletedInitContainersBuilder = &Builder{}
+)
 
 // Builder helps to build store. It follows the builder pattern
 // (https://en.wikipedia.org/wiki/Builder_pattern).
@@ -41,6 +54,17 @@ type Builder struct {
 	internal ksmtypes.BuilderInterface
 }
 
+// RegisterCustomStore makes a store for the given resource available to all builders,
+// so that binaries using kube-state-metrics as a library can expose metrics for
+// additional resources. The store generates the given metric families for objects of
+// the expected type, which are listed and watched through listWatchFunc. The resource
+// is only built if it is passed to WithEnabledResources, e.g. via --resources.
+// RegisterCustomStore is safe for concurrent use, and the store is available to builds
+// started after it returns. It fails if a store for the resource is already available.
+func RegisterCustomStore(resource string, metricFamilies []generator.FamilyGenerator, expectedType interface{}, listWatchFunc func(kubeClient clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher) error {
+	return internalstore.RegisterStore(resource, metricFamilies, expectedType, listWatchFunc)
+}
+
 // NewBuilder returns a new builder.
 func NewBuilder() *Builder {
 	b := &Builder{
@@ -69,6 +93,24 @@ func (b *Builder) WithFieldSelectorFilter(fieldSelectorFilter string) {
 	b.internal.WithFieldSelectorFilter(fieldSelectorFilter)
 }
 
+// WithLabelSelectors sets the label selectors used when listing and watching resources.
+func (b *Builder) WithLabelSelectors(selectors map[string]string) error {
+	i, ok := b.internal.(ksmtypes.LabelSelectorsBuilder)
+	if !ok {
+		return fmt.Errorf("%T does not implement WithLabelSelectors", b.internal)
+	}
+	return i.WithLabelSelectors(selectors)
+}
+
+// WithObjectNames sets the names of the only objects listed and watched for the given resources.
+func (b *Builder) WithObjectNames(names map[string]string) error {
+	i, ok := b.internal.(ksmtypes.ObjectNamesBuilder)
+	if !ok {
+		return fmt.Errorf("%T does not implement WithObjectNames", b.internal)
+	}
+	return i.WithObjectNames(names)
+}
+
 // WithSharding sets the shard and totalShards property of a Builder.
 func (b *Builder) WithSharding(shard int32, totalShards int) {
 	b.internal.WithSharding(shard, totalShards)
@@ -84,6 +126,14 @@ func (b *Builder) WithKubeClient(c clientset.Interface) {
 	b.internal.WithKubeClient(c)
 }
 
+// WithClusterKubeClients sets the kube clients of the clusters whose metrics are exposed
+// in multi-cluster mode, keyed by the value of the cluster label their metrics carry.
+func (b *Builder) WithClusterKubeClients(clients map[string]clientset.Interface) {
+	if i, ok := b.internal.(ksmtypes.ClusterKubeClientsBuilder); ok {
+		i.WithClusterKubeClients(clients)
+	}
+}
+
 // WithCustomResourceClients sets the customResourceClients property of a Builder.
 func (b *Builder) WithCustomResourceClients(cs map[string]interface{}) {
 	b.internal.WithCustomResourceClients(cs)
@@ -94,6 +144,41 @@ func (b *Builder) WithUsingAPIServerCache(u bool) {
 	b.internal.WithUsingAPIServerCache(u)
 }
 
+// WithListChunkSize configures the maximum number of objects listed per request.
+func (b *Builder) WithListChunkSize(s int64) {
+	if i, ok := b.internal.(ksmtypes.ListChunkSizeBuilder); ok {
+		i.WithListChunkSize(s)
+	}
+}
+
+// WithMetricOverrides configures the help texts and units overriding the ones of the metric families.
+func (b *Builder) WithMetricOverrides(o options.MetricOverrides) {
+	if i, ok := b.internal.(ksmtypes.MetricOverridesBuilder); ok {
+		i.WithMetricOverrides(o)
+	}
+}
+
+// WithDisableUIDLabel configures whether the uid label is dropped from the metrics of
+// the resources carrying it by default.
+func (b *Builder) WithDisableUIDLabel(d bool) {
+	if i, ok := b.internal.(ksmtypes.DisableUIDLabelBuilder); ok {
+		i.WithDisableUIDLabel(d)
+	}
+}
+
+// WithDropCompletedInitContainers configures whether the init container metrics of pods
+// are dropped for init containers which terminated successfully.
+func (b *Builder) WithDropCompletedInitContainers(d bool) {
+	if i, ok := b.internal.(ksmtypes.DropCompletedInitContainersBuilder); ok {
+		i.WithDropCompletedInitContainers(d)
+	}
+}
+
+// WithClock sets the clock used by the metric families which depend on the current time.
+func (b *Builder) WithClock(c clock.PassiveClock) {
+	b.internal.WithClock(c)
+}
+
 // WithFamilyGeneratorFilter configures the family generator filter which decides which
 // metrics are to be exposed by the store build by the Builder.
 func (b *Builder) WithFamilyGeneratorFilter(l generator.FamilyGeneratorFilter) {
@@ -110,6 +195,16 @@ func (b *Builder) WithAllowLabels(l map[string][]string) error {
 	return b.internal.WithAllowLabels(l)
 }
 
+// WithDenyLabels configures which labels are never returned for metrics, even if
+// they are allowed by a wildcard.
+func (b *Builder) WithDenyLabels(l map[string][]string) error {
+	i, ok := b.internal.(ksmtypes.DenyLabelsBuilder)
+	if !ok {
+		return fmt.Errorf("%T does not implement WithDenyLabels", b.internal)
+	}
+	return i.WithDenyLabels(l)
+}
+
 // WithGenerateStoresFunc configures a custom generate store function
 func (b *Builder) WithGenerateStoresFunc(f ksmtypes.BuildStoresFunc) {
 	b.internal.WithGenerateStoresFunc(f)
@@ -17,14 +17,28 @@ limitations under the License.
 package builder_test
 
 import (
+	"bytes"
+	"context"
 	"reflect"
+	"strings"
 	"testing"
-
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
 
+	internalstore "k8s.io/kube-state-metrics/v2/internal/store"
 	"k8s.io/kube-state-metrics/v2/pkg/builder"
+	"k8s.io/kube-state-metrics/v2/pkg/metric"
 	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+	"k8s.io/kube-state-metrics/v2/pkg/options"
 )
 
 var (
@@ -63,6 +77,88 @@ func TestBuilderWithCustomStore(t *testing.T) {
 	}
 }
 
+// TestRegisterCustomStore registers a trivial store for config maps, the way a binary
+// using kube-state-metrics as a library would add a store without patching internal/store.
+func TestRegisterCustomStore(t *testing.T) {
+	families := []generator.FamilyGenerator{
+		*generator.NewFamilyGeneratorWithStability(
+			"kube_customconfigmap_keys",
+			"Number of keys in a config map.",
+			metric.Gauge,
+			"",
+			"",
+			func(obj interface{}) *metric.Family {
+				cm := obj.(*v1.ConfigMap)
+				return &metric.Family{
+					Metrics: []*metric.Metric{
+						{
+							LabelKeys:   []string{"namespace", "configmap"},
+							LabelValues: []string{cm.Namespace, cm.Name},
+							Value:       float64(len(cm.Data)),
+						},
+					},
+				}
+			},
+		),
+	}
+	listWatch := func(kubeClient clientset.Interface, ns string, _ string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return kubeClient.CoreV1().ConfigMaps(ns).List(context.TODO(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return kubeClient.CoreV1().ConfigMaps(ns).Watch(context.TODO(), opts)
+			},
+		}
+	}
+
+	if err := builder.RegisterCustomStore("customconfigmaps", families, &v1.ConfigMap{}, listWatch); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { internalstore.UnregisterStore("customconfigmaps") })
+	if err := builder.RegisterCustomStore("customconfigmaps", families, &v1.ConfigMap{}, listWatch); err == nil {
+		t.Error("expected an error when registering a store for the same resource twice")
+	}
+	if err := builder.RegisterCustomStore("pods", families, &v1.ConfigMap{}, listWatch); err == nil {
+		t.Error("expected an error when registering a store for a built-in resource")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	kubeClient := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cm1", Namespace: "default"},
+		Data:       map[string]string{"a": "1", "b": "2"},
+	})
+
+	b := builder.NewBuilder()
+	b.WithMetrics(prometheus.NewRegistry())
+	b.WithContext(ctx)
+	b.WithKubeClient(kubeClient)
+	b.WithNamespaces(options.DefaultNamespaces)
+	b.WithSharding(0, 1)
+	b.WithFamilyGeneratorFilter(generator.NewCompositeFamilyGeneratorFilter())
+	b.WithGenerateStoresFunc(b.DefaultGenerateStoresFunc())
+	if err := b.WithEnabledResources([]string{"customconfigmaps"}); err != nil {
+		t.Fatal(err)
+	}
+	writers := b.Build()
+
+	want := `kube_customconfigmap_keys{namespace="default",configmap="cm1"} 2`
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		buf := &bytes.Buffer{}
+		for _, w := range writers {
+			if err := w.WriteAll(buf); err != nil {
+				return false, err
+			}
+		}
+		return strings.Contains(buf.String(), want), nil
+	})
+	if err != nil {
+		t.Fatalf("expected the registered store to expose %s: %v", want, err)
+	}
+}
+
 func customStore(_ []generator.FamilyGenerator,
 	_ interface{},
 	_ func(kubeClient clientset.Interface, ns string, fieldSelector string) cache.ListerWatcher,
@@ -24,6 +24,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
 
 	"k8s.io/kube-state-metrics/v2/pkg/customresource"
 	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
@@ -41,6 +42,7 @@ type BuilderInterface interface {
 	WithKubeClient(c clientset.Interface)
 	WithCustomResourceClients(cs map[string]interface{})
 	WithUsingAPIServerCache(u bool)
+	WithClock(c clock.PassiveClock)
 	WithFamilyGeneratorFilter(l generator.FamilyGeneratorFilter)
 	WithAllowAnnotations(a map[string][]string) error
 	WithAllowLabels(l map[string][]string) error
@@ -53,6 +55,50 @@ type BuilderInterface interface {
 	WithGenerateCustomResourceStoresFunc(f BuildCustomResourceStoresFunc)
 }
 
+// The following interfaces are implemented by the Builder of kube-state-metrics, but are not part
+// of BuilderInterface, so that adding them does not break implementations of BuilderInterface
+// outside of kube-state-metrics.
+
+// ClusterKubeClientsBuilder is implemented by builders supporting multi-cluster mode.
+type ClusterKubeClientsBuilder interface {
+	WithClusterKubeClients(clients map[string]clientset.Interface)
+}
+
+// ListChunkSizeBuilder is implemented by builders supporting listing objects in chunks.
+type ListChunkSizeBuilder interface {
+	WithListChunkSize(s int64)
+}
+
+// MetricOverridesBuilder is implemented by builders supporting overriding the help texts and units of metric families.
+type MetricOverridesBuilder interface {
+	WithMetricOverrides(o options.MetricOverrides)
+}
+
+// ObjectNamesBuilder is implemented by builders supporting listing and watching single objects by name.
+type ObjectNamesBuilder interface {
+	WithObjectNames(names map[string]string) error
+}
+
+// LabelSelectorsBuilder is implemented by builders supporting listing and watching only the objects matching label selectors.
+type LabelSelectorsBuilder interface {
+	WithLabelSelectors(selectors map[string]string) error
+}
+
+// DisableUIDLabelBuilder is implemented by builders supporting dropping the uid label.
+type DisableUIDLabelBuilder interface {
+	WithDisableUIDLabel(d bool)
+}
+
+// DenyLabelsBuilder is implemented by builders supporting denying labels allowed by a wildcard.
+type DenyLabelsBuilder interface {
+	WithDenyLabels(l map[string][]string) error
+}
+
+// DropCompletedInitContainersBuilder is implemented by builders supporting dropping the metrics of completed init containers.
+type DropCompletedInitContainersBuilder interface {
+	WithDropCompletedInitContainers(d bool)
+}
+
 // BuildStoresFunc function signature that is used to return a list of cache.Store
 type BuildStoresFunc func(metricFamilies []generator.FamilyGenerator,
 	expectedType interface{},
@@ -116,3 +116,14 @@ type RegistryFactory interface {
 	// }
 	ListWatch(customResourceClient interface{}, ns string, fieldSelector string) cache.ListerWatcher
 }
+
+// ComparableRegistryFactory is an optional interface of a RegistryFactory which can tell whether
+// it generates the same metrics as a factory replacing it. When the custom resource store
+// factories are updated, the stores of custom resources whose factory is replaced by an
+// equal one are kept running instead of being rebuilt.
+type ComparableRegistryFactory interface {
+	RegistryFactory
+
+	// Equal reports whether the other factory generates the same metrics for the same custom resource.
+	Equal(other RegistryFactory) bool
+}
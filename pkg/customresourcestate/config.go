@@ -17,6 +17,7 @@ limitations under the License.
 package customresourcestate
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -64,6 +65,10 @@ type Resource struct {
 	// ResourcePlural sets the plural name of the resource. Defaults to the plural version of the Kind according to flect.Pluralize.
 	ResourcePlural string `yaml:"resourcePlural" json:"resourcePlural"`
 
+	// LabelsFromOwnerReference adds the owner_kind, owner_name and owner_is_controller labels to all metrics,
+	// taken from the first entry of metadata.ownerReferences. The labels are empty if the resource has no owner.
+	LabelsFromOwnerReference bool `yaml:"labelsFromOwnerReference" json:"labelsFromOwnerReference"`
+
 	// Metrics are the custom resource fields to be collected.
 	Metrics []Generator `yaml:"metrics" json:"metrics"`
 	// ErrorLogV defines the verbosity threshold for errors logged for this resource.
@@ -182,6 +187,13 @@ func FromConfig(decoder ConfigDecoder, discovererInstance *discovery.CRDiscovere
 	// Override the configuration with any custom overrides.
 	configOverrides(&customResourceConfig)
 
+	// Until discovery resolves them, newly configured resources are not known to be registered.
+	configured := make([]schema.GroupVersionKind, 0, len(customResourceConfig.Spec.Resources))
+	for _, resource := range customResourceConfig.Spec.Resources {
+		configured = append(configured, schema.GroupVersionKind(resource.GroupVersionKind))
+	}
+	discovererInstance.SetConfigured(configured...)
+
 	// Create a factory for each resource.
 	fn := func() (factories []customresource.RegistryFactory, err error) {
 		resources := customResourceConfig.Spec.Resources
@@ -226,6 +238,24 @@ func FromConfig(decoder ConfigDecoder, discovererInstance *discovery.CRDiscovere
 	return fn, nil
 }
 
+// ValidateConfig decodes a configuration source and compiles all of its resources without
+// resolving them against a cluster, returning the errors of all invalid resources.
+func ValidateConfig(decoder ConfigDecoder) error {
+	var customResourceConfig Metrics
+	if err := decoder.Decode(&customResourceConfig); err != nil {
+		return fmt.Errorf("failed to parse Custom Resource State metrics: %w", err)
+	}
+	configOverrides(&customResourceConfig)
+
+	var errs []error
+	for _, resource := range customResourceConfig.Spec.Resources {
+		if _, err := compile(resource); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", resource.GroupVersionKind, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // configOverrides applies overrides to the configuration.
 func configOverrides(config *Metrics) {
 	for i := range config.Spec.Resources {
@@ -22,6 +22,9 @@ type MetricMeta struct {
 	LabelsFromPath map[string][]string `yaml:"labelsFromPath" json:"labelsFromPath"`
 	// Path is the path to to generate metric(s) for.
 	Path []string `yaml:"path" json:"path"`
+	// JSONPath is a JSONPath expression to generate metric(s) for, e.g. `.status.conditions[?(@.type=="Ready")]`.
+	// It cannot be combined with Path.
+	JSONPath string `yaml:"jsonPath" json:"jsonPath"`
 }
 
 // MetricGauge targets a Path that may be a single value, array, or object. Arrays and objects will generate a metric per element.
@@ -35,6 +38,12 @@ type MetricGauge struct {
 	ValueFrom []string `yaml:"valueFrom" json:"valueFrom"`
 	// NilIsZero indicates that if a value is nil it will be treated as zero value.
 	NilIsZero bool `yaml:"nilIsZero" json:"nilIsZero"`
+	// Expression is an arithmetic expression over dot-separated paths under Path that will be the metric value,
+	// e.g. `status.used / status.total`. It cannot be combined with ValueFrom.
+	Expression string `yaml:"expression" json:"expression"`
+	// Transform is the name of a function registered with RegisterTransform that converts the value
+	// resolved by ValueFrom to the metric value, instead of the built-in conversion. It cannot be combined with Expression.
+	Transform string `yaml:"transform" json:"transform"`
 }
 
 // MetricInfo is a metric which is used to expose textual information.
@@ -43,6 +52,10 @@ type MetricInfo struct {
 	// LabelFromKey adds a label with the given name if Path is an object. The label value will be the object key.
 	LabelFromKey string `yaml:"labelFromKey" json:"labelFromKey"`
 	MetricMeta   `yaml:",inline" json:",inline"`
+
+	// ValueFrom is the path to a numeric field under Path that will be the metric value.
+	// The value is 1 if ValueFrom is not set or the field is missing.
+	ValueFrom []string `yaml:"valueFrom" json:"valueFrom"`
 }
 
 // MetricStateSet is a metric which represent a series of related boolean values, also called a bitset.
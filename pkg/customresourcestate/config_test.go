@@ -23,6 +23,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
 )
 
@@ -62,3 +63,113 @@ func toPaths(m map[string]valuePath) map[string]string {
 	}
 	return out
 }
+
+// vpaConfig is a subset of the VerticalPodAutoscaler config documented in
+// docs/metrics/extend/customresourcestate-metrics.md.
+const vpaConfig = `
+spec:
+  resources:
+    - groupVersionKind:
+        group: autoscaling.k8s.io
+        kind: "VerticalPodAutoscaler"
+        version: "v1"
+      labelsFromPath:
+        verticalpodautoscaler: [metadata, name]
+        namespace: [metadata, namespace]
+        target_api_version: [apiVersion]
+        target_kind: [spec, targetRef, kind]
+        target_name: [spec, targetRef, name]
+      metrics:
+        - name: "verticalpodautoscaler_spec_updatepolicy_updatemode"
+          help: "Update mode of the VerticalPodAutoscaler."
+          each:
+            type: StateSet
+            stateSet:
+              labelName: "update_mode"
+              path: [spec, updatePolicy, updateMode]
+              list: ["Auto", "Initial", "Off", "Recreate"]
+        - name: "verticalpodautoscaler_status_recommendation_containerrecommendations_target"
+          help: "Target memory resources the VerticalPodAutoscaler recommends for the container."
+          commonLabels:
+            unit: "byte"
+            resource: "memory"
+          each:
+            type: Gauge
+            gauge:
+              path: [status, recommendation, containerRecommendations]
+              labelsFromPath:
+                container: [containerName]
+              valueFrom: [target, memory]
+        - name: "verticalpodautoscaler_status_recommendation_containerrecommendations_target"
+          help: "Target cpu resources the VerticalPodAutoscaler recommends for the container."
+          commonLabels:
+            unit: "core"
+            resource: "cpu"
+          each:
+            type: Gauge
+            gauge:
+              path: [status, recommendation, containerRecommendations]
+              labelsFromPath:
+                container: [containerName]
+              valueFrom: [target, cpu]
+`
+
+func Test_VerticalPodAutoscaler_config(t *testing.T) {
+	var m Metrics
+	assert.NoError(t, yaml.NewDecoder(strings.NewReader(vpaConfig)).Decode(&m))
+	configOverrides(&m)
+	rf, err := NewCustomResourceMetrics(m.Spec.Resources[0])
+	assert.NoError(t, err)
+
+	vpa := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "autoscaling.k8s.io/v1",
+		"kind":       "VerticalPodAutoscaler",
+		"metadata": map[string]interface{}{
+			"name":      "hamster-vpa",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"targetRef": map[string]interface{}{
+				"kind": "Deployment",
+				"name": "hamster",
+			},
+			"updatePolicy": map[string]interface{}{
+				"updateMode": "Auto",
+			},
+		},
+		"status": map[string]interface{}{
+			"recommendation": map[string]interface{}{
+				"containerRecommendations": []interface{}{
+					map[string]interface{}{
+						"containerName": "hamster",
+						"target": map[string]interface{}{
+							"cpu":    "587m",
+							"memory": "262144k",
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	var got []string
+	for _, f := range rf.MetricFamilyGenerators() {
+		got = append(got, string(f.Generate(vpa).ByteSlice()))
+	}
+	const (
+		updateMode = `kube_customresource_verticalpodautoscaler_spec_updatepolicy_updatemode{customresource_group="autoscaling.k8s.io",customresource_kind="VerticalPodAutoscaler",customresource_version="v1",namespace="default",target_api_version="autoscaling.k8s.io/v1",target_kind="Deployment",target_name="hamster",update_mode=`
+		target     = `kube_customresource_verticalpodautoscaler_status_recommendation_containerrecommendations_target{container="hamster",customresource_group="autoscaling.k8s.io",customresource_kind="VerticalPodAutoscaler",customresource_version="v1",namespace="default",`
+		targetRef  = `target_api_version="autoscaling.k8s.io/v1",target_kind="Deployment",target_name="hamster",`
+	)
+	assert.Equal(t, []string{
+		updateMode + `"Auto",verticalpodautoscaler="hamster-vpa"} 1
+` + updateMode + `"Initial",verticalpodautoscaler="hamster-vpa"} 0
+` + updateMode + `"Off",verticalpodautoscaler="hamster-vpa"} 0
+` + updateMode + `"Recreate",verticalpodautoscaler="hamster-vpa"} 0
+`,
+		target + `resource="memory",` + targetRef + `unit="byte",verticalpodautoscaler="hamster-vpa"} 2.62144e+08
+`,
+		target + `resource="cpu",` + targetRef + `unit="core",verticalpodautoscaler="hamster-vpa"} 0.587
+`,
+	}, got)
+}
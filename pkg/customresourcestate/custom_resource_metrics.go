@@ -18,6 +18,7 @@ package customresourcestate
 
 import (
 	"context"
+	"reflect"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -40,9 +41,11 @@ type customResourceMetrics struct {
 	GroupVersionKind schema.GroupVersionKind
 	ResourceName     string
 	Families         []compiledFamily
+	// resource is the configuration the families were compiled from.
+	resource Resource
 }
 
-var _ customresource.RegistryFactory = &customResourceMetrics{}
+var _ customresource.ComparableRegistryFactory = &customResourceMetrics{}
 
 // NewCustomResourceMetrics creates a customresource.RegistryFactory from a configuration object.
 func NewCustomResourceMetrics(resource Resource) (customresource.RegistryFactory, error) {
@@ -56,9 +59,16 @@ func NewCustomResourceMetrics(resource Resource) (customresource.RegistryFactory
 		GroupVersionKind: gvk,
 		Families:         compiled,
 		ResourceName:     resource.GetResourceName(),
+		resource:         resource,
 	}, nil
 }
 
+// Equal reports whether the other factory was created from the same configuration.
+func (s customResourceMetrics) Equal(other customresource.RegistryFactory) bool {
+	o, ok := other.(*customResourceMetrics)
+	return ok && reflect.DeepEqual(s.resource, o.resource)
+}
+
 func (s customResourceMetrics) Name() string {
 	return s.ResourceName
 }
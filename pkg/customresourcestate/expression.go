@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresourcestate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// expressionNode is a node of a compiled gauge expression.
+// eval returns false if the expression cannot be evaluated for the given object,
+// i.e. if a path was not resolved or a division by zero happened.
+type expressionNode interface {
+	eval(obj interface{}, nilIsZero bool) (float64, bool, error)
+}
+
+type numberNode float64
+
+func (n numberNode) eval(interface{}, bool) (float64, bool, error) {
+	return float64(n), true, nil
+}
+
+type pathNode struct {
+	name string
+	path valuePath
+}
+
+func (n pathNode) eval(obj interface{}, nilIsZero bool) (float64, bool, error) {
+	got := n.path.Get(obj)
+	if got == nil && !nilIsZero {
+		return 0, false, nil
+	}
+	value, err := toFloat64(got, nilIsZero)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", n.name, err)
+	}
+	return value, true, nil
+}
+
+type negateNode struct {
+	x expressionNode
+}
+
+func (n negateNode) eval(obj interface{}, nilIsZero bool) (float64, bool, error) {
+	x, ok, err := n.x.eval(obj, nilIsZero)
+	return -x, ok, err
+}
+
+type binaryNode struct {
+	op   byte
+	x, y expressionNode
+}
+
+func (n binaryNode) eval(obj interface{}, nilIsZero bool) (float64, bool, error) {
+	x, ok, err := n.x.eval(obj, nilIsZero)
+	if !ok || err != nil {
+		return 0, ok, err
+	}
+	y, ok, err := n.y.eval(obj, nilIsZero)
+	if !ok || err != nil {
+		return 0, ok, err
+	}
+	switch n.op {
+	case '+':
+		return x + y, true, nil
+	case '-':
+		return x - y, true, nil
+	case '*':
+		return x * y, true, nil
+	default:
+		if y == 0 {
+			return 0, false, nil
+		}
+		return x / y, true, nil
+	}
+}
+
+// compiledExpression is an arithmetic expression over paths relative to the
+// element a gauge is generated for.
+type compiledExpression struct {
+	source string
+	root   expressionNode
+}
+
+// Eval evaluates the expression for the given element. It returns false if a path
+// of the expression was not resolved or the expression divides by zero.
+func (e *compiledExpression) Eval(obj interface{}, nilIsZero bool) (float64, bool, error) {
+	return e.root.eval(obj, nilIsZero)
+}
+
+// compileExpression parses an arithmetic expression built from numbers, paths,
+// parentheses and the operators +, -, * and /. Paths are dot-separated, e.g. `status.used / status.total`,
+// with segments which are not identifiers in double quotes.
+func compileExpression(source string) (*compiledExpression, error) {
+	p := &expressionParser{src: source}
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.src) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.src[p.pos], p.pos)
+	}
+	return &compiledExpression{source: source, root: root}, nil
+}
+
+type expressionParser struct {
+	src string
+	pos int
+}
+
+func (p *expressionParser) skipSpaces() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// peek returns the next non-space character, or 0 at the end of the expression.
+func (p *expressionParser) peek() byte {
+	p.skipSpaces()
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *expressionParser) parseSum() (expressionNode, error) {
+	x, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == '+' || op == '-'; op = p.peek() {
+		p.pos++
+		y, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		x = binaryNode{op: op, x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *expressionParser) parseProduct() (expressionNode, error) {
+	x, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == '*' || op == '/'; op = p.peek() {
+		p.pos++
+		y, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		x = binaryNode{op: op, x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *expressionParser) parseOperand() (expressionNode, error) {
+	c := p.peek()
+	switch {
+	case c == 0:
+		return nil, fmt.Errorf("unexpected end of expression")
+	case c == '-':
+		p.pos++
+		x, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return negateNode{x: x}, nil
+	case c == '(':
+		p.pos++
+		x, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing closing parenthesis at position %d", p.pos)
+		}
+		p.pos++
+		return x, nil
+	case isDigit(c) || c == '.':
+		start := p.pos
+		for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		n, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", p.src[start:p.pos])
+		}
+		return numberNode(n), nil
+	case isIdentifierStart(c) || c == '"':
+		return p.parsePath()
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", c, p.pos)
+	}
+}
+
+// parsePath parses a dot-separated path. Segments which are not identifiers,
+// e.g. containing a '-', must be quoted, e.g. `status."non-existent".total`.
+func (p *expressionParser) parsePath() (expressionNode, error) {
+	start := p.pos
+	var parts []string
+	for {
+		if p.pos < len(p.src) && p.src[p.pos] == '"' {
+			end := strings.IndexByte(p.src[p.pos+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("missing closing quote at position %d", p.pos)
+			}
+			parts = append(parts, p.src[p.pos+1:p.pos+1+end])
+			p.pos += end + 2
+		} else {
+			segment := p.pos
+			for p.pos < len(p.src) && (isIdentifierStart(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+				p.pos++
+			}
+			parts = append(parts, p.src[segment:p.pos])
+		}
+		if p.pos >= len(p.src) || p.src[p.pos] != '.' {
+			break
+		}
+		p.pos++
+	}
+	name := p.src[start:p.pos]
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("invalid path %q", name)
+		}
+	}
+	// A '-' right after a path is most likely part of an unquoted segment, not a subtraction.
+	if p.pos < len(p.src) && p.src[p.pos] == '-' {
+		return nil, fmt.Errorf("unexpected '-' after path %q at position %d: quote the path segment or separate the operator with spaces", name, p.pos)
+	}
+	path, err := compilePath(parts)
+	if err != nil {
+		return nil, err
+	}
+	return pathNode{name: name, path: path}, nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdentifierStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresourcestate
+
+import (
+	"strings"
+	"sync"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// compileJSONPath compiles a JSONPath expression, e.g. `.status.conditions[?(@.type=="Ready")]`,
+// into a valuePath. The surrounding braces are optional.
+// The path resolves to nil if the expression matches nothing or fails to evaluate,
+// to the matched value if it matches one value and to a list of the matched values otherwise.
+func compileJSONPath(expression string) (valuePath, error) {
+	template := expression
+	if !strings.HasPrefix(template, "{") {
+		template = "{" + template + "}"
+	}
+	j := jsonpath.New("jsonPath").AllowMissingKeys(true)
+	if err := j.Parse(template); err != nil {
+		return nil, err
+	}
+
+	// A JSONPath keeps state while it is evaluated, so it must not be used concurrently.
+	var mtx sync.Mutex
+	return valuePath{{
+		part: expression,
+		op: func(obj interface{}) interface{} {
+			mtx.Lock()
+			results, err := j.FindResults(obj)
+			mtx.Unlock()
+			if err != nil {
+				return nil
+			}
+
+			var values []interface{}
+			for _, result := range results {
+				for _, v := range result {
+					if v.IsValid() && v.CanInterface() {
+						values = append(values, v.Interface())
+					}
+				}
+			}
+			switch len(values) {
+			case 0:
+				return nil
+			case 1:
+				return values[0]
+			default:
+				return values
+			}
+		},
+		// Never share cached values with segment paths.
+		prefix: "\x01" + expression,
+	}}, nil
+}
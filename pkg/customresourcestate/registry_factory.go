@@ -59,6 +59,15 @@ func compileCommon(c MetricMeta) (*compiledCommon, error) {
 	if err != nil {
 		return nil, fmt.Errorf("path: %w", err)
 	}
+	if c.JSONPath != "" {
+		if len(c.Path) > 0 {
+			return nil, errors.New("path and jsonPath are mutually exclusive")
+		}
+		eachPath, err = compileJSONPath(c.JSONPath)
+		if err != nil {
+			return nil, fmt.Errorf("jsonPath: %w", err)
+		}
+	}
 	eachLabelsFromPath, err := compilePaths(c.LabelsFromPath)
 	if err != nil {
 		return nil, fmt.Errorf("labelsFromPath: %w", err)
@@ -91,12 +100,13 @@ func compileFamily(f Generator, resource Resource) (*compiledFamily, error) {
 		errorLogV = resource.ErrorLogV
 	}
 	return &compiledFamily{
-		Name:          fullName(resource, f),
-		ErrorLogV:     errorLogV,
-		Help:          f.Help,
-		Each:          metric,
-		Labels:        labels.CommonLabels,
-		LabelFromPath: labelsFromPath,
+		Name:           fullName(resource, f),
+		ErrorLogV:      errorLogV,
+		Help:           f.Help,
+		Each:           metric,
+		Labels:         labels.CommonLabels,
+		LabelFromPath:  labelsFromPath,
+		LabelFromOwner: resource.LabelsFromOwnerReference,
 	}, nil
 }
 
@@ -160,17 +170,39 @@ func newCompiledMetric(m Metric) (compiledMetric, error) {
 			return nil, errors.New("expected each.gauge to not be nil")
 		}
 		cc, err := compileCommon(m.Gauge.MetricMeta)
-		cc.t = metric.Gauge
 		if err != nil {
 			return nil, fmt.Errorf("each.gauge: %w", err)
 		}
+		cc.t = metric.Gauge
 		valueFromPath, err := compilePath(m.Gauge.ValueFrom)
 		if err != nil {
 			return nil, fmt.Errorf("each.gauge.valueFrom: %w", err)
 		}
+		var expression *compiledExpression
+		if m.Gauge.Expression != "" {
+			if len(m.Gauge.ValueFrom) > 0 {
+				return nil, errors.New("each.gauge: expression and valueFrom are mutually exclusive")
+			}
+			expression, err = compileExpression(m.Gauge.Expression)
+			if err != nil {
+				return nil, fmt.Errorf("each.gauge.expression: %w", err)
+			}
+		}
+		var transform TransformFunc
+		if m.Gauge.Transform != "" {
+			if m.Gauge.Expression != "" {
+				return nil, errors.New("each.gauge: expression and transform are mutually exclusive")
+			}
+			transform, err = lookupTransform(m.Gauge.Transform)
+			if err != nil {
+				return nil, fmt.Errorf("each.gauge.transform: %w", err)
+			}
+		}
 		return &compiledGauge{
 			compiledCommon: *cc,
 			ValueFrom:      valueFromPath,
+			Expression:     expression,
+			Transform:      transform,
 			NilIsZero:      m.Gauge.NilIsZero,
 			labelFromKey:   m.Gauge.LabelFromKey,
 		}, nil
@@ -179,23 +211,28 @@ func newCompiledMetric(m Metric) (compiledMetric, error) {
 			return nil, errors.New("expected each.info to not be nil")
 		}
 		cc, err := compileCommon(m.Info.MetricMeta)
-		cc.t = metric.Info
 		if err != nil {
 			return nil, fmt.Errorf("each.info: %w", err)
 		}
+		cc.t = metric.Info
+		valueFromPath, err := compilePath(m.Info.ValueFrom)
+		if err != nil {
+			return nil, fmt.Errorf("each.info.valueFrom: %w", err)
+		}
 		return &compiledInfo{
 			compiledCommon: *cc,
 			labelFromKey:   m.Info.LabelFromKey,
+			ValueFrom:      valueFromPath,
 		}, nil
 	case metric.StateSet:
 		if m.StateSet == nil {
 			return nil, errors.New("expected each.stateSet to not be nil")
 		}
 		cc, err := compileCommon(m.StateSet.MetricMeta)
-		cc.t = metric.StateSet
 		if err != nil {
 			return nil, fmt.Errorf("each.stateSet: %w", err)
 		}
+		cc.t = metric.StateSet
 		valueFromPath, err := compilePath(m.StateSet.ValueFrom)
 		if err != nil {
 			return nil, fmt.Errorf("each.stateSet.valueFrom: %w", err)
@@ -215,6 +252,8 @@ type compiledGauge struct {
 	compiledCommon
 	labelFromKey string
 	ValueFrom    valuePath
+	Expression   *compiledExpression
+	Transform    TransformFunc
 	NilIsZero    bool
 }
 
@@ -222,9 +261,26 @@ func (c *compiledGauge) Values(v interface{}) (result []eachValue, errs []error)
 	onError := func(err error) {
 		errs = append(errs, fmt.Errorf("%s: %v", c.Path(), err))
 	}
+	single := func() {
+		value, err := c.value(v)
+		if err != nil {
+			onError(err)
+			return
+		}
+		if value == nil {
+			return
+		}
+		addPathLabels(v, c.LabelFromPath(), value.Labels)
+		result = append(result, *value)
+	}
 
 	switch iter := v.(type) {
 	case map[string]interface{}:
+		// An expression is evaluated against the object itself, unless its entries are labelled by key.
+		if c.Expression != nil && c.labelFromKey == "" {
+			single()
+			break
+		}
 		for key, it := range iter {
 			// TODO: Handle multi-length valueFrom paths (https://github.com/kubernetes/kube-state-metrics/pull/1958#discussion_r1099243161).
 			// Try to deduce `valueFrom`'s value from the current element.
@@ -241,7 +297,7 @@ func (c *compiledGauge) Values(v interface{}) (result []eachValue, errs []error)
 				len(sValueFrom) > 2 {
 				extractedValueFrom := sValueFrom[1 : len(sValueFrom)-1]
 				if key == extractedValueFrom {
-					gotFloat, err := toFloat64(it, c.NilIsZero)
+					gotFloat, err := c.toFloat64(it)
 					if err != nil {
 						onError(fmt.Errorf("[%s]: %w", key, err))
 						continue
@@ -291,16 +347,7 @@ func (c *compiledGauge) Values(v interface{}) (result []eachValue, errs []error)
 			result = append(result, *value)
 		}
 	default:
-		value, err := c.value(v)
-		if err != nil {
-			onError(err)
-			break
-		}
-		if value == nil {
-			break
-		}
-		addPathLabels(v, c.LabelFromPath(), value.Labels)
-		result = append(result, *value)
+		single()
 	}
 	return
 }
@@ -308,6 +355,7 @@ func (c *compiledGauge) Values(v interface{}) (result []eachValue, errs []error)
 type compiledInfo struct {
 	compiledCommon
 	labelFromKey string
+	ValueFrom    valuePath
 }
 
 func (c *compiledInfo) Values(v interface{}) (result []eachValue, errs []error) {
@@ -349,9 +397,14 @@ func (c *compiledInfo) Values(v interface{}) (result []eachValue, errs []error)
 			addPathLabels(it, c.LabelFromPath(), labels)
 
 			if len(labels) > 0 {
+				value, err := c.value(it)
+				if err != nil {
+					onError(err)
+					continue
+				}
 				result = append(result, eachValue{
 					Labels: labels,
-					Value:  1,
+					Value:  value,
 				})
 			}
 		}
@@ -371,11 +424,32 @@ func (c *compiledInfo) values(v interface{}) (result []eachValue, err []error) {
 	value := eachValue{Value: 1, Labels: map[string]string{}}
 	addPathLabels(v, c.labelFromPath, value.Labels)
 	if len(value.Labels) != 0 {
+		var e error
+		value.Value, e = c.value(v)
+		if e != nil {
+			return nil, []error{e}
+		}
 		result = append(result, value)
 	}
 	return
 }
 
+// value returns the value of the ValueFrom field of v, or 1 if ValueFrom is not set or the field is missing.
+func (c *compiledInfo) value(v interface{}) (float64, error) {
+	if len(c.ValueFrom) == 0 {
+		return 1, nil
+	}
+	got := c.ValueFrom.Get(v)
+	if got == nil {
+		return 1, nil
+	}
+	value, err := toFloat64(got, false)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", c.ValueFrom, err)
+	}
+	return value, nil
+}
+
 type compiledStateSet struct {
 	compiledCommon
 	LabelName string
@@ -446,6 +520,20 @@ func less(a, b map[string]string) bool {
 
 func (c compiledGauge) value(it interface{}) (*eachValue, error) {
 	labels := make(map[string]string)
+	if c.Expression != nil {
+		value, ok, err := c.Expression.Eval(it, c.NilIsZero)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Expression.source, err)
+		}
+		// Skip the value if a path was not resolved or the expression divides by zero.
+		if !ok {
+			return nil, nil
+		}
+		return &eachValue{
+			Labels: labels,
+			Value:  value,
+		}, nil
+	}
 	got := c.ValueFrom.Get(it)
 	// If `valueFrom` was not resolved, respect `NilIsZero` and return.
 	if got == nil {
@@ -462,7 +550,7 @@ func (c compiledGauge) value(it interface{}) (*eachValue, error) {
 		// Don't error if there was not a type-casting issue (`toFloat64`).
 		return nil, nil
 	}
-	value, err := toFloat64(got, c.NilIsZero)
+	value, err := c.toFloat64(got)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", c.ValueFrom, err)
 	}
@@ -472,6 +560,14 @@ func (c compiledGauge) value(it interface{}) (*eachValue, error) {
 	}, nil
 }
 
+// toFloat64 converts a resolved value with the gauge's transform if set, or the built-in conversion otherwise.
+func (c compiledGauge) toFloat64(value interface{}) (float64, error) {
+	if c.Transform != nil && value != nil {
+		return c.Transform(value)
+	}
+	return toFloat64(value, c.NilIsZero)
+}
+
 func (e eachValue) DefaultLabels(defaults map[string]string) {
 	for k, v := range defaults {
 		if _, ok := e.Labels[k]; !ok {
@@ -498,24 +594,59 @@ func (e eachValue) ToMetric() *metric.Metric {
 }
 
 type compiledFamily struct {
-	Each          compiledEach
-	Labels        map[string]string
-	LabelFromPath map[string]valuePath
-	Name          string
-	Help          string
-	ErrorLogV     klog.Level
+	Each           compiledEach
+	Labels         map[string]string
+	LabelFromPath  map[string]valuePath
+	LabelFromOwner bool
+	Name           string
+	Help           string
+	ErrorLogV      klog.Level
 }
 
 func (f compiledFamily) BaseLabels(obj map[string]interface{}) map[string]string {
+	return f.baseLabels(obj, nil)
+}
+
+func (f compiledFamily) baseLabels(obj map[string]interface{}, cache pathCache) map[string]string {
 	result := make(map[string]string)
 	for k, v := range f.Labels {
 		result[k] = v
 	}
-	addPathLabels(obj, f.LabelFromPath, result)
+	if f.LabelFromOwner {
+		addOwnerLabels(obj, result)
+	}
+	addCachedPathLabels(obj, f.LabelFromPath, result, cache)
 	return result
 }
 
+// addOwnerLabels adds labels describing the first owner reference of the object,
+// encoded like kube_pod_owner does: all labels are empty if the object has no owner,
+// and owner_is_controller is false if the owner reference does not set controller.
+func addOwnerLabels(obj map[string]interface{}, result map[string]string) {
+	owners := (&unstructured.Unstructured{Object: obj}).GetOwnerReferences()
+	if len(owners) == 0 {
+		result["owner_kind"] = ""
+		result["owner_name"] = ""
+		result["owner_is_controller"] = ""
+		return
+	}
+
+	owner := owners[0]
+	result["owner_kind"] = owner.Kind
+	result["owner_name"] = owner.Name
+	if owner.Controller != nil {
+		result["owner_is_controller"] = strconv.FormatBool(*owner.Controller)
+	} else {
+		result["owner_is_controller"] = "false"
+	}
+}
+
 func addPathLabels(obj interface{}, labels map[string]valuePath, result map[string]string) {
+	addCachedPathLabels(obj, labels, result, nil)
+}
+
+// addCachedPathLabels is like addPathLabels, but resolves the paths through the given cache.
+func addCachedPathLabels(obj interface{}, labels map[string]valuePath, result map[string]string, cache pathCache) {
 	// *prefixed is a special case, it means copy an object
 	// always do that first so other labels can override
 	var stars []string
@@ -526,7 +657,7 @@ func addPathLabels(obj interface{}, labels map[string]valuePath, result map[stri
 	}
 	sort.Strings(stars)
 	for _, star := range stars {
-		m := labels[star].Get(obj)
+		m := cache.Get(obj, labels[star])
 		if kv, ok := m.(map[string]interface{}); ok {
 			for k, v := range kv {
 				if strings.HasSuffix(star, "*") {
@@ -540,7 +671,7 @@ func addPathLabels(obj interface{}, labels map[string]valuePath, result map[stri
 		if strings.HasPrefix(k, "*") || strings.HasSuffix(k, "*") {
 			continue
 		}
-		value := v.Get(obj)
+		value := cache.Get(obj, v)
 		// skip label if value is nil
 		if value == nil {
 			continue
@@ -552,6 +683,8 @@ func addPathLabels(obj interface{}, labels map[string]valuePath, result map[stri
 type pathOp struct {
 	op   func(interface{}) interface{}
 	part string
+	// prefix identifies the path up to and including this op.
+	prefix string
 }
 
 type valuePath []pathOp
@@ -579,6 +712,36 @@ func (p valuePath) String() string {
 	return b.String()
 }
 
+// pathCache holds the values of the paths resolved from the root of an object
+// while generating a family for it, keyed by path prefix, so that the paths
+// sharing a prefix only walk it once.
+type pathCache map[string]interface{}
+
+// Get resolves the path for the given object, resuming from the longest prefix
+// of the path that was already resolved. A nil cache resolves the whole path.
+func (c pathCache) Get(obj interface{}, p valuePath) interface{} {
+	if c == nil {
+		return p.Get(obj)
+	}
+
+	start := 0
+	for i := len(p) - 1; i >= 0; i-- {
+		if v, ok := c[p[i].prefix]; ok {
+			obj = v
+			start = i + 1
+			break
+		}
+	}
+	for _, op := range p[start:] {
+		if obj == nil {
+			return nil
+		}
+		obj = op.op(obj)
+		c[op.prefix] = obj
+	}
+	return obj
+}
+
 func compilePath(path []string) (out valuePath, _ error) {
 	for i := range path {
 		part := path[i]
@@ -662,6 +825,9 @@ func compilePath(path []string) (out valuePath, _ error) {
 			})
 		}
 	}
+	for i := range out {
+		out[i].prefix = strings.Join(path[:i+1], "\x00")
+	}
 	return out, nil
 }
 
@@ -681,9 +847,11 @@ func famGen(f compiledFamily) generator.FamilyGenerator {
 func generate(u *unstructured.Unstructured, f compiledFamily, errLog klog.Verbose) *metric.Family {
 	klog.V(10).InfoS("Checked", "compiledFamilyName", f.Name, "unstructuredName", u.GetName())
 	var metrics []*metric.Metric
-	baseLabels := f.BaseLabels(u.Object)
+	// The family labels and the metric path often share a prefix, e.g. an element of a large array.
+	cache := pathCache{}
+	baseLabels := f.baseLabels(u.Object, cache)
 
-	values, errors := scrapeValuesFor(f.Each, u.Object)
+	values, errors := scrapeValues(f.Each, cache.Get(u.Object, f.Each.Path()))
 	for _, err := range errors {
 		errLog.ErrorS(err, f.Name)
 	}
@@ -700,7 +868,11 @@ func generate(u *unstructured.Unstructured, f compiledFamily, errLog klog.Verbos
 }
 
 func scrapeValuesFor(e compiledEach, obj map[string]interface{}) ([]eachValue, []error) {
-	v := e.Path().Get(obj)
+	return scrapeValues(e, e.Path().Get(obj))
+}
+
+// scrapeValues returns the values for v, the object already resolved by the path of e.
+func scrapeValues(e compiledEach, v interface{}) ([]eachValue, []error) {
 	result, errs := e.Values(v)
 
 	// return results in a consistent order (simplifies testing)
@@ -19,10 +19,13 @@ package customresourcestate
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 
 	"k8s.io/kube-state-metrics/v2/pkg/metric"
@@ -30,6 +33,26 @@ import (
 
 var cr map[string]interface{}
 
+// ownedCR is a custom resource owned by another custom resource.
+var ownedCR = map[string]interface{}{
+	"metadata": map[string]interface{}{
+		"name": "foo-owned",
+		"ownerReferences": []interface{}{
+			map[string]interface{}{
+				"apiVersion": "myteam.io/v1",
+				"kind":       "Bar",
+				"name":       "bar",
+				"controller": true,
+			},
+			map[string]interface{}{
+				"apiVersion": "myteam.io/v1",
+				"kind":       "Baz",
+				"name":       "baz",
+			},
+		},
+	},
+}
+
 func init() {
 	type Obj map[string]interface{}
 	type Array []interface{}
@@ -131,6 +154,18 @@ func Test_addPathLabels(t *testing.T) {
 				"string": "bar",
 			},
 		}},
+		{name: "list lookup", args: args{
+			obj: cr,
+			labels: map[string]valuePath{
+				"ready":       mustCompilePath(t, "status", "conditions", "[type=Ready]", "status"),
+				"provisioned": mustCompilePath(t, "status", "conditions", "[type=Provisioned]", "status"),
+				"synced":      mustCompilePath(t, "status", "conditions", "[type=Synced]", "status"),
+			},
+			want: map[string]string{
+				"ready":       "True",
+				"provisioned": "False",
+			},
+		}},
 		{name: "*", args: args{
 			obj: cr,
 			labels: map[string]valuePath{
@@ -323,6 +358,48 @@ func Test_values(t *testing.T) {
 			newEachValue(t, 1, "active", "1"),
 			newEachValue(t, 1, "active", "3"),
 		}},
+		{name: "info value from path", each: &compiledInfo{
+			compiledCommon: compiledCommon{
+				labelFromPath: map[string]valuePath{
+					"version": mustCompilePath(t, "spec", "version"),
+				},
+			},
+			ValueFrom: mustCompilePath(t, "status", "uptime"),
+		}, wantResult: []eachValue{
+			newEachValue(t, 43.21, "version", "v0.0.0"),
+		}},
+		{name: "info value from missing path", each: &compiledInfo{
+			compiledCommon: compiledCommon{
+				labelFromPath: map[string]valuePath{
+					"version": mustCompilePath(t, "spec", "version"),
+				},
+			},
+			ValueFrom: mustCompilePath(t, "status", "does-not-exist"),
+		}, wantResult: []eachValue{
+			newEachValue(t, 1, "version", "v0.0.0"),
+		}},
+		{name: "info label from path with value from path", each: &compiledInfo{
+			compiledCommon: compiledCommon{
+				path: mustCompilePath(t, "status", "sub"),
+				labelFromPath: map[string]valuePath{
+					"active": mustCompilePath(t, "active"),
+				},
+			},
+			ValueFrom: mustCompilePath(t, "ready"),
+		}, wantResult: []eachValue{
+			newEachValue(t, 2, "active", "1"),
+			newEachValue(t, 4, "active", "3"),
+		}},
+		{name: "info value from non-numeric path", each: &compiledInfo{
+			compiledCommon: compiledCommon{
+				labelFromPath: map[string]valuePath{
+					"version": mustCompilePath(t, "spec", "version"),
+				},
+			},
+			ValueFrom: mustCompilePath(t, "spec", "version"),
+		}, wantResult: nil, wantErrors: []error{
+			errors.New("[]: [[spec,version]: strconv.ParseFloat: parsing \"v0.0.0\": invalid syntax]"),
+		}},
 		{name: "stateset", each: &compiledStateSet{
 			compiledCommon: compiledCommon{
 				path: mustCompilePath(t, "status", "phase"),
@@ -340,6 +417,20 @@ func Test_values(t *testing.T) {
 		}, wantResult: []eachValue{
 			newEachValue(t, 1),
 		}},
+		{name: "status_conditions_second", each: &compiledGauge{
+			compiledCommon: compiledCommon{
+				path: mustCompilePath(t, "status", "conditions", "[type=Provisioned]", "status"),
+			},
+		}, wantResult: []eachValue{
+			newEachValue(t, 0),
+		}},
+		{name: "status_conditions_no_match", each: &compiledGauge{
+			compiledCommon: compiledCommon{
+				path: mustCompilePath(t, "status", "conditions", "[type=Synced]", "status"),
+			},
+		}, wantResult: nil, wantErrors: []error{
+			errors.New("[status,conditions,[type=Synced],status]: got nil while resolving path"),
+		}},
 		{name: "status_conditions_all", each: &compiledGauge{
 			compiledCommon: compiledCommon{
 				path: mustCompilePath(t, "status", "conditions"),
@@ -352,6 +443,66 @@ func Test_values(t *testing.T) {
 			newEachValue(t, 0, "type", "Provisioned"),
 			newEachValue(t, 1, "type", "Ready"),
 		}},
+		{name: "expression ratio", each: &compiledGauge{
+			compiledCommon: compiledCommon{
+				path: mustCompilePath(t, "status", "sub"),
+			},
+			labelFromKey: "type",
+			Expression:   mustCompileExpression(t, "active / ready"),
+		}, wantResult: []eachValue{
+			newEachValue(t, 0.5, "type", "type-a"),
+			newEachValue(t, 0.75, "type", "type-b"),
+		}},
+		{name: "expression unit conversion", each: &compiledGauge{
+			compiledCommon: compiledCommon{
+				path: mustCompilePath(t, "status"),
+			},
+			Expression: mustCompileExpression(t, "quantity_binarySI / (1024 * 1024 * 1024)"),
+		}, wantResult: []eachValue{
+			newEachValue(t, 5),
+		}},
+		{name: "expression division by zero", each: &compiledGauge{
+			compiledCommon: compiledCommon{
+				path: mustCompilePath(t, "spec"),
+			},
+			Expression: mustCompileExpression(t, "replicas / (replicas - 1)"),
+		}, wantResult: nil, wantErrors: nil},
+		{name: "expression with non-existent path", each: &compiledGauge{
+			compiledCommon: compiledCommon{
+				path: mustCompilePath(t, "spec"),
+			},
+			Expression: mustCompileExpression(t, `replicas / "non-existent".total`),
+		}, wantResult: nil, wantErrors: nil},
+		{name: "expression with quoted path segment", each: &compiledGauge{
+			compiledCommon: compiledCommon{
+				path: mustCompilePath(t, "status", "sub"),
+			},
+			Expression: mustCompileExpression(t, `"type-a".active / "type-a".ready`),
+		}, wantResult: []eachValue{
+			newEachValue(t, 0.5),
+		}},
+		{name: "transform", each: &compiledGauge{
+			compiledCommon: compiledCommon{
+				path: mustCompilePath(t, "status"),
+			},
+			ValueFrom: mustCompilePath(t, "phase"),
+			Transform: func(value interface{}) (float64, error) {
+				return float64(len(value.(string))), nil
+			},
+		}, wantResult: []eachValue{
+			newEachValue(t, 3),
+		}},
+		{name: "transform error", each: &compiledGauge{
+			compiledCommon: compiledCommon{
+				path: mustCompilePath(t, "status"),
+			},
+			ValueFrom: mustCompilePath(t, "phase"),
+			Transform: func(value interface{}) (float64, error) {
+				return 0, fmt.Errorf("unexpected phase %v", value)
+			},
+		}, wantResult: nil, wantErrors: []error{
+			errors.New("[status]: [phase]: unexpected phase foo"),
+		}},
 		{name: "= expression matching", each: &compiledInfo{
 			compiledCommon: compiledCommon{
 				labelFromPath: map[string]valuePath{
@@ -371,13 +522,44 @@ func Test_values(t *testing.T) {
 	}
 }
 
+func Test_newCompiledMetric_transform(t *testing.T) {
+	assert.NoError(t, RegisterTransform("test_length", func(value interface{}) (float64, error) {
+		return float64(len(fmt.Sprint(value))), nil
+	}))
+	assert.EqualError(t, RegisterTransform("test_length", func(interface{}) (float64, error) { return 0, nil }), `transform "test_length" is already registered`)
+	assert.EqualError(t, RegisterTransform("", func(interface{}) (float64, error) { return 0, nil }), "transform name must not be empty")
+	assert.EqualError(t, RegisterTransform("test_nil", nil), `transform "test_nil": function must not be nil`)
+
+	tests := []struct {
+		name    string
+		gauge   MetricGauge
+		wantErr string
+	}{
+		{name: "registered", gauge: MetricGauge{ValueFrom: []string{"phase"}, Transform: "test_length"}},
+		{name: "unknown", gauge: MetricGauge{ValueFrom: []string{"phase"}, Transform: "unknown"}, wantErr: `each.gauge.transform: unknown transform "unknown"`},
+		{name: "with expression", gauge: MetricGauge{Expression: "active / ready", Transform: "test_length"}, wantErr: "each.gauge: expression and transform are mutually exclusive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gauge := tt.gauge
+			_, err := newCompiledMetric(Metric{Type: metric.Gauge, Gauge: &gauge})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
 func Test_compiledFamily_BaseLabels(t *testing.T) {
 	tests := []struct {
 		name   string
 		fields compiledFamily
+		obj    map[string]interface{}
 		want   map[string]string
 	}{
-		{name: "both", fields: compiledFamily{
+		{name: "both", obj: cr, fields: compiledFamily{
 			Labels: map[string]string{
 				"hello": "world",
 			},
@@ -388,11 +570,53 @@ func Test_compiledFamily_BaseLabels(t *testing.T) {
 			"hello": "world",
 			"foo":   "baz",
 		}},
+		{name: "owner", obj: ownedCR, fields: compiledFamily{
+			LabelFromOwner: true,
+		}, want: map[string]string{
+			"owner_kind":          "Bar",
+			"owner_name":          "bar",
+			"owner_is_controller": "true",
+		}},
+		{name: "no owner", obj: cr, fields: compiledFamily{
+			LabelFromOwner: true,
+		}, want: map[string]string{
+			"owner_kind":          "",
+			"owner_name":          "",
+			"owner_is_controller": "",
+		}},
+		{name: "owner without controller", obj: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"ownerReferences": []interface{}{
+					map[string]interface{}{
+						"apiVersion": "myteam.io/v1",
+						"kind":       "Bar",
+						"name":       "bar",
+						"uid":        "bar-uid",
+					},
+				},
+			},
+		}, fields: compiledFamily{
+			LabelFromOwner: true,
+		}, want: map[string]string{
+			"owner_kind":          "Bar",
+			"owner_name":          "bar",
+			"owner_is_controller": "false",
+		}},
+		{name: "path overrides owner", obj: ownedCR, fields: compiledFamily{
+			LabelFromOwner: true,
+			LabelFromPath: map[string]valuePath{
+				"owner_name": mustCompilePath(t, "metadata", "name"),
+			},
+		}, want: map[string]string{
+			"owner_kind":          "Bar",
+			"owner_name":          "foo-owned",
+			"owner_is_controller": "true",
+		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			f := tt.fields
-			if got := f.BaseLabels(cr); !reflect.DeepEqual(got, tt.want) {
+			if got := f.BaseLabels(tt.obj); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("BaseLabels() = %v, want %v", got, tt.want)
 			}
 		})
@@ -531,6 +755,32 @@ func Test_valuePath_Get(t *testing.T) {
 	}
 }
 
+func Test_toFloat64(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    float64
+		wantErr bool
+	}{
+		{name: "binarySI quantity", value: "2Gi", want: 2147483648},
+		{name: "binarySI quantity in mebibytes", value: "500Mi", want: 524288000},
+		{name: "milli quantity", value: "1500m", want: 1.5},
+		{name: "decimalSI quantity", value: "1k", want: 1000},
+		{name: "invalid quantity", value: "1.5Gx", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toFloat64(tt.value, false)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func newEachValue(t *testing.T, value float64, labels ...string) eachValue {
 	t.Helper()
 	if len(labels)%2 != 0 {
@@ -554,3 +804,208 @@ func mustCompilePath(t *testing.T, path ...string) valuePath {
 	}
 	return out
 }
+
+func mustCompileExpression(t *testing.T, expression string) *compiledExpression {
+	t.Helper()
+	out, err := compileExpression(expression)
+	if err != nil {
+		t.Fatalf("expression %q: %v", expression, err)
+	}
+	return out
+}
+
+func Test_compileExpression(t *testing.T) {
+	obj := map[string]interface{}{
+		"used":   3,
+		"total":  "12",
+		"in-use": 6,
+	}
+	tests := []struct {
+		expression string
+		want       float64
+		wantErr    bool
+	}{
+		{expression: "used / total", want: 0.25},
+		{expression: "  used*100/total ", want: 25},
+		{expression: "total - used * 2", want: 6},
+		{expression: "(total - used) * 2", want: 18},
+		{expression: "-used + 1.5", want: -1.5},
+		{expression: "used /", wantErr: true},
+		{expression: "(used / total", wantErr: true},
+		{expression: "used total", wantErr: true},
+		{expression: "used % total", wantErr: true},
+		{expression: "status..used", wantErr: true},
+		{expression: `"in-use" / total`, want: 0.5},
+		{expression: `total-"in-use"`, wantErr: true},
+		{expression: `total - "in-use"`, want: 6},
+		{expression: "in-use / total", wantErr: true},
+		{expression: "total-1", wantErr: true},
+		{expression: `"in-use / total`, wantErr: true},
+		{expression: `""`, wantErr: true},
+		{expression: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			e, err := compileExpression(tt.expression)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			got, ok, err := e.Eval(obj, false)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_compileJSONPath(t *testing.T) {
+	t.Run("same result as segment paths", func(t *testing.T) {
+		tests := []struct {
+			jsonPath string
+			path     []string
+		}{
+			{jsonPath: ".spec.replicas", path: []string{"spec", "replicas"}},
+			{jsonPath: "{.spec.order[1].id}", path: []string{"spec", "order", "1", "id"}},
+			{jsonPath: `.status.conditions[?(@.type=="Ready")].status`, path: []string{"status", "conditions", "[type=Ready]", "status"}},
+			{jsonPath: ".status.sub", path: []string{"status", "sub"}},
+			{jsonPath: ".status.condition_values", path: []string{"status", "condition_values"}},
+			{jsonPath: ".spec.missing", path: []string{"spec", "missing"}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.jsonPath, func(t *testing.T) {
+				p, err := compileJSONPath(tt.jsonPath)
+				assert.NoError(t, err)
+				assert.Equal(t, mustCompilePath(t, tt.path...).Get(cr), p.Get(cr))
+			})
+		}
+	})
+
+	t.Run("same gauge values as segment paths", func(t *testing.T) {
+		p, err := compileJSONPath(".status.condition_values[*]")
+		assert.NoError(t, err)
+		gauge := func(path valuePath) compiledEach {
+			return &compiledGauge{
+				compiledCommon: compiledCommon{
+					path: path,
+					labelFromPath: map[string]valuePath{
+						"name": mustCompilePath(t, "name"),
+					},
+				},
+				ValueFrom: mustCompilePath(t, "value"),
+			}
+		}
+		want, wantErrs := scrapeValuesFor(gauge(mustCompilePath(t, "status", "condition_values")), cr)
+		got, errs := scrapeValuesFor(gauge(p), cr)
+		assert.Equal(t, want, got)
+		assert.Equal(t, wantErrs, errs)
+	})
+
+	t.Run("recursive descent", func(t *testing.T) {
+		p, err := compileJSONPath("$.status.sub..ready")
+		assert.NoError(t, err)
+		got, errs := scrapeValuesFor(&compiledGauge{compiledCommon: compiledCommon{path: p}}, cr)
+		assert.Empty(t, errs)
+		// Objects are descended in no particular order.
+		assert.ElementsMatch(t, []eachValue{newEachValue(t, 2), newEachValue(t, 4)}, got)
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		_, err := compileJSONPath(".status.conditions[?(@.type==")
+		assert.Error(t, err)
+	})
+
+	t.Run("exclusive with path", func(t *testing.T) {
+		_, err := compileCommon(MetricMeta{Path: []string{"status"}, JSONPath: ".status"})
+		assert.Error(t, err)
+	})
+}
+
+func Test_pathCache_Get(t *testing.T) {
+	c := pathCache{}
+
+	for _, path := range [][]string{
+		{"status", "sub", "type-a", "ready"},
+		{"status", "sub", "type-a", "active"},
+		{"status", "sub", "type-b"},
+		{"spec", "order", "[id=3]", "value"},
+		{"spec", "order", "[id=2]", "value"},
+		{"status", "missing", "field"},
+	} {
+		p := mustCompilePath(t, path...)
+		assert.Equal(t, p.Get(cr), c.Get(cr, p), "path %v", path)
+	}
+	assert.Contains(t, c, mustCompilePath(t, "status", "sub")[1].prefix)
+
+	// A nil cache resolves the whole path.
+	assert.Equal(t, float64(1), pathCache(nil).Get(cr, mustCompilePath(t, "status", "sub", "type-a", "active")))
+}
+
+// BenchmarkGenerateLargeArray generates a family whose labels and value resolve
+// the same element of a large array, with and without sharing the path lookups.
+func BenchmarkGenerateLargeArray(b *testing.B) {
+	items := make([]interface{}, 5000)
+	for i := range items {
+		items[i] = map[string]interface{}{
+			"name": fmt.Sprintf("item-%d", i),
+			"zone": fmt.Sprintf("zone-%d", i%3),
+			"rack": fmt.Sprintf("rack-%d", i%7),
+			"stats": map[string]interface{}{
+				"ready":   i,
+				"active":  i,
+				"updated": i,
+			},
+		}
+	}
+	obj := map[string]interface{}{
+		"status": map[string]interface{}{
+			"items": items,
+		},
+	}
+
+	element := []string{"status", "items", "[name=item-4999]"}
+	resource := Resource{
+		GroupVersionKind: gkv("myteam.io", "v1", "Foo"),
+		Metrics: []Generator{{
+			Name: "item_ready",
+			Labels: Labels{
+				LabelsFromPath: map[string][]string{
+					"name": append(element, "name"),
+					"zone": append(element, "zone"),
+					"rack": append(element, "rack"),
+				},
+			},
+			Each: Metric{
+				Type: metric.Gauge,
+				Gauge: &MetricGauge{
+					MetricMeta: MetricMeta{
+						Path: append(element, "stats"),
+					},
+					ValueFrom: []string{"ready"},
+				},
+			},
+		}},
+	}
+	families, err := compile(resource)
+	if err != nil {
+		b.Fatal(err)
+	}
+	f := families[0]
+	u := &unstructured.Unstructured{Object: obj}
+
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			f.BaseLabels(u.Object)
+			scrapeValuesFor(f.Each, u.Object)
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		errLog := klog.V(0)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			generate(u, f, errLog)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresourcestate
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// TransformFunc maps a value resolved by a gauge to the metric value.
+// The value is never nil and has one of the types produced by decoding JSON, e.g. string, bool,
+// float64, []interface{} or map[string]interface{}. Returning an error skips the value and logs it.
+type TransformFunc func(value interface{}) (float64, error)
+
+var (
+	transformsMu sync.RWMutex
+	transforms   = map[string]TransformFunc{}
+)
+
+// RegisterTransform registers a transformation function that gauges can reference by name
+// through the transform field of the configuration. It is meant to be called by custom
+// binaries embedding kube-state-metrics, e.g. from an init function, before the configuration is loaded.
+// Registering an empty name, a nil function or a name that is already registered returns an error.
+func RegisterTransform(name string, f TransformFunc) error {
+	if name == "" {
+		return errors.New("transform name must not be empty")
+	}
+	if f == nil {
+		return fmt.Errorf("transform %q: function must not be nil", name)
+	}
+	transformsMu.Lock()
+	defer transformsMu.Unlock()
+	if _, ok := transforms[name]; ok {
+		return fmt.Errorf("transform %q is already registered", name)
+	}
+	transforms[name] = f
+	return nil
+}
+
+// lookupTransform returns the transformation function registered under the given name.
+func lookupTransform(name string) (TransformFunc, error) {
+	transformsMu.RLock()
+	defer transformsMu.RUnlock()
+	f, ok := transforms[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown transform %q", name)
+	}
+	return f, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customresourcestate_test
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"k8s.io/kube-state-metrics/v2/pkg/customresourcestate"
+)
+
+func ExampleRegisterTransform() {
+	// A custom binary registers its transforms before the configuration is loaded.
+	if err := customresourcestate.RegisterTransform("semver_major", func(value interface{}) (float64, error) {
+		version, ok := value.(string)
+		if !ok {
+			return 0, fmt.Errorf("expected string but found %T", value)
+		}
+		major, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), ".")
+		return strconv.ParseFloat(major, 64)
+	}); err != nil {
+		panic(err)
+	}
+
+	var resource customresourcestate.Resource
+	if err := yaml.Unmarshal([]byte(`
+groupVersionKind:
+  group: myteam.io
+  version: v1
+  kind: Foo
+metrics:
+  - name: version_major
+    help: Major version of the foo.
+    each:
+      type: gauge
+      gauge:
+        path: [spec]
+        valueFrom: [version]
+        transform: semver_major
+`), &resource); err != nil {
+		panic(err)
+	}
+	factory, err := customresourcestate.NewCustomResourceMetrics(resource)
+	if err != nil {
+		panic(err)
+	}
+
+	foo := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"version": "v2.4.1"},
+	}}
+	for _, g := range factory.MetricFamilyGenerators() {
+		os.Stdout.Write(g.Generate(foo).ByteSlice())
+	}
+	// Output:
+	// kube_customresource_version_major{customresource_group="myteam.io",customresource_kind="Foo",customresource_version="v1"} 2
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metric
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Exemplar references data outside of the metric set, such as the ID of a trace
+// or of an object, which is related to a time series.
+// See https://github.com/prometheus/OpenMetrics/blob/v1.0.0/specification/OpenMetrics.md#exemplars.
+type Exemplar struct {
+	LabelKeys   []string
+	LabelValues []string
+	Value       float64
+}
+
+func (e *Exemplar) write(s *strings.Builder) {
+	if len(e.LabelKeys) != len(e.LabelValues) {
+		panic(fmt.Sprintf(
+			"expected exemplar labelKeys %q to be of same length as labelValues %q",
+			e.LabelKeys, e.LabelValues,
+		))
+	}
+
+	s.WriteString(" # ")
+	if len(e.LabelKeys) == 0 {
+		s.WriteString("{}")
+	}
+	labelsToString(s, e.LabelKeys, e.LabelValues)
+	s.WriteByte(' ')
+	writeFloat(s, e.Value)
+}
+
+// exemplarStripper drops the exemplars of the time series written to it.
+type exemplarStripper struct {
+	w io.Writer
+	// partial holds the beginning of a line whose end has not been written yet.
+	partial []byte
+}
+
+// StripExemplars returns a writer which writes everything written to it to w,
+// except for the exemplars of time series. It is meant for exposition formats
+// which do not support exemplars, such as the Prometheus text format.
+func StripExemplars(w io.Writer) io.Writer {
+	return &exemplarStripper{w: w}
+}
+
+func (e *exemplarStripper) Write(p []byte) (int, error) {
+	n := len(p)
+
+	// Fast path: complete lines without any '#' can not contain exemplars.
+	if len(e.partial) == 0 && len(p) > 0 && p[len(p)-1] == '\n' && bytes.IndexByte(p, '#') < 0 {
+		_, err := e.w.Write(p)
+		return n, err
+	}
+
+	buf := make([]byte, 0, len(p))
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			e.partial = append(e.partial, p...)
+			break
+		}
+		line := p[:i+1]
+		p = p[i+1:]
+		if len(e.partial) > 0 {
+			line = append(e.partial, line...)
+			e.partial = nil
+		}
+		buf = appendWithoutExemplar(buf, line)
+	}
+
+	if len(buf) > 0 {
+		if _, err := e.w.Write(buf); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// appendWithoutExemplar appends the given line to dst without the exemplar of its
+// time series, i.e. without everything following the first '#' outside of a label
+// value. Comments such as HELP and TYPE lines are appended unchanged.
+func appendWithoutExemplar(dst, line []byte) []byte {
+	if len(line) == 0 || line[0] == '#' || bytes.IndexByte(line, '#') < 0 {
+		return append(dst, line...)
+	}
+
+	inValue := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if inValue {
+				i++
+			}
+		case '"':
+			inValue = !inValue
+		case '#':
+			if !inValue {
+				dst = append(dst, bytes.TrimRight(line[:i], " ")...)
+				return append(dst, '\n')
+			}
+		}
+	}
+	return append(dst, line...)
+}
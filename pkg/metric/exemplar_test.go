@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metric
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFamilyExemplar(t *testing.T) {
+	m := &Metric{
+		LabelKeys:   []string{"namespace", "pod", "container"},
+		LabelValues: []string{"default", "pod1", "app"},
+		Value:       3,
+		Exemplar: &Exemplar{
+			LabelKeys:   []string{"uid"},
+			LabelValues: []string{"abc-123"},
+			Value:       1,
+		},
+	}
+
+	counter := Family{
+		Name:    "kube_pod_container_status_restarts_total",
+		Type:    Counter,
+		Metrics: []*Metric{m},
+	}
+	want := "kube_pod_container_status_restarts_total{namespace=\"default\",pod=\"pod1\",container=\"app\"} 3 # {uid=\"abc-123\"} 1\n"
+	if got := string(counter.ByteSlice()); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	gauge := Family{
+		Name:    "kube_pod_container_status_restarts",
+		Type:    Gauge,
+		Metrics: []*Metric{m},
+	}
+	want = "kube_pod_container_status_restarts{namespace=\"default\",pod=\"pod1\",container=\"app\"} 3\n"
+	if got := string(gauge.ByteSlice()); got != want {
+		t.Errorf("expected no exemplar for a gauge: want %q, got %q", want, got)
+	}
+}
+
+func TestStripExemplars(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   string
+	}{
+		{
+			name:   "no exemplars",
+			writes: []string{"# HELP foo_total Foo.\n# TYPE foo_total counter\n", "foo_total{a=\"b\"} 1\n"},
+			want:   "# HELP foo_total Foo.\n# TYPE foo_total counter\nfoo_total{a=\"b\"} 1\n",
+		},
+		{
+			name:   "exemplar",
+			writes: []string{"foo_total{a=\"b\"} 1 # {trace_id=\"abc\"} 1\nfoo_total{a=\"c\"} 2\n"},
+			want:   "foo_total{a=\"b\"} 1\nfoo_total{a=\"c\"} 2\n",
+		},
+		{
+			name:   "hash in label value",
+			writes: []string{`foo_total{a="#1 \"#2\""} 1 # {trace_id="abc"} 1` + "\n" + `foo_total{a="#3"} 2` + "\n"},
+			want:   `foo_total{a="#1 \"#2\""} 1` + "\n" + `foo_total{a="#3"} 2` + "\n",
+		},
+		{
+			name:   "line split across writes",
+			writes: []string{"foo_total{a=\"b\"} 1 # {tra", "ce_id=\"abc\"} 1\n"},
+			want:   "foo_total{a=\"b\"} 1\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			w := StripExemplars(buf)
+			for _, s := range test.writes {
+				p := []byte(s)
+				n, err := w.Write(p)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if n != len(p) {
+					t.Errorf("expected %d bytes to be written, got %d", len(p), n)
+				}
+				if string(p) != s {
+					t.Errorf("expected the written buffer to be left unchanged, got %q", p)
+				}
+			}
+			if buf.String() != test.want {
+				t.Errorf("want %q, got %q", test.want, buf.String())
+			}
+		})
+	}
+}
@@ -31,6 +31,10 @@ type Family struct {
 	Name    string
 	Type    Type
 	Metrics []*Metric
+	// Deferred, if set, generates the metrics of the family instead of Metrics each time the
+	// family is written out, rather than once when the object it belongs to is added or updated.
+	// It is meant for metrics depending on other objects, which may change while the object does not.
+	Deferred func() []*Metric
 }
 
 // Inspect use to inspect the inside of a Family
@@ -38,12 +42,41 @@ func (f Family) Inspect(inspect func(Family)) {
 	inspect(f)
 }
 
+// Each calls fn for every metric of the family. For a deferred family, fn is
+// called for the generated metrics every time the family is written out.
+func (f *Family) Each(fn func(m *Metric)) {
+	for _, m := range f.Metrics {
+		fn(m)
+	}
+	if f.Deferred != nil {
+		deferred := f.Deferred
+		f.Deferred = func() []*Metric {
+			ms := deferred()
+			for _, m := range ms {
+				fn(m)
+			}
+			return ms
+		}
+	}
+}
+
+// IsDeferred returns true if the metrics of the family are generated when it is written out.
+func (f Family) IsDeferred() bool {
+	return f.Deferred != nil
+}
+
 // ByteSlice returns the given Family in its string representation.
+// Exemplars are only written for counters, as OpenMetrics does not allow
+// them for other metric types.
 func (f Family) ByteSlice() []byte {
+	metrics := f.Metrics
+	if f.Deferred != nil {
+		metrics = f.Deferred()
+	}
 	b := strings.Builder{}
-	for _, m := range f.Metrics {
+	for _, m := range metrics {
 		b.WriteString(f.Name)
-		m.Write(&b)
+		m.write(&b, f.Type == Counter)
 	}
 
 	return []byte(b.String())
@@ -29,6 +29,9 @@ const (
 )
 
 var (
+	// floatFormat is the format in which values are rendered, see strconv.FormatFloat.
+	floatFormat byte = 'g'
+
 	numBufPool = sync.Pool{
 		New: func() interface{} {
 			b := make([]byte, 0, initialNumBufSize)
@@ -56,15 +59,36 @@ var (
 	Counter Type = "counter"
 )
 
+// SetExponentialNotation configures whether large and small values may be rendered
+// in exponential notation, e.g. 1.501779547e+09, which is the default. Otherwise they
+// are rendered with full precision without an exponent, e.g. 1501779547, as some
+// ingestion pipelines misparse the exponential notation.
+// It must be called before any metrics are generated.
+func SetExponentialNotation(enabled bool) {
+	if enabled {
+		floatFormat = 'g'
+	} else {
+		floatFormat = 'f'
+	}
+}
+
 // Metric represents a single time series.
 type Metric struct {
 	// The name of a metric is injected by its family to reduce duplication.
 	LabelKeys   []string
 	LabelValues []string
 	Value       float64
+	// Exemplar is an optional exemplar of the time series. It is only exposed
+	// for counters and in the OpenMetrics format.
+	Exemplar *Exemplar
 }
 
 func (m *Metric) Write(s *strings.Builder) {
+	m.write(s, true)
+}
+
+// write writes the time series, including its exemplar if withExemplar is set.
+func (m *Metric) write(s *strings.Builder, withExemplar bool) {
 	if len(m.LabelKeys) != len(m.LabelValues) {
 		panic(fmt.Sprintf(
 			"expected labelKeys %q to be of same length as labelValues %q",
@@ -75,6 +99,9 @@ func (m *Metric) Write(s *strings.Builder) {
 	labelsToString(s, m.LabelKeys, m.LabelValues)
 	s.WriteByte(' ')
 	writeFloat(s, m.Value)
+	if withExemplar && m.Exemplar != nil {
+		m.Exemplar.write(s)
+	}
 	s.WriteByte('\n')
 }
 
@@ -126,7 +153,7 @@ func writeFloat(w *strings.Builder, f float64) {
 		w.WriteString("-Inf")
 	default:
 		bp := numBufPool.Get().(*[]byte)
-		*bp = strconv.AppendFloat((*bp)[:0], f, 'g', -1, 64)
+		*bp = strconv.AppendFloat((*bp)[:0], f, floatFormat, -1, 64)
 		w.Write(*bp)
 		numBufPool.Put(bp)
 	}
@@ -41,6 +41,38 @@ func TestFamilyString(t *testing.T) {
 	}
 }
 
+func TestExponentialNotation(t *testing.T) {
+	defer SetExponentialNotation(true)
+
+	tests := []struct {
+		value       float64
+		exponential string
+		full        string
+	}{
+		{value: 1501779547, exponential: "1.501779547e+09", full: "1501779547"},
+		{value: 1501779547.5, exponential: "1.5017795475e+09", full: "1501779547.5"},
+		{value: 137, exponential: "137", full: "137"},
+		{value: 0.000001, exponential: "1e-06", full: "0.000001"},
+	}
+
+	for _, test := range tests {
+		m := Metric{Value: test.value}
+		for _, enabled := range []bool{true, false} {
+			SetExponentialNotation(enabled)
+			want := test.full
+			if enabled {
+				want = test.exponential
+			}
+
+			s := strings.Builder{}
+			m.Write(&s)
+			if got := strings.TrimSpace(s.String()); got != want {
+				t.Errorf("exponential notation %t: want %q, got %q", enabled, want, got)
+			}
+		}
+	}
+}
+
 func BenchmarkMetricWrite(b *testing.B) {
 	tests := []struct {
 		testName       string
@@ -20,7 +20,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	basemetrics "k8s.io/component-base/metrics"
+	"k8s.io/klog/v2"
 
 	"k8s.io/kube-state-metrics/v2/pkg/metric"
 )
@@ -28,11 +31,13 @@ import (
 // FamilyGenerator provides everything needed to generate a metric family with a
 // Kubernetes object.
 // DeprecatedVersion is defined only if the metric for which this options applies is,
-// in fact, deprecated.
+// in fact, deprecated. It is announced in the HELP text of the metric family.
+// Unit is only set if the unit of the metric family is announced in a UNIT header.
 type FamilyGenerator struct {
 	GenerateFunc      func(obj interface{}) *metric.Family
 	Name              string
 	Help              string
+	Unit              string
 	Type              metric.Type
 	DeprecatedVersion string
 	StabilityLevel    basemetrics.StabilityLevel
@@ -51,9 +56,6 @@ func NewFamilyGeneratorWithStability(name string, help string, metricType metric
 		DeprecatedVersion: deprecatedVersion,
 		GenerateFunc:      generateFunc,
 	}
-	if deprecatedVersion != "" {
-		f.Help = fmt.Sprintf("(Deprecated since %s) %s", deprecatedVersion, help)
-	}
 	return f
 }
 
@@ -82,11 +84,20 @@ func (g *FamilyGenerator) generateHeader() string {
 	header.WriteString(g.Name)
 	header.WriteByte(' ')
 	if g.StabilityLevel == basemetrics.STABLE {
-		header.WriteString(fmt.Sprintf("[%v] %v", g.StabilityLevel, g.Help))
-	} else {
-		header.WriteString(g.Help)
+		header.WriteString(fmt.Sprintf("[%v] ", g.StabilityLevel))
+	}
+	if g.DeprecatedVersion != "" {
+		header.WriteString(fmt.Sprintf("[DEPRECATED since v%s] ", strings.TrimPrefix(g.DeprecatedVersion, "v")))
 	}
+	header.WriteString(g.Help)
 	header.WriteByte('\n')
+	if g.Unit != "" {
+		header.WriteString("# UNIT ")
+		header.WriteString(g.Name)
+		header.WriteByte(' ')
+		header.WriteString(g.Unit)
+		header.WriteByte('\n')
+	}
 	header.WriteString("# TYPE ")
 	header.WriteString(g.Name)
 	header.WriteByte(' ')
@@ -110,8 +121,30 @@ func ExtractMetricFamilyHeaders(families []FamilyGenerator) []string {
 // ComposeMetricGenFuncs takes a slice of metric families and returns a function
 // that composes their metric generation functions into a single one.
 func ComposeMetricGenFuncs(familyGens []FamilyGenerator) func(obj interface{}) []metric.FamilyInterface {
-	return func(obj interface{}) []metric.FamilyInterface {
-		families := make([]metric.FamilyInterface, len(familyGens))
+	return ComposeMetricGenFuncsWithErrors(familyGens, nil)
+}
+
+// ComposeMetricGenFuncsWithErrors is like ComposeMetricGenFuncs, but counts the objects
+// whose metrics could not be generated in generateErrors, if it is not nil.
+// If generating the metrics of an object panics, e.g. because the object is not of the
+// expected type, the object is skipped: all of its families are left empty.
+func ComposeMetricGenFuncsWithErrors(familyGens []FamilyGenerator, generateErrors prometheus.Counter) func(obj interface{}) []metric.FamilyInterface {
+	return func(obj interface{}) (families []metric.FamilyInterface) {
+		families = make([]metric.FamilyInterface, len(familyGens))
+
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			klog.ErrorS(fmt.Errorf("%v", r), "Failed to generate metrics, skipping object", "type", fmt.Sprintf("%T", obj))
+			if generateErrors != nil {
+				generateErrors.Inc()
+			}
+			for i := range families {
+				families[i] = &metric.Family{Name: familyGens[i].Name, Type: familyGens[i].Type}
+			}
+		}()
 
 		for i, gen := range familyGens {
 			families[i] = gen.Generate(obj)
@@ -120,3 +153,15 @@ func ComposeMetricGenFuncs(familyGens []FamilyGenerator) func(obj interface{}) [
 		return families
 	}
 }
+
+// NewGenerateErrorsMetric registers and returns the kube_state_metrics_generate_errors_total
+// metric, counting the objects whose metrics could not be generated by resource.
+func NewGenerateErrorsMetric(r prometheus.Registerer) *prometheus.CounterVec {
+	return promauto.With(r).NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kube_state_metrics_generate_errors_total",
+			Help: "Number of objects skipped because their metrics could not be generated",
+		},
+		[]string{"resource"},
+	)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	basemetrics "k8s.io/component-base/metrics"
+
+	"k8s.io/kube-state-metrics/v2/pkg/metric"
+)
+
+func TestExtractMetricFamilyHeaders(t *testing.T) {
+	tests := []struct {
+		name              string
+		stabilityLevel    basemetrics.StabilityLevel
+		deprecatedVersion string
+		unit              string
+		want              string
+	}{
+		{
+			name:           "alpha",
+			stabilityLevel: basemetrics.ALPHA,
+			want:           "# HELP kube_foo_info Information about foo.\n# TYPE kube_foo_info gauge",
+		},
+		{
+			name:           "stable",
+			stabilityLevel: basemetrics.STABLE,
+			want:           "# HELP kube_foo_info [STABLE] Information about foo.\n# TYPE kube_foo_info gauge",
+		},
+		{
+			name:              "deprecated alpha",
+			stabilityLevel:    basemetrics.ALPHA,
+			deprecatedVersion: "v2.14.0",
+			want:              "# HELP kube_foo_info [DEPRECATED since v2.14.0] Information about foo.\n# TYPE kube_foo_info gauge",
+		},
+		{
+			name:              "deprecated stable",
+			stabilityLevel:    basemetrics.STABLE,
+			deprecatedVersion: "v2.14.0",
+			want:              "# HELP kube_foo_info [STABLE] [DEPRECATED since v2.14.0] Information about foo.\n# TYPE kube_foo_info gauge",
+		},
+		{
+			name:              "deprecated without v prefix",
+			stabilityLevel:    basemetrics.ALPHA,
+			deprecatedVersion: "2.14.0",
+			want:              "# HELP kube_foo_info [DEPRECATED since v2.14.0] Information about foo.\n# TYPE kube_foo_info gauge",
+		},
+		{
+			name:           "unit",
+			stabilityLevel: basemetrics.ALPHA,
+			unit:           "info",
+			want:           "# HELP kube_foo_info Information about foo.\n# UNIT kube_foo_info info\n# TYPE kube_foo_info gauge",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := NewFamilyGeneratorWithStability("kube_foo_info", "Information about foo.", metric.Gauge, test.stabilityLevel, test.deprecatedVersion, nil)
+			f.Unit = test.unit
+			headers := ExtractMetricFamilyHeaders([]FamilyGenerator{*f})
+			if len(headers) != 1 || headers[0] != test.want {
+				t.Errorf("want headers %q, got %q", test.want, headers)
+			}
+			if f.Help != "Information about foo." {
+				t.Errorf("expected the help text to be kept as is, got %q", f.Help)
+			}
+		})
+	}
+}
+
+func TestComposeMetricGenFuncsWithErrors(t *testing.T) {
+	families := []FamilyGenerator{
+		*NewFamilyGeneratorWithStability("kube_pod_foo", "Foo of a pod.", metric.Gauge, basemetrics.ALPHA, "", func(obj interface{}) *metric.Family {
+			return &metric.Family{Metrics: []*metric.Metric{{Value: 1}}}
+		}),
+		*NewFamilyGeneratorWithStability("kube_pod_bar", "Bar of a pod.", metric.Gauge, basemetrics.ALPHA, "", func(obj interface{}) *metric.Family {
+			p := obj.(*v1.Pod)
+			return &metric.Family{Metrics: []*metric.Metric{{LabelKeys: []string{"pod"}, LabelValues: []string{p.Name}, Value: 1}}}
+		}),
+	}
+	generateErrors := NewGenerateErrorsMetric(prometheus.NewRegistry()).WithLabelValues("*v1.Pod")
+	f := ComposeMetricGenFuncsWithErrors(families, generateErrors)
+
+	got := f(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1"}})
+	if len(got) != 2 || string(got[1].ByteSlice()) != "kube_pod_bar{pod=\"pod1\"} 1\n" {
+		t.Fatalf("unexpected metrics for a pod: %v", got)
+	}
+
+	// A malformed object is skipped instead of crashing.
+	got = f(&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "configmap1"}})
+	if len(got) != len(families) {
+		t.Fatalf("expected %d families, got %d", len(families), len(got))
+	}
+	for i, family := range got {
+		if b := family.ByteSlice(); len(b) != 0 {
+			t.Errorf("expected family %d to be empty, got %q", i, b)
+		}
+	}
+	if errs := testutil.ToFloat64(generateErrors); errs != 1 {
+		t.Errorf("expected 1 generate error, got %v", errs)
+	}
+}
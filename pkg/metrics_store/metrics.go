@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsstore
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var storeObjectCountDesc = prometheus.NewDesc(
+	"kube_state_metrics_store_object_count",
+	"Number of objects currently held by the stores of a resource in kube-state-metrics",
+	[]string{"resource"}, nil,
+)
+
+// ObjectCountCollector provides the kube_state_metrics_store_object_count
+// metric, computed from the sizes of the registered MetricsStores at collection time.
+type ObjectCountCollector struct {
+	mtx    sync.RWMutex
+	stores map[string][]*MetricsStore
+}
+
+// NewObjectCountCollector takes in a prometheus registry and initializes
+// and registers an ObjectCountCollector.
+func NewObjectCountCollector(r prometheus.Registerer) *ObjectCountCollector {
+	c := &ObjectCountCollector{}
+	if r != nil {
+		r.MustRegister(c)
+	}
+	return c
+}
+
+// SetStores replaces the stores being tracked, keyed by resource name.
+func (c *ObjectCountCollector) SetStores(stores map[string][]*MetricsStore) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.stores = stores
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *ObjectCountCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- storeObjectCountDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *ObjectCountCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	for resource, stores := range c.stores {
+		count := 0
+		for _, s := range stores {
+			count += s.Len()
+		}
+		ch <- prometheus.MustNewConstMetric(storeObjectCountDesc, prometheus.GaugeValue, float64(count), resource)
+	}
+}
+
+var cacheSyncCompletedDesc = prometheus.NewDesc(
+	"kube_state_metrics_cache_sync_completed",
+	"Whether the initial list of a resource has been synced into the stores of kube-state-metrics",
+	[]string{"resource"}, nil,
+)
+
+// CacheSyncCollector provides the kube_state_metrics_cache_sync_completed
+// metric, computed from the sync state of the registered MetricsStores at collection time.
+type CacheSyncCollector struct {
+	mtx    sync.RWMutex
+	stores map[string][]*MetricsStore
+}
+
+// NewCacheSyncCollector takes in a prometheus registry and initializes
+// and registers a CacheSyncCollector.
+func NewCacheSyncCollector(r prometheus.Registerer) *CacheSyncCollector {
+	c := &CacheSyncCollector{}
+	if r != nil {
+		r.MustRegister(c)
+	}
+	return c
+}
+
+// SetStores replaces the stores being tracked, keyed by resource name.
+func (c *CacheSyncCollector) SetStores(stores map[string][]*MetricsStore) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.stores = stores
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *CacheSyncCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cacheSyncCompletedDesc
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *CacheSyncCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	for resource, stores := range c.stores {
+		synced := 1.0
+		for _, s := range stores {
+			if !s.HasSynced() {
+				synced = 0
+				break
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(cacheSyncCompletedDesc, prometheus.GaugeValue, synced, resource)
+	}
+}
+
+// NewCapturedLabelKeysMetric takes in a prometheus registry and initializes
+// and registers the kube_state_metrics_captured_label_keys metric.
+func NewCapturedLabelKeysMetric(r prometheus.Registerer) *prometheus.GaugeVec {
+	return promauto.With(r).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kube_state_metrics_captured_label_keys",
+			Help: "The Kubernetes label keys captured in the labels metric of a resource, as resolved from the labels allowlist.",
+		}, []string{"resource", "label_key"},
+	)
+}
+
+// NewResourceEnabledMetric takes in a prometheus registry and initializes
+// and registers the kube_state_metrics_resource_enabled metric.
+func NewResourceEnabledMetric(r prometheus.Registerer) *prometheus.GaugeVec {
+	return promauto.With(r).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kube_state_metrics_resource_enabled",
+			Help: "Whether metrics of a resource are exposed by this instance. 1 if the resource is enabled, 0 otherwise.",
+		}, []string{"resource"},
+	)
+}
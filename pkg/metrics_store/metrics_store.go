@@ -17,9 +17,12 @@ limitations under the License.
 package metricsstore
 
 import (
+	"bytes"
 	"sync"
+	"sync/atomic"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
 
 	"k8s.io/kube-state-metrics/v2/pkg/metric"
 )
@@ -31,7 +34,8 @@ type MetricsStore struct {
 	// metrics is a map indexed by Kubernetes object id, containing a slice of
 	// metric families, containing a slice of metrics. We need to keep metrics
 	// grouped by metric families in order to zip families with their help text in
-	// MetricsStore.WriteAll().
+	// MetricsStore.WriteAll(). Objects with deferred metric families are stored
+	// as *deferredFamilies instead.
 	metrics sync.Map
 
 	// generateMetricsFunc generates metrics based on a given Kubernetes object
@@ -41,6 +45,43 @@ type MetricsStore struct {
 	// later on zipped with with their corresponding metric families in
 	// MetricStore.WriteAll().
 	headers []string
+	// series contains the number of pre-rendered series of each metric family,
+	// kept up to date as objects are added and deleted.
+	series []atomic.Int64
+	// deferredObjects is the number of objects with deferred metric families.
+	deferredObjects atomic.Int64
+	// synced is set once the store was populated by the initial list of its reflector.
+	synced atomic.Bool
+
+	// changesMtx protects trackChanges, changes, deletedChanges and changesSince.
+	changesMtx sync.Mutex
+	// trackChanges is set once TrackChanges was called.
+	trackChanges bool
+	// changes contains the last change of each object added, updated or
+	// deleted after the change with the sequence number changesSince.
+	changes        map[types.UID]change
+	deletedChanges int
+	changesSince   uint64
+}
+
+// change is a change of an object recorded by a MetricsStore.
+type change struct {
+	seq     uint64
+	deleted bool
+}
+
+// changeSequence numbers the changes recorded by all MetricsStores, so that
+// a single sequence number tells which changes of all stores were seen.
+var changeSequence atomic.Uint64
+
+// maxDeletedChanges is the number of deleted objects a MetricsStore keeps
+// track of. Once exceeded, they are dropped and changes up to the last deletion
+// are no longer available.
+const maxDeletedChanges = 10000
+
+// LastChange returns the sequence number of the last change recorded by any MetricsStore.
+func LastChange() uint64 {
+	return changeSequence.Load()
 }
 
 // NewMetricsStore returns a new MetricsStore
@@ -48,10 +89,27 @@ func NewMetricsStore(headers []string, generateFunc func(interface{}) []metric.F
 	return &MetricsStore{
 		generateMetricsFunc: generateFunc,
 		headers:             headers,
+		series:              make([]atomic.Int64, len(headers)),
 		metrics:             sync.Map{},
 	}
 }
 
+// Len returns the number of objects currently held by the MetricsStore.
+func (s *MetricsStore) Len() int {
+	n := 0
+	s.metrics.Range(func(_, _ interface{}) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// HasSynced returns true once the MetricsStore was populated by the initial
+// list of its reflector.
+func (s *MetricsStore) HasSynced() bool {
+	return s.synced.Load()
+}
+
 // Implementing k8s.io/client-go/tools/cache.Store interface
 
 // Add inserts adds to the MetricsStore by calling the metrics generator functions and
@@ -64,16 +122,80 @@ func (s *MetricsStore) Add(obj interface{}) error {
 
 	families := s.generateMetricsFunc(obj)
 	familyStrings := make([][]byte, len(families))
+	var deferred map[int]metric.FamilyInterface
 
 	for i, f := range families {
+		if d, ok := f.(interface{ IsDeferred() bool }); ok && d.IsDeferred() {
+			if deferred == nil {
+				deferred = map[int]metric.FamilyInterface{}
+			}
+			deferred[i] = f
+			continue
+		}
 		familyStrings[i] = f.ByteSlice()
 	}
 
-	s.metrics.Store(o.GetUID(), familyStrings)
+	var entry interface{} = familyStrings
+	if deferred != nil {
+		entry = &deferredFamilies{families: familyStrings, deferred: deferred}
+	}
+	if previous, loaded := s.metrics.Swap(o.GetUID(), entry); loaded {
+		s.countSeries(previous, -1)
+	}
+	s.countSeries(entry, 1)
+	s.recordChange(o.GetUID(), false)
+
+	return nil
+}
 
+// deferredFamilies holds the metric families of an object some of which are
+// deferred, i.e. generate their metrics only when they are written out.
+type deferredFamilies struct {
+	families [][]byte
+	deferred map[int]metric.FamilyInterface
+}
+
+// familyBytes returns the metrics of the i-th metric family of the given entry
+// of the metrics map, generating them if the family is deferred.
+func familyBytes(entry interface{}, i int) []byte {
+	switch e := entry.(type) {
+	case [][]byte:
+		return e[i]
+	case *deferredFamilies:
+		if f, ok := e.deferred[i]; ok {
+			return f.ByteSlice()
+		}
+		return e.families[i]
+	}
 	return nil
 }
 
+// countSeries adds the pre-rendered series of the given entry of the metrics map,
+// multiplied by sign, to the series counts of their metric families.
+func (s *MetricsStore) countSeries(entry interface{}, sign int64) {
+	families := entry
+	if e, ok := entry.(*deferredFamilies); ok {
+		families = e.families
+		s.deferredObjects.Add(sign)
+	}
+	for i, family := range families.([][]byte) {
+		if i < len(s.series) {
+			s.series[i].Add(sign * int64(bytes.Count(family, []byte("\n"))))
+		}
+	}
+}
+
+// familyCount returns the number of metric families of the given entry of the metrics map.
+func familyCount(entry interface{}) int {
+	switch e := entry.(type) {
+	case [][]byte:
+		return len(e)
+	case *deferredFamilies:
+		return len(e.families)
+	}
+	return 0
+}
+
 // Update updates the existing entry in the MetricsStore.
 func (s *MetricsStore) Update(obj interface{}) error {
 	// TODO: For now, just call Add, in the future one could check if the resource version changed?
@@ -88,7 +210,10 @@ func (s *MetricsStore) Delete(obj interface{}) error {
 		return err
 	}
 
-	s.metrics.Delete(o.GetUID())
+	if previous, loaded := s.metrics.LoadAndDelete(o.GetUID()); loaded {
+		s.countSeries(previous, -1)
+	}
+	s.recordChange(o.GetUID(), true)
 
 	return nil
 }
@@ -116,7 +241,16 @@ func (s *MetricsStore) GetByKey(_ string) (item interface{}, exists bool, err er
 // Replace will delete the contents of the store, using instead the
 // given list.
 func (s *MetricsStore) Replace(list []interface{}, _ string) error {
+	var previous []types.UID
+	s.metrics.Range(func(key, _ interface{}) bool {
+		previous = append(previous, key.(types.UID))
+		return true
+	})
 	s.metrics.Clear()
+	for i := range s.series {
+		s.series[i].Store(0)
+	}
+	s.deferredObjects.Store(0)
 
 	for _, o := range list {
 		err := s.Add(o)
@@ -124,6 +258,13 @@ func (s *MetricsStore) Replace(list []interface{}, _ string) error {
 			return err
 		}
 	}
+	// Objects which are not part of the new list were deleted in the meantime.
+	for _, uid := range previous {
+		if _, ok := s.metrics.Load(uid); !ok {
+			s.recordChange(uid, true)
+		}
+	}
+	s.synced.Store(true)
 
 	return nil
 }
@@ -132,3 +273,67 @@ func (s *MetricsStore) Replace(list []interface{}, _ string) error {
 func (s *MetricsStore) Resync() error {
 	return nil
 }
+
+// TrackChanges makes the MetricsStore record the objects added, updated and
+// deleted from now on, to be retrieved with ChangesSince. Any changes recorded
+// so far are discarded.
+func (s *MetricsStore) TrackChanges() {
+	s.changesMtx.Lock()
+	defer s.changesMtx.Unlock()
+
+	s.trackChanges = true
+	s.changes = map[types.UID]change{}
+	s.deletedChanges = 0
+	s.changesSince = changeSequence.Load()
+}
+
+// ChangesSince returns the ids of the objects added or updated, and of the
+// objects deleted, after the change with the given sequence number. ok is false
+// if these changes are not available, either because TrackChanges was not
+// called or because they happened before the oldest change still recorded.
+func (s *MetricsStore) ChangesSince(since uint64) (changed, deleted []types.UID, ok bool) {
+	s.changesMtx.Lock()
+	defer s.changesMtx.Unlock()
+
+	if !s.trackChanges || since < s.changesSince {
+		return nil, nil, false
+	}
+	for uid, c := range s.changes {
+		if c.seq <= since {
+			continue
+		}
+		if c.deleted {
+			deleted = append(deleted, uid)
+		} else {
+			changed = append(changed, uid)
+		}
+	}
+	return changed, deleted, true
+}
+
+func (s *MetricsStore) recordChange(uid types.UID, deleted bool) {
+	s.changesMtx.Lock()
+	defer s.changesMtx.Unlock()
+
+	if !s.trackChanges {
+		return
+	}
+	seq := changeSequence.Add(1)
+	if previous, ok := s.changes[uid]; ok && previous.deleted {
+		s.deletedChanges--
+	}
+	s.changes[uid] = change{seq: seq, deleted: deleted}
+	if !deleted {
+		return
+	}
+	s.deletedChanges++
+	if s.deletedChanges > maxDeletedChanges {
+		for uid, c := range s.changes {
+			if c.deleted {
+				delete(s.changes, uid)
+			}
+		}
+		s.deletedChanges = 0
+		s.changesSince = seq
+	}
+}
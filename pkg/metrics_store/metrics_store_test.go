@@ -83,3 +83,61 @@ func TestObjectsSameNameDifferentNamespaces(t *testing.T) {
 		}
 	}
 }
+
+func TestDeferredFamilies(t *testing.T) {
+	ready := 1.0
+
+	genFunc := func(obj interface{}) []metric.FamilyInterface {
+		o, err := meta.Accessor(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		uid := string(o.GetUID())
+
+		return []metric.FamilyInterface{
+			&metric.Family{
+				Name: "kube_service_info",
+				Metrics: []*metric.Metric{
+					{
+						LabelKeys:   []string{"uid"},
+						LabelValues: []string{uid},
+						Value:       float64(1),
+					},
+				},
+			},
+			&metric.Family{
+				Name: "kube_service_status_ready_endpoints",
+				Deferred: func() []*metric.Metric {
+					return []*metric.Metric{
+						{
+							LabelKeys:   []string{"uid"},
+							LabelValues: []string{uid},
+							Value:       ready,
+						},
+					}
+				},
+			},
+		}
+	}
+
+	ms := NewMetricsStore([]string{"Information about service.", "The number of ready endpoints of a service."}, genFunc)
+	if err := ms.Add(&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "service", Namespace: "a", UID: "a"}}); err != nil {
+		t.Fatal(err)
+	}
+	mw := NewMetricsWriter(ms)
+
+	for _, ready = range []float64{1, 3} {
+		w := strings.Builder{}
+		if err := mw.WriteAll(&w); err != nil {
+			t.Fatalf("failed to write metrics: %v", err)
+		}
+		for _, want := range []string{
+			"kube_service_info{uid=\"a\"} 1\n",
+			fmt.Sprintf("kube_service_status_ready_endpoints{uid=\"a\"} %v\n", ready),
+		} {
+			if !strings.Contains(w.String(), want) {
+				t.Fatalf("expected to find %q in:\n%s", want, w.String())
+			}
+		}
+	}
+}
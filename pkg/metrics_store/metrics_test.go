@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsstore
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"k8s.io/kube-state-metrics/v2/pkg/metric"
+)
+
+func TestObjectCountCollector(t *testing.T) {
+	genFunc := func(_ interface{}) []metric.FamilyInterface {
+		return []metric.FamilyInterface{&metric.Family{Name: "kube_pod_info"}}
+	}
+	newPod := func(id string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: id, Namespace: "default", UID: types.UID(id)}}
+	}
+
+	podStore1 := NewMetricsStore([]string{"Information about pod."}, genFunc)
+	podStore2 := NewMetricsStore([]string{"Information about pod."}, genFunc)
+	serviceStore := NewMetricsStore([]string{"Information about service."}, genFunc)
+
+	r := prometheus.NewRegistry()
+	c := NewObjectCountCollector(r)
+	c.SetStores(map[string][]*MetricsStore{
+		"pods":     {podStore1, podStore2},
+		"services": {serviceStore},
+	})
+
+	expect := func(pods, services int) {
+		t.Helper()
+		want := fmt.Sprintf(`# HELP kube_state_metrics_store_object_count Number of objects currently held by the stores of a resource in kube-state-metrics
+# TYPE kube_state_metrics_store_object_count gauge
+kube_state_metrics_store_object_count{resource="pods"} %d
+kube_state_metrics_store_object_count{resource="services"} %d
+`, pods, services)
+		if err := testutil.GatherAndCompare(r, strings.NewReader(want), "kube_state_metrics_store_object_count"); err != nil {
+			t.Error(err)
+		}
+	}
+
+	expect(0, 0)
+
+	for _, id := range []string{"a", "b", "c"} {
+		if err := podStore1.Add(newPod(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := podStore2.Add(newPod("d")); err != nil {
+		t.Fatal(err)
+	}
+	// Updating an existing object must not change the count.
+	if err := podStore1.Update(newPod("a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := serviceStore.Add(newPod("e")); err != nil {
+		t.Fatal(err)
+	}
+	expect(4, 1)
+
+	if err := podStore1.Delete(newPod("b")); err != nil {
+		t.Fatal(err)
+	}
+	if err := serviceStore.Delete(newPod("e")); err != nil {
+		t.Fatal(err)
+	}
+	expect(3, 0)
+
+	if err := podStore1.Replace([]interface{}{newPod("f")}, ""); err != nil {
+		t.Fatal(err)
+	}
+	expect(2, 0)
+}
+
+func TestCacheSyncCollector(t *testing.T) {
+	genFunc := func(_ interface{}) []metric.FamilyInterface {
+		return []metric.FamilyInterface{&metric.Family{Name: "kube_pod_info"}}
+	}
+
+	podStore1 := NewMetricsStore([]string{"Information about pod."}, genFunc)
+	podStore2 := NewMetricsStore([]string{"Information about pod."}, genFunc)
+	serviceStore := NewMetricsStore([]string{"Information about service."}, genFunc)
+
+	r := prometheus.NewRegistry()
+	c := NewCacheSyncCollector(r)
+	c.SetStores(map[string][]*MetricsStore{
+		"pods":     {podStore1, podStore2},
+		"services": {serviceStore},
+	})
+
+	expect := func(pods, services int) {
+		t.Helper()
+		want := fmt.Sprintf(`# HELP kube_state_metrics_cache_sync_completed Whether the initial list of a resource has been synced into the stores of kube-state-metrics
+# TYPE kube_state_metrics_cache_sync_completed gauge
+kube_state_metrics_cache_sync_completed{resource="pods"} %d
+kube_state_metrics_cache_sync_completed{resource="services"} %d
+`, pods, services)
+		if err := testutil.GatherAndCompare(r, strings.NewReader(want), "kube_state_metrics_cache_sync_completed"); err != nil {
+			t.Error(err)
+		}
+	}
+
+	expect(0, 0)
+
+	// A resource is only synced once all of its stores are.
+	if err := podStore1.Replace(nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := serviceStore.Replace(nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	expect(0, 1)
+
+	if err := podStore2.Replace(nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	expect(1, 1)
+}
@@ -17,11 +17,15 @@ limitations under the License.
 package metricsstore
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/prometheus/common/expfmt"
+	"k8s.io/apimachinery/pkg/types"
 
 	"k8s.io/kube-state-metrics/v2/pkg/metric"
 )
@@ -37,14 +41,72 @@ type MetricsWriterList []*MetricsWriter
 // metrics with the same name coming from different stores end up grouped together.
 // It also ensures that the metric headers are only written out once.
 type MetricsWriter struct {
-	stores []*MetricsStore
+	stores       []*MetricsStore
+	resourceName string
+	// shared holds the metric families shared with other writers, see SanitizeHeaders.
+	shared *sharedFamilies
+}
+
+// sharedFamilies maps the index of each metric family shared with other writers
+// to the same family in the other writers.
+type sharedFamilies struct {
+	mtx      sync.RWMutex
+	families map[int][]familyRef
+}
+
+// familyRef refers to the metric family with the given index of a MetricsWriter.
+type familyRef struct {
+	writer *MetricsWriter
+	index  int
 }
 
 // NewMetricsWriter creates a new MetricsWriter.
 func NewMetricsWriter(stores ...*MetricsStore) *MetricsWriter {
 	return &MetricsWriter{
 		stores: stores,
+		shared: &sharedFamilies{},
+	}
+}
+
+// NewResourceMetricsWriter creates a new MetricsWriter for the given resource.
+func NewResourceMetricsWriter(resourceName string, stores ...*MetricsStore) *MetricsWriter {
+	return &MetricsWriter{
+		stores:       stores,
+		resourceName: resourceName,
+		shared:       &sharedFamilies{},
+	}
+}
+
+// ResourceName returns the name of the resource the MetricsWriter was created for,
+// or an empty string if it was created with NewMetricsWriter.
+func (m MetricsWriter) ResourceName() string {
+	return m.resourceName
+}
+
+// HasSynced returns true once all underlying stores were populated by the
+// initial list of their reflectors.
+func (m MetricsWriter) HasSynced() bool {
+	for _, s := range m.stores {
+		if !s.HasSynced() {
+			return false
+		}
 	}
+	return true
+}
+
+// WriteOptions configure how a MetricsWriter writes out metrics.
+type WriteOptions struct {
+	// Sort sorts the series of each metric family by their label sets,
+	// so that the output is stable across scrapes.
+	Sort bool
+	// MaxSeriesPerFamily is the maximum number of series of a metric family.
+	// Metric families with more series are dropped entirely, including their headers.
+	// The series of a family shared with other writers, see SanitizeHeaders, are
+	// counted together. 0 means no limit.
+	MaxSeriesPerFamily int
+	// FamilyDropped is called with the name of every metric family dropped
+	// because of MaxSeriesPerFamily, if it is not nil.
+	FamilyDropped func(family string)
 }
 
 // WriteAll writes out metrics from the underlying stores to the given writer.
@@ -52,11 +114,40 @@ func NewMetricsWriter(stores ...*MetricsStore) *MetricsWriter {
 // WriteAll writes metrics so that the ones with the same name
 // are grouped together when written out.
 func (m MetricsWriter) WriteAll(w io.Writer) error {
+	return m.WriteAllWithOptions(w, WriteOptions{})
+}
+
+// WriteAllSorted writes out metrics from the underlying stores to the given
+// writer like WriteAll, but additionally sorts the series of each metric family
+// by their label sets, so that the output is stable across scrapes.
+func (m MetricsWriter) WriteAllSorted(w io.Writer) error {
+	return m.WriteAllWithOptions(w, WriteOptions{Sort: true})
+}
+
+// WriteAllWithOptions writes out metrics from the underlying stores to the given
+// writer like WriteAll, configured by the given options.
+func (m MetricsWriter) WriteAllWithOptions(w io.Writer, opts WriteOptions) error {
 	if len(m.stores) == 0 {
 		return nil
 	}
 
 	for i, help := range m.stores[0].headers {
+		// With a limit, the deferred series are generated upfront to be counted,
+		// and written out after the pre-rendered ones.
+		familyBytes := familyBytes
+		var deferred []byte
+		if opts.MaxSeriesPerFamily > 0 {
+			familyBytes = renderedFamilyBytes
+			deferred = m.deferredFamily(i)
+			if m.familySeries(i, deferred) > opts.MaxSeriesPerFamily {
+				// A shared family is reported by the writer holding its headers only.
+				if opts.FamilyDropped != nil && help != "" {
+					opts.FamilyDropped(familyName(help))
+				}
+				continue
+			}
+		}
+
 		if help != "" && help != "\n" {
 			help += "\n"
 		}
@@ -73,10 +164,17 @@ func (m MetricsWriter) WriteAll(w io.Writer) error {
 			return err
 		}
 
+		if opts.Sort {
+			err = m.writeSortedFamily(w, i, familyBytes, deferred)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		for _, s := range m.stores {
 			s.metrics.Range(func(_ interface{}, value interface{}) bool {
-				metricFamilies := value.([][]byte)
-				_, err = w.Write(metricFamilies[i])
+				_, err = w.Write(familyBytes(value, i))
 				if err != nil {
 					err = fmt.Errorf("failed to write metrics family: %v", err)
 					return false
@@ -87,21 +185,219 @@ func (m MetricsWriter) WriteAll(w io.Writer) error {
 				return err
 			}
 		}
+		if _, err := w.Write(deferred); err != nil {
+			return fmt.Errorf("failed to write metrics family: %v", err)
+		}
+	}
+	return nil
+}
+
+// TrackChanges makes the underlying stores record the objects added, updated
+// and deleted, to be written out by WriteDelta.
+func (m MetricsWriter) TrackChanges() {
+	for _, s := range m.stores {
+		s.TrackChanges()
+	}
+}
+
+// WriteDelta writes out the series of the objects added, updated or deleted
+// after the change with the given sequence number, see LastChange. This requires
+// TrackChanges to have been called.
+//
+// The series of every added or updated object are preceded by a
+// "# CHANGED <resource> <uid>" line and replace all previous series of that object.
+// Deleted objects are written out as a "# DELETED <resource> <uid>" line only.
+// If the changes are no longer available, a "# RESET <resource>" line is written
+// out instead, followed by all objects, see WriteObjects.
+// Metric headers are not written out.
+func (m MetricsWriter) WriteDelta(w io.Writer, since uint64) error {
+	var changed, deleted [][]types.UID
+	for _, s := range m.stores {
+		c, d, ok := s.ChangesSince(since)
+		if !ok {
+			if _, err := fmt.Fprintf(w, "# RESET %s\n", m.resourceName); err != nil {
+				return fmt.Errorf("failed to write delta: %v", err)
+			}
+			return m.WriteObjects(w)
+		}
+		changed = append(changed, c)
+		deleted = append(deleted, d)
+	}
+
+	for i, s := range m.stores {
+		slices.Sort(changed[i])
+		slices.Sort(deleted[i])
+		for _, uid := range changed[i] {
+			value, ok := s.metrics.Load(uid)
+			if !ok {
+				// The object was deleted after its changes were taken.
+				deleted[i] = append(deleted[i], uid)
+				continue
+			}
+			if err := m.writeObject(w, uid, value); err != nil {
+				return err
+			}
+		}
+		for _, uid := range deleted[i] {
+			if _, err := fmt.Fprintf(w, "# DELETED %s %s\n", m.resourceName, uid); err != nil {
+				return fmt.Errorf("failed to write delta: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// WriteObjects writes out the series of all objects held by the underlying stores,
+// each preceded by a "# CHANGED <resource> <uid>" line as in WriteDelta.
+func (m MetricsWriter) WriteObjects(w io.Writer) error {
+	var err error
+	for _, s := range m.stores {
+		s.metrics.Range(func(key, value interface{}) bool {
+			err = m.writeObject(w, key.(types.UID), value)
+			return err == nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeObject writes out the series of the object with the given id for WriteDelta.
+func (m MetricsWriter) writeObject(w io.Writer, uid types.UID, value interface{}) error {
+	if _, err := fmt.Fprintf(w, "# CHANGED %s %s\n", m.resourceName, uid); err != nil {
+		return fmt.Errorf("failed to write delta: %v", err)
+	}
+	for i := 0; i < familyCount(value); i++ {
+		if _, err := w.Write(familyBytes(value, i)); err != nil {
+			return fmt.Errorf("failed to write metrics family: %v", err)
+		}
+	}
+	return nil
+}
+
+// familySeries returns the number of series of the i-th metric family in all underlying stores,
+// given its deferred series, including the series of the same family in other writers.
+func (m MetricsWriter) familySeries(i int, deferred []byte) int {
+	series := m.renderedSeries(i) + bytes.Count(deferred, []byte("\n"))
+
+	m.shared.mtx.RLock()
+	defer m.shared.mtx.RUnlock()
+	// Deferred families are never shared, as only the families of custom resources are.
+	for _, f := range m.shared.families[i] {
+		series += f.writer.renderedSeries(f.index)
+	}
+	return series
+}
+
+// renderedSeries returns the number of pre-rendered series of the i-th metric family in all underlying stores.
+func (m MetricsWriter) renderedSeries(i int) int {
+	series := int64(0)
+	for _, s := range m.stores {
+		if i < len(s.series) {
+			series += s.series[i].Load()
+		}
+	}
+	return int(series)
+}
+
+// deferredFamily generates the deferred series of the i-th metric family in all underlying stores.
+func (m MetricsWriter) deferredFamily(i int) []byte {
+	var deferred []byte
+	for _, s := range m.stores {
+		if s.deferredObjects.Load() == 0 {
+			continue
+		}
+		s.metrics.Range(func(_ interface{}, value interface{}) bool {
+			if e, ok := value.(*deferredFamilies); ok {
+				if f, ok := e.deferred[i]; ok {
+					deferred = append(deferred, f.ByteSlice()...)
+				}
+			}
+			return true
+		})
+	}
+	return deferred
+}
+
+// renderedFamilyBytes returns the pre-rendered metrics of the i-th metric family of the given
+// entry of the metrics map, or nil if the family is deferred.
+func renderedFamilyBytes(entry interface{}, i int) []byte {
+	if e, ok := entry.(*deferredFamilies); ok {
+		if _, ok := e.deferred[i]; ok {
+			return nil
+		}
+	}
+	return familyBytes(entry, i)
+}
+
+// familyName returns the name of the metric family of the given headers.
+func familyName(headers string) string {
+	fields := strings.Fields(headers)
+	if len(fields) < 3 || fields[0] != "#" {
+		return ""
+	}
+	return fields[2]
+}
+
+// writeSortedFamily collects the series of the i-th metric family from all
+// underlying stores, as returned by familyBytes, and the given additional series,
+// and writes them out sorted.
+func (m MetricsWriter) writeSortedFamily(w io.Writer, i int, familyBytes func(interface{}, int) []byte, additional []byte) error {
+	var series [][]byte
+	appendSeries := func(family []byte) {
+		for _, line := range bytes.SplitAfter(family, []byte("\n")) {
+			if len(line) > 0 {
+				series = append(series, line)
+			}
+		}
+	}
+	for _, s := range m.stores {
+		s.metrics.Range(func(_ interface{}, value interface{}) bool {
+			appendSeries(familyBytes(value, i))
+			return true
+		})
+	}
+	appendSeries(additional)
+
+	slices.SortFunc(series, bytes.Compare)
+
+	for _, line := range series {
+		_, err := w.Write(line)
+		if err != nil {
+			return fmt.Errorf("failed to write metrics family: %v", err)
+		}
 	}
 	return nil
 }
 
 // SanitizeHeaders sanitizes the headers of the given MetricsWriterList.
+//
+// Duplicate headers of the same metric family (generated through CRS) are blanked, so that the
+// series of the family are written out following the headers of its first occurrence. The writers
+// sharing the family are recorded, so that its series are counted together for MaxSeriesPerFamily.
 func SanitizeHeaders(contentType string, writers MetricsWriterList) MetricsWriterList {
 	var lastHeader string
+	var family []familyRef
+	var sharedFamilies [][]familyRef
+	addFamily := func() {
+		if len(family) > 1 {
+			sharedFamilies = append(sharedFamilies, family)
+		}
+	}
+
 	for _, writer := range writers {
 		if len(writer.stores) > 0 {
-			for i := 0; i < len(writer.stores[0].headers); {
-				header := writer.stores[0].headers[i]
+			for i, header := range writer.stores[0].headers {
+				// Blank headers continue the family of the last header, if any.
+				if header == "" {
+					if family != nil {
+						family = append(family, familyRef{writer: writer, index: i})
+					}
+					continue
+				}
 
-				// Removes duplicate headers from the given MetricsWriterList for the same family (generated through CRS).
 				// These are expected to be consecutive since G** resolution generates groups of similar metrics with same headers before moving onto the next G** spec in the CRS configuration.
-				// Skip this step if we encounter a repeated header, as it will be removed.
 				if header != lastHeader && strings.HasPrefix(header, "# HELP") {
 
 					// If the requested content type was proto-based (such as FmtProtoDelim, FmtProtoText, or FmtProtoCompact), replace "info" and "statesets" with "gauge", as they are not recognized by Prometheus' protobuf machinery.
@@ -119,22 +415,41 @@ func SanitizeHeaders(contentType string, writers MetricsWriterList) MetricsWrite
 					}
 				}
 
-				// Nullify duplicate headers after the sanitization to not miss out on any new candidates.
+				// Blank duplicate headers after the sanitization to not miss out on any new candidates.
+				// They are not removed, as they are zipped with the metric families of the stores by index.
 				if header == lastHeader {
-					writer.stores[0].headers = append(writer.stores[0].headers[:i], writer.stores[0].headers[i+1:]...)
-
-					// Do not increment the index, as the next header is now at the current index.
+					writer.stores[0].headers[i] = ""
+					family = append(family, familyRef{writer: writer, index: i})
 					continue
 				}
 
 				// Update the last header.
 				lastHeader = header
+				addFamily()
+				family = []familyRef{{writer: writer, index: i}}
+			}
+		}
+	}
+	addFamily()
 
-				// Move to the next header.
-				i++
+	shared := map[*MetricsWriter]map[int][]familyRef{}
+	for _, family := range sharedFamilies {
+		for _, f := range family {
+			if shared[f.writer] == nil {
+				shared[f.writer] = map[int][]familyRef{}
+			}
+			for _, other := range family {
+				if other != f {
+					shared[f.writer][f.index] = append(shared[f.writer][f.index], other)
+				}
 			}
 		}
 	}
+	for _, writer := range writers {
+		writer.shared.mtx.Lock()
+		writer.shared.families = shared[writer]
+		writer.shared.mtx.Unlock()
+	}
 
 	return writers
 }
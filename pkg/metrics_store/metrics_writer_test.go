@@ -19,6 +19,7 @@ package metricsstore
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
@@ -27,7 +28,9 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"k8s.io/kube-state-metrics/v2/pkg/metric"
 )
@@ -236,7 +239,451 @@ func TestWriteAllWithMultipleStores(t *testing.T) {
 	}
 }
 
+// TestWriteAllSorted checks that series generated from maps, such as pod
+// resource requests, are written out in the same order on every scrape.
+func TestWriteAllSorted(t *testing.T) {
+	genFunc := func(obj interface{}) []metric.FamilyInterface {
+		p := obj.(*v1.Pod)
+
+		mf := metric.Family{
+			Name: "kube_pod_container_resource_requests",
+		}
+		for _, c := range p.Spec.Containers {
+			for resourceName, val := range c.Resources.Requests {
+				mf.Metrics = append(mf.Metrics, &metric.Metric{
+					LabelKeys:   []string{"namespace", "pod", "container", "resource"},
+					LabelValues: []string{p.Namespace, p.Name, c.Name, string(resourceName)},
+					Value:       float64(val.Value()),
+				})
+			}
+		}
+
+		return []metric.FamilyInterface{&mf}
+	}
+	requests := v1.ResourceList{
+		v1.ResourceCPU:              resource.MustParse("1"),
+		v1.ResourceMemory:           resource.MustParse("1Gi"),
+		v1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+		v1.ResourceStorage:          resource.MustParse("3Gi"),
+		"nvidia.com/gpu":            resource.MustParse("1"),
+	}
+	s1 := NewMetricsStore([]string{"Requests of pod containers"}, genFunc)
+	s2 := NewMetricsStore([]string{"Requests of pod containers"}, genFunc)
+	for i, store := range []*MetricsStore{s1, s2, s1} {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				UID:       types.UID(fmt.Sprintf("uid%d", i)),
+				Name:      fmt.Sprintf("pod%d", i),
+				Namespace: "default",
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{Name: "b", Resources: v1.ResourceRequirements{Requests: requests}},
+					{Name: "a", Resources: v1.ResourceRequirements{Requests: requests}},
+				},
+			},
+		}
+		if err := store.Add(pod); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writer := NewMetricsWriter(s1, s2)
+	w := strings.Builder{}
+	if err := writer.WriteAllSorted(&w); err != nil {
+		t.Fatalf("failed to write metrics: %v", err)
+	}
+	want := w.String()
+
+	lines := strings.Split(strings.TrimRight(want, "\n"), "\n")
+	if len(lines) != 31 {
+		t.Fatalf("Invalid number of lines, got %d, want %d", len(lines), 31)
+	}
+	if lines[0] != "Requests of pod containers" {
+		t.Fatalf("Invalid metrics header on line 0, got %s, want %s", lines[0], "Requests of pod containers")
+	}
+	if !slices.IsSorted(lines[1:]) {
+		t.Fatalf("Series are not sorted:\n%s", want)
+	}
+
+	for i := 0; i < 10; i++ {
+		w := strings.Builder{}
+		if err := writer.WriteAllSorted(&w); err != nil {
+			t.Fatalf("failed to write metrics: %v", err)
+		}
+		if diff := cmp.Diff(want, w.String()); diff != "" {
+			t.Fatalf("Output of scrape %d differs (-want, +got):\n%s", i, diff)
+		}
+	}
+}
+
 // TestWriteAllWithEmptyStores checks that nothing is printed if no metrics exist for metric families.
+func TestWriteAllWithMaxSeriesPerFamily(t *testing.T) {
+	genFunc := func(obj interface{}) []metric.FamilyInterface {
+		p := obj.(*v1.Pod)
+
+		info := metric.Family{
+			Name: "kube_pod_info",
+			Metrics: []*metric.Metric{
+				{
+					LabelKeys:   []string{"namespace", "pod"},
+					LabelValues: []string{p.Namespace, p.Name},
+					Value:       1,
+				},
+			},
+		}
+		containers := metric.Family{
+			Name: "kube_pod_container_info",
+		}
+		for _, c := range p.Spec.Containers {
+			containers.Metrics = append(containers.Metrics, &metric.Metric{
+				LabelKeys:   []string{"namespace", "pod", "container"},
+				LabelValues: []string{p.Namespace, p.Name, c.Name},
+				Value:       1,
+			})
+		}
+
+		return []metric.FamilyInterface{&info, &containers}
+	}
+	headers := []string{
+		"# HELP kube_pod_info Information about pod.\n# TYPE kube_pod_info gauge",
+		"# HELP kube_pod_container_info Information about a container in a pod.\n# TYPE kube_pod_container_info gauge",
+	}
+	s1 := NewMetricsStore(headers, genFunc)
+	s2 := NewMetricsStore(headers, genFunc)
+	for i, store := range []*MetricsStore{s1, s2} {
+		pod := &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				UID:       types.UID(fmt.Sprintf("uid%d", i)),
+				Name:      fmt.Sprintf("pod%d", i),
+				Namespace: "default",
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "a"}, {Name: "b"}},
+			},
+		}
+		if err := store.Add(pod); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The container family has 4 series across both stores and exceeds the limit, the pod family has 2 and does not.
+	var dropped []string
+	writer := NewMetricsWriter(s1, s2)
+	w := strings.Builder{}
+	if err := writer.WriteAllWithOptions(&w, WriteOptions{
+		Sort:               true,
+		MaxSeriesPerFamily: 3,
+		FamilyDropped: func(family string) {
+			dropped = append(dropped, family)
+		},
+	}); err != nil {
+		t.Fatalf("failed to write metrics: %v", err)
+	}
+
+	want := `# HELP kube_pod_info Information about pod.
+# TYPE kube_pod_info gauge
+kube_pod_info{namespace="default",pod="pod0"} 1
+kube_pod_info{namespace="default",pod="pod1"} 1
+`
+	if diff := cmp.Diff(want, w.String()); diff != "" {
+		t.Errorf("unexpected output (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"kube_pod_container_info"}, dropped); diff != "" {
+		t.Errorf("unexpected dropped families (-want, +got):\n%s", diff)
+	}
+
+	// Families exactly at the limit are kept.
+	w.Reset()
+	if err := writer.WriteAllWithOptions(&w, WriteOptions{MaxSeriesPerFamily: 4}); err != nil {
+		t.Fatalf("failed to write metrics: %v", err)
+	}
+	if got := strings.Count(w.String(), "kube_pod_container_info{"); got != 4 {
+		t.Errorf("expected 4 container series, got %d:\n%s", got, w.String())
+	}
+}
+
+func TestWriteAllWithMaxSeriesPerFamilyDeferred(t *testing.T) {
+	generated := 0
+	genFunc := func(obj interface{}) []metric.FamilyInterface {
+		p := obj.(*v1.Pod)
+
+		return []metric.FamilyInterface{&metric.Family{
+			Name: "kube_pod_status_unscheduled_duration_seconds",
+			Deferred: func() []*metric.Metric {
+				generated++
+				return []*metric.Metric{
+					{
+						LabelKeys:   []string{"pod"},
+						LabelValues: []string{p.Name},
+						Value:       60,
+					},
+				}
+			},
+		}}
+	}
+	headers := []string{"# HELP kube_pod_status_unscheduled_duration_seconds The number of seconds since the pod moved into unscheduled status.\n# TYPE kube_pod_status_unscheduled_duration_seconds gauge"}
+	s := NewMetricsStore(headers, genFunc)
+	for i := range 3 {
+		pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{UID: types.UID(fmt.Sprintf("uid%d", i)), Name: fmt.Sprintf("pod%d", i)}}
+		if err := s.Add(pod); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writer := NewMetricsWriter(s)
+	w := strings.Builder{}
+	if err := writer.WriteAllWithOptions(&w, WriteOptions{Sort: true, MaxSeriesPerFamily: 3}); err != nil {
+		t.Fatalf("failed to write metrics: %v", err)
+	}
+	want := headers[0] + `
+kube_pod_status_unscheduled_duration_seconds{pod="pod0"} 60
+kube_pod_status_unscheduled_duration_seconds{pod="pod1"} 60
+kube_pod_status_unscheduled_duration_seconds{pod="pod2"} 60
+`
+	if diff := cmp.Diff(want, w.String()); diff != "" {
+		t.Errorf("unexpected output (-want, +got):\n%s", diff)
+	}
+	// The deferred metrics are generated once per object to be counted and written out.
+	if generated != 3 {
+		t.Errorf("expected the deferred metrics to be generated 3 times, got %d", generated)
+	}
+
+	w.Reset()
+	if err := writer.WriteAllWithOptions(&w, WriteOptions{MaxSeriesPerFamily: 2}); err != nil {
+		t.Fatalf("failed to write metrics: %v", err)
+	}
+	if w.Len() != 0 {
+		t.Errorf("expected the family to be dropped, got:\n%s", w.String())
+	}
+}
+
+func TestWriteAllWithMaxSeriesPerFamilySharedFamily(t *testing.T) {
+	fooHeader := "# HELP foo foo_help\n# TYPE foo gauge"
+	barHeader := "# HELP bar bar_help\n# TYPE bar gauge"
+	genFunc := func(families ...string) func(obj interface{}) []metric.FamilyInterface {
+		return func(obj interface{}) []metric.FamilyInterface {
+			name := obj.(*v1.Pod).Name
+			var result []metric.FamilyInterface
+			for _, family := range families {
+				result = append(result, &metric.Family{
+					Name: family,
+					Metrics: []*metric.Metric{
+						{
+							LabelKeys:   []string{"name"},
+							LabelValues: []string{name},
+							Value:       1,
+						},
+					},
+				})
+			}
+			return result
+		}
+	}
+
+	// Two custom resources generate the foo family, the second one also the bar family.
+	s1 := NewMetricsStore([]string{fooHeader}, genFunc("foo"))
+	s2 := NewMetricsStore([]string{fooHeader, barHeader}, genFunc("foo", "bar"))
+	for i, store := range []*MetricsStore{s1, s1, s2} {
+		obj := &v1.Pod{ObjectMeta: metav1.ObjectMeta{UID: types.UID(fmt.Sprintf("uid%d", i)), Name: fmt.Sprintf("obj%d", i)}}
+		if err := store.Add(obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writers := SanitizeHeaders(string(expfmt.NewFormat(expfmt.TypeTextPlain)), MetricsWriterList{NewMetricsWriter(s1), NewMetricsWriter(s2)})
+
+	writeAll := func(opts WriteOptions) string {
+		w := strings.Builder{}
+		for _, writer := range writers {
+			if err := writer.WriteAllWithOptions(&w, opts); err != nil {
+				t.Fatalf("failed to write metrics: %v", err)
+			}
+		}
+		return w.String()
+	}
+
+	// The foo family has 3 series across both writers and is kept at the limit.
+	want := fooHeader + `
+foo{name="obj0"} 1
+foo{name="obj1"} 1
+foo{name="obj2"} 1
+` + barHeader + `
+bar{name="obj2"} 1
+`
+	if diff := cmp.Diff(want, writeAll(WriteOptions{Sort: true, MaxSeriesPerFamily: 3})); diff != "" {
+		t.Errorf("unexpected output (-want, +got):\n%s", diff)
+	}
+
+	// Over the limit, the foo family is dropped from both writers, leaving neither its header nor any of its series.
+	var dropped []string
+	want = barHeader + `
+bar{name="obj2"} 1
+`
+	if diff := cmp.Diff(want, writeAll(WriteOptions{
+		Sort:               true,
+		MaxSeriesPerFamily: 2,
+		FamilyDropped: func(family string) {
+			dropped = append(dropped, family)
+		},
+	})); diff != "" {
+		t.Errorf("unexpected output (-want, +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"foo"}, dropped); diff != "" {
+		t.Errorf("unexpected dropped families (-want, +got):\n%s", diff)
+	}
+
+	// Series counts follow deleted objects, and sanitizing the headers again keeps the family shared.
+	if err := s1.Delete(&v1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "uid0"}}); err != nil {
+		t.Fatal(err)
+	}
+	writers = SanitizeHeaders(string(expfmt.NewFormat(expfmt.TypeTextPlain)), writers)
+	want = fooHeader + `
+foo{name="obj1"} 1
+foo{name="obj2"} 1
+` + barHeader + `
+bar{name="obj2"} 1
+`
+	if diff := cmp.Diff(want, writeAll(WriteOptions{Sort: true, MaxSeriesPerFamily: 2})); diff != "" {
+		t.Errorf("unexpected output (-want, +got):\n%s", diff)
+	}
+}
+
+func TestWriteDelta(t *testing.T) {
+	genFunc := func(obj interface{}) []metric.FamilyInterface {
+		svc := obj.(*v1.Service)
+		mf := metric.Family{
+			Name: "kube_service_info",
+			Metrics: []*metric.Metric{
+				{
+					LabelKeys:   []string{"namespace", "service", "type"},
+					LabelValues: []string{svc.Namespace, svc.Name, string(svc.Spec.Type)},
+					Value:       float64(1),
+				},
+			},
+		}
+		return []metric.FamilyInterface{&mf}
+	}
+	newService := func(uid, name string, serviceType v1.ServiceType) *v1.Service {
+		return &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{UID: types.UID(uid), Name: name, Namespace: "a"},
+			Spec:       v1.ServiceSpec{Type: serviceType},
+		}
+	}
+
+	store := NewMetricsStore([]string{"# HELP kube_service_info Information about service."}, genFunc)
+	if err := store.Add(newService("a1", "existing", v1.ServiceTypeClusterIP)); err != nil {
+		t.Fatal(err)
+	}
+	writer := NewResourceMetricsWriter("services", store)
+
+	writeDelta := func(since uint64) string {
+		w := strings.Builder{}
+		if err := writer.WriteDelta(&w, since); err != nil {
+			t.Fatalf("failed to write delta: %v", err)
+		}
+		return w.String()
+	}
+
+	if got, want := writeDelta(LastChange()), "# RESET services\n# CHANGED services a1\n"; !strings.HasPrefix(got, want) {
+		t.Fatalf("expected a reset without change tracking, got:\n%s", got)
+	}
+
+	writer.TrackChanges()
+	cursor := LastChange()
+
+	steps := []struct {
+		name   string
+		change func() error
+		want   string
+	}{
+		{
+			name:   "no changes",
+			change: func() error { return nil },
+			want:   "",
+		},
+		{
+			name:   "add",
+			change: func() error { return store.Add(newService("a2", "added", v1.ServiceTypeClusterIP)) },
+			want: `# CHANGED services a2
+kube_service_info{namespace="a",service="added",type="ClusterIP"} 1
+`,
+		},
+		{
+			name:   "update",
+			change: func() error { return store.Update(newService("a1", "existing", v1.ServiceTypeNodePort)) },
+			want: `# CHANGED services a1
+kube_service_info{namespace="a",service="existing",type="NodePort"} 1
+`,
+		},
+		{
+			name:   "delete",
+			change: func() error { return store.Delete(newService("a2", "added", v1.ServiceTypeClusterIP)) },
+			want: `# DELETED services a2
+`,
+		},
+		{
+			name: "add then delete",
+			change: func() error {
+				if err := store.Add(newService("a3", "short-lived", v1.ServiceTypeClusterIP)); err != nil {
+					return err
+				}
+				return store.Delete(newService("a3", "short-lived", v1.ServiceTypeClusterIP))
+			},
+			want: `# DELETED services a3
+`,
+		},
+		{
+			name: "replace",
+			change: func() error {
+				return store.Replace([]interface{}{newService("a4", "relisted", v1.ServiceTypeClusterIP)}, "")
+			},
+			want: `# CHANGED services a4
+kube_service_info{namespace="a",service="relisted",type="ClusterIP"} 1
+# DELETED services a1
+`,
+		},
+	}
+
+	first := cursor
+	for _, step := range steps {
+		if err := step.change(); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		// A consumer which failed to read the delta asks for the same changes again.
+		for i := 0; i < 2; i++ {
+			if got := writeDelta(cursor); got != step.want {
+				t.Errorf("%s: unexpected delta (-want +got):\n%s", step.name, cmp.Diff(step.want, got))
+			}
+		}
+		cursor = LastChange()
+	}
+
+	// Another consumer still at the first cursor gets all changes since then at once.
+	want := `# CHANGED services a4
+kube_service_info{namespace="a",service="relisted",type="ClusterIP"} 1
+# DELETED services a1
+# DELETED services a2
+# DELETED services a3
+`
+	if got := writeDelta(first); got != want {
+		t.Errorf("unexpected delta since the first cursor (-want +got):\n%s", cmp.Diff(want, got))
+	}
+
+	// Changes before the oldest deletion still recorded are no longer available.
+	for i := 0; i <= maxDeletedChanges; i++ {
+		if err := store.Delete(newService(fmt.Sprintf("d%d", i), "deleted", v1.ServiceTypeClusterIP)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want = `# RESET services
+# CHANGED services a4
+kube_service_info{namespace="a",service="relisted",type="ClusterIP"} 1
+`
+	if got := writeDelta(cursor); got != want {
+		t.Errorf("unexpected delta after dropping deletions (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
 func TestWriteAllWithEmptyStores(t *testing.T) {
 	genFunc := func(_ interface{}) []metric.FamilyInterface {
 		mf1 := metric.Family{
@@ -286,6 +733,7 @@ func TestSanitizeHeaders(t *testing.T) {
 				"# HELP foo foo_help\n# TYPE foo counter",
 			},
 			expectedHeaders: []string{
+				"",
 				"# HELP foo foo_help\n# TYPE foo gauge",
 				"# HELP foo foo_help\n# TYPE foo info",
 				"# HELP foo foo_help\n# TYPE foo stateset",
@@ -309,10 +757,17 @@ func TestSanitizeHeaders(t *testing.T) {
 				"# HELP foo foo_help\n# TYPE foo counter",
 			},
 			expectedHeaders: []string{
+				"",
+				"",
+				"",
 				"# HELP foo foo_help\n# TYPE foo gauge",
+				"",
 				"# HELP foo foo_help\n# TYPE foo info",
+				"",
 				"# HELP foo foo_help\n# TYPE foo stateset",
+				"",
 				"# HELP foo foo_help\n# TYPE foo counter",
+				"",
 			},
 		},
 		{
@@ -326,7 +781,10 @@ func TestSanitizeHeaders(t *testing.T) {
 				"# HELP foo foo_help\n# TYPE foo counter",
 			},
 			expectedHeaders: []string{
+				"",
 				"# HELP foo foo_help\n# TYPE foo gauge",
+				"",
+				"",
 				"# HELP foo foo_help\n# TYPE foo counter",
 			},
 		},
@@ -347,8 +805,17 @@ func TestSanitizeHeaders(t *testing.T) {
 				"# HELP foo foo_help\n# TYPE foo counter",
 			},
 			expectedHeaders: []string{
+				"",
+				"",
+				"",
 				"# HELP foo foo_help\n# TYPE foo gauge",
+				"",
+				"",
+				"",
+				"",
+				"",
 				"# HELP foo foo_help\n# TYPE foo counter",
+				"",
 			},
 		},
 	}
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metricsconfig provides a filter which restricts the exposed metric
+// families to exactly the ones listed in a metrics config file.
+package metricsconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+)
+
+// Config is the content of a metrics config file.
+type Config struct {
+	// Metrics are the names of the metric families to enable.
+	Metrics []string `yaml:"metrics"`
+}
+
+// MetricFamilyFilter filters metric families which are not listed in a metrics config.
+type MetricFamilyFilter struct {
+	metrics map[string]struct{}
+}
+
+// Test returns true if the given generator is listed in the metrics config of the filter.
+// A filter without a metrics config passes all generators.
+func (filter MetricFamilyFilter) Test(generator generator.FamilyGenerator) bool {
+	if filter.metrics == nil {
+		return true
+	}
+	_, ok := filter.metrics[generator.Name]
+	return ok
+}
+
+// Metrics returns the sorted names of the metric families listed in the metrics config.
+func (filter MetricFamilyFilter) Metrics() []string {
+	metrics := make([]string, 0, len(filter.metrics))
+	for m := range filter.metrics {
+		metrics = append(metrics, m)
+	}
+	sort.Strings(metrics)
+	return metrics
+}
+
+// Count returns the amount of metric families listed in the metrics config.
+func (filter MetricFamilyFilter) Count() int {
+	return len(filter.metrics)
+}
+
+// Validate returns an error listing the metric families of the metrics config
+// which are not among the given known metric families.
+func (filter MetricFamilyFilter) Validate(known []string) error {
+	knownSet := make(map[string]struct{}, len(known))
+	for _, k := range known {
+		knownSet[k] = struct{}{}
+	}
+	unknown := []string{}
+	for _, m := range filter.Metrics() {
+		if _, ok := knownSet[m]; !ok {
+			unknown = append(unknown, m)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown metric families in metrics config: %s", strings.Join(unknown, ","))
+	}
+	return nil
+}
+
+// NewMetricFamilyFilter creates a new MetricFamilyFilter from the metrics config file at the given path.
+// An empty path creates a filter which passes all metric families.
+func NewMetricFamilyFilter(path string) (*MetricFamilyFilter, error) {
+	if path == "" {
+		return &MetricFamilyFilter{}, nil
+	}
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metrics config file: %v", err)
+	}
+	var config Config
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metrics config file: %v", err)
+	}
+	if len(config.Metrics) == 0 {
+		return nil, fmt.Errorf("metrics config file %s does not list any metric families", path)
+	}
+	metrics := make(map[string]struct{}, len(config.Metrics))
+	for _, m := range config.Metrics {
+		metrics[m] = struct{}{}
+	}
+	return &MetricFamilyFilter{metrics: metrics}, nil
+}
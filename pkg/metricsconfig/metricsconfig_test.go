@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	basemetrics "k8s.io/component-base/metrics"
+
+	"k8s.io/kube-state-metrics/v2/pkg/metric"
+	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "metrics-config.yml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFilter(t *testing.T) {
+	subset := writeConfig(t, `
+metrics:
+  - kube_pod_info
+  - kube_deployment_spec_replicas
+`)
+	tests := []struct {
+		MetricFamily string
+		Path         string
+		Want         bool
+	}{
+		{"kube_pod_info", subset, true},
+		{"kube_deployment_spec_replicas", subset, true},
+		{"kube_pod_info_total", subset, false},
+		{"kube_pod_status_phase", subset, false},
+		{"kube_pod_status_phase", "", true},
+	}
+
+	for _, test := range tests {
+		filter, err := NewMetricFamilyFilter(test.Path)
+		if err != nil {
+			t.Fatalf("did not expect NewMetricFamilyFilter to fail, the error is %v", err)
+		}
+
+		familyGenerator := *generator.NewFamilyGeneratorWithStability(
+			test.MetricFamily,
+			"",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			func(_ interface{}) *metric.Family {
+				return nil
+			},
+		)
+
+		if got := filter.Test(familyGenerator); got != test.Want {
+			t.Errorf("the metric family %s with metrics config %q: want %t, got %t", test.MetricFamily, test.Path, test.Want, got)
+		}
+	}
+}
+
+func TestNewMetricFamilyFilterInvalidConfig(t *testing.T) {
+	for _, content := range []string{"metrics: [\n", "metrics: []\n"} {
+		if _, err := NewMetricFamilyFilter(writeConfig(t, content)); err == nil {
+			t.Errorf("expected an error for metrics config %q", content)
+		}
+	}
+	if _, err := NewMetricFamilyFilter(filepath.Join(t.TempDir(), "missing.yml")); err == nil {
+		t.Error("expected an error for a missing metrics config file")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	filter, err := NewMetricFamilyFilter(writeConfig(t, "metrics: [kube_pod_info, kube_pod_inf]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := filter.Validate([]string{"kube_pod_info", "kube_pod_labels"}); err == nil || err.Error() != "unknown metric families in metrics config: kube_pod_inf" {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+	if err := filter.Validate([]string{"kube_pod_info", "kube_pod_inf"}); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+}
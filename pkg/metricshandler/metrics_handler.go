@@ -17,6 +17,7 @@ limitations under the License.
 package metricshandler
 
 import (
+	"bytes"
 	"compress/gzip"
 	"context"
 	"errors"
@@ -26,7 +27,10 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/common/expfmt"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -37,6 +41,7 @@ import (
 	"k8s.io/klog/v2"
 
 	ksmtypes "k8s.io/kube-state-metrics/v2/pkg/builder/types"
+	"k8s.io/kube-state-metrics/v2/pkg/metric"
 	metricsstore "k8s.io/kube-state-metrics/v2/pkg/metrics_store"
 	"k8s.io/kube-state-metrics/v2/pkg/options"
 )
@@ -50,12 +55,20 @@ type MetricsHandler struct {
 
 	cancel func()
 
-	// mtx protects metricsWriters, curShard, and curTotalShards
+	// mtx protects metricsWriters, curShard, curTotalShards and deltaEpoch
 	mtx                *sync.RWMutex
 	metricsWriters     metricsstore.MetricsWriterList
 	curTotalShards     int
 	curShard           int32
 	enableGZIPEncoding bool
+	// deltaEpoch identifies the metrics writers in the cursors of the delta
+	// endpoint. It changes whenever they are rebuilt.
+	deltaEpoch int64
+
+	scrapeDuration *prometheus.GaugeVec
+	seriesEmitted  *prometheus.CounterVec
+	familyDropped  *prometheus.CounterVec
+	lastScrape     prometheus.Gauge
 }
 
 // New creates and returns a new MetricsHandler with the given options.
@@ -69,6 +82,40 @@ func New(opts *options.Options, kubeClient kubernetes.Interface, storeBuilder ks
 	}
 }
 
+// WithMetrics initializes and registers the kube_state_metrics_scrape_duration_seconds,
+// kube_state_metrics_series_emitted_total, kube_state_metrics_family_dropped_total and
+// kube_state_metrics_last_scrape_success_timestamp_seconds metrics of the MetricsHandler
+// with the given prometheus registry.
+func (m *MetricsHandler) WithMetrics(r prometheus.Registerer) {
+	m.scrapeDuration = promauto.With(r).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kube_state_metrics_scrape_duration_seconds",
+			Help: "Duration in seconds of writing out the metrics of a resource during the last scrape",
+		},
+		[]string{"resource"},
+	)
+	m.seriesEmitted = promauto.With(r).NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kube_state_metrics_series_emitted_total",
+			Help: "Total number of series of a resource written out across all scrapes",
+		},
+		[]string{"resource"},
+	)
+	m.familyDropped = promauto.With(r).NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kube_state_metrics_family_dropped_total",
+			Help: "Total number of times a metric family was dropped from a scrape because it exceeded --max-series-per-family",
+		},
+		[]string{"family"},
+	)
+	m.lastScrape = promauto.With(r).NewGauge(
+		prometheus.GaugeOpts{
+			Name: "kube_state_metrics_last_scrape_success_timestamp_seconds",
+			Help: "Unix timestamp of the last time the metrics of all resources were written out without error",
+		},
+	)
+}
+
 // BuildWriters builds the metrics writers, cancelling any previous context and passing a new one on every build.
 // Build can be used multiple times and concurrently.
 func (m *MetricsHandler) BuildWriters(ctx context.Context) {
@@ -81,6 +128,38 @@ func (m *MetricsHandler) BuildWriters(ctx context.Context) {
 	ctx, m.cancel = context.WithCancel(ctx)
 	m.storeBuilder.WithContext(ctx)
 	m.metricsWriters = m.storeBuilder.Build()
+	m.trackChanges()
+}
+
+// RebuildWriters rebuilds the metrics writers within the context of the last build. Unlike BuildWriters,
+// it keeps the stores of all resources which did not change since the last build running.
+func (m *MetricsHandler) RebuildWriters(ctx context.Context) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.cancel == nil {
+		ctx, m.cancel = context.WithCancel(ctx)
+		m.storeBuilder.WithContext(ctx)
+	}
+	m.metricsWriters = m.storeBuilder.Build()
+	m.trackChanges()
+}
+
+// HasSynced returns true once the metrics writers were built and the stores of
+// all of them were populated by the initial list of their reflectors.
+func (m *MetricsHandler) HasSynced() bool {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	if m.cancel == nil {
+		return false
+	}
+	for _, w := range m.metricsWriters {
+		if !w.HasSynced() {
+			return false
+		}
+	}
+	return true
 }
 
 // ConfigureSharding configures sharding. Configuration can be used multiple times and
@@ -221,11 +300,16 @@ func (m *MetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	m.metricsWriters = metricsstore.SanitizeHeaders(string(contentType), m.metricsWriters)
-	for _, w := range m.metricsWriters {
-		err := w.WriteAll(writer)
-		if err != nil {
-			klog.ErrorS(err, "Failed to write metrics")
-		}
+	var succeeded bool
+	if contentType.FormatType() == expfmt.TypeOpenMetrics {
+		succeeded = m.writeMetrics(writer)
+	} else {
+		// Exemplars are only supported by OpenMetrics.
+		succeeded = m.writeMetrics(metric.StripExemplars(writer))
+	}
+	// Only scrapes count as successful, not remote-write pushes sharing writeMetrics.
+	if succeeded && m.lastScrape != nil {
+		m.lastScrape.SetToCurrentTime()
 	}
 
 	// OpenMetrics spec requires that we end with an EOF directive.
@@ -245,6 +329,141 @@ func (m *MetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ServeDeltaHTTP writes the series of all objects added, updated or deleted since
+// the cursor given by the cursor query parameter to the response body, see
+// metricsstore.MetricsWriter.WriteDelta. The response ends with a "# CURSOR <cursor>"
+// line, giving the cursor to pass on the next request. Without a valid cursor, e.g.
+// on the first request or after the metrics writers were rebuilt, the response
+// starts with a "# RESET" line, after which it contains the full state.
+// It is only served if --enable-delta-endpoint is set.
+func (m *MetricsHandler) ServeDeltaHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	// Changes recorded while the delta is written out are written out again on the next request.
+	cursor := metricsstore.LastChange()
+	since, ok := parseDeltaCursor(r.URL.Query().Get("cursor"), m.deltaEpoch)
+
+	w.Header().Set("Content-Type", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+	writer := metric.StripExemplars(w)
+
+	if !ok {
+		if _, err := writer.Write([]byte("# RESET\n")); err != nil {
+			klog.ErrorS(err, "Failed to write delta reset")
+			return
+		}
+	}
+	for _, mw := range m.metricsWriters {
+		var err error
+		if ok {
+			err = mw.WriteDelta(writer, since)
+		} else {
+			err = mw.WriteObjects(writer)
+		}
+		// The cursor is left out, so that the consumer requests the same changes again.
+		if err != nil {
+			klog.ErrorS(err, "Failed to write metrics delta", "resource", mw.ResourceName())
+			return
+		}
+	}
+	if _, err := fmt.Fprintf(writer, "# CURSOR %d-%d\n", m.deltaEpoch, cursor); err != nil {
+		klog.ErrorS(err, "Failed to write delta cursor")
+	}
+}
+
+// parseDeltaCursor returns the sequence number of the last change seen according
+// to the given cursor of the delta endpoint. ok is false if the cursor is not valid
+// for the given epoch of the metrics writers.
+func parseDeltaCursor(cursor string, epoch int64) (since uint64, ok bool) {
+	e, seq, found := strings.Cut(cursor, "-")
+	if !found || e != strconv.FormatInt(epoch, 10) {
+		return 0, false
+	}
+	since, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return since, true
+}
+
+// trackChanges makes the metrics writers track the changes of their objects
+// for the delta endpoint, invalidating the cursors handed out so far.
+func (m *MetricsHandler) trackChanges() {
+	if !m.opts.EnableDeltaEndpoint {
+		return
+	}
+	for _, w := range m.metricsWriters {
+		w.TrackChanges()
+	}
+	m.deltaEpoch = time.Now().UnixNano()
+}
+
+// WriteMetrics writes all generated metrics in the Prometheus text format to the given writer.
+func (m *MetricsHandler) WriteMetrics(w io.Writer) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	m.writeMetrics(metric.StripExemplars(w))
+}
+
+// writeMetrics writes all generated metrics to the given writer, and returns whether
+// the metrics of all resources were written out without error.
+func (m *MetricsHandler) writeMetrics(writer io.Writer) bool {
+	succeeded := true
+	for _, w := range m.metricsWriters {
+		start := time.Now()
+		cw := &seriesCountingWriter{Writer: writer}
+		err := w.WriteAllWithOptions(cw, metricsstore.WriteOptions{
+			Sort:               m.opts.SortMetrics,
+			MaxSeriesPerFamily: m.opts.MaxSeriesPerFamily,
+			FamilyDropped:      m.familyDroppedFunc(),
+		})
+		if err != nil {
+			klog.ErrorS(err, "Failed to write metrics")
+			succeeded = false
+		}
+		if m.scrapeDuration != nil {
+			m.scrapeDuration.WithLabelValues(w.ResourceName()).Set(time.Since(start).Seconds())
+		}
+		if m.seriesEmitted != nil {
+			m.seriesEmitted.WithLabelValues(w.ResourceName()).Add(float64(cw.series))
+		}
+	}
+	return succeeded
+}
+
+// familyDroppedFunc returns the callback counting metric families dropped because
+// they exceeded --max-series-per-family.
+func (m *MetricsHandler) familyDroppedFunc() func(family string) {
+	return func(family string) {
+		klog.V(2).InfoS("Dropped metric family exceeding the maximum number of series", "family", family, "maxSeriesPerFamily", m.opts.MaxSeriesPerFamily)
+		if m.familyDropped != nil {
+			m.familyDropped.WithLabelValues(family).Inc()
+		}
+	}
+}
+
+// seriesCountingWriter counts the series passing through it, i.e. the non-empty
+// lines which are not comments.
+type seriesCountingWriter struct {
+	io.Writer
+	series int
+}
+
+func (w *seriesCountingWriter) Write(p []byte) (int, error) {
+	for rest := p; len(rest) > 0; {
+		line := rest
+		if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+			line, rest = rest[:i], rest[i+1:]
+		} else {
+			rest = nil
+		}
+		if len(line) > 0 && line[0] != '#' {
+			w.series++
+		}
+	}
+	return w.Writer.Write(p)
+}
+
 func shardingSettingsFromStatefulSet(ss *appsv1.StatefulSet, podName string) (nominal int32, totalReplicas int, err error) {
 	nominal, err = detectNominalFromPod(ss.Name, podName)
 	if err != nil {
@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricshandler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"k8s.io/kube-state-metrics/v2/pkg/metric"
+	metricsstore "k8s.io/kube-state-metrics/v2/pkg/metrics_store"
+	"k8s.io/kube-state-metrics/v2/pkg/options"
+)
+
+func TestServeHTTPScrapeDuration(t *testing.T) {
+	newStore := func(name string) *metricsstore.MetricsStore {
+		genFunc := func(_ interface{}) []metric.FamilyInterface {
+			return []metric.FamilyInterface{&metric.Family{
+				Name:    name,
+				Metrics: []*metric.Metric{{Value: 1}},
+			}}
+		}
+		store := metricsstore.NewMetricsStore([]string{"# HELP " + name + " Test metric.\n# TYPE " + name + " gauge"}, genFunc)
+		if err := store.Add(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "foo", UID: "foo"}}); err != nil {
+			t.Fatal(err)
+		}
+		return store
+	}
+
+	r := prometheus.NewRegistry()
+	m := New(options.NewOptions(), nil, nil, false)
+	m.WithMetrics(r)
+	m.metricsWriters = metricsstore.MetricsWriterList{
+		metricsstore.NewResourceMetricsWriter("pods", newStore("kube_pod_test")),
+		metricsstore.NewResourceMetricsWriter("services", newStore("kube_service_test")),
+	}
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, name := range []string{"kube_pod_test 1", "kube_service_test 1"} {
+		if !strings.Contains(rec.Body.String(), name) {
+			t.Errorf("expected %q in response body, got:\n%s", name, rec.Body.String())
+		}
+	}
+
+	families, err := r.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]bool{}
+	for _, f := range families {
+		if f.GetName() != "kube_state_metrics_scrape_duration_seconds" {
+			continue
+		}
+		for _, metric := range f.GetMetric() {
+			if metric.GetGauge().GetValue() < 0 {
+				t.Errorf("expected a non-negative scrape duration, got %v", metric.GetGauge().GetValue())
+			}
+			got[labelValue(metric, "resource")] = true
+		}
+	}
+	for _, resource := range []string{"pods", "services"} {
+		if !got[resource] {
+			t.Errorf("expected a scrape duration for resource %s, got %v", resource, got)
+		}
+	}
+}
+
+func TestServeHTTPSeriesEmitted(t *testing.T) {
+	genFunc := func(_ interface{}) []metric.FamilyInterface {
+		return []metric.FamilyInterface{&metric.Family{
+			Name: "kube_pod_test",
+			Metrics: []*metric.Metric{
+				{LabelKeys: []string{"condition"}, LabelValues: []string{"true"}, Value: 1},
+				{LabelKeys: []string{"condition"}, LabelValues: []string{"false"}, Value: 0},
+			},
+		}}
+	}
+	store := metricsstore.NewMetricsStore([]string{"# HELP kube_pod_test Test metric.\n# TYPE kube_pod_test gauge"}, genFunc)
+	for _, name := range []string{"foo", "bar", "baz"} {
+		if err := store.Add(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name)}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r := prometheus.NewRegistry()
+	m := New(options.NewOptions(), nil, nil, false)
+	m.WithMetrics(r)
+	m.metricsWriters = metricsstore.MetricsWriterList{
+		metricsstore.NewResourceMetricsWriter("pods", store),
+	}
+
+	for scrape := 1; scrape <= 2; scrape++ {
+		m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		want := fmt.Sprintf(`
+			# HELP kube_state_metrics_series_emitted_total Total number of series of a resource written out across all scrapes
+			# TYPE kube_state_metrics_series_emitted_total counter
+			kube_state_metrics_series_emitted_total{resource="pods"} %d
+		`, 6*scrape)
+		if err := testutil.GatherAndCompare(r, strings.NewReader(want), "kube_state_metrics_series_emitted_total"); err != nil {
+			t.Errorf("scrape %d: %v", scrape, err)
+		}
+	}
+}
+
+func TestServeHTTPLastScrapeSuccess(t *testing.T) {
+	r := prometheus.NewRegistry()
+	m := New(options.NewOptions(), nil, nil, false)
+	m.WithMetrics(r)
+	m.metricsWriters = metricsstore.MetricsWriterList{
+		metricsstore.NewResourceMetricsWriter("pods", metricsstore.NewMetricsStore([]string{"# HELP kube_pod_test Test metric.\n# TYPE kube_pod_test gauge"}, nil)),
+	}
+
+	if got := testutil.ToFloat64(m.lastScrape); got != 0 {
+		t.Fatalf("expected no successful scrape before the first scrape, got %v", got)
+	}
+
+	// Writing the metrics for a remote-write push is not a scrape.
+	m.WriteMetrics(io.Discard)
+	if got := testutil.ToFloat64(m.lastScrape); got != 0 {
+		t.Fatalf("expected no successful scrape after writing the metrics for a push, got %v", got)
+	}
+
+	before := float64(time.Now().UnixNano()) / 1e9
+	m.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	after := float64(time.Now().UnixNano()) / 1e9
+
+	if got := testutil.ToFloat64(m.lastScrape); got < before || got > after {
+		t.Errorf("expected the last successful scrape between %v and %v, got %v", before, after, got)
+	}
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
+
+func TestServeHTTPExemplars(t *testing.T) {
+	genFunc := func(_ interface{}) []metric.FamilyInterface {
+		return []metric.FamilyInterface{&metric.Family{
+			Name: "kube_pod_test_total",
+			Type: metric.Counter,
+			Metrics: []*metric.Metric{{
+				LabelKeys:   []string{"pod"},
+				LabelValues: []string{"foo"},
+				Value:       3,
+				Exemplar: &metric.Exemplar{
+					LabelKeys:   []string{"uid"},
+					LabelValues: []string{"abc-123"},
+					Value:       1,
+				},
+			}},
+		}}
+	}
+	store := metricsstore.NewMetricsStore([]string{"# HELP kube_pod_test_total Test metric.\n# TYPE kube_pod_test_total counter"}, genFunc)
+	if err := store.Add(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "foo", UID: "foo"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := New(options.NewOptions(), nil, nil, false)
+	m.metricsWriters = metricsstore.MetricsWriterList{
+		metricsstore.NewResourceMetricsWriter("pods", store),
+	}
+
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{
+			name:   "OpenMetrics",
+			accept: "application/openmetrics-text;version=1.0.0",
+			want:   `kube_pod_test_total{pod="foo"} 3 # {uid="abc-123"} 1` + "\n",
+		},
+		{
+			name: "text",
+			want: `kube_pod_test_total{pod="foo"} 3` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if test.accept != "" {
+				req.Header.Set("Accept", test.accept)
+			}
+			rec := httptest.NewRecorder()
+			m.ServeHTTP(rec, req)
+			if !strings.Contains(rec.Body.String(), test.want) {
+				t.Errorf("expected %q in response body, got:\n%s", test.want, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package metricsprofile provides profiles which restrict the exposed metric
+// families to a predefined subset.
+package metricsprofile
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+)
+
+const (
+	// Full exposes all metric families.
+	Full = "full"
+	// Metadata only exposes the info, labels, annotations and created metric families,
+	// which describe the inventory of objects rather than their spec or status.
+	Metadata = "metadata"
+)
+
+// Profiles lists the available profiles.
+var Profiles = []string{Full, Metadata}
+
+var metadataFamilies = regexp.MustCompile(`^kube_.+_(info|labels|annotations|created)$`)
+
+// MetricFamilyFilter filters metric families which are not part of a profile.
+type MetricFamilyFilter struct {
+	profile string
+}
+
+// Test returns true if the given generator is part of the profile of the filter.
+func (filter MetricFamilyFilter) Test(generator generator.FamilyGenerator) bool {
+	if filter.profile == Metadata {
+		return metadataFamilies.MatchString(generator.Name)
+	}
+	return true
+}
+
+// NewMetricFamilyFilter creates a new MetricFamilyFilter for the given profile.
+// An empty profile defaults to the Full profile.
+func NewMetricFamilyFilter(profile string) (*MetricFamilyFilter, error) {
+	if profile == "" {
+		profile = Full
+	}
+	if err := Validate(profile); err != nil {
+		return nil, err
+	}
+	return &MetricFamilyFilter{profile: profile}, nil
+}
+
+// Validate returns an error if the given profile does not exist.
+func Validate(profile string) error {
+	for _, p := range Profiles {
+		if p == profile {
+			return nil
+		}
+	}
+	return fmt.Errorf("metrics profile %q does not exist. Available profiles: %s", profile, strings.Join(Profiles, ","))
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricsprofile
+
+import (
+	"testing"
+
+	basemetrics "k8s.io/component-base/metrics"
+
+	"k8s.io/kube-state-metrics/v2/pkg/metric"
+	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
+)
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		MetricFamily string
+		Profile      string
+		Want         bool
+	}{
+		{"kube_pod_info", Metadata, true},
+		{"kube_pod_labels", Metadata, true},
+		{"kube_pod_annotations", Metadata, true},
+		{"kube_pod_created", Metadata, true},
+		{"kube_pod_container_info", Metadata, true},
+		{"kube_pod_status_phase", Metadata, false},
+		{"kube_deployment_spec_replicas", Metadata, false},
+		{"kube_pod_container_status_restarts_total", Metadata, false},
+		{"kube_pod_status_phase", Full, true},
+		{"kube_pod_info", Full, true},
+	}
+
+	for _, test := range tests {
+		filter, err := NewMetricFamilyFilter(test.Profile)
+		if err != nil {
+			t.Fatalf("did not expect NewMetricFamilyFilter to fail, the error is %v", err)
+		}
+
+		familyGenerator := *generator.NewFamilyGeneratorWithStability(
+			test.MetricFamily,
+			"",
+			metric.Gauge,
+			basemetrics.ALPHA,
+			"",
+			func(_ interface{}) *metric.Family {
+				return nil
+			},
+		)
+
+		if got := filter.Test(familyGenerator); got != test.Want {
+			t.Errorf("the metric family %s in profile %s: want %t, got %t", test.MetricFamily, test.Profile, test.Want, got)
+		}
+	}
+}
+
+func TestNewMetricFamilyFilterUnknownProfile(t *testing.T) {
+	if _, err := NewMetricFamilyFilter("minimal"); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/prometheus/common/version"
 	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
+
+	"k8s.io/kube-state-metrics/v2/pkg/metricsprofile"
 )
 
 var (
@@ -42,6 +44,7 @@ var (
 type Options struct {
 	AnnotationsAllowList LabelsAllowList `yaml:"annotations_allow_list"`
 	LabelsAllowList      LabelsAllowList `yaml:"labels_allow_list"`
+	LabelsDenyList       LabelsAllowList `yaml:"labels_deny_list"`
 	MetricAllowlist      MetricSet       `yaml:"metric_allowlist"`
 	MetricDenylist       MetricSet       `yaml:"metric_denylist"`
 	MetricOptInList      MetricSet       `yaml:"metric_opt_in_list"`
@@ -53,32 +56,57 @@ type Options struct {
 	CustomResourceConfigFile string   `yaml:"custom_resource_config_file"`
 	Host                     string   `yaml:"host"`
 	Kubeconfig               string   `yaml:"kubeconfig"`
+	KubeconfigContexts       []string `yaml:"kubeconfig_contexts"`
+	MetricsConfig            string   `yaml:"metrics_config"`
+	MetricsPath              string   `yaml:"metrics_path"`
+	MetricsProfile           string   `yaml:"metrics_profile"`
 	Namespace                string   `yaml:"namespace"`
 	Node                     NodeType `yaml:"node"`
+	ObjectNameDaemonSets     string   `yaml:"object_name_daemonsets"`
+	ObjectNameDeployments    string   `yaml:"object_name_deployments"`
+	ObjectNamePods           string   `yaml:"object_name_pods"`
+	ObjectNameStatefulSets   string   `yaml:"object_name_statefulsets"`
 	Pod                      string   `yaml:"pod"`
+	PodLabelSelector         string   `yaml:"selector_pods"`
+	RemoteWriteURL           string   `yaml:"remote_write_url"`
 	TLSConfig                string   `yaml:"tls_config"`
 	TelemetryHost            string   `yaml:"telemetry_host"`
+	WebBearerTokenFile       string   `yaml:"web_bearer_token_file"`
+	WebConfigFile            string   `yaml:"web_config_file"`
 
 	Config string
 
+	RemoteWriteHeaders map[string]string `yaml:"remote_write_headers"`
+	// MetricOverrides can only be set in the options config file.
+	MetricOverrides MetricOverrides `yaml:"metric_overrides"`
+
 	Namespaces              NamespaceList `yaml:"namespaces"`
 	NamespacesDenylist      NamespaceList `yaml:"namespaces_denylist"`
 	AutoGoMemlimitRatio     float64       `yaml:"auto-gomemlimit-ratio"`
+	MaxSeriesPerFamily      int           `yaml:"max_series_per_family"`
+	ListChunkSize           int64         `yaml:"list_chunk_size"`
 	Port                    int           `yaml:"port"`
 	TelemetryPort           int           `yaml:"telemetry_port"`
 	TotalShards             int           `yaml:"total_shards"`
+	RemoteWriteInterval     time.Duration `yaml:"remote_write_interval"`
 	ServerReadTimeout       time.Duration `yaml:"server_read_timeout"`
 	ServerWriteTimeout      time.Duration `yaml:"server_write_timeout"`
 	ServerIdleTimeout       time.Duration `yaml:"server_idle_timeout"`
 	ServerReadHeaderTimeout time.Duration `yaml:"server_read_header_timeout"`
 
-	Shard                int32 `yaml:"shard"`
-	AutoGoMemlimit       bool  `yaml:"auto-gomemlimit"`
-	CustomResourcesOnly  bool  `yaml:"custom_resources_only"`
-	EnableGZIPEncoding   bool  `yaml:"enable_gzip_encoding"`
-	Help                 bool  `yaml:"help"`
-	TrackUnscheduledPods bool  `yaml:"track_unscheduled_pods"`
-	UseAPIServerCache    bool  `yaml:"use_api_server_cache"`
+	Shard                       int32 `yaml:"shard"`
+	AutoGoMemlimit              bool  `yaml:"auto-gomemlimit"`
+	CustomResourcesOnly         bool  `yaml:"custom_resources_only"`
+	DisableExponentialNotation  bool  `yaml:"disable_exponential_notation"`
+	DisableUIDLabel             bool  `yaml:"disable_uid_label"`
+	DropCompletedInitContainers bool  `yaml:"drop_completed_init_containers"`
+	EnableDeltaEndpoint         bool  `yaml:"enable_delta_endpoint"`
+	EnableGZIPEncoding          bool  `yaml:"enable_gzip_encoding"`
+	Help                        bool  `yaml:"help"`
+	SortMetrics                 bool  `yaml:"sort_metrics"`
+	TrackUnscheduledPods        bool  `yaml:"track_unscheduled_pods"`
+	UseAPIServerCache           bool  `yaml:"use_api_server_cache"`
+	ValidateConfig              bool  `yaml:"validate_config"`
 }
 
 // GetConfigFile is the getter for --config value.
@@ -95,6 +123,7 @@ func NewOptions() *Options {
 		MetricOptInList:      MetricSet{},
 		AnnotationsAllowList: LabelsAllowList{},
 		LabelsAllowList:      LabelsAllowList{},
+		LabelsDenyList:       LabelsAllowList{},
 	}
 }
 
@@ -139,11 +168,19 @@ func (o *Options) AddFlags(cmd *cobra.Command) {
 	autoshardingNotice := "When set, it is expected that --pod and --pod-namespace are both set. Most likely this should be passed via the downward API. This is used for auto-detecting sharding. If set, this has preference over statically configured sharding. This is experimental, it may be removed without notice."
 
 	o.cmd.Flags().BoolVar(&o.CustomResourcesOnly, "custom-resource-state-only", false, "Only provide Custom Resource State metrics (experimental)")
+	o.cmd.Flags().BoolVar(&o.DisableExponentialNotation, "disable-exponential-notation", false, "Render metric values such as timestamps with full precision and without exponential notation, e.g. 1501779547 instead of 1.501779547e+09.")
+	o.cmd.Flags().BoolVar(&o.DisableUIDLabel, "disable-uid-label", false, "Drop the uid label from the metrics of pods, services and service accounts. The uid changes whenever an object is recreated under the same name, so dropping it reduces the cardinality of the metrics.")
+	o.cmd.Flags().BoolVar(&o.DropCompletedInitContainers, "drop-completed-init-containers", false, "Drop the init container metrics of pods for init containers which terminated successfully. Completed init containers otherwise keep their series for the whole lifetime of long-running pods.")
+	o.cmd.Flags().BoolVar(&o.EnableDeltaEndpoint, "enable-delta-endpoint", false, "Expose the series of the objects added, updated or deleted since the last read of the endpoint at the metrics path suffixed with /delta, e.g. /metrics/delta. Meant for a single consumer which reconstructs the full state, in very large clusters. Tracking the changes adds memory overhead. This is experimental.")
 	o.cmd.Flags().BoolVar(&o.EnableGZIPEncoding, "enable-gzip-encoding", false, "Gzip responses when requested by clients via 'Accept-Encoding: gzip' header.")
 	o.cmd.Flags().BoolVar(&o.TrackUnscheduledPods, "track-unscheduled-pods", false, "This configuration is used in conjunction with node configuration. When this configuration is true, node configuration is empty and the metric of unscheduled pods is fetched from the Kubernetes API Server. This is experimental.")
 	o.cmd.Flags().BoolVarP(&o.Help, "help", "h", false, "Print Help text")
+	o.cmd.Flags().BoolVar(&o.SortMetrics, "sort-metrics", false, "Sort the series within each metric family by their labels before writing them out, producing a stable output order. This adds CPU and memory overhead to every scrape.")
 	o.cmd.Flags().BoolVarP(&o.UseAPIServerCache, "use-apiserver-cache", "", false, "Sets resourceVersion=0 for ListWatch requests, using cached resources from the apiserver instead of an etcd quorum read.")
+	o.cmd.Flags().BoolVar(&o.ValidateConfig, "validate-config", false, "Validate the custom resource state configuration and the metric allow, deny and opt-in lists, then exit without connecting to a cluster. Exits with a non-zero status code if the configuration is invalid.")
 	o.cmd.Flags().Int32Var(&o.Shard, "shard", int32(0), "The instances shard nominal (zero indexed) within the total number of shards. (default 0)")
+	o.cmd.Flags().Int64Var(&o.ListChunkSize, "list-chunk-size", 0, "The maximum number of objects listed per request when listing a resource, e.g. 500. Paginating the lists of large resources reduces the load on the apiserver and the memory spikes of kube-state-metrics at startup. 0 uses the default page size of client-go.")
+	o.cmd.Flags().IntVar(&o.MaxSeriesPerFamily, "max-series-per-family", 0, "The maximum number of series of a metric family per scrape. Metric families with more series are dropped from the scrape entirely and counted in kube_state_metrics_family_dropped_total, protecting Prometheus from runaway cardinality. 0 means no limit.")
 	o.cmd.Flags().IntVar(&o.Port, "port", 8080, `Port to expose metrics on.`)
 	o.cmd.Flags().IntVar(&o.TelemetryPort, "telemetry-port", 8081, `Port to expose kube-state-metrics self metrics on.`)
 	o.cmd.Flags().IntVar(&o.TotalShards, "total-shards", 1, "The total number of shards. Sharding is disabled when total shards is set to 1.")
@@ -152,23 +189,38 @@ func (o *Options) AddFlags(cmd *cobra.Command) {
 	o.cmd.Flags().Float64Var(&o.AutoGoMemlimitRatio, "auto-gomemlimit-ratio", float64(0.9), "The ratio of reserved GOMEMLIMIT memory to the detected maximum container or system memory. (experimental)")
 	o.cmd.Flags().StringVar(&o.CustomResourceConfig, "custom-resource-state-config", "", "Inline Custom Resource State Metrics config YAML (experimental)")
 	o.cmd.Flags().StringVar(&o.CustomResourceConfigFile, "custom-resource-state-config-file", "", "Path to a Custom Resource State Metrics config file (experimental)")
-	o.cmd.Flags().StringVar(&o.Host, "host", "::", `Host to expose metrics on.`)
+	o.cmd.Flags().StringVar(&o.Host, "host", "::", `Host to expose metrics on. IPv6 addresses may be given with or without brackets. The default '::' listens on all IPv4 and IPv6 addresses on dual-stack hosts.`)
 	o.cmd.Flags().StringVar(&o.Kubeconfig, "kubeconfig", "", "Absolute path to the kubeconfig file")
+	o.cmd.Flags().StringSliceVar(&o.KubeconfigContexts, "kubeconfig-context", nil, "Comma-separated list of contexts of the kubeconfig file to use instead of its current context. If more than one context is given, kube-state-metrics runs in multi-cluster mode and exposes the metrics of all of their clusters, each carrying a cluster label set to the name of its context. Custom resource state metrics are not supported in multi-cluster mode.")
+	o.cmd.Flags().StringVar(&o.ObjectNameDaemonSets, "object-name-daemonsets", "", "Name of the only daemonset to list and watch, e.g. for debugging a single object. A metadata.name field selector is applied when listing and watching daemonsets. This composes with the namespace and node filters.")
+	o.cmd.Flags().StringVar(&o.ObjectNameDeployments, "object-name-deployments", "", "Name of the only deployment to list and watch, e.g. for debugging a single object. A metadata.name field selector is applied when listing and watching deployments. This composes with the namespace and node filters.")
+	o.cmd.Flags().StringVar(&o.ObjectNamePods, "object-name-pods", "", "Name of the only pod to list and watch, e.g. for debugging a single object. A metadata.name field selector is applied when listing and watching pods. This composes with the namespace and node filters.")
+	o.cmd.Flags().StringVar(&o.ObjectNameStatefulSets, "object-name-statefulsets", "", "Name of the only statefulset to list and watch, e.g. for debugging a single object. A metadata.name field selector is applied when listing and watching statefulsets. This composes with the namespace and node filters.")
 	o.cmd.Flags().StringVar(&o.Namespace, "pod-namespace", "", "Name of the namespace of the pod specified by --pod. "+autoshardingNotice)
 	o.cmd.Flags().StringVar(&o.Pod, "pod", "", "Name of the pod that contains the kube-state-metrics container. "+autoshardingNotice)
-	o.cmd.Flags().StringVar(&o.TLSConfig, "tls-config", "", "Path to the TLS configuration file")
-	o.cmd.Flags().StringVar(&o.TelemetryHost, "telemetry-host", "::", `Host to expose kube-state-metrics self metrics on.`)
+	o.cmd.Flags().StringVar(&o.PodLabelSelector, "selector-pods", "", "Label selector applied when listing and watching pods, e.g. 'app in (a,b)'. Only matching pods are exposed. This composes with the namespace and node filters.")
+	o.cmd.Flags().StringVar(&o.RemoteWriteURL, "remote-write-url", "", "URL of a Prometheus remote-write endpoint to periodically push the metrics to. Pushing is disabled if empty. This is experimental.")
+	o.cmd.Flags().StringToStringVar(&o.RemoteWriteHeaders, "remote-write-headers", nil, "Comma-separated list of HTTP headers to send with every remote-write request, e.g. for authentication (Example: 'Authorization=Bearer <token>').")
+	o.cmd.Flags().StringVar(&o.TLSConfig, "tls-config", "", "Path to the TLS configuration file. Deprecated: use --web.config-file instead.")
+	o.cmd.Flags().StringVar(&o.WebBearerTokenFile, "web.bearer-token-file", "", "Path to a file containing a bearer token which clients have to send in the 'Authorization' header to access the metrics and telemetry servers. Can not be combined with basic_auth_users in --web.config-file.")
+	o.cmd.Flags().StringVar(&o.WebConfigFile, "web.config-file", "", "Path to the web configuration file, configuring TLS and basic authentication via basic_auth_users. See https://github.com/prometheus/exporter-toolkit/blob/master/docs/web-configuration.md. Takes precedence over --tls-config.")
+	o.cmd.Flags().StringVar(&o.TelemetryHost, "telemetry-host", "::", `Host to expose kube-state-metrics self metrics on. IPv6 addresses may be given with or without brackets. The default '::' listens on all IPv4 and IPv6 addresses on dual-stack hosts.`)
 	o.cmd.Flags().StringVar(&o.Config, "config", "", "Path to the kube-state-metrics options config file")
 	o.cmd.Flags().StringVar((*string)(&o.Node), "node", "", "Name of the node that contains the kube-state-metrics pod. Most likely it should be passed via the downward API. This is used for daemonset sharding. Only available for resources (pod metrics) that support spec.nodeName fieldSelector. This is experimental.")
 	o.cmd.Flags().Var(&o.AnnotationsAllowList, "metric-annotations-allowlist", "Comma-separated list of Kubernetes annotations keys that will be used in the resource' labels metric. By default the annotations metrics are not exposed. To include them, provide a list of resource names in their plural form and Kubernetes annotation keys you would like to allow for them (Example: '=namespaces=[kubernetes.io/team,...],pods=[kubernetes.io/team],...)'. A single '*' can be provided per resource instead to allow any annotations, but that has severe performance implications (Example: '=pods=[*]').")
+	o.cmd.Flags().Var(&o.LabelsDenyList, "metric-labels-denylist", "Comma-separated list of Kubernetes label keys that will never be used in the resource' labels metric, even if they are allowed by a wildcard in --metric-labels-allowlist. The format is the same as for --metric-labels-allowlist, without support for the '*' label key (Example: '=pods=[pod-template-hash],statefulsets=[controller-revision-hash]').")
 	o.cmd.Flags().Var(&o.LabelsAllowList, "metric-labels-allowlist", "Comma-separated list of additional Kubernetes label keys that will be used in the resource' labels metric. By default the labels metrics are not exposed. To include them, provide a list of resource names in their plural form and Kubernetes label keys you would like to allow for them (Example: '=namespaces=[k8s-label-1,k8s-label-n,...],pods=[app],...)'. A single '*' can be provided per resource instead to allow any labels, but that has severe performance implications (Example: '=pods=[*]'). Additionally, an asterisk (*) can be provided as a key, which will resolve to all resources, i.e., assuming '--resources=deployments,pods', '=*=[*]' will resolve to '=deployments=[*],pods=[*]'.")
 	o.cmd.Flags().Var(&o.MetricAllowlist, "metric-allowlist", "Comma-separated list of metrics to be exposed. This list comprises of exact metric names and/or regex patterns. The allowlist and denylist are mutually exclusive.")
 	o.cmd.Flags().Var(&o.MetricDenylist, "metric-denylist", "Comma-separated list of metrics not to be enabled. This list comprises of exact metric names and/or regex patterns. The allowlist and denylist are mutually exclusive.")
+	o.cmd.Flags().StringVar(&o.MetricsConfig, "metrics-config", "", "Path to a metrics config file listing the names of exactly the metric families to be exposed under its 'metrics' key. Listed opt-in metric families are enabled as well. Unknown metric families fail the startup, unless custom resource state metrics are configured. This is in addition to the metric allow- and denylists.")
+	o.cmd.Flags().StringVar(&o.MetricsPath, "metrics-path", "/metrics", "Path to expose metrics on. The health endpoints are always exposed at the root.")
+	o.cmd.Flags().StringVar(&o.MetricsProfile, "metrics-profile", metricsprofile.Full, fmt.Sprintf("Profile restricting the exposed metric families, one of %s. The %q profile only exposes the kube_*_info, kube_*_labels, kube_*_annotations and kube_*_created metric families. This is in addition to the metric allow- and denylists.", strings.Join(metricsprofile.Profiles, ","), metricsprofile.Metadata))
 	o.cmd.Flags().Var(&o.MetricOptInList, "metric-opt-in-list", "Comma-separated list of metrics which are opt-in and not enabled by default. This is in addition to the metric allow- and denylists")
 	o.cmd.Flags().Var(&o.Namespaces, "namespaces", fmt.Sprintf("Comma-separated list of namespaces to be enabled. Defaults to %q", &DefaultNamespaces))
 	o.cmd.Flags().Var(&o.NamespacesDenylist, "namespaces-denylist", "Comma-separated list of namespaces not to be enabled. If namespaces and namespaces-denylist are both set, only namespaces that are excluded in namespaces-denylist will be used.")
 	o.cmd.Flags().Var(&o.Resources, "resources", fmt.Sprintf("Comma-separated list of Resources to be enabled. Defaults to %q", &DefaultResources))
 
+	o.cmd.Flags().DurationVar(&o.RemoteWriteInterval, "remote-write-interval", time.Minute, "The interval at which the metrics are pushed to --remote-write-url.")
 	o.cmd.Flags().DurationVar(&o.ServerReadTimeout, "server-read-timeout", defaultServerReadTimeout, "The maximum duration for reading the entire request, including the body. Align with the scrape interval or timeout of scraping clients. ")
 	o.cmd.Flags().DurationVar(&o.ServerWriteTimeout, "server-write-timeout", defaultServerWriteTimeout, "The maximum duration before timing out writes of the response. Align with the scrape interval or timeout of scraping clients..")
 	o.cmd.Flags().DurationVar(&o.ServerIdleTimeout, "server-idle-timeout", defaultServerIdleTimeout, "The maximum amount of time to wait for the next request when keep-alives are enabled. Align with the idletimeout of your scrape clients.")
@@ -188,6 +240,33 @@ func (o *Options) Usage() {
 
 // Validate validates arguments
 func (o *Options) Validate() error {
+	if o.MetricsProfile != "" {
+		if err := metricsprofile.Validate(o.MetricsProfile); err != nil {
+			return err
+		}
+	}
+
+	switch o.MetricsPath {
+	case "/", "/healthz", "/livez", "/readyz",
+		"/debug/pprof/", "/debug/pprof/cmdline", "/debug/pprof/profile", "/debug/pprof/symbol", "/debug/pprof/trace":
+		return fmt.Errorf("value for --metrics-path=%s conflicts with another endpoint", o.MetricsPath)
+	}
+	if !strings.HasPrefix(o.MetricsPath, "/") {
+		return fmt.Errorf("value for --metrics-path=%s must start with a slash", o.MetricsPath)
+	}
+
+	if o.ListChunkSize < 0 {
+		return fmt.Errorf("value for --list-chunk-size=%d must not be negative", o.ListChunkSize)
+	}
+
+	if o.MaxSeriesPerFamily < 0 {
+		return fmt.Errorf("value for --max-series-per-family=%d must not be negative", o.MaxSeriesPerFamily)
+	}
+
+	if o.RemoteWriteURL != "" && o.RemoteWriteInterval <= 0 {
+		return fmt.Errorf("value for --remote-write-interval=%s must be greater than 0", o.RemoteWriteInterval)
+	}
+
 	shardableResource := "pods"
 	if o.Node == "" {
 		return nil
@@ -63,3 +63,67 @@ func TestOptionsParse(t *testing.T) {
 		})
 	}
 }
+
+func TestOptionsValidateMetricsPath(t *testing.T) {
+	tests := []struct {
+		Desc         string
+		MetricsPath  string
+		ExpectsError bool
+	}{
+		{
+			Desc:        "default metrics path",
+			MetricsPath: "/metrics",
+		},
+		{
+			Desc:        "custom metrics path",
+			MetricsPath: "/kube-metrics",
+		},
+		{
+			Desc:         "root path",
+			MetricsPath:  "/",
+			ExpectsError: true,
+		},
+		{
+			Desc:         "healthz path",
+			MetricsPath:  "/healthz",
+			ExpectsError: true,
+		},
+		{
+			Desc:         "livez path",
+			MetricsPath:  "/livez",
+			ExpectsError: true,
+		},
+		{
+			Desc:         "readyz path",
+			MetricsPath:  "/readyz",
+			ExpectsError: true,
+		},
+		{
+			Desc:         "pprof path",
+			MetricsPath:  "/debug/pprof/",
+			ExpectsError: true,
+		},
+		{
+			Desc:         "relative path",
+			MetricsPath:  "metrics",
+			ExpectsError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Desc, func(t *testing.T) {
+			opts := NewOptions()
+			opts.MetricsPath = test.MetricsPath
+
+			err := opts.Validate()
+
+			if !test.ExpectsError && err != nil {
+				t.Errorf("Error for test with description: %s: %v", test.Desc, err.Error())
+			}
+
+			if test.ExpectsError && err == nil {
+				t.Errorf("Expected error for test with description: %s", test.Desc)
+			}
+		})
+	}
+}
@@ -18,6 +18,9 @@ package options
 
 import (
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 
@@ -315,3 +318,35 @@ func (l *LabelsAllowList) String() string {
 func (l *LabelsAllowList) Type() string {
 	return "string"
 }
+
+// MetricOverride overrides the help text and unit of a metric family.
+type MetricOverride struct {
+	Help string `yaml:"help"`
+	Unit string `yaml:"unit"`
+}
+
+// MetricOverrides maps the names of metric families to their overrides.
+type MetricOverrides map[string]MetricOverride
+
+// Validate returns an error listing the overridden metric families which are not among the
+// given known metric families, and the units which are not a suffix of their metric family name.
+func (o MetricOverrides) Validate(known []string) error {
+	knownSet := make(map[string]struct{}, len(known))
+	for _, k := range known {
+		knownSet[k] = struct{}{}
+	}
+	var errs []error
+	unknown := []string{}
+	for _, name := range slices.Sorted(maps.Keys(o)) {
+		if _, ok := knownSet[name]; !ok {
+			unknown = append(unknown, name)
+		}
+		if unit := o[name].Unit; unit != "" && !strings.HasSuffix(name, "_"+unit) {
+			errs = append(errs, fmt.Errorf("unit %q of metric family %s must be a suffix of its name", unit, name))
+		}
+	}
+	if len(unknown) > 0 {
+		errs = append([]error{fmt.Errorf("unknown metric families in metric overrides: %s", strings.Join(unknown, ","))}, errs...)
+	}
+	return errors.Join(errs...)
+}
@@ -392,3 +392,46 @@ func TestLabelsAllowListSet(t *testing.T) {
 		}
 	}
 }
+
+func TestMetricOverridesValidate(t *testing.T) {
+	known := []string{"kube_pod_info", "kube_pod_start_time", "kube_pod_container_resource_requests"}
+	tests := []struct {
+		Desc      string
+		Overrides MetricOverrides
+		WantErr   string
+	}{
+		{
+			Desc: "known metric families",
+			Overrides: MetricOverrides{
+				"kube_pod_info":       {Help: "Pod information."},
+				"kube_pod_start_time": {Unit: "time"},
+			},
+		},
+		{
+			Desc: "unknown metric families",
+			Overrides: MetricOverrides{
+				"kube_pod_info": {Help: "Pod information."},
+				"kube_pod_inf":  {Help: "Pod information."},
+				"kube_pod_fo":   {Help: "Pod information."},
+			},
+			WantErr: "unknown metric families in metric overrides: kube_pod_fo,kube_pod_inf",
+		},
+		{
+			Desc: "unit which is not a suffix",
+			Overrides: MetricOverrides{
+				"kube_pod_container_resource_requests": {Unit: "bytes"},
+			},
+			WantErr: `unit "bytes" of metric family kube_pod_container_resource_requests must be a suffix of its name`,
+		},
+	}
+
+	for _, test := range tests {
+		err := test.Overrides.Validate(known)
+		if test.WantErr == "" && err != nil {
+			t.Errorf("Test error for Desc: %s. Got Error: %v", test.Desc, err)
+		}
+		if test.WantErr != "" && (err == nil || err.Error() != test.WantErr) {
+			t.Errorf("Test error for Desc: %s. Want Error: %s. Got Error: %v", test.Desc, test.WantErr, err)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"hash/fnv"
 
 	jump "github.com/dgryski/go-jump"
+	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -28,20 +29,32 @@ import (
 )
 
 type shardedListWatch struct {
-	sharding *sharding
-	lw       cache.ListerWatcher
+	sharding   *sharding
+	lw         cache.ListerWatcher
+	shardedOut prometheus.Counter
 }
 
 // NewShardedListWatch returns a new shardedListWatch via the cache.ListerWatcher interface.
-// In the case of no sharding needed, it returns the provided cache.ListerWatcher
+// In the case of no sharding needed, it returns the provided cache.ListerWatcher.
 func NewShardedListWatch(shard int32, totalShards int, lw cache.ListerWatcher) cache.ListerWatcher {
+	return NewInstrumentedShardedListWatch(shard, totalShards, lw, nil, "")
+}
+
+// NewInstrumentedShardedListWatch returns a new shardedListWatch like NewShardedListWatch,
+// which counts the listed objects and watch events of the given resource dropped because
+// they belong to another shard in kube_state_metrics_sharded_out_total, if m is not nil.
+func NewInstrumentedShardedListWatch(shard int32, totalShards int, lw cache.ListerWatcher, m *Metrics, resource string) cache.ListerWatcher {
 	// This is an "optimization" as this configuration means no sharding is to
 	// be performed.
 	if shard == 0 && totalShards == 1 {
 		return lw
 	}
 
-	return &shardedListWatch{sharding: &sharding{shard: shard, totalShards: totalShards}, lw: lw}
+	s := &shardedListWatch{sharding: &sharding{shard: shard, totalShards: totalShards}, lw: lw}
+	if m != nil {
+		s.shardedOut = m.ShardedOut.WithLabelValues(resource)
+	}
+	return s
 }
 
 func (s *shardedListWatch) List(options metav1.ListOptions) (runtime.Object, error) {
@@ -67,6 +80,8 @@ func (s *shardedListWatch) List(options metav1.ListOptions) (runtime.Object, err
 		}
 		if s.sharding.keep(a) {
 			res.Items = append(res.Items, runtime.RawExtension{Object: item})
+		} else {
+			s.dropped()
 		}
 	}
 	res.ListMeta.ResourceVersion = metaObj.GetResourceVersion()
@@ -87,10 +102,20 @@ func (s *shardedListWatch) Watch(options metav1.ListOptions) (watch.Interface, e
 			return in, true
 		}
 
-		return in, s.sharding.keep(a)
+		if !s.sharding.keep(a) {
+			s.dropped()
+			return in, false
+		}
+		return in, true
 	}), nil
 }
 
+func (s *shardedListWatch) dropped() {
+	if s.shardedOut != nil {
+		s.shardedOut.Inc()
+	}
+}
+
 type sharding struct {
 	shard       int32
 	totalShards int
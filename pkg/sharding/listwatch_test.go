@@ -17,11 +17,18 @@ limitations under the License.
 package sharding
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
 )
 
 func TestSharding(t *testing.T) {
@@ -54,3 +61,85 @@ func TestSharding(t *testing.T) {
 		t.Fatal("Shard two should not pick up the object.")
 	}
 }
+
+func TestShardedListWatchShardedOut(t *testing.T) {
+	list := &v1.ConfigMapList{}
+	for i := 0; i < 10; i++ {
+		list.Items = append(list.Items, v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("configmap%d", i),
+				Namespace: "ns1",
+				UID:       types.UID(fmt.Sprintf("uid%d", i)),
+			},
+		})
+	}
+	watcher := watch.NewFake()
+	lw := &cache.ListWatch{
+		ListFunc: func(_ metav1.ListOptions) (runtime.Object, error) {
+			return list, nil
+		},
+		WatchFunc: func(_ metav1.ListOptions) (watch.Interface, error) {
+			return watcher, nil
+		},
+	}
+
+	// The builder labels the counter with the type of the listed objects.
+	resource := "*v1.ConfigMap"
+	kept := 0
+	var m0 *Metrics
+	for shard := int32(0); shard < 2; shard++ {
+		// Every shard is a separate kube-state-metrics instance with its own registry.
+		m := NewShardingMetrics(prometheus.NewRegistry())
+		if shard == 0 {
+			m0 = m
+		}
+		res, err := NewInstrumentedShardedListWatch(shard, 2, lw, m, resource).List(metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		items, err := meta.ExtractList(res)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dropped := testutil.ToFloat64(m.ShardedOut.WithLabelValues(resource)); int(dropped) != len(list.Items)-len(items) {
+			t.Errorf("shard %d: expected %d dropped objects, got %v", shard, len(list.Items)-len(items), dropped)
+		}
+		kept += len(items)
+	}
+	if kept != len(list.Items) {
+		t.Errorf("expected the shards to keep %d objects in total, got %d", len(list.Items), kept)
+	}
+
+	// Watch events of objects of the other shard are added to the same series.
+	listDropped := testutil.ToFloat64(m0.ShardedOut.WithLabelValues(resource))
+	w, err := NewInstrumentedShardedListWatch(0, 2, lw, m0, resource).Watch(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &sharding{shard: 0, totalShards: 2}
+	wantDropped := 0
+	go func() {
+		for i := range list.Items {
+			watcher.Modify(&list.Items[i])
+		}
+		watcher.Stop()
+	}()
+	for i := range list.Items {
+		if !s.keep(&list.Items[i]) {
+			wantDropped++
+		}
+	}
+	for range w.ResultChan() {
+	}
+	if dropped := testutil.ToFloat64(m0.ShardedOut.WithLabelValues(resource)) - listDropped; int(dropped) != wantDropped {
+		t.Errorf("expected %d dropped watch events, got %v", wantDropped, dropped)
+	}
+	if n := testutil.CollectAndCount(m0.ShardedOut); n != 1 {
+		t.Errorf("expected a single series labelled with resource %q, got %d", resource, n)
+	}
+
+	// NewShardedListWatch shards without counting the dropped objects.
+	if _, err := NewShardedListWatch(0, 2, lw).List(metav1.ListOptions{}); err != nil {
+		t.Fatal(err)
+	}
+}
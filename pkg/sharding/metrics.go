@@ -26,11 +26,12 @@ const (
 	LabelOrdinal = "shard_ordinal"
 )
 
-// Metrics stores the pointers of kube_state_metrics_shard_ordinal
-// and kube_state_metrics_total_shards metrics.
+// Metrics stores the pointers of kube_state_metrics_shard_ordinal,
+// kube_state_metrics_total_shards and kube_state_metrics_sharded_out_total metrics.
 type Metrics struct {
-	Ordinal *prometheus.GaugeVec
-	Total   prometheus.Gauge
+	Ordinal    *prometheus.GaugeVec
+	Total      prometheus.Gauge
+	ShardedOut *prometheus.CounterVec
 }
 
 // NewShardingMetrics takes in a prometheus registry and initializes
@@ -49,5 +50,11 @@ func NewShardingMetrics(r prometheus.Registerer) *Metrics {
 				Help: "Number of total shards this instance is aware of",
 			},
 		),
+		ShardedOut: promauto.With(r).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "kube_state_metrics_sharded_out_total",
+				Help: "Number of listed objects and watch events dropped because the object belongs to another shard",
+			}, []string{"resource"},
+		),
 	}
 }
@@ -27,6 +27,7 @@ import (
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/klog/v2"
 	testUnstructuredMock "k8s.io/sample-controller/pkg/apis/samplecontroller/v1alpha1"
 
@@ -39,6 +40,28 @@ import (
 var config *rest.Config
 var currentKubeClient clientset.Interface
 var currentDiscoveryClient *discovery.DiscoveryClient
+var kubeconfigContext string
+
+// SetKubeconfigContext sets the context of the kubeconfig file the clients are created for.
+// It must be called before any client is created.
+func SetKubeconfigContext(context string) {
+	kubeconfigContext = context
+}
+
+// BuildConfig builds a rest config from the given apiserver URL and kubeconfig file,
+// using the given context of the kubeconfig file instead of its current one if set.
+func BuildConfig(apiserver string, kubeconfig string, context string) (*rest.Config, error) {
+	if context == "" {
+		return clientcmd.BuildConfigFromFlags(apiserver, kubeconfig)
+	}
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	overrides := &clientcmd.ConfigOverrides{
+		CurrentContext: context,
+		ClusterInfo:    clientcmdapi.Cluster{Server: apiserver},
+	}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+}
 
 // CreateKubeClient creates a Kubernetes clientset and a custom resource clientset.
 func CreateKubeClient(apiserver string, kubeconfig string) (clientset.Interface, error) {
@@ -49,17 +72,39 @@ func CreateKubeClient(apiserver string, kubeconfig string) (clientset.Interface,
 	var err error
 
 	if config == nil {
-		config, err = clientcmd.BuildConfigFromFlags(apiserver, kubeconfig)
+		config, err = BuildConfig(apiserver, kubeconfig, kubeconfigContext)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	config.UserAgent = fmt.Sprintf("%s/%s (%s/%s) kubernetes/%s", "kube-state-metrics", version.Version, runtime.GOOS, runtime.GOARCH, version.Revision)
-	config.AcceptContentTypes = "application/vnd.kubernetes.protobuf,application/json"
-	config.ContentType = "application/vnd.kubernetes.protobuf"
+	kubeClient, err := newKubeClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	currentKubeClient = kubeClient
+	return kubeClient, nil
+}
+
+// CreateKubeClientForContext creates a Kubernetes clientset for the given context of
+// the kubeconfig file. Unlike CreateKubeClient, the clientset is not memoized.
+func CreateKubeClientForContext(apiserver string, kubeconfig string, context string) (clientset.Interface, error) {
+	contextConfig, err := BuildConfig(apiserver, kubeconfig, context)
+	if err != nil {
+		return nil, err
+	}
+	return newKubeClient(contextConfig)
+}
 
-	kubeClient, err := clientset.NewForConfig(config)
+// newKubeClient creates a Kubernetes clientset for the given config and tests the
+// communication with its apiserver.
+func newKubeClient(restConfig *rest.Config) (clientset.Interface, error) {
+	restConfig.UserAgent = fmt.Sprintf("%s/%s (%s/%s) kubernetes/%s", "kube-state-metrics", version.Version, runtime.GOOS, runtime.GOARCH, version.Revision)
+	restConfig.AcceptContentTypes = "application/vnd.kubernetes.protobuf,application/json"
+	restConfig.ContentType = "application/vnd.kubernetes.protobuf"
+
+	kubeClient, err := clientset.NewForConfig(restConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +120,6 @@ func CreateKubeClient(apiserver string, kubeconfig string) (clientset.Interface,
 	klog.InfoS("Run with Kubernetes cluster version", "major", v.Major, "minor", v.Minor, "gitVersion", v.GitVersion, "gitTreeState", v.GitTreeState, "gitCommit", v.GitCommit, "platform", v.Platform)
 	klog.InfoS("Communication with server successful")
 
-	currentKubeClient = kubeClient
 	return kubeClient, nil
 }
 
@@ -84,7 +128,7 @@ func CreateCustomResourceClients(apiserver string, kubeconfig string, factories
 	// Not relying on memoized clients here because the factories are subject to change.
 	var err error
 	if config == nil {
-		config, err = clientcmd.BuildConfigFromFlags(apiserver, kubeconfig)
+		config, err = BuildConfig(apiserver, kubeconfig, kubeconfigContext)
 		if err != nil {
 			return nil, err
 		}
@@ -118,7 +162,7 @@ func CreateDiscoveryClient(apiserver string, kubeconfig string) (*discovery.Disc
 	var err error
 	if config == nil {
 		var err error
-		config, err = clientcmd.BuildConfigFromFlags(apiserver, kubeconfig)
+		config, err = BuildConfig(apiserver, kubeconfig, kubeconfigContext)
 		if err != nil {
 			return nil, err
 		}
@@ -17,23 +17,36 @@ limitations under the License.
 package watch
 
 import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 )
 
-// ListWatchMetrics stores the pointers of kube_state_metrics_[list|watch]_total metrics.
+// ListWatchMetrics stores the pointers of kube_state_metrics_[list|watch]_total,
+// kube_state_metrics_watch_errors_total and kube_state_metrics_informer_events_total metrics.
 type ListWatchMetrics struct {
-	WatchTotal *prometheus.CounterVec
-	ListTotal  *prometheus.CounterVec
+	WatchTotal     *prometheus.CounterVec
+	ListTotal      *prometheus.CounterVec
+	WatchErrors    *prometheus.CounterVec
+	InformerEvents *prometheus.CounterVec
 }
 
 // NewListWatchMetrics takes in a prometheus registry and initializes
-// and registers the kube_state_metrics_list_total and
-// kube_state_metrics_watch_total metrics. It returns those registered metrics.
+// and registers the kube_state_metrics_list_total,
+// kube_state_metrics_watch_total, kube_state_metrics_watch_errors_total and
+// kube_state_metrics_informer_events_total metrics. It returns those registered metrics.
 func NewListWatchMetrics(r prometheus.Registerer) *ListWatchMetrics {
 	return &ListWatchMetrics{
 		WatchTotal: promauto.With(r).NewCounterVec(
@@ -50,9 +63,76 @@ func NewListWatchMetrics(r prometheus.Registerer) *ListWatchMetrics {
 			},
 			[]string{"result", "resource"},
 		),
+		WatchErrors: promauto.With(r).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "kube_state_metrics_watch_errors_total",
+				Help: "Number of times listing and watching a resource failed in kube-state-metrics",
+			},
+			[]string{"resource"},
+		),
+		InformerEvents: promauto.With(r).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "kube_state_metrics_informer_events_total",
+				Help: "Number of objects added, updated and deleted by the watch of a resource in kube-state-metrics",
+			},
+			[]string{"resource", "event_type"},
+		),
 	}
 }
 
+// RunReflector runs the given reflector until stopCh is closed, like cache.Reflector.Run does.
+// Every failure of the reflector to list and watch the resource increases the
+// kube_state_metrics_watch_errors_total counter before it is handled, and retries are backed off
+// with the same backoff manager cache.Reflector uses.
+func RunReflector(r *cache.Reflector, metrics *ListWatchMetrics, resource string, stopCh <-chan struct{}) {
+	handler := newWatchErrorHandler(metrics, resource, cache.DefaultWatchErrorHandler)
+	//nolint:staticcheck // cache.Reflector does not expose its backoff manager, so this mirrors it.
+	backoff := wait.NewExponentialBackoffManager(800*time.Millisecond, 30*time.Second, 2*time.Minute, 2.0, 1.0, clock.RealClock{})
+	wait.BackoffUntil(func() {
+		if err := r.ListAndWatch(stopCh); err != nil {
+			handler(r, err)
+		}
+	}, backoff, true, stopCh)
+}
+
+// loggedRBACRules holds the ClusterRole rules already logged by any watch error handler, as
+// the reflectors of the namespaces of a resource all fail on the same missing rule.
+var loggedRBACRules sync.Map
+
+// newWatchErrorHandler returns a cache.WatchErrorHandler counting the errors of the reflector
+// for the given resource before passing them on to next. Forbidden errors are logged together
+// with the missing ClusterRole rule instead, which is only logged once per process.
+func newWatchErrorHandler(metrics *ListWatchMetrics, resource string, next cache.WatchErrorHandler) cache.WatchErrorHandler {
+	watchErrors := metrics.WatchErrors.WithLabelValues(resource)
+	return func(r *cache.Reflector, err error) {
+		watchErrors.Inc()
+		if apierrors.IsForbidden(err) {
+			klog.ErrorS(err, "Not permitted to list and watch resource, check the RBAC permissions of kube-state-metrics", "resource", resource)
+			if rule := rbacRule(err); rule != "" {
+				if _, logged := loggedRBACRules.LoadOrStore(rule, struct{}{}); !logged {
+					klog.InfoS("Add the following rule to the ClusterRole of kube-state-metrics", "resource", resource, "rule", rule)
+				}
+			}
+			return
+		}
+		next(r, err)
+	}
+}
+
+// rbacRule returns the ClusterRole rule required to list and watch the resource
+// the given forbidden error was returned for, or an empty string if the error does not name the resource.
+func rbacRule(err error) string {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return ""
+	}
+	details := status.Status().Details
+	if details == nil || details.Kind == "" {
+		return ""
+	}
+	return fmt.Sprintf(`apiGroups: [%q], resources: [%q], verbs: ["list", "watch"]`, details.Group, details.Kind)
+}
+
 // InstrumentedListerWatcher provides the kube_state_metrics_watch_total metric
 // with a cache.ListerWatcher obj and the related resource.
 type InstrumentedListerWatcher struct {
@@ -75,8 +155,9 @@ func NewInstrumentedListerWatcher(lw cache.ListerWatcher, metrics *ListWatchMetr
 // List is a wrapper func around the cache.ListerWatcher.List func. It increases the success/error
 // / counters based on the outcome of the List operation it instruments.
 func (i *InstrumentedListerWatcher) List(options metav1.ListOptions) (runtime.Object, error) {
-
-	if i.useAPIServerCache {
+	// Subsequent pages of a paginated list must not set a resource version. Lists are only
+	// paginated if the API server has its watch cache disabled and serves them from etcd.
+	if i.useAPIServerCache && options.Continue == "" {
 		options.ResourceVersion = "0"
 	}
 
@@ -102,3 +183,40 @@ func (i *InstrumentedListerWatcher) Watch(options metav1.ListOptions) (watch.Int
 	i.metrics.WatchTotal.WithLabelValues("success", i.resource).Inc()
 	return res, nil
 }
+
+// InstrumentedStore is a wrapper around a cache.Store that counts the objects
+// added, updated and deleted by the reflector filling it.
+type InstrumentedStore struct {
+	cache.Store
+	adds    prometheus.Counter
+	updates prometheus.Counter
+	deletes prometheus.Counter
+}
+
+// NewInstrumentedStore returns a new InstrumentedStore.
+func NewInstrumentedStore(store cache.Store, metrics *ListWatchMetrics, resource string) cache.Store {
+	return &InstrumentedStore{
+		Store:   store,
+		adds:    metrics.InformerEvents.WithLabelValues(resource, "add"),
+		updates: metrics.InformerEvents.WithLabelValues(resource, "update"),
+		deletes: metrics.InformerEvents.WithLabelValues(resource, "delete"),
+	}
+}
+
+// Add is a wrapper func around the cache.Store.Add func. It increases the add event counter.
+func (i *InstrumentedStore) Add(obj interface{}) error {
+	i.adds.Inc()
+	return i.Store.Add(obj)
+}
+
+// Update is a wrapper func around the cache.Store.Update func. It increases the update event counter.
+func (i *InstrumentedStore) Update(obj interface{}) error {
+	i.updates.Inc()
+	return i.Store.Update(obj)
+}
+
+// Delete is a wrapper func around the cache.Store.Delete func. It increases the delete event counter.
+func (i *InstrumentedStore) Delete(obj interface{}) error {
+	i.deletes.Inc()
+	return i.Store.Delete(obj)
+}
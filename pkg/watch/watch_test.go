@@ -0,0 +1,244 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package watch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/textlogger"
+)
+
+func TestRunReflectorCountsForbiddenErrors(t *testing.T) {
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("missing RBAC permissions"))
+	lw := &cache.ListWatch{
+		ListFunc: func(_ metav1.ListOptions) (runtime.Object, error) {
+			return nil, forbidden
+		},
+		WatchFunc: func(_ metav1.ListOptions) (watch.Interface, error) {
+			return nil, forbidden
+		},
+	}
+
+	metrics := NewListWatchMetrics(prometheus.NewRegistry())
+	reflector := cache.NewReflectorWithOptions(NewInstrumentedListerWatcher(lw, metrics, "*v1.Pod", false), &v1.Pod{}, cache.NewStore(cache.MetaNamespaceKeyFunc), cache.ReflectorOptions{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go RunReflector(reflector, metrics, "*v1.Pod", ctx.Done())
+
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(_ context.Context) (bool, error) {
+		return testutil.ToFloat64(metrics.WatchErrors.WithLabelValues("*v1.Pod")) >= 1, nil
+	})
+	if err != nil {
+		t.Fatalf("expected the forbidden error to be counted: %v", err)
+	}
+	if got := testutil.ToFloat64(metrics.ListTotal.WithLabelValues("error", "*v1.Pod")); got < 1 {
+		t.Errorf("expected the failed list to be counted, got %v", got)
+	}
+
+	// The first retry is backed off, so no further attempt is made right away.
+	time.Sleep(100 * time.Millisecond)
+	if got := testutil.ToFloat64(metrics.WatchErrors.WithLabelValues("*v1.Pod")); got != 1 {
+		t.Errorf("expected retries to be backed off, got %v errors", got)
+	}
+}
+
+func TestWatchErrorHandler(t *testing.T) {
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("missing RBAC permissions"))
+	unavailable := apierrors.NewServiceUnavailable("unavailable")
+
+	metrics := NewListWatchMetrics(prometheus.NewRegistry())
+	var handled []error
+	handler := newWatchErrorHandler(metrics, "*v1.Pod", func(_ *cache.Reflector, err error) {
+		handled = append(handled, err)
+	})
+
+	handler(nil, forbidden)
+	handler(nil, unavailable)
+
+	if got := testutil.ToFloat64(metrics.WatchErrors.WithLabelValues("*v1.Pod")); got != 2 {
+		t.Errorf("expected both errors to be counted, got %v", got)
+	}
+	if len(handled) != 1 || handled[0] != unavailable {
+		t.Errorf("expected only the non-forbidden error to be passed on, got %v", handled)
+	}
+}
+
+func TestWatchErrorHandlerLogsRBACRuleOnce(t *testing.T) {
+	var logs bytes.Buffer
+	klog.SetLogger(textlogger.NewLogger(textlogger.NewConfig(textlogger.Output(&logs))))
+	defer klog.ClearLogger()
+	loggedRBACRules.Clear()
+	defer loggedRBACRules.Clear()
+
+	// The reflectors of two namespaces watch the same resource, each with its own handler.
+	forbidden := apierrors.NewForbidden(schema.GroupResource{Group: "coordination.k8s.io", Resource: "leases"}, "", errors.New("missing RBAC permissions"))
+	metrics := NewListWatchMetrics(prometheus.NewRegistry())
+	for range []string{"default", "kube-system"} {
+		handler := newWatchErrorHandler(metrics, "*v1.Lease", func(_ *cache.Reflector, err error) {
+			t.Errorf("expected the forbidden error to be handled, got %v passed on", err)
+		})
+		handler(nil, forbidden)
+		handler(nil, forbidden)
+	}
+
+	out := logs.String()
+	if got := strings.Count(out, "Not permitted to list and watch resource"); got != 4 {
+		t.Errorf("expected every forbidden error to be logged, got %d in %q", got, out)
+	}
+	rule := `rule="apiGroups: [\"coordination.k8s.io\"], resources: [\"leases\"], verbs: [\"list\", \"watch\"]"`
+	if got := strings.Count(out, rule); got != 1 {
+		t.Errorf("expected the ClusterRole rule to be logged once, got %d in %q", got, out)
+	}
+}
+
+func TestInstrumentedListerWatcherUseAPIServerCache(t *testing.T) {
+	tests := []struct {
+		name                string
+		useAPIServerCache   bool
+		options             metav1.ListOptions
+		wantResourceVersion string
+	}{
+		{
+			name:                "disabled",
+			options:             metav1.ListOptions{ResourceVersion: "42"},
+			wantResourceVersion: "42",
+		},
+		{
+			name:                "enabled",
+			useAPIServerCache:   true,
+			options:             metav1.ListOptions{Limit: 500},
+			wantResourceVersion: "0",
+		},
+		{
+			name:                "enabled overrides a resource version",
+			useAPIServerCache:   true,
+			options:             metav1.ListOptions{ResourceVersion: "42"},
+			wantResourceVersion: "0",
+		},
+		{
+			name:              "enabled with a continue token of a paginated list",
+			useAPIServerCache: true,
+			options:           metav1.ListOptions{Limit: 500, Continue: "token"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got metav1.ListOptions
+			lw := &cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					got = options
+					return &v1.PodList{}, nil
+				},
+			}
+
+			metrics := NewListWatchMetrics(prometheus.NewRegistry())
+			if _, err := NewInstrumentedListerWatcher(lw, metrics, "*v1.Pod", tt.useAPIServerCache).List(tt.options); err != nil {
+				t.Fatal(err)
+			}
+			if got.ResourceVersion != tt.wantResourceVersion {
+				t.Errorf("expected resource version %q, got %q", tt.wantResourceVersion, got.ResourceVersion)
+			}
+			if got.Continue != tt.options.Continue || got.Limit != tt.options.Limit {
+				t.Errorf("expected the other list options to be passed through, got %+v", got)
+			}
+		})
+	}
+}
+
+func TestInstrumentedStoreCountsEvents(t *testing.T) {
+	metrics := NewListWatchMetrics(prometheus.NewRegistry())
+	store := NewInstrumentedStore(cache.NewStore(cache.MetaNamespaceKeyFunc), metrics, "*v1.Pod")
+
+	pod := func(name string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+	for _, event := range []func() error{
+		func() error { return store.Add(pod("pod1")) },
+		func() error { return store.Add(pod("pod2")) },
+		func() error { return store.Update(pod("pod1")) },
+		func() error { return store.Update(pod("pod1")) },
+		func() error { return store.Update(pod("pod2")) },
+		func() error { return store.Delete(pod("pod2")) },
+	} {
+		if err := event(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for eventType, want := range map[string]float64{
+		"add":    2,
+		"update": 3,
+		"delete": 1,
+	} {
+		if got := testutil.ToFloat64(metrics.InformerEvents.WithLabelValues("*v1.Pod", eventType)); got != want {
+			t.Errorf("expected %v %s events, got %v", want, eventType, got)
+		}
+	}
+	if got := len(store.ListKeys()); got != 1 {
+		t.Errorf("expected the events to be passed through to the store, got %d objects", got)
+	}
+}
+
+func TestRBACRule(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "core group",
+			err:  apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("missing RBAC permissions")),
+			want: `apiGroups: [""], resources: ["pods"], verbs: ["list", "watch"]`,
+		},
+		{
+			name: "wrapped named group",
+			err:  fmt.Errorf("failed to list *v1.Lease: %w", apierrors.NewForbidden(schema.GroupResource{Group: "coordination.k8s.io", Resource: "leases"}, "", errors.New("missing RBAC permissions"))),
+			want: `apiGroups: ["coordination.k8s.io"], resources: ["leases"], verbs: ["list", "watch"]`,
+		},
+		{
+			name: "no status",
+			err:  errors.New("forbidden"),
+			want: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := rbacRule(test.err); got != test.want {
+				t.Errorf("want rule %q, got %q", test.want, got)
+			}
+		})
+	}
+}